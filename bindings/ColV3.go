@@ -17,6 +17,8 @@
 
 package bindings
 
+import "encoding/json"
+
 type ColV3 struct {
 	*Schema
 	/** label */
@@ -100,3 +102,37 @@ func NewColV3() *ColV3 {
 		Percentiles:       nil,
 	}
 }
+
+// UnmarshalJSON to handle possible Infinity and NaN values
+func (o *ColV3) UnmarshalJSON(data []byte) error {
+	type Alias ColV3
+	aux := &struct {
+		Mins            []interface{} `json:"mins"`
+		Maxs            []interface{} `json:"maxs"`
+		Mean            interface{}   `json:"mean"`
+		Sigma           interface{}   `json:"sigma"`
+		Data            []interface{} `json:"data"`
+		HistogramBase   interface{}   `json:"histogram_base"`
+		HistogramStride interface{}   `json:"histogram_stride"`
+		Percentiles     []interface{} `json:"percentiles"`
+		*Alias
+	}{
+		Mean:            o.Mean,
+		Sigma:           o.Sigma,
+		HistogramBase:   o.HistogramBase,
+		HistogramStride: o.HistogramStride,
+		Alias:           (*Alias)(o),
+	}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	o.Mins = jsonToDoubls(aux.Mins)
+	o.Maxs = jsonToDoubls(aux.Maxs)
+	o.Mean = jsonToDoubl(aux.Mean)
+	o.Sigma = jsonToDoubl(aux.Sigma)
+	o.Data = jsonToDoubls(aux.Data)
+	o.HistogramBase = jsonToDoubl(aux.HistogramBase)
+	o.HistogramStride = jsonToDoubl(aux.HistogramStride)
+	o.Percentiles = jsonToDoubls(aux.Percentiles)
+	return nil
+}
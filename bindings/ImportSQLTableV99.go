@@ -0,0 +1,51 @@
+/*
+  Copyright (C) 2016 H2O.ai, Inc. <http://h2o.ai/>
+
+  This program is free software: you can redistribute it and/or modify
+  it under the terms of the GNU Affero General Public License as
+  published by the Free Software Foundation, either version 3 of the
+  License, or (at your option) any later version.
+
+  This program is distributed in the hope that it will be useful,
+  but WITHOUT ANY WARRANTY; without even the implied warranty of
+  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+  GNU Affero General Public License for more details.
+
+  You should have received a copy of the GNU Affero General Public License
+  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package bindings
+
+type ImportSQLTableV99 struct {
+	*RequestSchema
+	/** connection_url */
+	ConnectionUrl string `json:"connection_url"`
+	/** table */
+	Table string `json:"table"`
+	/** select_query */
+	SelectQuery string `json:"select_query"`
+	/** username */
+	Username string `json:"username"`
+	/** password */
+	Password string `json:"password"`
+	/** columns */
+	Columns string `json:"columns"`
+	/** optimize */
+	Optimize bool `json:"optimize"`
+}
+
+func NewImportSQLTableV99() *ImportSQLTableV99 {
+	return &ImportSQLTableV99{
+		ConnectionUrl: "",
+		Table:         "",
+		SelectQuery:   "",
+		Username:      "",
+		Password:      "",
+		Columns:       "*",
+		Optimize:      true,
+		RequestSchema: &RequestSchema{
+			ExcludeFields: "",
+		},
+	}
+}
@@ -21,12 +21,13 @@ import "encoding/json"
 
 // This bypasses ModelMetricsBase used to get metric scalars
 type ModelMetrics struct {
-	Mse                  float64 `json:"MSE,omitempty"`
-	R2                   float64 `json:"r2,omitempty"`
-	Logloss              float64 `json:"logloss,omitempty"`
-	Auc                  float64 `json:"AUC,omitempty"`
-	Gini                 float64 `json:"Gini,omitempty"`
-	MeanResidualDeviance float64 `json:"mean_residual_deviance,omitempty"`
+	Mse                  float64     `json:"MSE,omitempty"`
+	R2                   float64     `json:"r2,omitempty"`
+	Logloss              float64     `json:"logloss,omitempty"`
+	Auc                  float64     `json:"AUC,omitempty"`
+	Gini                 float64     `json:"Gini,omitempty"`
+	MeanResidualDeviance float64     `json:"mean_residual_deviance,omitempty"`
+	Frame                *FrameKeyV3 `json:"frame,omitempty"`
 }
 
 func (o *ModelMetrics) UnmarshalJSON(data []byte) error {
@@ -37,6 +38,7 @@ func (o *ModelMetrics) UnmarshalJSON(data []byte) error {
 		Auc                  interface{} `json:"AUC,omitempty"`
 		Gini                 interface{} `json:"Gini,omitempty"`
 		MeanResidualDeviance interface{} `json:"mean_residual_deviance,omitempty"`
+		Frame                *FrameKeyV3 `json:"frame,omitempty"`
 	}{
 		o.Mse,
 		o.R2,
@@ -44,6 +46,7 @@ func (o *ModelMetrics) UnmarshalJSON(data []byte) error {
 		o.Auc,
 		o.Gini,
 		o.MeanResidualDeviance,
+		o.Frame,
 	}
 	if err := json.Unmarshal(data, &aux); err != nil {
 		return err
@@ -54,5 +57,6 @@ func (o *ModelMetrics) UnmarshalJSON(data []byte) error {
 	o.Auc = jsonToDoubl(aux.Auc)
 	o.Gini = jsonToDoubl(aux.Gini)
 	o.MeanResidualDeviance = jsonToDoubl(aux.MeanResidualDeviance)
+	o.Frame = aux.Frame
 	return nil
 }
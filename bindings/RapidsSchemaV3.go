@@ -0,0 +1,48 @@
+/*
+  Copyright (C) 2016 H2O.ai, Inc. <http://h2o.ai/>
+
+  This program is free software: you can redistribute it and/or modify
+  it under the terms of the GNU Affero General Public License as
+  published by the Free Software Foundation, either version 3 of the
+  License, or (at your option) any later version.
+
+  This program is distributed in the hope that it will be useful,
+  but WITHOUT ANY WARRANTY; without even the implied warranty of
+  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+  GNU Affero General Public License for more details.
+
+  You should have received a copy of the GNU Affero General Public License
+  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package bindings
+
+type RapidsSchemaV3 struct {
+	*RequestSchema
+	/** A Rapids AST expression */
+	Ast string `json:"ast"`
+	/** Session key */
+	SessionId string `json:"session_id,omitempty"`
+	/** [DEPRECATED] Key name to assign Frame results */
+	Id string `json:"id,omitempty"`
+}
+
+func NewRapidsSchemaV3() *RapidsSchemaV3 {
+	return &RapidsSchemaV3{
+		Ast:       "",
+		SessionId: "",
+		Id:        "",
+		RequestSchema: &RequestSchema{
+			ExcludeFields: "",
+		},
+	}
+}
+
+type RapidsFrameV3 struct {
+	/** Frame result */
+	Key *FrameKeyV3 `json:"key,omitempty"`
+	/** Rows in Frame result */
+	NumRows int64 `json:"num_rows,omitempty"`
+	/** Columns in Frame result */
+	NumCols int32 `json:"num_cols,omitempty"`
+}
@@ -0,0 +1,39 @@
+/*
+  Copyright (C) 2016 H2O.ai, Inc. <http://h2o.ai/>
+
+  This program is free software: you can redistribute it and/or modify
+  it under the terms of the GNU Affero General Public License as
+  published by the Free Software Foundation, either version 3 of the
+  License, or (at your option) any later version.
+
+  This program is distributed in the hope that it will be useful,
+  but WITHOUT ANY WARRANTY; without even the implied warranty of
+  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+  GNU Affero General Public License for more details.
+
+  You should have received a copy of the GNU Affero General Public License
+  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package bindings
+
+type TypeaheadV3 struct {
+	*RequestSchema
+	/** src */
+	Src string `json:"src"`
+	/** Limit */
+	Limit int32 `json:"limit"`
+	/** Matches */
+	Matches []string `json:"matches,omitempty"`
+}
+
+func NewTypeaheadV3() *TypeaheadV3 {
+	return &TypeaheadV3{
+		Src:     "",
+		Limit:   0,
+		Matches: nil,
+		RequestSchema: &RequestSchema{
+			ExcludeFields: "",
+		},
+	}
+}
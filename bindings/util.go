@@ -36,6 +36,8 @@ func jsonToFloat(j interface{}) float32 {
 		}
 	case float64:
 		return float32(j.(float64))
+	case nil:
+		return float32(math.NaN())
 	default:
 		panic(fmt.Sprintf("unexepcted type %T: %v", j, j))
 		return 0
@@ -56,6 +58,8 @@ func jsonToDoubl(j interface{}) float64 {
 		}
 	case float64:
 		return j.(float64)
+	case nil:
+		return math.NaN()
 	default:
 		panic(fmt.Sprintf("unexepcted type %T: %v", j, j))
 		return 0
@@ -78,6 +82,8 @@ func jsonToFloats(j []interface{}) []float32 {
 			}
 		case float64:
 			ret[i] = float32(inter.(float64))
+		case nil:
+			ret[i] = float32(math.NaN())
 		default:
 			panic(fmt.Sprintf("unexepcted type %T: %v", inter, inter))
 		}
@@ -100,6 +106,8 @@ func jsonToDoubls(j []interface{}) []float64 {
 			}
 		case float64:
 			ret[i] = inter.(float64)
+		case nil:
+			ret[i] = math.NaN()
 		default:
 			panic(fmt.Sprintf("unexepcted type %T: %v", inter, inter))
 		}
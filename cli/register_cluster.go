@@ -44,7 +44,7 @@ func registerCluster(c *context) *cobra.Command {
 
 		// -- Execution --
 
-		clusterId, err := c.remote.RegisterCluster(clusterAddress)
+		clusterId, err := c.remote.RegisterCluster(clusterAddress, "http", "", "", "")
 		if err != nil {
 			log.Fatalln(err) //FIXME format error
 		}
@@ -34,21 +34,27 @@ func registerGeneratedCommands(c *context, cmd *cobra.Command) {
 		activate(c),
 		add(c),
 		build(c),
+		cancel(c),
 		check(c),
+		compare(c),
 		create(c),
 		deactivate(c),
 		delete_(c),
 		find(c),
 		get(c),
 		import_(c),
+		keep(c),
 		link(c),
 		ping(c),
 		register(c),
+		reimport(c),
 		set(c),
 		share(c),
 		split(c),
 		start(c),
 		stop(c),
+		sync(c),
+		test(c),
 		unlink(c),
 		unregister(c),
 		unshare(c),
@@ -209,24 +215,26 @@ func buildModel(c *context) *cobra.Command {
 				log.Fatalln(err)
 			}
 			lines := []string{
-				fmt.Sprintf("Id:\t%v\t", model.Id),                                   // No description available
-				fmt.Sprintf("TrainingDatasetId:\t%v\t", model.TrainingDatasetId),     // No description available
-				fmt.Sprintf("ValidationDatasetId:\t%v\t", model.ValidationDatasetId), // No description available
-				fmt.Sprintf("Name:\t%v\t", model.Name),                               // No description available
-				fmt.Sprintf("ClusterName:\t%v\t", model.ClusterName),                 // No description available
-				fmt.Sprintf("ModelKey:\t%v\t", model.ModelKey),                       // No description available
-				fmt.Sprintf("Algorithm:\t%v\t", model.Algorithm),                     // No description available
-				fmt.Sprintf("ModelCategory:\t%v\t", model.ModelCategory),             // No description available
-				fmt.Sprintf("DatasetName:\t%v\t", model.DatasetName),                 // No description available
-				fmt.Sprintf("ResponseColumnName:\t%v\t", model.ResponseColumnName),   // No description available
-				fmt.Sprintf("LogicalName:\t%v\t", model.LogicalName),                 // No description available
-				fmt.Sprintf("Location:\t%v\t", model.Location),                       // No description available
-				fmt.Sprintf("ModelObjectType:\t%v\t", model.ModelObjectType),         // No description available
-				fmt.Sprintf("MaxRuntime:\t%v\t", model.MaxRuntime),                   // No description available
-				fmt.Sprintf("JSONMetrics:\t%v\t", model.JSONMetrics),                 // No description available
-				fmt.Sprintf("CreatedAt:\t%v\t", model.CreatedAt),                     // No description available
-				fmt.Sprintf("LabelId:\t%v\t", model.LabelId),                         // No description available
-				fmt.Sprintf("LabelName:\t%v\t", model.LabelName),                     // No description available
+				fmt.Sprintf("Id:\t%v\t", model.Id),                                         // No description available
+				fmt.Sprintf("TrainingDatasetId:\t%v\t", model.TrainingDatasetId),           // No description available
+				fmt.Sprintf("ValidationDatasetId:\t%v\t", model.ValidationDatasetId),       // No description available
+				fmt.Sprintf("TrainingDatasetVersion:\t%v\t", model.TrainingDatasetVersion), // No description available
+				fmt.Sprintf("Stale:\t%v\t", model.Stale),                                   // Whether the model is gone from its cluster
+				fmt.Sprintf("Name:\t%v\t", model.Name),                                     // No description available
+				fmt.Sprintf("ClusterName:\t%v\t", model.ClusterName),                       // No description available
+				fmt.Sprintf("ModelKey:\t%v\t", model.ModelKey),                             // No description available
+				fmt.Sprintf("Algorithm:\t%v\t", model.Algorithm),                           // No description available
+				fmt.Sprintf("ModelCategory:\t%v\t", model.ModelCategory),                   // No description available
+				fmt.Sprintf("DatasetName:\t%v\t", model.DatasetName),                       // No description available
+				fmt.Sprintf("ResponseColumnName:\t%v\t", model.ResponseColumnName),         // No description available
+				fmt.Sprintf("LogicalName:\t%v\t", model.LogicalName),                       // No description available
+				fmt.Sprintf("Location:\t%v\t", model.Location),                             // No description available
+				fmt.Sprintf("ModelObjectType:\t%v\t", model.ModelObjectType),               // No description available
+				fmt.Sprintf("MaxRuntime:\t%v\t", model.MaxRuntime),                         // No description available
+				fmt.Sprintf("JSONMetrics:\t%v\t", model.JSONMetrics),                       // No description available
+				fmt.Sprintf("CreatedAt:\t%v\t", model.CreatedAt),                           // No description available
+				fmt.Sprintf("LabelId:\t%v\t", model.LabelId),                               // No description available
+				fmt.Sprintf("LabelName:\t%v\t", model.LabelName),                           // No description available
 			}
 			c.printt("Attribute\tValue\t", lines)
 			return
@@ -257,6 +265,127 @@ func buildModel(c *context) *cobra.Command {
 	return cmd
 }
 
+var cancelHelp = `
+cancel [?]
+Cancel entities
+Commands:
+
+    $ steam cancel cluster ...
+    $ steam cancel dataset ...
+    $ steam cancel job ...
+`
+
+func cancel(c *context) *cobra.Command {
+	cmd := newCmd(c, cancelHelp, nil)
+
+	cmd.AddCommand(cancelCluster(c))
+	cmd.AddCommand(cancelDataset(c))
+	cmd.AddCommand(cancelJob(c))
+	return cmd
+}
+
+var cancelClusterHelp = `
+cluster [?]
+Cancel Cluster
+Examples:
+
+    Cancel a cluster launch on Yarn
+    $ steam cancel cluster --launch \
+        --cluster-id=?
+
+`
+
+func cancelCluster(c *context) *cobra.Command {
+	var launch bool     // Switch for CancelClusterLaunch()
+	var clusterId int64 // No description available
+
+	cmd := newCmd(c, cancelClusterHelp, func(c *context, args []string) {
+		if launch { // CancelClusterLaunch
+
+			// Cancel a cluster launch on Yarn
+			err := c.remote.CancelClusterLaunch(
+				clusterId, // No description available
+			)
+			if err != nil {
+				log.Fatalln(err)
+			}
+			return
+		}
+	})
+	cmd.Flags().BoolVar(&launch, "launch", launch, "Cancel a cluster launch on Yarn")
+
+	cmd.Flags().Int64Var(&clusterId, "cluster-id", clusterId, "No description available")
+	return cmd
+}
+
+var cancelDatasetHelp = `
+dataset [?]
+Cancel Dataset
+Examples:
+
+    Cancel a dataset import job
+    $ steam cancel dataset --job \
+        --job-id=?
+
+`
+
+func cancelDataset(c *context) *cobra.Command {
+	var job bool    // Switch for CancelDatasetJob()
+	var jobId int64 // No description available
+
+	cmd := newCmd(c, cancelDatasetHelp, func(c *context, args []string) {
+		if job { // CancelDatasetJob
+
+			// Cancel a dataset import job
+			err := c.remote.CancelDatasetJob(
+				jobId, // No description available
+			)
+			if err != nil {
+				log.Fatalln(err)
+			}
+			return
+		}
+	})
+	cmd.Flags().BoolVar(&job, "job", job, "Cancel a dataset import job")
+
+	cmd.Flags().Int64Var(&jobId, "job-id", jobId, "No description available")
+	return cmd
+}
+
+var cancelJobHelp = `
+job [?]
+Cancel Job
+Examples:
+
+    Cancel a running job
+    $ steam cancel job \
+        --cluster-id=? \
+        --job-name=?
+
+`
+
+func cancelJob(c *context) *cobra.Command {
+	var clusterId int64 // No description available
+	var jobName string  // No description available
+
+	cmd := newCmd(c, cancelJobHelp, func(c *context, args []string) {
+
+		// Cancel a running job
+		err := c.remote.CancelJob(
+			clusterId, // No description available
+			jobName,   // No description available
+		)
+		if err != nil {
+			log.Fatalln(err)
+		}
+		return
+	})
+
+	cmd.Flags().Int64Var(&clusterId, "cluster-id", clusterId, "No description available")
+	cmd.Flags().StringVar(&jobName, "job-name", jobName, "No description available")
+	return cmd
+}
+
 var checkHelp = `
 check [?]
 Check entities
@@ -303,11 +432,72 @@ func checkMojo(c *context) *cobra.Command {
 	return cmd
 }
 
+var compareHelp = `
+compare [?]
+Compare entities
+Commands:
+
+    $ steam compare datasets ...
+`
+
+func compare(c *context) *cobra.Command {
+	cmd := newCmd(c, compareHelp, nil)
+
+	cmd.AddCommand(compareDatasets(c))
+	return cmd
+}
+
+var compareDatasetsHelp = `
+datasets [?]
+Compare Datasets
+Examples:
+
+    Compare the schema and distributions of two datasets
+    $ steam compare datasets \
+        --base-dataset-id=? \
+        --other-dataset-id=?
+
+`
+
+func compareDatasets(c *context) *cobra.Command {
+	var baseDatasetId int64  // No description available
+	var otherDatasetId int64 // No description available
+
+	cmd := newCmd(c, compareDatasetsHelp, func(c *context, args []string) {
+
+		// Compare the schema and distributions of two datasets
+		comparison, err := c.remote.CompareDatasets(
+			baseDatasetId,  // No description available
+			otherDatasetId, // No description available
+		)
+		if err != nil {
+			log.Fatalln(err)
+		}
+		lines := []string{
+			fmt.Sprintf("BaseDatasetId:\t%v\t", comparison.BaseDatasetId),    // No description available
+			fmt.Sprintf("OtherDatasetId:\t%v\t", comparison.OtherDatasetId),  // No description available
+			fmt.Sprintf("AddedColumns:\t%+v\t", comparison.AddedColumns),     // No description available
+			fmt.Sprintf("RemovedColumns:\t%+v\t", comparison.RemovedColumns), // No description available
+			fmt.Sprintf("Columns:\t%+v\t", comparison.Columns),               // No description available
+			fmt.Sprintf("SchemaChanged:\t%v\t", comparison.SchemaChanged),    // No description available
+			fmt.Sprintf("MaxPSI:\t%v\t", comparison.MaxPSI),                  // No description available
+			fmt.Sprintf("CreatedAt:\t%v\t", comparison.CreatedAt),            // No description available
+		}
+		c.printt("Attribute\tValue\t", lines)
+		return
+	})
+
+	cmd.Flags().Int64Var(&baseDatasetId, "base-dataset-id", baseDatasetId, "No description available")
+	cmd.Flags().Int64Var(&otherDatasetId, "other-dataset-id", otherDatasetId, "No description available")
+	return cmd
+}
+
 var createHelp = `
 create [?]
 Create entities
 Commands:
 
+    $ steam create cluster ...
     $ steam create dataset ...
     $ steam create datasource ...
     $ steam create identity ...
@@ -321,6 +511,7 @@ Commands:
 func create(c *context) *cobra.Command {
 	cmd := newCmd(c, createHelp, nil)
 
+	cmd.AddCommand(createCluster(c))
 	cmd.AddCommand(createDataset(c))
 	cmd.AddCommand(createDatasource(c))
 	cmd.AddCommand(createIdentity(c))
@@ -332,23 +523,92 @@ func create(c *context) *cobra.Command {
 	return cmd
 }
 
+var createClusterHelp = `
+cluster [?]
+Create Cluster
+Examples:
+
+    Create a cluster launch template
+    $ steam create cluster --template \
+        --name=? \
+        --engine-id=? \
+        --size=? \
+        --memory=? \
+        --driver-args=? \
+        --queue=? \
+        --idle-timeout=? \
+        --tags=? \
+        --max-clusters=?
+
+`
+
+func createCluster(c *context) *cobra.Command {
+	var template bool     // Switch for CreateClusterTemplate()
+	var driverArgs string // Extra h2odriver arguments, separated by spaces
+	var engineId int64    // No description available
+	var idleTimeout int64 // Idle timeout in minutes (0 to use the workgroup default)
+	var maxClusters int   // Maximum number of running clusters started from the template (0 for no limit)
+	var memory string     // No description available
+	var name string       // No description available
+	var queue string      // Yarn queue to submit clusters to (optional)
+	var size int          // No description available
+	var tags string       // Comma-separated tags to find the template by
+
+	cmd := newCmd(c, createClusterHelp, func(c *context, args []string) {
+		if template { // CreateClusterTemplate
+
+			// Create a cluster launch template
+			templateId, err := c.remote.CreateClusterTemplate(
+				name,        // No description available
+				engineId,    // No description available
+				size,        // No description available
+				memory,      // No description available
+				driverArgs,  // Extra h2odriver arguments, separated by spaces
+				queue,       // Yarn queue to submit clusters to (optional)
+				idleTimeout, // Idle timeout in minutes (0 to use the workgroup default)
+				tags,        // Comma-separated tags to find the template by
+				maxClusters, // Maximum number of running clusters started from the template (0 for no limit)
+			)
+			if err != nil {
+				log.Fatalln(err)
+			}
+			fmt.Printf("TemplateId:\t%v\n", templateId)
+			return
+		}
+	})
+	cmd.Flags().BoolVar(&template, "template", template, "Create a cluster launch template")
+
+	cmd.Flags().StringVar(&driverArgs, "driver-args", driverArgs, "Extra h2odriver arguments, separated by spaces")
+	cmd.Flags().Int64Var(&engineId, "engine-id", engineId, "No description available")
+	cmd.Flags().Int64Var(&idleTimeout, "idle-timeout", idleTimeout, "Idle timeout in minutes (0 to use the workgroup default)")
+	cmd.Flags().IntVar(&maxClusters, "max-clusters", maxClusters, "Maximum number of running clusters started from the template (0 for no limit)")
+	cmd.Flags().StringVar(&memory, "memory", memory, "No description available")
+	cmd.Flags().StringVar(&name, "name", name, "No description available")
+	cmd.Flags().StringVar(&queue, "queue", queue, "Yarn queue to submit clusters to (optional)")
+	cmd.Flags().IntVar(&size, "size", size, "No description available")
+	cmd.Flags().StringVar(&tags, "tags", tags, "Comma-separated tags to find the template by")
+	return cmd
+}
+
 var createDatasetHelp = `
 dataset [?]
 Create Dataset
 Examples:
 
-    Create a dataset
+    Start importing a dataset
     $ steam create dataset \
         --cluster-id=? \
         --datasource-id=? \
         --name=? \
         --description=? \
-        --response-column-name=?
+        --response-column-name=? \
+        --compare-with-previous=?
 
 `
 
 func createDataset(c *context) *cobra.Command {
 	var clusterId int64           // No description available
+	var compareWithPrevious bool  // Compare the new dataset with the previous dataset from the same datasource
 	var datasourceId int64        // No description available
 	var description string        // No description available
 	var name string               // No description available
@@ -356,22 +616,24 @@ func createDataset(c *context) *cobra.Command {
 
 	cmd := newCmd(c, createDatasetHelp, func(c *context, args []string) {
 
-		// Create a dataset
-		datasetId, err := c.remote.CreateDataset(
-			clusterId,          // No description available
-			datasourceId,       // No description available
-			name,               // No description available
-			description,        // No description available
-			responseColumnName, // No description available
+		// Start importing a dataset
+		jobId, err := c.remote.CreateDataset(
+			clusterId,           // No description available
+			datasourceId,        // No description available
+			name,                // No description available
+			description,         // No description available
+			responseColumnName,  // No description available
+			compareWithPrevious, // Compare the new dataset with the previous dataset from the same datasource
 		)
 		if err != nil {
 			log.Fatalln(err)
 		}
-		fmt.Printf("DatasetId:\t%v\n", datasetId)
+		fmt.Printf("JobId:\t%v\n", jobId)
 		return
 	})
 
 	cmd.Flags().Int64Var(&clusterId, "cluster-id", clusterId, "No description available")
+	cmd.Flags().BoolVar(&compareWithPrevious, "compare-with-previous", compareWithPrevious, "Compare the new dataset with the previous dataset from the same datasource")
 	cmd.Flags().Int64Var(&datasourceId, "datasource-id", datasourceId, "No description available")
 	cmd.Flags().StringVar(&description, "description", description, "No description available")
 	cmd.Flags().StringVar(&name, "name", name, "No description available")
@@ -389,24 +651,27 @@ Examples:
         --project-id=? \
         --name=? \
         --description=? \
-        --path=?
+        --kind=? \
+        --configuration=?
 
 `
 
 func createDatasource(c *context) *cobra.Command {
-	var description string // No description available
-	var name string        // No description available
-	var path string        // No description available
-	var projectId int64    // No description available
+	var configuration string // Settings of the kind as a JSON object; file: path; hdfs: uri; s3: endpoint, region, bucket, prefix, accessKey, secretKey; jdbc: url, table or query, username, password
+	var description string   // No description available
+	var kind string          // Datasource kind: file, hdfs, s3 or jdbc
+	var name string          // No description available
+	var projectId int64      // No description available
 
 	cmd := newCmd(c, createDatasourceHelp, func(c *context, args []string) {
 
 		// Create a datasource
 		datasourceId, err := c.remote.CreateDatasource(
-			projectId,   // No description available
-			name,        // No description available
-			description, // No description available
-			path,        // No description available
+			projectId,     // No description available
+			name,          // No description available
+			description,   // No description available
+			kind,          // Datasource kind: file, hdfs, s3 or jdbc
+			configuration, // Settings of the kind as a JSON object; file: path; hdfs: uri; s3: endpoint, region, bucket, prefix, accessKey, secretKey; jdbc: url, table or query, username, password
 		)
 		if err != nil {
 			log.Fatalln(err)
@@ -415,9 +680,10 @@ func createDatasource(c *context) *cobra.Command {
 		return
 	})
 
+	cmd.Flags().StringVar(&configuration, "configuration", configuration, "Settings of the kind as a JSON object; file: path; hdfs: uri; s3: endpoint, region, bucket, prefix, accessKey, secretKey; jdbc: url, table or query, username, password")
 	cmd.Flags().StringVar(&description, "description", description, "No description available")
+	cmd.Flags().StringVar(&kind, "kind", kind, "Datasource kind: file, hdfs, s3 or jdbc")
 	cmd.Flags().StringVar(&name, "name", name, "No description available")
-	cmd.Flags().StringVar(&path, "path", path, "No description available")
 	cmd.Flags().Int64Var(&projectId, "project-id", projectId, "No description available")
 	return cmd
 }
@@ -693,6 +959,7 @@ Commands:
     $ steam delete dataset ...
     $ steam delete datasource ...
     $ steam delete engine ...
+    $ steam delete keytab ...
     $ steam delete label ...
     $ steam delete model ...
     $ steam delete package ...
@@ -709,6 +976,7 @@ func delete_(c *context) *cobra.Command {
 	cmd.AddCommand(deleteDataset(c))
 	cmd.AddCommand(deleteDatasource(c))
 	cmd.AddCommand(deleteEngine(c))
+	cmd.AddCommand(deleteKeytab(c))
 	cmd.AddCommand(deleteLabel(c))
 	cmd.AddCommand(deleteModel(c))
 	cmd.AddCommand(deletePackage(c))
@@ -724,6 +992,10 @@ cluster [?]
 Delete Cluster
 Examples:
 
+    Delete a cluster launch template
+    $ steam delete cluster --template \
+        --template-id=?
+
     Delete a cluster
     $ steam delete cluster \
         --cluster-id=?
@@ -731,21 +1003,38 @@ Examples:
 `
 
 func deleteCluster(c *context) *cobra.Command {
-	var clusterId int64 // No description available
+	var template bool    // Switch for DeleteClusterTemplate()
+	var clusterId int64  // No description available
+	var templateId int64 // No description available
 
 	cmd := newCmd(c, deleteClusterHelp, func(c *context, args []string) {
+		if template { // DeleteClusterTemplate
 
-		// Delete a cluster
-		err := c.remote.DeleteCluster(
-			clusterId, // No description available
-		)
-		if err != nil {
-			log.Fatalln(err)
+			// Delete a cluster launch template
+			err := c.remote.DeleteClusterTemplate(
+				templateId, // No description available
+			)
+			if err != nil {
+				log.Fatalln(err)
+			}
+			return
+		}
+		if true { // default
+
+			// Delete a cluster
+			err := c.remote.DeleteCluster(
+				clusterId, // No description available
+			)
+			if err != nil {
+				log.Fatalln(err)
+			}
+			return
 		}
-		return
 	})
+	cmd.Flags().BoolVar(&template, "template", template, "Delete a cluster launch template")
 
 	cmd.Flags().Int64Var(&clusterId, "cluster-id", clusterId, "No description available")
+	cmd.Flags().Int64Var(&templateId, "template-id", templateId, "No description available")
 	return cmd
 }
 
@@ -839,6 +1128,36 @@ func deleteEngine(c *context) *cobra.Command {
 	return cmd
 }
 
+var deleteKeytabHelp = `
+keytab [?]
+Delete Keytab
+Examples:
+
+    Delete an uploaded Kerberos keytab
+    $ steam delete keytab \
+        --keytab-id=?
+
+`
+
+func deleteKeytab(c *context) *cobra.Command {
+	var keytabId int64 // No description available
+
+	cmd := newCmd(c, deleteKeytabHelp, func(c *context, args []string) {
+
+		// Delete an uploaded Kerberos keytab
+		err := c.remote.DeleteKeytab(
+			keytabId, // No description available
+		)
+		if err != nil {
+			log.Fatalln(err)
+		}
+		return
+	})
+
+	cmd.Flags().Int64Var(&keytabId, "keytab-id", keytabId, "No description available")
+	return cmd
+}
+
 var deleteLabelHelp = `
 label [?]
 Delete Label
@@ -1196,33 +1515,35 @@ func findModels(c *context) *cobra.Command {
 			lines := make([]string, len(models))
 			for i, e := range models {
 				lines[i] = fmt.Sprintf(
-					"%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t",
-					e.Id,                  // No description available
-					e.TrainingDatasetId,   // No description available
-					e.ValidationDatasetId, // No description available
-					e.Name,                // No description available
-					e.ClusterName,         // No description available
-					e.ModelKey,            // No description available
-					e.Algorithm,           // No description available
-					e.ModelCategory,       // No description available
-					e.DatasetName,         // No description available
-					e.ResponseColumnName,  // No description available
-					e.LogicalName,         // No description available
-					e.Location,            // No description available
-					e.ModelObjectType,     // No description available
-					e.MaxRuntime,          // No description available
-					e.JSONMetrics,         // No description available
-					e.CreatedAt,           // No description available
-					e.LabelId,             // No description available
-					e.LabelName,           // No description available
-					e.Mse,                 // No description available
-					e.RSquared,            // No description available
-					e.Logloss,             // No description available
-					e.Auc,                 // No description available
-					e.Gini,                // No description available
+					"%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t",
+					e.Id,                     // No description available
+					e.TrainingDatasetId,      // No description available
+					e.ValidationDatasetId,    // No description available
+					e.TrainingDatasetVersion, // No description available
+					e.Stale,                  // Whether the model is gone from its cluster
+					e.Name,                   // No description available
+					e.ClusterName,            // No description available
+					e.ModelKey,               // No description available
+					e.Algorithm,              // No description available
+					e.ModelCategory,          // No description available
+					e.DatasetName,            // No description available
+					e.ResponseColumnName,     // No description available
+					e.LogicalName,            // No description available
+					e.Location,               // No description available
+					e.ModelObjectType,        // No description available
+					e.MaxRuntime,             // No description available
+					e.JSONMetrics,            // No description available
+					e.CreatedAt,              // No description available
+					e.LabelId,                // No description available
+					e.LabelName,              // No description available
+					e.Mse,                    // No description available
+					e.RSquared,               // No description available
+					e.Logloss,                // No description available
+					e.Auc,                    // No description available
+					e.Gini,                   // No description available
 				)
 			}
-			c.printt("Id\tTrainingDatasetId\tValidationDatasetId\tName\tClusterName\tModelKey\tAlgorithm\tModelCategory\tDatasetName\tResponseColumnName\tLogicalName\tLocation\tModelObjectType\tMaxRuntime\tJSONMetrics\tCreatedAt\tLabelId\tLabelName\tMse\tRSquared\tLogloss\tAuc\tGini\t", lines)
+			c.printt("Id\tTrainingDatasetId\tValidationDatasetId\tTrainingDatasetVersion\tStale\tName\tClusterName\tModelKey\tAlgorithm\tModelCategory\tDatasetName\tResponseColumnName\tLogicalName\tLocation\tModelObjectType\tMaxRuntime\tJSONMetrics\tCreatedAt\tLabelId\tLabelName\tMse\tRSquared\tLogloss\tAuc\tGini\t", lines)
 			return
 		}
 		if multinomial { // FindModelsMultinomial
@@ -1242,31 +1563,33 @@ func findModels(c *context) *cobra.Command {
 			lines := make([]string, len(models))
 			for i, e := range models {
 				lines[i] = fmt.Sprintf(
-					"%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t",
-					e.Id,                  // No description available
-					e.TrainingDatasetId,   // No description available
-					e.ValidationDatasetId, // No description available
-					e.Name,                // No description available
-					e.ClusterName,         // No description available
-					e.ModelKey,            // No description available
-					e.Algorithm,           // No description available
-					e.ModelCategory,       // No description available
-					e.DatasetName,         // No description available
-					e.ResponseColumnName,  // No description available
-					e.LogicalName,         // No description available
-					e.Location,            // No description available
-					e.ModelObjectType,     // No description available
-					e.MaxRuntime,          // No description available
-					e.JSONMetrics,         // No description available
-					e.CreatedAt,           // No description available
-					e.LabelId,             // No description available
-					e.LabelName,           // No description available
-					e.Mse,                 // No description available
-					e.RSquared,            // No description available
-					e.Logloss,             // No description available
+					"%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t",
+					e.Id,                     // No description available
+					e.TrainingDatasetId,      // No description available
+					e.ValidationDatasetId,    // No description available
+					e.TrainingDatasetVersion, // No description available
+					e.Stale,                  // Whether the model is gone from its cluster
+					e.Name,                   // No description available
+					e.ClusterName,            // No description available
+					e.ModelKey,               // No description available
+					e.Algorithm,              // No description available
+					e.ModelCategory,          // No description available
+					e.DatasetName,            // No description available
+					e.ResponseColumnName,     // No description available
+					e.LogicalName,            // No description available
+					e.Location,               // No description available
+					e.ModelObjectType,        // No description available
+					e.MaxRuntime,             // No description available
+					e.JSONMetrics,            // No description available
+					e.CreatedAt,              // No description available
+					e.LabelId,                // No description available
+					e.LabelName,              // No description available
+					e.Mse,                    // No description available
+					e.RSquared,               // No description available
+					e.Logloss,                // No description available
 				)
 			}
-			c.printt("Id\tTrainingDatasetId\tValidationDatasetId\tName\tClusterName\tModelKey\tAlgorithm\tModelCategory\tDatasetName\tResponseColumnName\tLogicalName\tLocation\tModelObjectType\tMaxRuntime\tJSONMetrics\tCreatedAt\tLabelId\tLabelName\tMse\tRSquared\tLogloss\t", lines)
+			c.printt("Id\tTrainingDatasetId\tValidationDatasetId\tTrainingDatasetVersion\tStale\tName\tClusterName\tModelKey\tAlgorithm\tModelCategory\tDatasetName\tResponseColumnName\tLogicalName\tLocation\tModelObjectType\tMaxRuntime\tJSONMetrics\tCreatedAt\tLabelId\tLabelName\tMse\tRSquared\tLogloss\t", lines)
 			return
 		}
 		if regression { // FindModelsRegression
@@ -1286,31 +1609,33 @@ func findModels(c *context) *cobra.Command {
 			lines := make([]string, len(models))
 			for i, e := range models {
 				lines[i] = fmt.Sprintf(
-					"%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t",
-					e.Id,                   // No description available
-					e.TrainingDatasetId,    // No description available
-					e.ValidationDatasetId,  // No description available
-					e.Name,                 // No description available
-					e.ClusterName,          // No description available
-					e.ModelKey,             // No description available
-					e.Algorithm,            // No description available
-					e.ModelCategory,        // No description available
-					e.DatasetName,          // No description available
-					e.ResponseColumnName,   // No description available
-					e.LogicalName,          // No description available
-					e.Location,             // No description available
-					e.ModelObjectType,      // No description available
-					e.MaxRuntime,           // No description available
-					e.JSONMetrics,          // No description available
-					e.CreatedAt,            // No description available
-					e.LabelId,              // No description available
-					e.LabelName,            // No description available
-					e.Mse,                  // No description available
-					e.RSquared,             // No description available
-					e.MeanResidualDeviance, // No description available
+					"%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t",
+					e.Id,                     // No description available
+					e.TrainingDatasetId,      // No description available
+					e.ValidationDatasetId,    // No description available
+					e.TrainingDatasetVersion, // No description available
+					e.Stale,                  // Whether the model is gone from its cluster
+					e.Name,                   // No description available
+					e.ClusterName,            // No description available
+					e.ModelKey,               // No description available
+					e.Algorithm,              // No description available
+					e.ModelCategory,          // No description available
+					e.DatasetName,            // No description available
+					e.ResponseColumnName,     // No description available
+					e.LogicalName,            // No description available
+					e.Location,               // No description available
+					e.ModelObjectType,        // No description available
+					e.MaxRuntime,             // No description available
+					e.JSONMetrics,            // No description available
+					e.CreatedAt,              // No description available
+					e.LabelId,                // No description available
+					e.LabelName,              // No description available
+					e.Mse,                    // No description available
+					e.RSquared,               // No description available
+					e.MeanResidualDeviance,   // No description available
 				)
 			}
-			c.printt("Id\tTrainingDatasetId\tValidationDatasetId\tName\tClusterName\tModelKey\tAlgorithm\tModelCategory\tDatasetName\tResponseColumnName\tLogicalName\tLocation\tModelObjectType\tMaxRuntime\tJSONMetrics\tCreatedAt\tLabelId\tLabelName\tMse\tRSquared\tMeanResidualDeviance\t", lines)
+			c.printt("Id\tTrainingDatasetId\tValidationDatasetId\tTrainingDatasetVersion\tStale\tName\tClusterName\tModelKey\tAlgorithm\tModelCategory\tDatasetName\tResponseColumnName\tLogicalName\tLocation\tModelObjectType\tMaxRuntime\tJSONMetrics\tCreatedAt\tLabelId\tLabelName\tMse\tRSquared\tMeanResidualDeviance\t", lines)
 			return
 		}
 	})
@@ -1349,6 +1674,7 @@ Commands:
     $ steam get identity ...
     $ steam get job ...
     $ steam get jobs ...
+    $ steam get keytabs ...
     $ steam get labels ...
     $ steam get model ...
     $ steam get models ...
@@ -1385,6 +1711,7 @@ func get(c *context) *cobra.Command {
 	cmd.AddCommand(getIdentity(c))
 	cmd.AddCommand(getJob(c))
 	cmd.AddCommand(getJobs(c))
+	cmd.AddCommand(getKeytabs(c))
 	cmd.AddCommand(getLabels(c))
 	cmd.AddCommand(getModel(c))
 	cmd.AddCommand(getModels(c))
@@ -1577,6 +1904,38 @@ cluster [?]
 Get Cluster
 Examples:
 
+    Get the launch progress and log of a cluster started using Yarn
+    $ steam get cluster --launch \
+        --cluster-id=? \
+        --log-lines=?
+
+    Get the aggregated Yarn logs of a cluster started using Yarn
+    $ steam get cluster --logs \
+        --cluster-id=? \
+        --container-id=? \
+        --tail-lines=? \
+        --offset=?
+
+    Get resource usage samples of a cluster's nodes
+    $ steam get cluster --metrics \
+        --cluster-id=? \
+        --from=? \
+        --to=? \
+        --step=?
+
+    Get cluster launch template details
+    $ steam get cluster --template \
+        --template-id=?
+
+    List cluster launch templates
+    $ steam get cluster --templates \
+        --offset=? \
+        --limit=?
+
+    Get the proxy rules of a cluster
+    $ steam get cluster --proxy-policy \
+        --cluster-id=?
+
     Get cluster details
     $ steam get cluster \
         --cluster-id=?
@@ -1592,83 +1951,260 @@ Examples:
 `
 
 func getCluster(c *context) *cobra.Command {
-	var onYarn bool     // Switch for GetClusterOnYarn()
-	var status bool     // Switch for GetClusterStatus()
-	var clusterId int64 // No description available
+	var launch bool        // Switch for GetClusterLaunch()
+	var logs bool          // Switch for GetClusterLogs()
+	var metrics bool       // Switch for GetClusterMetrics()
+	var template bool      // Switch for GetClusterTemplate()
+	var templates bool     // Switch for GetClusterTemplates()
+	var proxyPolicy bool   // Switch for GetClusterProxyPolicy()
+	var onYarn bool        // Switch for GetClusterOnYarn()
+	var status bool        // Switch for GetClusterStatus()
+	var clusterId int64    // No description available
+	var containerId string // Container to get the logs of (optional; all containers by default)
+	var from int64         // Start of the period (Unix time; defaults to an hour before its end)
+	var limit int64        // No description available
+	var logLines int       // Number of log lines to return
+	var offset int64       // No description available
+	var step int64         // Seconds to average samples over (0 for raw samples)
+	var tailLines int      // Number of log lines to return (at most 1000)
+	var templateId int64   // No description available
+	var to int64           // End of the period (Unix time; defaults to now)
 
 	cmd := newCmd(c, getClusterHelp, func(c *context, args []string) {
-		if onYarn { // GetClusterOnYarn
+		if launch { // GetClusterLaunch
 
-			// Get cluster details (Yarn only)
-			cluster, err := c.remote.GetClusterOnYarn(
+			// Get the launch progress and log of a cluster started using Yarn
+			launch, err := c.remote.GetClusterLaunch(
 				clusterId, // No description available
+				logLines,  // Number of log lines to return
 			)
 			if err != nil {
 				log.Fatalln(err)
 			}
 			lines := []string{
-				fmt.Sprintf("Id:\t%v\t", cluster.Id),                       // No description available
-				fmt.Sprintf("EngineId:\t%v\t", cluster.EngineId),           // No description available
-				fmt.Sprintf("Size:\t%v\t", cluster.Size),                   // No description available
-				fmt.Sprintf("ApplicationId:\t%v\t", cluster.ApplicationId), // No description available
-				fmt.Sprintf("Memory:\t%v\t", cluster.Memory),               // No description available
-				fmt.Sprintf("Username:\t%v\t", cluster.Username),           // No description available
+				fmt.Sprintf("ClusterId:\t%v\t", launch.ClusterId),         // No description available
+				fmt.Sprintf("State:\t%v\t", launch.State),                 // No description available
+				fmt.Sprintf("ApplicationId:\t%v\t", launch.ApplicationId), // No description available
+				fmt.Sprintf("Size:\t%v\t", launch.Size),                   // No description available
+				fmt.Sprintf("NodesUp:\t%v\t", launch.NodesUp),             // No description available
+				fmt.Sprintf("Errors:\t%v\t", launch.Errors),               // No description available
+				fmt.Sprintf("Log:\t%v\t", launch.Log),                     // No description available
 			}
 			c.printt("Attribute\tValue\t", lines)
 			return
 		}
-		if status { // GetClusterStatus
+		if logs { // GetClusterLogs
 
-			// Get cluster status
-			clusterStatus, err := c.remote.GetClusterStatus(
-				clusterId, // No description available
+			// Get the aggregated Yarn logs of a cluster started using Yarn
+			logs, err := c.remote.GetClusterLogs(
+				clusterId,   // No description available
+				containerId, // Container to get the logs of (optional; all containers by default)
+				tailLines,   // Number of log lines to return (at most 1000)
+				offset,      // Number of lines at the end of the log to skip, for paging back
 			)
 			if err != nil {
 				log.Fatalln(err)
 			}
 			lines := []string{
-				fmt.Sprintf("Version:\t%v\t", clusterStatus.Version),                           // No description available
-				fmt.Sprintf("Status:\t%v\t", clusterStatus.Status),                             // No description available
-				fmt.Sprintf("MaxMemory:\t%v\t", clusterStatus.MaxMemory),                       // No description available
-				fmt.Sprintf("TotalCpuCount:\t%v\t", clusterStatus.TotalCpuCount),               // No description available
-				fmt.Sprintf("TotalAllowedCpuCount:\t%v\t", clusterStatus.TotalAllowedCpuCount), // No description available
+				fmt.Sprintf("ClusterId:\t%v\t", logs.ClusterId),         // No description available
+				fmt.Sprintf("ApplicationId:\t%v\t", logs.ApplicationId), // No description available
+				fmt.Sprintf("Log:\t%v\t", logs.Log),                     // No description available
+				fmt.Sprintf("TotalLines:\t%v\t", logs.TotalLines),       // No description available
+				fmt.Sprintf("Offset:\t%v\t", logs.Offset),               // No description available
 			}
 			c.printt("Attribute\tValue\t", lines)
 			return
 		}
-		if true { // default
+		if metrics { // GetClusterMetrics
 
-			// Get cluster details
-			cluster, err := c.remote.GetCluster(
+			// Get resource usage samples of a cluster's nodes
+			metrics, err := c.remote.GetClusterMetrics(
 				clusterId, // No description available
+				from,      // Start of the period (Unix time; defaults to an hour before its end)
+				to,        // End of the period (Unix time; defaults to now)
+				step,      // Seconds to average samples over (0 for raw samples)
+			)
+			if err != nil {
+				log.Fatalln(err)
+			}
+			lines := make([]string, len(metrics))
+			for i, e := range metrics {
+				lines[i] = fmt.Sprintf(
+					"%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t",
+					e.Time,         // No description available
+					e.Node,         // No description available
+					e.Healthy,      // No description available
+					e.SysLoad,      // No description available
+					e.MyCpuPct,     // No description available
+					e.SysCpuPct,    // No description available
+					e.FreeMem,      // No description available
+					e.MaxMem,       // No description available
+					e.PojoMem,      // No description available
+					e.MemValueSize, // No description available
+				)
+			}
+			c.printt("Time\tNode\tHealthy\tSysLoad\tMyCpuPct\tSysCpuPct\tFreeMem\tMaxMem\tPojoMem\tMemValueSize\t", lines)
+			return
+		}
+		if template { // GetClusterTemplate
+
+			// Get cluster launch template details
+			template, err := c.remote.GetClusterTemplate(
+				templateId, // No description available
 			)
 			if err != nil {
 				log.Fatalln(err)
 			}
 			lines := []string{
-				fmt.Sprintf("Id:\t%v\t", cluster.Id),               // No description available
-				fmt.Sprintf("Name:\t%v\t", cluster.Name),           // No description available
-				fmt.Sprintf("TypeId:\t%v\t", cluster.TypeId),       // No description available
-				fmt.Sprintf("DetailId:\t%v\t", cluster.DetailId),   // No description available
-				fmt.Sprintf("Address:\t%v\t", cluster.Address),     // No description available
-				fmt.Sprintf("State:\t%v\t", cluster.State),         // No description available
-				fmt.Sprintf("CreatedAt:\t%v\t", cluster.CreatedAt), // No description available
+				fmt.Sprintf("Id:\t%v\t", template.Id),                   // No description available
+				fmt.Sprintf("Name:\t%v\t", template.Name),               // No description available
+				fmt.Sprintf("EngineId:\t%v\t", template.EngineId),       // No description available
+				fmt.Sprintf("Size:\t%v\t", template.Size),               // No description available
+				fmt.Sprintf("Memory:\t%v\t", template.Memory),           // No description available
+				fmt.Sprintf("DriverArgs:\t%v\t", template.DriverArgs),   // No description available
+				fmt.Sprintf("Queue:\t%v\t", template.Queue),             // No description available
+				fmt.Sprintf("IdleTimeout:\t%v\t", template.IdleTimeout), // No description available
+				fmt.Sprintf("Tags:\t%v\t", template.Tags),               // No description available
+				fmt.Sprintf("MaxClusters:\t%v\t", template.MaxClusters), // No description available
+				fmt.Sprintf("CreatedAt:\t%v\t", template.CreatedAt),     // No description available
 			}
 			c.printt("Attribute\tValue\t", lines)
 			return
 		}
-	})
-	cmd.Flags().BoolVar(&onYarn, "on-yarn", onYarn, "Get cluster details (Yarn only)")
-	cmd.Flags().BoolVar(&status, "status", status, "Get cluster status")
-
-	cmd.Flags().Int64Var(&clusterId, "cluster-id", clusterId, "No description available")
-	return cmd
-}
-
-var getClustersHelp = `
-clusters [?]
-Get Clusters
-Examples:
+		if templates { // GetClusterTemplates
+
+			// List cluster launch templates
+			templates, err := c.remote.GetClusterTemplates(
+				offset, // No description available
+				limit,  // No description available
+			)
+			if err != nil {
+				log.Fatalln(err)
+			}
+			lines := make([]string, len(templates))
+			for i, e := range templates {
+				lines[i] = fmt.Sprintf(
+					"%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t",
+					e.Id,          // No description available
+					e.Name,        // No description available
+					e.EngineId,    // No description available
+					e.Size,        // No description available
+					e.Memory,      // No description available
+					e.DriverArgs,  // No description available
+					e.Queue,       // No description available
+					e.IdleTimeout, // No description available
+					e.Tags,        // No description available
+					e.MaxClusters, // No description available
+					e.CreatedAt,   // No description available
+				)
+			}
+			c.printt("Id\tName\tEngineId\tSize\tMemory\tDriverArgs\tQueue\tIdleTimeout\tTags\tMaxClusters\tCreatedAt\t", lines)
+			return
+		}
+		if proxyPolicy { // GetClusterProxyPolicy
+
+			// Get the proxy rules of a cluster
+			policy, err := c.remote.GetClusterProxyPolicy(
+				clusterId, // No description available
+			)
+			if err != nil {
+				log.Fatalln(err)
+			}
+			fmt.Printf("Policy:\t%v\n", policy)
+			return
+		}
+		if onYarn { // GetClusterOnYarn
+
+			// Get cluster details (Yarn only)
+			cluster, err := c.remote.GetClusterOnYarn(
+				clusterId, // No description available
+			)
+			if err != nil {
+				log.Fatalln(err)
+			}
+			lines := []string{
+				fmt.Sprintf("Id:\t%v\t", cluster.Id),                       // No description available
+				fmt.Sprintf("EngineId:\t%v\t", cluster.EngineId),           // No description available
+				fmt.Sprintf("Size:\t%v\t", cluster.Size),                   // No description available
+				fmt.Sprintf("ApplicationId:\t%v\t", cluster.ApplicationId), // No description available
+				fmt.Sprintf("Memory:\t%v\t", cluster.Memory),               // No description available
+				fmt.Sprintf("Username:\t%v\t", cluster.Username),           // No description available
+				fmt.Sprintf("IdleTimeout:\t%v\t", cluster.IdleTimeout),     // No description available
+				fmt.Sprintf("IdleDeadline:\t%v\t", cluster.IdleDeadline),   // No description available
+				fmt.Sprintf("Queue:\t%v\t", cluster.Queue),                 // No description available
+				fmt.Sprintf("NodeLabel:\t%v\t", cluster.NodeLabel),         // No description available
+				fmt.Sprintf("DriverArgs:\t%v\t", cluster.DriverArgs),       // No description available
+			}
+			c.printt("Attribute\tValue\t", lines)
+			return
+		}
+		if status { // GetClusterStatus
+
+			// Get cluster status
+			clusterStatus, err := c.remote.GetClusterStatus(
+				clusterId, // No description available
+			)
+			if err != nil {
+				log.Fatalln(err)
+			}
+			lines := []string{
+				fmt.Sprintf("Version:\t%v\t", clusterStatus.Version),                           // No description available
+				fmt.Sprintf("Status:\t%v\t", clusterStatus.Status),                             // No description available
+				fmt.Sprintf("MaxMemory:\t%v\t", clusterStatus.MaxMemory),                       // No description available
+				fmt.Sprintf("TotalCpuCount:\t%v\t", clusterStatus.TotalCpuCount),               // No description available
+				fmt.Sprintf("TotalAllowedCpuCount:\t%v\t", clusterStatus.TotalAllowedCpuCount), // No description available
+			}
+			c.printt("Attribute\tValue\t", lines)
+			return
+		}
+		if true { // default
+
+			// Get cluster details
+			cluster, err := c.remote.GetCluster(
+				clusterId, // No description available
+			)
+			if err != nil {
+				log.Fatalln(err)
+			}
+			lines := []string{
+				fmt.Sprintf("Id:\t%v\t", cluster.Id),               // No description available
+				fmt.Sprintf("Name:\t%v\t", cluster.Name),           // No description available
+				fmt.Sprintf("TypeId:\t%v\t", cluster.TypeId),       // No description available
+				fmt.Sprintf("DetailId:\t%v\t", cluster.DetailId),   // No description available
+				fmt.Sprintf("Address:\t%v\t", cluster.Address),     // No description available
+				fmt.Sprintf("State:\t%v\t", cluster.State),         // No description available
+				fmt.Sprintf("CreatedAt:\t%v\t", cluster.CreatedAt), // No description available
+			}
+			c.printt("Attribute\tValue\t", lines)
+			return
+		}
+	})
+	cmd.Flags().BoolVar(&launch, "launch", launch, "Get the launch progress and log of a cluster started using Yarn")
+	cmd.Flags().BoolVar(&logs, "logs", logs, "Get the aggregated Yarn logs of a cluster started using Yarn")
+	cmd.Flags().BoolVar(&metrics, "metrics", metrics, "Get resource usage samples of a cluster's nodes")
+	cmd.Flags().BoolVar(&template, "template", template, "Get cluster launch template details")
+	cmd.Flags().BoolVar(&templates, "templates", templates, "List cluster launch templates")
+	cmd.Flags().BoolVar(&proxyPolicy, "proxy-policy", proxyPolicy, "Get the proxy rules of a cluster")
+	cmd.Flags().BoolVar(&onYarn, "on-yarn", onYarn, "Get cluster details (Yarn only)")
+	cmd.Flags().BoolVar(&status, "status", status, "Get cluster status")
+
+	cmd.Flags().Int64Var(&clusterId, "cluster-id", clusterId, "No description available")
+	cmd.Flags().StringVar(&containerId, "container-id", containerId, "Container to get the logs of (optional; all containers by default)")
+	cmd.Flags().Int64Var(&from, "from", from, "Start of the period (Unix time; defaults to an hour before its end)")
+	cmd.Flags().Int64Var(&limit, "limit", 10000, "No description available")
+	cmd.Flags().IntVar(&logLines, "log-lines", logLines, "Number of log lines to return")
+	cmd.Flags().Int64Var(&offset, "offset", offset, "No description available")
+	cmd.Flags().Int64Var(&step, "step", step, "Seconds to average samples over (0 for raw samples)")
+	cmd.Flags().IntVar(&tailLines, "tail-lines", tailLines, "Number of log lines to return (at most 1000)")
+	cmd.Flags().Int64Var(&templateId, "template-id", templateId, "No description available")
+	cmd.Flags().Int64Var(&to, "to", to, "End of the period (Unix time; defaults to now)")
+	return cmd
+}
+
+var getClustersHelp = `
+clusters [?]
+Get Clusters
+Examples:
 
     List clusters
     $ steam get clusters \
@@ -1748,39 +2284,263 @@ dataset [?]
 Get Dataset
 Examples:
 
+    Get the state of a dataset import job
+    $ steam get dataset --job \
+        --job-id=?
+
+    List the dataset import jobs of a datasource, newest first
+    $ steam get dataset --jobs \
+        --datasource-id=? \
+        --offset=? \
+        --limit=?
+
     Get dataset details
     $ steam get dataset \
         --dataset-id=?
 
+    Get the column profile of a dataset
+    $ steam get dataset --columns \
+        --dataset-id=? \
+        --refresh=?
+
+    Get the columns a dataset assigns to model parameters
+    $ steam get dataset --column-roles \
+        --dataset-id=?
+
+    List the versions of a datasource's data, newest first
+    $ steam get dataset --versions \
+        --datasource-id=?
+
+    Get the comparison recorded when a dataset was created
+    $ steam get dataset --comparison \
+        --dataset-id=?
+
 `
 
 func getDataset(c *context) *cobra.Command {
-	var datasetId int64 // No description available
+	var job bool           // Switch for GetDatasetJob()
+	var jobs bool          // Switch for GetDatasetJobs()
+	var columns bool       // Switch for GetDatasetColumns()
+	var columnRoles bool   // Switch for GetDatasetColumnRoles()
+	var versions bool      // Switch for GetDatasetVersions()
+	var comparison bool    // Switch for GetDatasetComparison()
+	var datasetId int64    // No description available
+	var datasourceId int64 // No description available
+	var jobId int64        // No description available
+	var limit int64        // No description available
+	var offset int64       // No description available
+	var refresh bool       // Recompute the profile from the dataset's frame on its cluster
 
 	cmd := newCmd(c, getDatasetHelp, func(c *context, args []string) {
+		if job { // GetDatasetJob
 
-		// Get dataset details
-		dataset, err := c.remote.GetDataset(
-			datasetId, // No description available
-		)
-		if err != nil {
-			log.Fatalln(err)
+			// Get the state of a dataset import job
+			datasetJob, err := c.remote.GetDatasetJob(
+				jobId, // No description available
+			)
+			if err != nil {
+				log.Fatalln(err)
+			}
+			lines := []string{
+				fmt.Sprintf("Id:\t%v\t", datasetJob.Id),                         // No description available
+				fmt.Sprintf("ClusterId:\t%v\t", datasetJob.ClusterId),           // No description available
+				fmt.Sprintf("DatasourceId:\t%v\t", datasetJob.DatasourceId),     // No description available
+				fmt.Sprintf("DatasetId:\t%v\t", datasetJob.DatasetId),           // No description available
+				fmt.Sprintf("Name:\t%v\t", datasetJob.Name),                     // No description available
+				fmt.Sprintf("State:\t%v\t", datasetJob.State),                   // queued, importing, parsing, done, failed or cancelled
+				fmt.Sprintf("ClusterJobName:\t%v\t", datasetJob.ClusterJobName), // The H2O job currently running the import
+				fmt.Sprintf("Progress:\t%v\t", datasetJob.Progress),             // No description available
+				fmt.Sprintf("Error:\t%v\t", datasetJob.Error),                   // No description available
+				fmt.Sprintf("CreatedAt:\t%v\t", datasetJob.CreatedAt),           // No description available
+				fmt.Sprintf("FinishedAt:\t%v\t", datasetJob.FinishedAt),         // No description available
+			}
+			c.printt("Attribute\tValue\t", lines)
+			return
 		}
-		lines := []string{
-			fmt.Sprintf("Id:\t%v\t", dataset.Id),                                 // No description available
-			fmt.Sprintf("DatasourceId:\t%v\t", dataset.DatasourceId),             // No description available
-			fmt.Sprintf("Name:\t%v\t", dataset.Name),                             // No description available
-			fmt.Sprintf("Description:\t%v\t", dataset.Description),               // No description available
-			fmt.Sprintf("FrameName:\t%v\t", dataset.FrameName),                   // No description available
-			fmt.Sprintf("ResponseColumnName:\t%v\t", dataset.ResponseColumnName), // No description available
-			fmt.Sprintf("JSONProperties:\t%v\t", dataset.JSONProperties),         // No description available
-			fmt.Sprintf("CreatedAt:\t%v\t", dataset.CreatedAt),                   // No description available
+		if jobs { // GetDatasetJobs
+
+			// List the dataset import jobs of a datasource, newest first
+			datasetJobs, err := c.remote.GetDatasetJobs(
+				datasourceId, // No description available
+				offset,       // No description available
+				limit,        // No description available
+			)
+			if err != nil {
+				log.Fatalln(err)
+			}
+			lines := make([]string, len(datasetJobs))
+			for i, e := range datasetJobs {
+				lines[i] = fmt.Sprintf(
+					"%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t",
+					e.Id,             // No description available
+					e.ClusterId,      // No description available
+					e.DatasourceId,   // No description available
+					e.DatasetId,      // No description available
+					e.Name,           // No description available
+					e.State,          // queued, importing, parsing, done, failed or cancelled
+					e.ClusterJobName, // The H2O job currently running the import
+					e.Progress,       // No description available
+					e.Error,          // No description available
+					e.CreatedAt,      // No description available
+					e.FinishedAt,     // No description available
+				)
+			}
+			c.printt("Id\tClusterId\tDatasourceId\tDatasetId\tName\tState\tClusterJobName\tProgress\tError\tCreatedAt\tFinishedAt\t", lines)
+			return
+		}
+		if columns { // GetDatasetColumns
+
+			// Get the column profile of a dataset
+			columns, err := c.remote.GetDatasetColumns(
+				datasetId, // No description available
+				refresh,   // Recompute the profile from the dataset's frame on its cluster
+			)
+			if err != nil {
+				log.Fatalln(err)
+			}
+			lines := make([]string, len(columns))
+			for i, e := range columns {
+				lines[i] = fmt.Sprintf(
+					"%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%+v\t%v\t%v\t%v\t%+v\t%v\t",
+					e.Name,              // No description available
+					e.Type,              // No description available
+					e.MissingCount,      // No description available
+					e.ZeroCount,         // No description available
+					e.Min,               // No description available
+					e.Max,               // No description available
+					e.Mean,              // No description available
+					e.Sigma,             // No description available
+					e.Domain,            // No description available
+					e.DomainCardinality, // No description available
+					e.HistogramBase,     // No description available
+					e.HistogramStride,   // No description available
+					e.HistogramBins,     // No description available
+					e.Role,              // response, ignored, weight, fold or offset; empty for other model features
+				)
+			}
+			c.printt("Name\tType\tMissingCount\tZeroCount\tMin\tMax\tMean\tSigma\tDomain\tDomainCardinality\tHistogramBase\tHistogramStride\tHistogramBins\tRole\t", lines)
+			return
+		}
+		if columnRoles { // GetDatasetColumnRoles
+
+			// Get the columns a dataset assigns to model parameters
+			roles, err := c.remote.GetDatasetColumnRoles(
+				datasetId, // No description available
+			)
+			if err != nil {
+				log.Fatalln(err)
+			}
+			lines := []string{
+				fmt.Sprintf("DatasetId:\t%v\t", roles.DatasetId),                   // No description available
+				fmt.Sprintf("ResponseColumnName:\t%v\t", roles.ResponseColumnName), // No description available
+				fmt.Sprintf("IgnoredColumns:\t%+v\t", roles.IgnoredColumns),        // No description available
+				fmt.Sprintf("WeightsColumn:\t%v\t", roles.WeightsColumn),           // No description available
+				fmt.Sprintf("FoldColumn:\t%v\t", roles.FoldColumn),                 // No description available
+				fmt.Sprintf("OffsetColumn:\t%v\t", roles.OffsetColumn),             // No description available
+			}
+			c.printt("Attribute\tValue\t", lines)
+			return
+		}
+		if versions { // GetDatasetVersions
+
+			// List the versions of a datasource's data, newest first
+			datasets, err := c.remote.GetDatasetVersions(
+				datasourceId, // No description available
+			)
+			if err != nil {
+				log.Fatalln(err)
+			}
+			lines := make([]string, len(datasets))
+			for i, e := range datasets {
+				lines[i] = fmt.Sprintf(
+					"%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t",
+					e.Id,                 // No description available
+					e.DatasourceId,       // No description available
+					e.ClusterId,          // No description available
+					e.ParentId,           // No description available
+					e.Version,            // No description available
+					e.PreviousId,         // No description available
+					e.RowCount,           // No description available
+					e.ColumnHash,         // No description available
+					e.Checksum,           // No description available
+					e.Name,               // No description available
+					e.Description,        // No description available
+					e.FrameName,          // No description available
+					e.ResponseColumnName, // No description available
+					e.JSONProperties,     // No description available
+					e.CreatedAt,          // No description available
+					e.Stale,              // Whether the dataset's frame is gone from its cluster
+				)
+			}
+			c.printt("Id\tDatasourceId\tClusterId\tParentId\tVersion\tPreviousId\tRowCount\tColumnHash\tChecksum\tName\tDescription\tFrameName\tResponseColumnName\tJSONProperties\tCreatedAt\tStale\t", lines)
+			return
+		}
+		if comparison { // GetDatasetComparison
+
+			// Get the comparison recorded when a dataset was created
+			comparison, err := c.remote.GetDatasetComparison(
+				datasetId, // No description available
+			)
+			if err != nil {
+				log.Fatalln(err)
+			}
+			lines := []string{
+				fmt.Sprintf("BaseDatasetId:\t%v\t", comparison.BaseDatasetId),    // No description available
+				fmt.Sprintf("OtherDatasetId:\t%v\t", comparison.OtherDatasetId),  // No description available
+				fmt.Sprintf("AddedColumns:\t%+v\t", comparison.AddedColumns),     // No description available
+				fmt.Sprintf("RemovedColumns:\t%+v\t", comparison.RemovedColumns), // No description available
+				fmt.Sprintf("Columns:\t%+v\t", comparison.Columns),               // No description available
+				fmt.Sprintf("SchemaChanged:\t%v\t", comparison.SchemaChanged),    // No description available
+				fmt.Sprintf("MaxPSI:\t%v\t", comparison.MaxPSI),                  // No description available
+				fmt.Sprintf("CreatedAt:\t%v\t", comparison.CreatedAt),            // No description available
+			}
+			c.printt("Attribute\tValue\t", lines)
+			return
+		}
+		if true { // default
+
+			// Get dataset details
+			dataset, err := c.remote.GetDataset(
+				datasetId, // No description available
+			)
+			if err != nil {
+				log.Fatalln(err)
+			}
+			lines := []string{
+				fmt.Sprintf("Id:\t%v\t", dataset.Id),                                 // No description available
+				fmt.Sprintf("DatasourceId:\t%v\t", dataset.DatasourceId),             // No description available
+				fmt.Sprintf("ClusterId:\t%v\t", dataset.ClusterId),                   // No description available
+				fmt.Sprintf("ParentId:\t%v\t", dataset.ParentId),                     // No description available
+				fmt.Sprintf("Version:\t%v\t", dataset.Version),                       // No description available
+				fmt.Sprintf("PreviousId:\t%v\t", dataset.PreviousId),                 // No description available
+				fmt.Sprintf("RowCount:\t%v\t", dataset.RowCount),                     // No description available
+				fmt.Sprintf("ColumnHash:\t%v\t", dataset.ColumnHash),                 // No description available
+				fmt.Sprintf("Checksum:\t%v\t", dataset.Checksum),                     // No description available
+				fmt.Sprintf("Name:\t%v\t", dataset.Name),                             // No description available
+				fmt.Sprintf("Description:\t%v\t", dataset.Description),               // No description available
+				fmt.Sprintf("FrameName:\t%v\t", dataset.FrameName),                   // No description available
+				fmt.Sprintf("ResponseColumnName:\t%v\t", dataset.ResponseColumnName), // No description available
+				fmt.Sprintf("JSONProperties:\t%v\t", dataset.JSONProperties),         // No description available
+				fmt.Sprintf("CreatedAt:\t%v\t", dataset.CreatedAt),                   // No description available
+				fmt.Sprintf("Stale:\t%v\t", dataset.Stale),                           // Whether the dataset's frame is gone from its cluster
+			}
+			c.printt("Attribute\tValue\t", lines)
+			return
 		}
-		c.printt("Attribute\tValue\t", lines)
-		return
 	})
+	cmd.Flags().BoolVar(&job, "job", job, "Get the state of a dataset import job")
+	cmd.Flags().BoolVar(&jobs, "jobs", jobs, "List the dataset import jobs of a datasource, newest first")
+	cmd.Flags().BoolVar(&columns, "columns", columns, "Get the column profile of a dataset")
+	cmd.Flags().BoolVar(&columnRoles, "column-roles", columnRoles, "Get the columns a dataset assigns to model parameters")
+	cmd.Flags().BoolVar(&versions, "versions", versions, "List the versions of a datasource's data, newest first")
+	cmd.Flags().BoolVar(&comparison, "comparison", comparison, "Get the comparison recorded when a dataset was created")
 
 	cmd.Flags().Int64Var(&datasetId, "dataset-id", datasetId, "No description available")
+	cmd.Flags().Int64Var(&datasourceId, "datasource-id", datasourceId, "No description available")
+	cmd.Flags().Int64Var(&jobId, "job-id", jobId, "No description available")
+	cmd.Flags().Int64Var(&limit, "limit", 10000, "No description available")
+	cmd.Flags().Int64Var(&offset, "offset", offset, "No description available")
+	cmd.Flags().BoolVar(&refresh, "refresh", refresh, "Recompute the profile from the dataset's frame on its cluster")
 	return cmd
 }
 
@@ -1821,18 +2581,26 @@ func getDatasets(c *context) *cobra.Command {
 			lines := make([]string, len(dataset))
 			for i, e := range dataset {
 				lines[i] = fmt.Sprintf(
-					"%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t",
+					"%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t",
 					e.Id,                 // No description available
 					e.DatasourceId,       // No description available
+					e.ClusterId,          // No description available
+					e.ParentId,           // No description available
+					e.Version,            // No description available
+					e.PreviousId,         // No description available
+					e.RowCount,           // No description available
+					e.ColumnHash,         // No description available
+					e.Checksum,           // No description available
 					e.Name,               // No description available
 					e.Description,        // No description available
 					e.FrameName,          // No description available
 					e.ResponseColumnName, // No description available
 					e.JSONProperties,     // No description available
 					e.CreatedAt,          // No description available
+					e.Stale,              // Whether the dataset's frame is gone from its cluster
 				)
 			}
-			c.printt("Id\tDatasourceId\tName\tDescription\tFrameName\tResponseColumnName\tJSONProperties\tCreatedAt\t", lines)
+			c.printt("Id\tDatasourceId\tClusterId\tParentId\tVersion\tPreviousId\tRowCount\tColumnHash\tChecksum\tName\tDescription\tFrameName\tResponseColumnName\tJSONProperties\tCreatedAt\tStale\t", lines)
 			return
 		}
 		if true { // default
@@ -1849,18 +2617,26 @@ func getDatasets(c *context) *cobra.Command {
 			lines := make([]string, len(datasets))
 			for i, e := range datasets {
 				lines[i] = fmt.Sprintf(
-					"%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t",
+					"%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t",
 					e.Id,                 // No description available
 					e.DatasourceId,       // No description available
+					e.ClusterId,          // No description available
+					e.ParentId,           // No description available
+					e.Version,            // No description available
+					e.PreviousId,         // No description available
+					e.RowCount,           // No description available
+					e.ColumnHash,         // No description available
+					e.Checksum,           // No description available
 					e.Name,               // No description available
 					e.Description,        // No description available
 					e.FrameName,          // No description available
 					e.ResponseColumnName, // No description available
 					e.JSONProperties,     // No description available
 					e.CreatedAt,          // No description available
+					e.Stale,              // Whether the dataset's frame is gone from its cluster
 				)
 			}
-			c.printt("Id\tDatasourceId\tName\tDescription\tFrameName\tResponseColumnName\tJSONProperties\tCreatedAt\t", lines)
+			c.printt("Id\tDatasourceId\tClusterId\tParentId\tVersion\tPreviousId\tRowCount\tColumnHash\tChecksum\tName\tDescription\tFrameName\tResponseColumnName\tJSONProperties\tCreatedAt\tStale\t", lines)
 			return
 		}
 	})
@@ -1897,13 +2673,13 @@ func getDatasource(c *context) *cobra.Command {
 			log.Fatalln(err)
 		}
 		lines := []string{
-			fmt.Sprintf("Id:\t%v\t", datasource.Id),                       // No description available
-			fmt.Sprintf("ProjectId:\t%v\t", datasource.ProjectId),         // No description available
-			fmt.Sprintf("Name:\t%v\t", datasource.Name),                   // No description available
-			fmt.Sprintf("Description:\t%v\t", datasource.Description),     // No description available
-			fmt.Sprintf("Kind:\t%v\t", datasource.Kind),                   // No description available
-			fmt.Sprintf("Configuration:\t%v\t", datasource.Configuration), // No description available
-			fmt.Sprintf("CreatedAt:\t%v\t", datasource.CreatedAt),         // No description available
+			fmt.Sprintf("Id:\t%v\t", datasource.Id),                   // No description available
+			fmt.Sprintf("ProjectId:\t%v\t", datasource.ProjectId),     // No description available
+			fmt.Sprintf("Name:\t%v\t", datasource.Name),               // No description available
+			fmt.Sprintf("Description:\t%v\t", datasource.Description), // No description available
+			fmt.Sprintf("Kind:\t%v\t", datasource.Kind),               // No description available
+			fmt.Sprintf("Config:\t%+v\t", datasource.Config),          // No description available
+			fmt.Sprintf("CreatedAt:\t%v\t", datasource.CreatedAt),     // No description available
 		}
 		c.printt("Attribute\tValue\t", lines)
 		return
@@ -1945,17 +2721,17 @@ func getDatasources(c *context) *cobra.Command {
 		lines := make([]string, len(datasources))
 		for i, e := range datasources {
 			lines[i] = fmt.Sprintf(
-				"%v\t%v\t%v\t%v\t%v\t%v\t%v\t",
-				e.Id,            // No description available
-				e.ProjectId,     // No description available
-				e.Name,          // No description available
-				e.Description,   // No description available
-				e.Kind,          // No description available
-				e.Configuration, // No description available
-				e.CreatedAt,     // No description available
+				"%v\t%v\t%v\t%v\t%v\t%+v\t%v\t",
+				e.Id,          // No description available
+				e.ProjectId,   // No description available
+				e.Name,        // No description available
+				e.Description, // No description available
+				e.Kind,        // No description available
+				e.Config,      // No description available
+				e.CreatedAt,   // No description available
 			)
 		}
-		c.printt("Id\tProjectId\tName\tDescription\tKind\tConfiguration\tCreatedAt\t", lines)
+		c.printt("Id\tProjectId\tName\tDescription\tKind\tConfig\tCreatedAt\t", lines)
 		return
 	})
 
@@ -1989,10 +2765,16 @@ func getEngine(c *context) *cobra.Command {
 			log.Fatalln(err)
 		}
 		lines := []string{
-			fmt.Sprintf("Id:\t%v\t", engine.Id),               // No description available
-			fmt.Sprintf("Name:\t%v\t", engine.Name),           // No description available
-			fmt.Sprintf("Location:\t%v\t", engine.Location),   // No description available
-			fmt.Sprintf("CreatedAt:\t%v\t", engine.CreatedAt), // No description available
+			fmt.Sprintf("Id:\t%v\t", engine.Id),                          // No description available
+			fmt.Sprintf("Name:\t%v\t", engine.Name),                      // No description available
+			fmt.Sprintf("Location:\t%v\t", engine.Location),              // No description available
+			fmt.Sprintf("CreatedAt:\t%v\t", engine.CreatedAt),            // No description available
+			fmt.Sprintf("Version:\t%v\t", engine.Version),                // No description available
+			fmt.Sprintf("Distribution:\t%v\t", engine.Distribution),      // No description available
+			fmt.Sprintf("Checksum:\t%v\t", engine.Checksum),              // No description available
+			fmt.Sprintf("ApiLevel:\t%v\t", engine.ApiLevel),              // No description available
+			fmt.Sprintf("MojoAlgorithms:\t%+v\t", engine.MojoAlgorithms), // No description available
+			fmt.Sprintf("DeepWater:\t%v\t", engine.DeepWater),            // No description available
 		}
 		c.printt("Attribute\tValue\t", lines)
 		return
@@ -2024,14 +2806,20 @@ func getEngines(c *context) *cobra.Command {
 		lines := make([]string, len(engines))
 		for i, e := range engines {
 			lines[i] = fmt.Sprintf(
-				"%v\t%v\t%v\t%v\t",
-				e.Id,        // No description available
-				e.Name,      // No description available
-				e.Location,  // No description available
-				e.CreatedAt, // No description available
+				"%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%+v\t%v\t",
+				e.Id,             // No description available
+				e.Name,           // No description available
+				e.Location,       // No description available
+				e.CreatedAt,      // No description available
+				e.Version,        // No description available
+				e.Distribution,   // No description available
+				e.Checksum,       // No description available
+				e.ApiLevel,       // No description available
+				e.MojoAlgorithms, // No description available
+				e.DeepWater,      // No description available
 			)
 		}
-		c.printt("Id\tName\tLocation\tCreatedAt\t", lines)
+		c.printt("Id\tName\tLocation\tCreatedAt\tVersion\tDistribution\tChecksum\tApiLevel\tMojoAlgorithms\tDeepWater\t", lines)
 		return
 	})
 
@@ -2389,6 +3177,42 @@ func getJobs(c *context) *cobra.Command {
 	return cmd
 }
 
+var getKeytabsHelp = `
+keytabs [?]
+Get Keytabs
+Examples:
+
+    List the Kerberos keytabs uploaded by the current identity
+    $ steam get keytabs
+
+`
+
+func getKeytabs(c *context) *cobra.Command {
+
+	cmd := newCmd(c, getKeytabsHelp, func(c *context, args []string) {
+
+		// List the Kerberos keytabs uploaded by the current identity
+		keytabs, err := c.remote.GetKeytabs()
+		if err != nil {
+			log.Fatalln(err)
+		}
+		lines := make([]string, len(keytabs))
+		for i, e := range keytabs {
+			lines[i] = fmt.Sprintf(
+				"%v\t%v\t%v\t%v\t",
+				e.Id,        // No description available
+				e.Name,      // No description available
+				e.Principal, // No description available
+				e.CreatedAt, // No description available
+			)
+		}
+		c.printt("Id\tName\tPrincipal\tCreatedAt\t", lines)
+		return
+	})
+
+	return cmd
+}
+
 var getLabelsHelp = `
 labels [?]
 Get Labels
@@ -2476,29 +3300,31 @@ func getModel(c *context) *cobra.Command {
 				log.Fatalln(err)
 			}
 			lines := []string{
-				fmt.Sprintf("Id:\t%v\t", model.Id),                                   // No description available
-				fmt.Sprintf("TrainingDatasetId:\t%v\t", model.TrainingDatasetId),     // No description available
-				fmt.Sprintf("ValidationDatasetId:\t%v\t", model.ValidationDatasetId), // No description available
-				fmt.Sprintf("Name:\t%v\t", model.Name),                               // No description available
-				fmt.Sprintf("ClusterName:\t%v\t", model.ClusterName),                 // No description available
-				fmt.Sprintf("ModelKey:\t%v\t", model.ModelKey),                       // No description available
-				fmt.Sprintf("Algorithm:\t%v\t", model.Algorithm),                     // No description available
-				fmt.Sprintf("ModelCategory:\t%v\t", model.ModelCategory),             // No description available
-				fmt.Sprintf("DatasetName:\t%v\t", model.DatasetName),                 // No description available
-				fmt.Sprintf("ResponseColumnName:\t%v\t", model.ResponseColumnName),   // No description available
-				fmt.Sprintf("LogicalName:\t%v\t", model.LogicalName),                 // No description available
-				fmt.Sprintf("Location:\t%v\t", model.Location),                       // No description available
-				fmt.Sprintf("ModelObjectType:\t%v\t", model.ModelObjectType),         // No description available
-				fmt.Sprintf("MaxRuntime:\t%v\t", model.MaxRuntime),                   // No description available
-				fmt.Sprintf("JSONMetrics:\t%v\t", model.JSONMetrics),                 // No description available
-				fmt.Sprintf("CreatedAt:\t%v\t", model.CreatedAt),                     // No description available
-				fmt.Sprintf("LabelId:\t%v\t", model.LabelId),                         // No description available
-				fmt.Sprintf("LabelName:\t%v\t", model.LabelName),                     // No description available
-				fmt.Sprintf("Mse:\t%v\t", model.Mse),                                 // No description available
-				fmt.Sprintf("RSquared:\t%v\t", model.RSquared),                       // No description available
-				fmt.Sprintf("Logloss:\t%v\t", model.Logloss),                         // No description available
-				fmt.Sprintf("Auc:\t%v\t", model.Auc),                                 // No description available
-				fmt.Sprintf("Gini:\t%v\t", model.Gini),                               // No description available
+				fmt.Sprintf("Id:\t%v\t", model.Id),                                         // No description available
+				fmt.Sprintf("TrainingDatasetId:\t%v\t", model.TrainingDatasetId),           // No description available
+				fmt.Sprintf("ValidationDatasetId:\t%v\t", model.ValidationDatasetId),       // No description available
+				fmt.Sprintf("TrainingDatasetVersion:\t%v\t", model.TrainingDatasetVersion), // No description available
+				fmt.Sprintf("Stale:\t%v\t", model.Stale),                                   // Whether the model is gone from its cluster
+				fmt.Sprintf("Name:\t%v\t", model.Name),                                     // No description available
+				fmt.Sprintf("ClusterName:\t%v\t", model.ClusterName),                       // No description available
+				fmt.Sprintf("ModelKey:\t%v\t", model.ModelKey),                             // No description available
+				fmt.Sprintf("Algorithm:\t%v\t", model.Algorithm),                           // No description available
+				fmt.Sprintf("ModelCategory:\t%v\t", model.ModelCategory),                   // No description available
+				fmt.Sprintf("DatasetName:\t%v\t", model.DatasetName),                       // No description available
+				fmt.Sprintf("ResponseColumnName:\t%v\t", model.ResponseColumnName),         // No description available
+				fmt.Sprintf("LogicalName:\t%v\t", model.LogicalName),                       // No description available
+				fmt.Sprintf("Location:\t%v\t", model.Location),                             // No description available
+				fmt.Sprintf("ModelObjectType:\t%v\t", model.ModelObjectType),               // No description available
+				fmt.Sprintf("MaxRuntime:\t%v\t", model.MaxRuntime),                         // No description available
+				fmt.Sprintf("JSONMetrics:\t%v\t", model.JSONMetrics),                       // No description available
+				fmt.Sprintf("CreatedAt:\t%v\t", model.CreatedAt),                           // No description available
+				fmt.Sprintf("LabelId:\t%v\t", model.LabelId),                               // No description available
+				fmt.Sprintf("LabelName:\t%v\t", model.LabelName),                           // No description available
+				fmt.Sprintf("Mse:\t%v\t", model.Mse),                                       // No description available
+				fmt.Sprintf("RSquared:\t%v\t", model.RSquared),                             // No description available
+				fmt.Sprintf("Logloss:\t%v\t", model.Logloss),                               // No description available
+				fmt.Sprintf("Auc:\t%v\t", model.Auc),                                       // No description available
+				fmt.Sprintf("Gini:\t%v\t", model.Gini),                                     // No description available
 			}
 			c.printt("Attribute\tValue\t", lines)
 			return
@@ -2513,27 +3339,29 @@ func getModel(c *context) *cobra.Command {
 				log.Fatalln(err)
 			}
 			lines := []string{
-				fmt.Sprintf("Id:\t%v\t", model.Id),                                   // No description available
-				fmt.Sprintf("TrainingDatasetId:\t%v\t", model.TrainingDatasetId),     // No description available
-				fmt.Sprintf("ValidationDatasetId:\t%v\t", model.ValidationDatasetId), // No description available
-				fmt.Sprintf("Name:\t%v\t", model.Name),                               // No description available
-				fmt.Sprintf("ClusterName:\t%v\t", model.ClusterName),                 // No description available
-				fmt.Sprintf("ModelKey:\t%v\t", model.ModelKey),                       // No description available
-				fmt.Sprintf("Algorithm:\t%v\t", model.Algorithm),                     // No description available
-				fmt.Sprintf("ModelCategory:\t%v\t", model.ModelCategory),             // No description available
-				fmt.Sprintf("DatasetName:\t%v\t", model.DatasetName),                 // No description available
-				fmt.Sprintf("ResponseColumnName:\t%v\t", model.ResponseColumnName),   // No description available
-				fmt.Sprintf("LogicalName:\t%v\t", model.LogicalName),                 // No description available
-				fmt.Sprintf("Location:\t%v\t", model.Location),                       // No description available
-				fmt.Sprintf("ModelObjectType:\t%v\t", model.ModelObjectType),         // No description available
-				fmt.Sprintf("MaxRuntime:\t%v\t", model.MaxRuntime),                   // No description available
-				fmt.Sprintf("JSONMetrics:\t%v\t", model.JSONMetrics),                 // No description available
-				fmt.Sprintf("CreatedAt:\t%v\t", model.CreatedAt),                     // No description available
-				fmt.Sprintf("LabelId:\t%v\t", model.LabelId),                         // No description available
-				fmt.Sprintf("LabelName:\t%v\t", model.LabelName),                     // No description available
-				fmt.Sprintf("Mse:\t%v\t", model.Mse),                                 // No description available
-				fmt.Sprintf("RSquared:\t%v\t", model.RSquared),                       // No description available
-				fmt.Sprintf("Logloss:\t%v\t", model.Logloss),                         // No description available
+				fmt.Sprintf("Id:\t%v\t", model.Id),                                         // No description available
+				fmt.Sprintf("TrainingDatasetId:\t%v\t", model.TrainingDatasetId),           // No description available
+				fmt.Sprintf("ValidationDatasetId:\t%v\t", model.ValidationDatasetId),       // No description available
+				fmt.Sprintf("TrainingDatasetVersion:\t%v\t", model.TrainingDatasetVersion), // No description available
+				fmt.Sprintf("Stale:\t%v\t", model.Stale),                                   // Whether the model is gone from its cluster
+				fmt.Sprintf("Name:\t%v\t", model.Name),                                     // No description available
+				fmt.Sprintf("ClusterName:\t%v\t", model.ClusterName),                       // No description available
+				fmt.Sprintf("ModelKey:\t%v\t", model.ModelKey),                             // No description available
+				fmt.Sprintf("Algorithm:\t%v\t", model.Algorithm),                           // No description available
+				fmt.Sprintf("ModelCategory:\t%v\t", model.ModelCategory),                   // No description available
+				fmt.Sprintf("DatasetName:\t%v\t", model.DatasetName),                       // No description available
+				fmt.Sprintf("ResponseColumnName:\t%v\t", model.ResponseColumnName),         // No description available
+				fmt.Sprintf("LogicalName:\t%v\t", model.LogicalName),                       // No description available
+				fmt.Sprintf("Location:\t%v\t", model.Location),                             // No description available
+				fmt.Sprintf("ModelObjectType:\t%v\t", model.ModelObjectType),               // No description available
+				fmt.Sprintf("MaxRuntime:\t%v\t", model.MaxRuntime),                         // No description available
+				fmt.Sprintf("JSONMetrics:\t%v\t", model.JSONMetrics),                       // No description available
+				fmt.Sprintf("CreatedAt:\t%v\t", model.CreatedAt),                           // No description available
+				fmt.Sprintf("LabelId:\t%v\t", model.LabelId),                               // No description available
+				fmt.Sprintf("LabelName:\t%v\t", model.LabelName),                           // No description available
+				fmt.Sprintf("Mse:\t%v\t", model.Mse),                                       // No description available
+				fmt.Sprintf("RSquared:\t%v\t", model.RSquared),                             // No description available
+				fmt.Sprintf("Logloss:\t%v\t", model.Logloss),                               // No description available
 			}
 			c.printt("Attribute\tValue\t", lines)
 			return
@@ -2548,27 +3376,29 @@ func getModel(c *context) *cobra.Command {
 				log.Fatalln(err)
 			}
 			lines := []string{
-				fmt.Sprintf("Id:\t%v\t", model.Id),                                     // No description available
-				fmt.Sprintf("TrainingDatasetId:\t%v\t", model.TrainingDatasetId),       // No description available
-				fmt.Sprintf("ValidationDatasetId:\t%v\t", model.ValidationDatasetId),   // No description available
-				fmt.Sprintf("Name:\t%v\t", model.Name),                                 // No description available
-				fmt.Sprintf("ClusterName:\t%v\t", model.ClusterName),                   // No description available
-				fmt.Sprintf("ModelKey:\t%v\t", model.ModelKey),                         // No description available
-				fmt.Sprintf("Algorithm:\t%v\t", model.Algorithm),                       // No description available
-				fmt.Sprintf("ModelCategory:\t%v\t", model.ModelCategory),               // No description available
-				fmt.Sprintf("DatasetName:\t%v\t", model.DatasetName),                   // No description available
-				fmt.Sprintf("ResponseColumnName:\t%v\t", model.ResponseColumnName),     // No description available
-				fmt.Sprintf("LogicalName:\t%v\t", model.LogicalName),                   // No description available
-				fmt.Sprintf("Location:\t%v\t", model.Location),                         // No description available
-				fmt.Sprintf("ModelObjectType:\t%v\t", model.ModelObjectType),           // No description available
-				fmt.Sprintf("MaxRuntime:\t%v\t", model.MaxRuntime),                     // No description available
-				fmt.Sprintf("JSONMetrics:\t%v\t", model.JSONMetrics),                   // No description available
-				fmt.Sprintf("CreatedAt:\t%v\t", model.CreatedAt),                       // No description available
-				fmt.Sprintf("LabelId:\t%v\t", model.LabelId),                           // No description available
-				fmt.Sprintf("LabelName:\t%v\t", model.LabelName),                       // No description available
-				fmt.Sprintf("Mse:\t%v\t", model.Mse),                                   // No description available
-				fmt.Sprintf("RSquared:\t%v\t", model.RSquared),                         // No description available
-				fmt.Sprintf("MeanResidualDeviance:\t%v\t", model.MeanResidualDeviance), // No description available
+				fmt.Sprintf("Id:\t%v\t", model.Id),                                         // No description available
+				fmt.Sprintf("TrainingDatasetId:\t%v\t", model.TrainingDatasetId),           // No description available
+				fmt.Sprintf("ValidationDatasetId:\t%v\t", model.ValidationDatasetId),       // No description available
+				fmt.Sprintf("TrainingDatasetVersion:\t%v\t", model.TrainingDatasetVersion), // No description available
+				fmt.Sprintf("Stale:\t%v\t", model.Stale),                                   // Whether the model is gone from its cluster
+				fmt.Sprintf("Name:\t%v\t", model.Name),                                     // No description available
+				fmt.Sprintf("ClusterName:\t%v\t", model.ClusterName),                       // No description available
+				fmt.Sprintf("ModelKey:\t%v\t", model.ModelKey),                             // No description available
+				fmt.Sprintf("Algorithm:\t%v\t", model.Algorithm),                           // No description available
+				fmt.Sprintf("ModelCategory:\t%v\t", model.ModelCategory),                   // No description available
+				fmt.Sprintf("DatasetName:\t%v\t", model.DatasetName),                       // No description available
+				fmt.Sprintf("ResponseColumnName:\t%v\t", model.ResponseColumnName),         // No description available
+				fmt.Sprintf("LogicalName:\t%v\t", model.LogicalName),                       // No description available
+				fmt.Sprintf("Location:\t%v\t", model.Location),                             // No description available
+				fmt.Sprintf("ModelObjectType:\t%v\t", model.ModelObjectType),               // No description available
+				fmt.Sprintf("MaxRuntime:\t%v\t", model.MaxRuntime),                         // No description available
+				fmt.Sprintf("JSONMetrics:\t%v\t", model.JSONMetrics),                       // No description available
+				fmt.Sprintf("CreatedAt:\t%v\t", model.CreatedAt),                           // No description available
+				fmt.Sprintf("LabelId:\t%v\t", model.LabelId),                               // No description available
+				fmt.Sprintf("LabelName:\t%v\t", model.LabelName),                           // No description available
+				fmt.Sprintf("Mse:\t%v\t", model.Mse),                                       // No description available
+				fmt.Sprintf("RSquared:\t%v\t", model.RSquared),                             // No description available
+				fmt.Sprintf("MeanResidualDeviance:\t%v\t", model.MeanResidualDeviance),     // No description available
 			}
 			c.printt("Attribute\tValue\t", lines)
 			return
@@ -2583,24 +3413,26 @@ func getModel(c *context) *cobra.Command {
 				log.Fatalln(err)
 			}
 			lines := []string{
-				fmt.Sprintf("Id:\t%v\t", model.Id),                                   // No description available
-				fmt.Sprintf("TrainingDatasetId:\t%v\t", model.TrainingDatasetId),     // No description available
-				fmt.Sprintf("ValidationDatasetId:\t%v\t", model.ValidationDatasetId), // No description available
-				fmt.Sprintf("Name:\t%v\t", model.Name),                               // No description available
-				fmt.Sprintf("ClusterName:\t%v\t", model.ClusterName),                 // No description available
-				fmt.Sprintf("ModelKey:\t%v\t", model.ModelKey),                       // No description available
-				fmt.Sprintf("Algorithm:\t%v\t", model.Algorithm),                     // No description available
-				fmt.Sprintf("ModelCategory:\t%v\t", model.ModelCategory),             // No description available
-				fmt.Sprintf("DatasetName:\t%v\t", model.DatasetName),                 // No description available
-				fmt.Sprintf("ResponseColumnName:\t%v\t", model.ResponseColumnName),   // No description available
-				fmt.Sprintf("LogicalName:\t%v\t", model.LogicalName),                 // No description available
-				fmt.Sprintf("Location:\t%v\t", model.Location),                       // No description available
-				fmt.Sprintf("ModelObjectType:\t%v\t", model.ModelObjectType),         // No description available
-				fmt.Sprintf("MaxRuntime:\t%v\t", model.MaxRuntime),                   // No description available
-				fmt.Sprintf("JSONMetrics:\t%v\t", model.JSONMetrics),                 // No description available
-				fmt.Sprintf("CreatedAt:\t%v\t", model.CreatedAt),                     // No description available
-				fmt.Sprintf("LabelId:\t%v\t", model.LabelId),                         // No description available
-				fmt.Sprintf("LabelName:\t%v\t", model.LabelName),                     // No description available
+				fmt.Sprintf("Id:\t%v\t", model.Id),                                         // No description available
+				fmt.Sprintf("TrainingDatasetId:\t%v\t", model.TrainingDatasetId),           // No description available
+				fmt.Sprintf("ValidationDatasetId:\t%v\t", model.ValidationDatasetId),       // No description available
+				fmt.Sprintf("TrainingDatasetVersion:\t%v\t", model.TrainingDatasetVersion), // No description available
+				fmt.Sprintf("Stale:\t%v\t", model.Stale),                                   // Whether the model is gone from its cluster
+				fmt.Sprintf("Name:\t%v\t", model.Name),                                     // No description available
+				fmt.Sprintf("ClusterName:\t%v\t", model.ClusterName),                       // No description available
+				fmt.Sprintf("ModelKey:\t%v\t", model.ModelKey),                             // No description available
+				fmt.Sprintf("Algorithm:\t%v\t", model.Algorithm),                           // No description available
+				fmt.Sprintf("ModelCategory:\t%v\t", model.ModelCategory),                   // No description available
+				fmt.Sprintf("DatasetName:\t%v\t", model.DatasetName),                       // No description available
+				fmt.Sprintf("ResponseColumnName:\t%v\t", model.ResponseColumnName),         // No description available
+				fmt.Sprintf("LogicalName:\t%v\t", model.LogicalName),                       // No description available
+				fmt.Sprintf("Location:\t%v\t", model.Location),                             // No description available
+				fmt.Sprintf("ModelObjectType:\t%v\t", model.ModelObjectType),               // No description available
+				fmt.Sprintf("MaxRuntime:\t%v\t", model.MaxRuntime),                         // No description available
+				fmt.Sprintf("JSONMetrics:\t%v\t", model.JSONMetrics),                       // No description available
+				fmt.Sprintf("CreatedAt:\t%v\t", model.CreatedAt),                           // No description available
+				fmt.Sprintf("LabelId:\t%v\t", model.LabelId),                               // No description available
+				fmt.Sprintf("LabelName:\t%v\t", model.LabelName),                           // No description available
 			}
 			c.printt("Attribute\tValue\t", lines)
 			return
@@ -2654,28 +3486,30 @@ func getModels(c *context) *cobra.Command {
 			lines := make([]string, len(models))
 			for i, e := range models {
 				lines[i] = fmt.Sprintf(
-					"%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t",
-					e.Id,                  // No description available
-					e.TrainingDatasetId,   // No description available
-					e.ValidationDatasetId, // No description available
-					e.Name,                // No description available
-					e.ClusterName,         // No description available
-					e.ModelKey,            // No description available
-					e.Algorithm,           // No description available
-					e.ModelCategory,       // No description available
-					e.DatasetName,         // No description available
-					e.ResponseColumnName,  // No description available
-					e.LogicalName,         // No description available
-					e.Location,            // No description available
-					e.ModelObjectType,     // No description available
-					e.MaxRuntime,          // No description available
-					e.JSONMetrics,         // No description available
-					e.CreatedAt,           // No description available
-					e.LabelId,             // No description available
-					e.LabelName,           // No description available
+					"%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t",
+					e.Id,                     // No description available
+					e.TrainingDatasetId,      // No description available
+					e.ValidationDatasetId,    // No description available
+					e.TrainingDatasetVersion, // No description available
+					e.Stale,                  // Whether the model is gone from its cluster
+					e.Name,                   // No description available
+					e.ClusterName,            // No description available
+					e.ModelKey,               // No description available
+					e.Algorithm,              // No description available
+					e.ModelCategory,          // No description available
+					e.DatasetName,            // No description available
+					e.ResponseColumnName,     // No description available
+					e.LogicalName,            // No description available
+					e.Location,               // No description available
+					e.ModelObjectType,        // No description available
+					e.MaxRuntime,             // No description available
+					e.JSONMetrics,            // No description available
+					e.CreatedAt,              // No description available
+					e.LabelId,                // No description available
+					e.LabelName,              // No description available
 				)
 			}
-			c.printt("Id\tTrainingDatasetId\tValidationDatasetId\tName\tClusterName\tModelKey\tAlgorithm\tModelCategory\tDatasetName\tResponseColumnName\tLogicalName\tLocation\tModelObjectType\tMaxRuntime\tJSONMetrics\tCreatedAt\tLabelId\tLabelName\t", lines)
+			c.printt("Id\tTrainingDatasetId\tValidationDatasetId\tTrainingDatasetVersion\tStale\tName\tClusterName\tModelKey\tAlgorithm\tModelCategory\tDatasetName\tResponseColumnName\tLogicalName\tLocation\tModelObjectType\tMaxRuntime\tJSONMetrics\tCreatedAt\tLabelId\tLabelName\t", lines)
 			return
 		}
 		if true { // default
@@ -2692,28 +3526,30 @@ func getModels(c *context) *cobra.Command {
 			lines := make([]string, len(models))
 			for i, e := range models {
 				lines[i] = fmt.Sprintf(
-					"%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t",
-					e.Id,                  // No description available
-					e.TrainingDatasetId,   // No description available
-					e.ValidationDatasetId, // No description available
-					e.Name,                // No description available
-					e.ClusterName,         // No description available
-					e.ModelKey,            // No description available
-					e.Algorithm,           // No description available
-					e.ModelCategory,       // No description available
-					e.DatasetName,         // No description available
-					e.ResponseColumnName,  // No description available
-					e.LogicalName,         // No description available
-					e.Location,            // No description available
-					e.ModelObjectType,     // No description available
-					e.MaxRuntime,          // No description available
-					e.JSONMetrics,         // No description available
-					e.CreatedAt,           // No description available
-					e.LabelId,             // No description available
-					e.LabelName,           // No description available
+					"%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t",
+					e.Id,                     // No description available
+					e.TrainingDatasetId,      // No description available
+					e.ValidationDatasetId,    // No description available
+					e.TrainingDatasetVersion, // No description available
+					e.Stale,                  // Whether the model is gone from its cluster
+					e.Name,                   // No description available
+					e.ClusterName,            // No description available
+					e.ModelKey,               // No description available
+					e.Algorithm,              // No description available
+					e.ModelCategory,          // No description available
+					e.DatasetName,            // No description available
+					e.ResponseColumnName,     // No description available
+					e.LogicalName,            // No description available
+					e.Location,               // No description available
+					e.ModelObjectType,        // No description available
+					e.MaxRuntime,             // No description available
+					e.JSONMetrics,            // No description available
+					e.CreatedAt,              // No description available
+					e.LabelId,                // No description available
+					e.LabelName,              // No description available
 				)
 			}
-			c.printt("Id\tTrainingDatasetId\tValidationDatasetId\tName\tClusterName\tModelKey\tAlgorithm\tModelCategory\tDatasetName\tResponseColumnName\tLogicalName\tLocation\tModelObjectType\tMaxRuntime\tJSONMetrics\tCreatedAt\tLabelId\tLabelName\t", lines)
+			c.printt("Id\tTrainingDatasetId\tValidationDatasetId\tTrainingDatasetVersion\tStale\tName\tClusterName\tModelKey\tAlgorithm\tModelCategory\tDatasetName\tResponseColumnName\tLogicalName\tLocation\tModelObjectType\tMaxRuntime\tJSONMetrics\tCreatedAt\tLabelId\tLabelName\t", lines)
 			return
 		}
 	})
@@ -3370,6 +4206,7 @@ func getWorkgroup(c *context) *cobra.Command {
 				fmt.Sprintf("Name:\t%v\t", workgroup.Name),               // No description available
 				fmt.Sprintf("Description:\t%v\t", workgroup.Description), // No description available
 				fmt.Sprintf("Created:\t%v\t", workgroup.Created),         // No description available
+				fmt.Sprintf("IdleTimeout:\t%v\t", workgroup.IdleTimeout), // No description available
 			}
 			c.printt("Attribute\tValue\t", lines)
 			return
@@ -3388,6 +4225,7 @@ func getWorkgroup(c *context) *cobra.Command {
 				fmt.Sprintf("Name:\t%v\t", workgroup.Name),               // No description available
 				fmt.Sprintf("Description:\t%v\t", workgroup.Description), // No description available
 				fmt.Sprintf("Created:\t%v\t", workgroup.Created),         // No description available
+				fmt.Sprintf("IdleTimeout:\t%v\t", workgroup.IdleTimeout), // No description available
 			}
 			c.printt("Attribute\tValue\t", lines)
 			return
@@ -3435,14 +4273,15 @@ func getWorkgroups(c *context) *cobra.Command {
 			lines := make([]string, len(workgroups))
 			for i, e := range workgroups {
 				lines[i] = fmt.Sprintf(
-					"%v\t%v\t%v\t%v\t",
+					"%v\t%v\t%v\t%v\t%v\t",
 					e.Id,          // No description available
 					e.Name,        // No description available
 					e.Description, // No description available
 					e.Created,     // No description available
+					e.IdleTimeout, // No description available
 				)
 			}
-			c.printt("Id\tName\tDescription\tCreated\t", lines)
+			c.printt("Id\tName\tDescription\tCreated\tIdleTimeout\t", lines)
 			return
 		}
 		if true { // default
@@ -3458,14 +4297,15 @@ func getWorkgroups(c *context) *cobra.Command {
 			lines := make([]string, len(workgroups))
 			for i, e := range workgroups {
 				lines[i] = fmt.Sprintf(
-					"%v\t%v\t%v\t%v\t",
+					"%v\t%v\t%v\t%v\t%v\t",
 					e.Id,          // No description available
 					e.Name,        // No description available
 					e.Description, // No description available
 					e.Created,     // No description available
+					e.IdleTimeout, // No description available
 				)
 			}
-			c.printt("Id\tName\tDescription\tCreated\t", lines)
+			c.printt("Id\tName\tDescription\tCreated\tIdleTimeout\t", lines)
 			return
 		}
 	})
@@ -3575,9 +4415,54 @@ func importModel(c *context) *cobra.Command {
 	return cmd
 }
 
-var linkHelp = `
-link [?]
-Link entities
+var keepHelp = `
+keep [?]
+Keep entities
+Commands:
+
+    $ steam keep alive ...
+`
+
+func keep(c *context) *cobra.Command {
+	cmd := newCmd(c, keepHelp, nil)
+
+	cmd.AddCommand(keepAlive(c))
+	return cmd
+}
+
+var keepAliveHelp = `
+alive [?]
+Keep Alive
+Examples:
+
+    Postpone the idle shutdown of a cluster
+    $ steam keep alive \
+        --cluster-id=?
+
+`
+
+func keepAlive(c *context) *cobra.Command {
+	var clusterId int64 // No description available
+
+	cmd := newCmd(c, keepAliveHelp, func(c *context, args []string) {
+
+		// Postpone the idle shutdown of a cluster
+		err := c.remote.KeepAlive(
+			clusterId, // No description available
+		)
+		if err != nil {
+			log.Fatalln(err)
+		}
+		return
+	})
+
+	cmd.Flags().Int64Var(&clusterId, "cluster-id", clusterId, "No description available")
+	return cmd
+}
+
+var linkHelp = `
+link [?]
+Link entities
 Commands:
 
     $ steam link identity ...
@@ -3797,18 +4682,30 @@ Examples:
 
     Connect to a cluster
     $ steam register cluster \
-        --address=?
+        --address=? \
+        --scheme=? \
+        --ca-cert=? \
+        --username=? \
+        --password=?
 
 `
 
 func registerCluster(c *context) *cobra.Command {
-	var address string // No description available
+	var address string  // No description available
+	var caCert string   // PEM-encoded CA bundle used to verify the cluster's certificate
+	var password string // Password for basic authentication
+	var scheme string   // Connection scheme: http (default) or https
+	var username string // Username for basic authentication
 
 	cmd := newCmd(c, registerClusterHelp, func(c *context, args []string) {
 
 		// Connect to a cluster
 		clusterId, err := c.remote.RegisterCluster(
-			address, // No description available
+			address,  // No description available
+			scheme,   // Connection scheme: http (default) or https
+			caCert,   // PEM-encoded CA bundle used to verify the cluster's certificate
+			username, // Username for basic authentication
+			password, // Password for basic authentication
 		)
 		if err != nil {
 			log.Fatalln(err)
@@ -3818,6 +4715,56 @@ func registerCluster(c *context) *cobra.Command {
 	})
 
 	cmd.Flags().StringVar(&address, "address", address, "No description available")
+	cmd.Flags().StringVar(&caCert, "ca-cert", caCert, "PEM-encoded CA bundle used to verify the cluster's certificate")
+	cmd.Flags().StringVar(&password, "password", password, "Password for basic authentication")
+	cmd.Flags().StringVar(&scheme, "scheme", scheme, "Connection scheme: http (default) or https")
+	cmd.Flags().StringVar(&username, "username", username, "Username for basic authentication")
+	return cmd
+}
+
+var reimportHelp = `
+reimport [?]
+Reimport entities
+Commands:
+
+    $ steam reimport dataset ...
+`
+
+func reimport(c *context) *cobra.Command {
+	cmd := newCmd(c, reimportHelp, nil)
+
+	cmd.AddCommand(reimportDataset(c))
+	return cmd
+}
+
+var reimportDatasetHelp = `
+dataset [?]
+Reimport Dataset
+Examples:
+
+    Re-import a stale dataset from its datasource into its cluster
+    $ steam reimport dataset \
+        --dataset-id=?
+
+`
+
+func reimportDataset(c *context) *cobra.Command {
+	var datasetId int64 // No description available
+
+	cmd := newCmd(c, reimportDatasetHelp, func(c *context, args []string) {
+
+		// Re-import a stale dataset from its datasource into its cluster
+		jobId, err := c.remote.ReimportDataset(
+			datasetId, // No description available
+		)
+		if err != nil {
+			log.Fatalln(err)
+		}
+		fmt.Printf("JobId:\t%v\n", jobId)
+		return
+	})
+
+	cmd.Flags().Int64Var(&datasetId, "dataset-id", datasetId, "No description available")
 	return cmd
 }
 
@@ -3827,12 +4774,18 @@ Set entities
 Commands:
 
     $ steam set attributes ...
+    $ steam set cluster ...
+    $ steam set dataset ...
+    $ steam set workgroup ...
 `
 
 func set(c *context) *cobra.Command {
 	cmd := newCmd(c, setHelp, nil)
 
 	cmd.AddCommand(setAttributes(c))
+	cmd.AddCommand(setCluster(c))
+	cmd.AddCommand(setDataset(c))
+	cmd.AddCommand(setWorkgroup(c))
 	return cmd
 }
 
@@ -3878,6 +4831,145 @@ func setAttributes(c *context) *cobra.Command {
 	return cmd
 }
 
+var setClusterHelp = `
+cluster [?]
+Set Cluster
+Examples:
+
+    Set the idle timeout of a cluster started using Yarn
+    $ steam set cluster --idle-timeout \
+        --cluster-id=? \
+        --minutes=?
+
+    Set the proxy rules of a cluster
+    $ steam set cluster --proxy-policy \
+        --cluster-id=? \
+        --policy=?
+
+`
+
+func setCluster(c *context) *cobra.Command {
+	var idleTimeout bool // Switch for SetClusterIdleTimeout()
+	var proxyPolicy bool // Switch for SetClusterProxyPolicy()
+	var clusterId int64  // No description available
+	var minutes int64    // Idle timeout in minutes (0 disables idle shutdown)
+	var policy string    // Proxy rules as a JSON array of {method, path, privilege}; empty for the default policy
+
+	cmd := newCmd(c, setClusterHelp, func(c *context, args []string) {
+		if idleTimeout { // SetClusterIdleTimeout
+
+			// Set the idle timeout of a cluster started using Yarn
+			err := c.remote.SetClusterIdleTimeout(
+				clusterId, // No description available
+				minutes,   // Idle timeout in minutes (0 disables idle shutdown)
+			)
+			if err != nil {
+				log.Fatalln(err)
+			}
+			return
+		}
+		if proxyPolicy { // SetClusterProxyPolicy
+
+			// Set the proxy rules of a cluster
+			err := c.remote.SetClusterProxyPolicy(
+				clusterId, // No description available
+				policy,    // Proxy rules as a JSON array of {method, path, privilege}; empty for the default policy
+			)
+			if err != nil {
+				log.Fatalln(err)
+			}
+			return
+		}
+	})
+	cmd.Flags().BoolVar(&idleTimeout, "idle-timeout", idleTimeout, "Set the idle timeout of a cluster started using Yarn")
+	cmd.Flags().BoolVar(&proxyPolicy, "proxy-policy", proxyPolicy, "Set the proxy rules of a cluster")
+
+	cmd.Flags().Int64Var(&clusterId, "cluster-id", clusterId, "No description available")
+	cmd.Flags().Int64Var(&minutes, "minutes", minutes, "Idle timeout in minutes (0 disables idle shutdown)")
+	cmd.Flags().StringVar(&policy, "policy", policy, "Proxy rules as a JSON array of {method, path, privilege}; empty for the default policy")
+	return cmd
+}
+
+var setDatasetHelp = `
+dataset [?]
+Set Dataset
+Examples:
+
+    Assign a role to a dataset column
+    $ steam set dataset --column-role \
+        --dataset-id=? \
+        --column-name=? \
+        --role=?
+
+`
+
+func setDataset(c *context) *cobra.Command {
+	var columnRole bool   // Switch for SetDatasetColumnRole()
+	var columnName string // No description available
+	var datasetId int64   // No description available
+	var role string       // response, ignored, weight, fold or offset; empty to clear
+
+	cmd := newCmd(c, setDatasetHelp, func(c *context, args []string) {
+		if columnRole { // SetDatasetColumnRole
+
+			// Assign a role to a dataset column
+			err := c.remote.SetDatasetColumnRole(
+				datasetId,  // No description available
+				columnName, // No description available
+				role,       // response, ignored, weight, fold or offset; empty to clear
+			)
+			if err != nil {
+				log.Fatalln(err)
+			}
+			return
+		}
+	})
+	cmd.Flags().BoolVar(&columnRole, "column-role", columnRole, "Assign a role to a dataset column")
+
+	cmd.Flags().StringVar(&columnName, "column-name", columnName, "No description available")
+	cmd.Flags().Int64Var(&datasetId, "dataset-id", datasetId, "No description available")
+	cmd.Flags().StringVar(&role, "role", role, "response, ignored, weight, fold or offset; empty to clear")
+	return cmd
+}
+
+var setWorkgroupHelp = `
+workgroup [?]
+Set Workgroup
+Examples:
+
+    Set the default idle timeout for clusters started by workgroup members
+    $ steam set workgroup --idle-timeout \
+        --workgroup-id=? \
+        --minutes=?
+
+`
+
+func setWorkgroup(c *context) *cobra.Command {
+	var idleTimeout bool  // Switch for SetWorkgroupIdleTimeout()
+	var minutes int64     // Idle timeout in minutes (0 disables idle shutdown)
+	var workgroupId int64 // Integer ID of a workgroup in Steam.
+
+	cmd := newCmd(c, setWorkgroupHelp, func(c *context, args []string) {
+		if idleTimeout { // SetWorkgroupIdleTimeout
+
+			// Set the default idle timeout for clusters started by workgroup members
+			err := c.remote.SetWorkgroupIdleTimeout(
+				workgroupId, // Integer ID of a workgroup in Steam.
+				minutes,     // Idle timeout in minutes (0 disables idle shutdown)
+			)
+			if err != nil {
+				log.Fatalln(err)
+			}
+			return
+		}
+	})
+	cmd.Flags().BoolVar(&idleTimeout, "idle-timeout", idleTimeout, "Set the default idle timeout for clusters started by workgroup members")
+
+	cmd.Flags().Int64Var(&minutes, "minutes", minutes, "Idle timeout in minutes (0 disables idle shutdown)")
+	cmd.Flags().Int64Var(&workgroupId, "workgroup-id", workgroupId, "Integer ID of a workgroup in Steam.")
+	return cmd
+}
+
 var shareHelp = `
 share [?]
 Share entities
@@ -3959,22 +5051,25 @@ Examples:
     $ steam split dataset \
         --dataset-id=? \
         --ratio1=? \
-        --ratio2=?
+        --ratio2=? \
+        --seed=?
 
 `
 
 func splitDataset(c *context) *cobra.Command {
 	var datasetId int64 // No description available
-	var ratio1 int      // No description available
-	var ratio2 int      // No description available
+	var ratio1 int      // Percentage of rows in the training split
+	var ratio2 int      // Percentage of rows in the validation split; any remainder becomes a test split
+	var seed int64      // Random seed; 0 picks one, which is recorded on the splits
 
 	cmd := newCmd(c, splitDatasetHelp, func(c *context, args []string) {
 
 		// Split a dataset
 		datasetIds, err := c.remote.SplitDataset(
 			datasetId, // No description available
-			ratio1,    // No description available
-			ratio2,    // No description available
+			ratio1,    // Percentage of rows in the training split
+			ratio2,    // Percentage of rows in the validation split; any remainder becomes a test split
+			seed,      // Random seed; 0 picks one, which is recorded on the splits
 		)
 		if err != nil {
 			log.Fatalln(err)
@@ -3984,8 +5079,9 @@ func splitDataset(c *context) *cobra.Command {
 	})
 
 	cmd.Flags().Int64Var(&datasetId, "dataset-id", datasetId, "No description available")
-	cmd.Flags().IntVar(&ratio1, "ratio1", ratio1, "No description available")
-	cmd.Flags().IntVar(&ratio2, "ratio2", ratio2, "No description available")
+	cmd.Flags().IntVar(&ratio1, "ratio1", ratio1, "Percentage of rows in the training split")
+	cmd.Flags().IntVar(&ratio2, "ratio2", ratio2, "Percentage of rows in the validation split; any remainder becomes a test split")
+	cmd.Flags().Int64Var(&seed, "seed", seed, "Random seed; 0 picks one, which is recorded on the splits")
 	return cmd
 }
 
@@ -4011,23 +5107,47 @@ cluster [?]
 Start Cluster
 Examples:
 
+    Start a cluster using the launcher for its cluster type
+    $ steam start cluster \
+        --cluster-name=? \
+        --cluster-type=? \
+        --engine-id=? \
+        --size=? \
+        --memory=? \
+        --keytab-id=?
+
     Start a cluster using Yarn
     $ steam start cluster --on-yarn \
         --cluster-name=? \
         --engine-id=? \
         --size=? \
         --memory=? \
-        --keytab=?
+        --keytab-id=? \
+        --queue=? \
+        --node-label=? \
+        --driver-args=?
+
+    Start a cluster on Yarn from a launch template
+    $ steam start cluster --from-template \
+        --template-id=? \
+        --cluster-name=? \
+        --keytab-id=?
 
 `
 
 func startCluster(c *context) *cobra.Command {
 	var onYarn bool        // Switch for StartClusterOnYarn()
+	var fromTemplate bool  // Switch for StartClusterFromTemplate()
 	var clusterName string // No description available
+	var clusterType string // Cluster type: yarn or local
+	var driverArgs string  // Extra h2odriver arguments, separated by spaces; must be allowed by the administrator
 	var engineId int64     // No description available
-	var keytab string      // No description available
+	var keytabId int64     // Uploaded keytab to authenticate with (0 for none)
 	var memory string      // No description available
+	var nodeLabel string   // Yarn node label expression to place the cluster with (optional)
+	var queue string       // Yarn queue to submit the cluster to (optional)
 	var size int           // No description available
+	var templateId int64   // No description available
 
 	cmd := newCmd(c, startClusterHelp, func(c *context, args []string) {
 		if onYarn { // StartClusterOnYarn
@@ -4038,7 +5158,41 @@ func startCluster(c *context) *cobra.Command {
 				engineId,    // No description available
 				size,        // No description available
 				memory,      // No description available
-				keytab,      // No description available
+				keytabId,    // Uploaded keytab to authenticate with (0 for none)
+				queue,       // Yarn queue to submit the cluster to (optional)
+				nodeLabel,   // Yarn node label expression to place the cluster with (optional)
+				driverArgs,  // Extra h2odriver arguments, separated by spaces; must be allowed by the administrator
+			)
+			if err != nil {
+				log.Fatalln(err)
+			}
+			fmt.Printf("ClusterId:\t%v\n", clusterId)
+			return
+		}
+		if fromTemplate { // StartClusterFromTemplate
+
+			// Start a cluster on Yarn from a launch template
+			clusterId, err := c.remote.StartClusterFromTemplate(
+				templateId,  // No description available
+				clusterName, // No description available
+				keytabId,    // Uploaded keytab to authenticate with (0 for none)
+			)
+			if err != nil {
+				log.Fatalln(err)
+			}
+			fmt.Printf("ClusterId:\t%v\n", clusterId)
+			return
+		}
+		if true { // default
+
+			// Start a cluster using the launcher for its cluster type
+			clusterId, err := c.remote.StartCluster(
+				clusterName, // No description available
+				clusterType, // Cluster type: yarn or local
+				engineId,    // No description available
+				size,        // No description available
+				memory,      // No description available
+				keytabId,    // Uploaded keytab to authenticate with (0 for none)
 			)
 			if err != nil {
 				log.Fatalln(err)
@@ -4048,12 +5202,18 @@ func startCluster(c *context) *cobra.Command {
 		}
 	})
 	cmd.Flags().BoolVar(&onYarn, "on-yarn", onYarn, "Start a cluster using Yarn")
+	cmd.Flags().BoolVar(&fromTemplate, "from-template", fromTemplate, "Start a cluster on Yarn from a launch template")
 
 	cmd.Flags().StringVar(&clusterName, "cluster-name", clusterName, "No description available")
+	cmd.Flags().StringVar(&clusterType, "cluster-type", clusterType, "Cluster type: yarn or local")
+	cmd.Flags().StringVar(&driverArgs, "driver-args", driverArgs, "Extra h2odriver arguments, separated by spaces; must be allowed by the administrator")
 	cmd.Flags().Int64Var(&engineId, "engine-id", engineId, "No description available")
-	cmd.Flags().StringVar(&keytab, "keytab", keytab, "No description available")
+	cmd.Flags().Int64Var(&keytabId, "keytab-id", keytabId, "Uploaded keytab to authenticate with (0 for none)")
 	cmd.Flags().StringVar(&memory, "memory", memory, "No description available")
+	cmd.Flags().StringVar(&nodeLabel, "node-label", nodeLabel, "Yarn node label expression to place the cluster with (optional)")
+	cmd.Flags().StringVar(&queue, "queue", queue, "Yarn queue to submit the cluster to (optional)")
 	cmd.Flags().IntVar(&size, "size", size, "No description available")
+	cmd.Flags().Int64Var(&templateId, "template-id", templateId, "No description available")
 	return cmd
 }
 
@@ -4118,17 +5278,21 @@ cluster [?]
 Stop Cluster
 Examples:
 
+    Stop a cluster started by Steam
+    $ steam stop cluster \
+        --cluster-id=?
+
     Stop a cluster using Yarn
     $ steam stop cluster --on-yarn \
         --cluster-id=? \
-        --keytab=?
+        --keytab-id=?
 
 `
 
 func stopCluster(c *context) *cobra.Command {
 	var onYarn bool     // Switch for StopClusterOnYarn()
 	var clusterId int64 // No description available
-	var keytab string   // No description available
+	var keytabId int64  // Uploaded keytab to authenticate with (0 to stop a cluster you started with the keytab it was started with)
 
 	cmd := newCmd(c, stopClusterHelp, func(c *context, args []string) {
 		if onYarn { // StopClusterOnYarn
@@ -4136,7 +5300,18 @@ func stopCluster(c *context) *cobra.Command {
 			// Stop a cluster using Yarn
 			err := c.remote.StopClusterOnYarn(
 				clusterId, // No description available
-				keytab,    // No description available
+				keytabId,  // Uploaded keytab to authenticate with (0 to stop a cluster you started with the keytab it was started with)
+			)
+			if err != nil {
+				log.Fatalln(err)
+			}
+			return
+		}
+		if true { // default
+
+			// Stop a cluster started by Steam
+			err := c.remote.StopCluster(
+				clusterId, // No description available
 			)
 			if err != nil {
 				log.Fatalln(err)
@@ -4147,7 +5322,7 @@ func stopCluster(c *context) *cobra.Command {
 	cmd.Flags().BoolVar(&onYarn, "on-yarn", onYarn, "Stop a cluster using Yarn")
 
 	cmd.Flags().Int64Var(&clusterId, "cluster-id", clusterId, "No description available")
-	cmd.Flags().StringVar(&keytab, "keytab", keytab, "No description available")
+	cmd.Flags().Int64Var(&keytabId, "keytab-id", keytabId, "Uploaded keytab to authenticate with (0 to stop a cluster you started with the keytab it was started with)")
 	return cmd
 }
 
@@ -4181,6 +5356,110 @@ func stopService(c *context) *cobra.Command {
 	return cmd
 }
 
+var syncHelp = `
+sync [?]
+Sync entities
+Commands:
+
+    $ steam sync cluster ...
+`
+
+func sync(c *context) *cobra.Command {
+	cmd := newCmd(c, syncHelp, nil)
+
+	cmd.AddCommand(syncCluster(c))
+	return cmd
+}
+
+var syncClusterHelp = `
+cluster [?]
+Sync Cluster
+Examples:
+
+    Mark datasets and models whose frames or models are gone from a cluster as stale
+    $ steam sync cluster \
+        --cluster-id=?
+
+`
+
+func syncCluster(c *context) *cobra.Command {
+	var clusterId int64 // No description available
+
+	cmd := newCmd(c, syncClusterHelp, func(c *context, args []string) {
+
+		// Mark datasets and models whose frames or models are gone from a cluster as stale
+		sync, err := c.remote.SyncCluster(
+			clusterId, // No description available
+		)
+		if err != nil {
+			log.Fatalln(err)
+		}
+		lines := []string{
+			fmt.Sprintf("ClusterId:\t%v\t", sync.ClusterId),          // No description available
+			fmt.Sprintf("FrameCount:\t%v\t", sync.FrameCount),        // No description available
+			fmt.Sprintf("ModelCount:\t%v\t", sync.ModelCount),        // No description available
+			fmt.Sprintf("StaleDatasets:\t%+v\t", sync.StaleDatasets), // No description available
+			fmt.Sprintf("StaleModels:\t%+v\t", sync.StaleModels),     // No description available
+			fmt.Sprintf("SyncedAt:\t%v\t", sync.SyncedAt),            // No description available
+		}
+		c.printt("Attribute\tValue\t", lines)
+		return
+	})
+
+	cmd.Flags().Int64Var(&clusterId, "cluster-id", clusterId, "No description available")
+	return cmd
+}
+
+var testHelp = `
+test [?]
+Test entities
+Commands:
+
+    $ steam test datasource ...
+`
+
+func test(c *context) *cobra.Command {
+	cmd := newCmd(c, testHelp, nil)
+
+	cmd.AddCommand(testDatasource(c))
+	return cmd
+}
+
+var testDatasourceHelp = `
+datasource [?]
+Test Datasource
+Examples:
+
+    Check that a datasource can be reached
+    $ steam test datasource \
+        --datasource-id=? \
+        --cluster-id=?
+
+`
+
+func testDatasource(c *context) *cobra.Command {
+	var clusterId int64    // Cluster to look for file and HDFS datasources from
+	var datasourceId int64 // No description available
+
+	cmd := newCmd(c, testDatasourceHelp, func(c *context, args []string) {
+
+		// Check that a datasource can be reached
+		message, err := c.remote.TestDatasource(
+			datasourceId, // No description available
+			clusterId,    // Cluster to look for file and HDFS datasources from
+		)
+		if err != nil {
+			log.Fatalln(err)
+		}
+		fmt.Printf("Message:\t%v\n", message)
+		return
+	})
+
+	cmd.Flags().Int64Var(&clusterId, "cluster-id", clusterId, "Cluster to look for file and HDFS datasources from")
+	cmd.Flags().Int64Var(&datasourceId, "datasource-id", datasourceId, "No description available")
+	return cmd
+}
+
 var unlinkHelp = `
 unlink [?]
 Unlink entities
@@ -4442,6 +5721,7 @@ update [?]
 Update entities
 Commands:
 
+    $ steam update cluster ...
     $ steam update dataset ...
     $ steam update datasource ...
     $ steam update identity ...
@@ -4453,6 +5733,7 @@ Commands:
 func update(c *context) *cobra.Command {
 	cmd := newCmd(c, updateHelp, nil)
 
+	cmd.AddCommand(updateCluster(c))
 	cmd.AddCommand(updateDataset(c))
 	cmd.AddCommand(updateDatasource(c))
 	cmd.AddCommand(updateIdentity(c))
@@ -4462,6 +5743,76 @@ func update(c *context) *cobra.Command {
 	return cmd
 }
 
+var updateClusterHelp = `
+cluster [?]
+Update Cluster
+Examples:
+
+    Update a cluster launch template
+    $ steam update cluster --template \
+        --template-id=? \
+        --name=? \
+        --engine-id=? \
+        --size=? \
+        --memory=? \
+        --driver-args=? \
+        --queue=? \
+        --idle-timeout=? \
+        --tags=? \
+        --max-clusters=?
+
+`
+
+func updateCluster(c *context) *cobra.Command {
+	var template bool     // Switch for UpdateClusterTemplate()
+	var driverArgs string // Extra h2odriver arguments, separated by spaces
+	var engineId int64    // No description available
+	var idleTimeout int64 // Idle timeout in minutes (0 to use the workgroup default)
+	var maxClusters int   // Maximum number of running clusters started from the template (0 for no limit)
+	var memory string     // No description available
+	var name string       // No description available
+	var queue string      // Yarn queue to submit clusters to (optional)
+	var size int          // No description available
+	var tags string       // Comma-separated tags to find the template by
+	var templateId int64  // No description available
+
+	cmd := newCmd(c, updateClusterHelp, func(c *context, args []string) {
+		if template { // UpdateClusterTemplate
+
+			// Update a cluster launch template
+			err := c.remote.UpdateClusterTemplate(
+				templateId,  // No description available
+				name,        // No description available
+				engineId,    // No description available
+				size,        // No description available
+				memory,      // No description available
+				driverArgs,  // Extra h2odriver arguments, separated by spaces
+				queue,       // Yarn queue to submit clusters to (optional)
+				idleTimeout, // Idle timeout in minutes (0 to use the workgroup default)
+				tags,        // Comma-separated tags to find the template by
+				maxClusters, // Maximum number of running clusters started from the template (0 for no limit)
+			)
+			if err != nil {
+				log.Fatalln(err)
+			}
+			return
+		}
+	})
+	cmd.Flags().BoolVar(&template, "template", template, "Update a cluster launch template")
+
+	cmd.Flags().StringVar(&driverArgs, "driver-args", driverArgs, "Extra h2odriver arguments, separated by spaces")
+	cmd.Flags().Int64Var(&engineId, "engine-id", engineId, "No description available")
+	cmd.Flags().Int64Var(&idleTimeout, "idle-timeout", idleTimeout, "Idle timeout in minutes (0 to use the workgroup default)")
+	cmd.Flags().IntVar(&maxClusters, "max-clusters", maxClusters, "Maximum number of running clusters started from the template (0 for no limit)")
+	cmd.Flags().StringVar(&memory, "memory", memory, "No description available")
+	cmd.Flags().StringVar(&name, "name", name, "No description available")
+	cmd.Flags().StringVar(&queue, "queue", queue, "Yarn queue to submit clusters to (optional)")
+	cmd.Flags().IntVar(&size, "size", size, "No description available")
+	cmd.Flags().StringVar(&tags, "tags", tags, "Comma-separated tags to find the template by")
+	cmd.Flags().Int64Var(&templateId, "template-id", templateId, "No description available")
+	return cmd
+}
+
 var updateDatasetHelp = `
 dataset [?]
 Update Dataset
@@ -4514,24 +5865,27 @@ Examples:
         --datasource-id=? \
         --name=? \
         --description=? \
-        --path=?
+        --kind=? \
+        --configuration=?
 
 `
 
 func updateDatasource(c *context) *cobra.Command {
-	var datasourceId int64 // No description available
-	var description string // No description available
-	var name string        // No description available
-	var path string        // No description available
+	var configuration string // Settings of the kind as a JSON object; file: path; hdfs: uri; s3: endpoint, region, bucket, prefix, accessKey, secretKey; jdbc: url, table or query, username, password. Secrets left out are kept
+	var datasourceId int64   // No description available
+	var description string   // No description available
+	var kind string          // Datasource kind: file, hdfs, s3 or jdbc
+	var name string          // No description available
 
 	cmd := newCmd(c, updateDatasourceHelp, func(c *context, args []string) {
 
 		// Update a datasource
 		err := c.remote.UpdateDatasource(
-			datasourceId, // No description available
-			name,         // No description available
-			description,  // No description available
-			path,         // No description available
+			datasourceId,  // No description available
+			name,          // No description available
+			description,   // No description available
+			kind,          // Datasource kind: file, hdfs, s3 or jdbc
+			configuration, // Settings of the kind as a JSON object; file: path; hdfs: uri; s3: endpoint, region, bucket, prefix, accessKey, secretKey; jdbc: url, table or query, username, password. Secrets left out are kept
 		)
 		if err != nil {
 			log.Fatalln(err)
@@ -4539,10 +5893,11 @@ func updateDatasource(c *context) *cobra.Command {
 		return
 	})
 
+	cmd.Flags().StringVar(&configuration, "configuration", configuration, "Settings of the kind as a JSON object; file: path; hdfs: uri; s3: endpoint, region, bucket, prefix, accessKey, secretKey; jdbc: url, table or query, username, password. Secrets left out are kept")
 	cmd.Flags().Int64Var(&datasourceId, "datasource-id", datasourceId, "No description available")
 	cmd.Flags().StringVar(&description, "description", description, "No description available")
+	cmd.Flags().StringVar(&kind, "kind", kind, "Datasource kind: file, hdfs, s3 or jdbc")
 	cmd.Flags().StringVar(&name, "name", name, "No description available")
-	cmd.Flags().StringVar(&path, "path", path, "No description available")
 	return cmd
 }
 
@@ -18,10 +18,12 @@
 package cli2
 
 import (
+	"crypto/tls"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
 	"log"
+	"net/http"
 	"net/url"
 	"os"
 	"path"
@@ -39,6 +41,7 @@ type context struct {
 	buildDate string
 	config    *Config
 	uploadURL string
+	client    *http.Client
 	remote    *web.Remote
 	trace     *log.Logger
 	username  string
@@ -81,10 +84,18 @@ func (c *context) configure(verbose, requiresAuth bool) {
 		log.Fatalln("You are not authenticated to a Steam server. See 'steam help login' for more details.")
 	}
 	httpScheme := "http"
+	var tlsConfig *tls.Config
 	if host.EnableTLS {
 		httpScheme = "https"
+		var err error
+		tlsConfig, err = rpc.NewClientTLSConfig(host.ClientCertPath, host.ClientKeyPath, host.CACertPath)
+		if err != nil {
+			log.Fatalln(err)
+		}
 	}
-	c.remote = &web.Remote{rpc.NewProc(httpScheme, "/web", "web", addr, host.Username, host.Password)}
+	proc := rpc.NewSecureProc(httpScheme, "/web", "web", addr, host.Username, host.Password, tlsConfig)
+	c.remote = &web.Remote{proc}
+	c.client = proc.Client()
 	c.uploadURL = (&url.URL{Scheme: httpScheme, Host: addr, Path: "/upload"}).String()
 	c.username = host.Username
 	c.password = host.Password
@@ -126,7 +137,7 @@ func (c *context) resetConfig() error {
 }
 
 func (c *context) transmitFile(filepath string, attrs map[string]string) error {
-	return transmitFile(c.uploadURL, c.username, c.password, filepath, attrs)
+	return transmitFile(c.client, c.uploadURL, c.username, c.password, filepath, attrs)
 }
 
 func (c *context) traceln(v ...interface{}) {
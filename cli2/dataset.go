@@ -0,0 +1,306 @@
+/*
+  Copyright (C) 2016 H2O.ai, Inc. <http://h2o.ai/>
+
+  This program is free software: you can redistribute it and/or modify
+  it under the terms of the GNU Affero General Public License as
+  published by the Free Software Foundation, either version 3 of the
+  License, or (at your option) any later version.
+
+  This program is distributed in the hope that it will be useful,
+  but WITHOUT ANY WARRANTY; without even the implied warranty of
+  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+  GNU Affero General Public License for more details.
+
+  You should have received a copy of the GNU Affero General Public License
+  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package cli2
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path"
+	"strconv"
+	"time"
+
+	"github.com/h2oai/steam/lib/fs"
+	"github.com/h2oai/steam/srv/web"
+	"github.com/spf13/cobra"
+)
+
+// datasetJobPollInterval is how often "create dataset --wait" checks on the
+// import.
+const datasetJobPollInterval = 2 * time.Second
+
+// addCreateDatasetWait adds --wait to the generated "create dataset" command,
+// which otherwise returns as soon as the import is queued.
+func addCreateDatasetWait(c *context, root *cobra.Command) {
+	cmd, _, err := root.Find([]string{"create", "dataset"})
+	if err != nil || cmd.Run == nil {
+		return
+	}
+
+	var wait bool
+	run := cmd.Run
+	cmd.Flags().BoolVar(&wait, "wait", wait, "Wait for the import to finish, then print the dataset ID")
+	cmd.Run = func(cmd *cobra.Command, args []string) {
+		if !wait {
+			run(cmd, args)
+			return
+		}
+
+		flags := cmd.Flags()
+		clusterId, _ := flags.GetInt64("cluster-id")
+		datasourceId, _ := flags.GetInt64("datasource-id")
+		name, _ := flags.GetString("name")
+		description, _ := flags.GetString("description")
+		responseColumnName, _ := flags.GetString("response-column-name")
+		compareWithPrevious, _ := flags.GetBool("compare-with-previous")
+
+		jobId, err := c.remote.CreateDataset(clusterId, datasourceId, name, description, responseColumnName, compareWithPrevious)
+		if err != nil {
+			log.Fatalln(err)
+		}
+		fmt.Printf("JobId:\t%v\n", jobId)
+
+		job, err := waitForDatasetJob(c, jobId)
+		if err != nil {
+			log.Fatalln(err)
+		}
+		if job.State != "done" {
+			log.Fatalf("Dataset job %d %s: %s\n", jobId, job.State, job.Error)
+		}
+		fmt.Printf("DatasetId:\t%v\n", job.DatasetId)
+	}
+}
+
+// waitForDatasetJob polls a dataset job until it ends, reporting each change
+// of state.
+func waitForDatasetJob(c *context, jobId int64) (*web.DatasetJob, error) {
+	var state string
+	for {
+		job, err := c.remote.GetDatasetJob(jobId)
+		if err != nil {
+			return nil, err
+		}
+		if job.FinishedAt != 0 {
+			return job, nil
+		}
+		if job.State != state {
+			fmt.Printf("State:\t%s\n", job.State)
+			state = job.State
+		}
+		c.tracef("Dataset job %d: %s %.0f%%\n", jobId, job.State, job.Progress*100)
+		time.Sleep(datasetJobPollInterval)
+	}
+}
+
+// defaultUploadChunkSize is how much of a file "upload dataset" sends per
+// request.
+const defaultUploadChunkSize = 32 << 20
+
+// uploadRetries is how many times in a row a chunk is retried after a
+// network failure.
+const uploadRetries = 5
+
+var uploadDatasetHelp = `
+dataset [path]
+Upload a local file to a project, as a datasource.
+Examples:
+
+	$ steam upload dataset \
+			--file-path=path/to/airlines.csv \
+			--project-id=1
+
+Interrupted uploads resume where they left off when run again.
+`
+
+func uploadDataset(c *context) *cobra.Command {
+	var (
+		filePath  string
+		projectId int64
+		name      string
+		chunkSize int64
+	)
+	cmd := newCmd(c, uploadDatasetHelp, func(c *context, args []string) {
+		if projectId <= 0 {
+			log.Fatalln("Invalid project Id")
+		}
+		if chunkSize <= 0 {
+			log.Fatalln("Invalid chunk size")
+		}
+
+		datasourceId, err := transmitDataset(c, filePath, projectId, name, chunkSize)
+		if err != nil {
+			log.Fatalln(err)
+		}
+		fmt.Printf("DatasourceId:\t%v\n", datasourceId)
+	})
+
+	cmd.Flags().StringVar(&filePath, "file-path", "", "File to be uploaded")
+	cmd.Flags().Int64Var(&projectId, "project-id", 0, "Target project id")
+	cmd.Flags().StringVar(&name, "name", "", "Datasource name (defaults to the file name)")
+	cmd.Flags().Int64Var(&chunkSize, "chunk-size", defaultUploadChunkSize, "Bytes sent per request")
+
+	return cmd
+}
+
+// transmitDataset uploads a file in chunks, picking up at whatever offset the
+// server reports, and returns the ID of the datasource created for it.
+func transmitDataset(c *context, filePath string, projectId int64, name string, chunkSize int64) (int64, error) {
+	filePath, err := fs.ResolvePath(filePath)
+	if err != nil {
+		return 0, err
+	}
+	f, err := os.Open(filePath)
+	if err != nil {
+		return 0, fmt.Errorf("Failed opening file: %v", err)
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		return 0, fmt.Errorf("Failed reading file: %v", err)
+	}
+
+	var offset int64
+	for failures := 0; ; {
+		if _, err := f.Seek(offset, io.SeekStart); err != nil {
+			return 0, fmt.Errorf("Failed reading file: %v", err)
+		}
+		attrs := map[string]string{
+			"type":       fs.KindDataset,
+			"project-id": strconv.FormatInt(projectId, 10),
+			"name":       name,
+			"size":       strconv.FormatInt(info.Size(), 10),
+			"offset":     strconv.FormatInt(offset, 10),
+		}
+		status, body, err := transmitChunk(c.client, c.uploadURL, c.username, c.password, path.Base(filePath), attrs, io.LimitReader(f, chunkSize))
+		if err != nil {
+			// The server reports where to resume on the next attempt
+			if failures++; failures > uploadRetries {
+				return 0, err
+			}
+			c.traceln("Retrying upload:", err)
+			time.Sleep(time.Duration(failures) * time.Second)
+			continue
+		}
+		failures = 0
+
+		var reply struct {
+			Offset       int64 `json:"offset"`
+			DatasourceId int64 `json:"datasourceId"`
+		}
+		if status != 200 && status != 409 {
+			return 0, fmt.Errorf("Failed uploading file: %d / %s", status, string(body))
+		}
+		if err := json.Unmarshal(body, &reply); err != nil {
+			return 0, fmt.Errorf("Failed reading upload response: %v", err)
+		}
+		if reply.DatasourceId != 0 {
+			return reply.DatasourceId, nil
+		}
+		if status == 409 {
+			fmt.Printf("Resuming at byte %d\n", reply.Offset)
+		}
+		offset = reply.Offset
+		c.tracef("Uploaded %d of %d bytes\n", offset, info.Size())
+	}
+}
+
+var previewHelp = `
+preview [resource-type]
+Preview a resource of the specified type.
+Examples:
+
+	$ steam preview dataset
+`
+
+func preview(c *context) *cobra.Command {
+	cmd := newCmd(c, previewHelp, nil)
+	cmd.AddCommand(previewDataset(c))
+	return cmd
+}
+
+var previewDatasetHelp = `
+dataset [?]
+Print a page of a dataset's rows.
+Examples:
+
+	$ steam preview dataset \
+			--dataset-id=1 \
+			--row-count=20 \
+			--columns=Origin,Distance
+
+Missing values are printed as NA, or left empty with --csv.
+`
+
+func previewDataset(c *context) *cobra.Command {
+	var (
+		datasetId int64
+		offset    int64
+		rowCount  int64
+		columns   []string
+		asCSV     bool
+	)
+	cmd := newCmd(c, previewDatasetHelp, func(c *context, args []string) {
+		preview, err := c.remote.PreviewDataset(datasetId, offset, rowCount, columns)
+		if err != nil {
+			log.Fatalln(err)
+		}
+
+		if asCSV {
+			if err := writePreviewCSV(os.Stdout, preview); err != nil {
+				log.Fatalln(err)
+			}
+			return
+		}
+
+		var header string
+		for _, col := range preview.Columns {
+			header += fmt.Sprintf("%s (%s)\t", col.Name, col.Type)
+		}
+		lines := make([]string, len(preview.Rows))
+		for i, row := range preview.Rows {
+			for j, value := range row.Values {
+				if row.Missing[j] {
+					value = "NA"
+				}
+				lines[i] += value + "\t"
+			}
+		}
+		c.printt(header, lines)
+		fmt.Printf("Rows %d to %d of %d, read from %s\n", preview.Offset+1, preview.Offset+int64(len(preview.Rows)), preview.TotalRows, preview.Source)
+	})
+
+	cmd.Flags().Int64Var(&datasetId, "dataset-id", 0, "Dataset to preview")
+	cmd.Flags().Int64Var(&offset, "offset", 0, "Number of rows to skip")
+	cmd.Flags().Int64Var(&rowCount, "row-count", 0, "Number of rows to print (default 10)")
+	cmd.Flags().StringSliceVar(&columns, "columns", nil, "Comma-separated names of the columns to print (default all)")
+	cmd.Flags().BoolVar(&asCSV, "csv", false, "Print the rows as CSV, with a header")
+
+	return cmd
+}
+
+// writePreviewCSV writes a dataset preview as CSV; missing values are empty.
+func writePreviewCSV(out io.Writer, preview *web.DatasetPreview) error {
+	w := csv.NewWriter(out)
+	header := make([]string, len(preview.Columns))
+	for j, col := range preview.Columns {
+		header[j] = col.Name
+	}
+	if err := w.Write(header); err != nil {
+		return err
+	}
+	for _, row := range preview.Rows {
+		if err := w.Write(row.Values); err != nil {
+			return err
+		}
+	}
+	w.Flush()
+	return w.Error()
+}
@@ -26,6 +26,7 @@ import (
 	"strconv"
 	"strings"
 	"syscall"
+	"time"
 
 	"github.com/h2oai/steam/lib/fs"
 	"github.com/h2oai/steam/master"
@@ -42,6 +43,11 @@ Examples:
 	$ steam login 192.168.42.42:9000 \
 			--username=arthur
 			--password=beeblebrox
+
+	$ steam login 192.168.42.42:9000 --secure \
+			--client-cert-path=robot.crt \
+			--client-key-path=robot.key \
+			--ca-cert-path=ca.crt
 `
 
 func login(c *context) *cobra.Command {
@@ -50,6 +56,9 @@ func login(c *context) *cobra.Command {
 		password             string
 		authenticationMethod string
 		enableTLS            bool
+		clientCertPath       string
+		clientKeyPath        string
+		caCertPath           string
 	)
 	cmd := newCmd(c, loginHelp, func(c *context, args []string) {
 		if len(args) != 1 {
@@ -57,7 +66,15 @@ func login(c *context) *cobra.Command {
 		}
 		address := args[0]
 
-		if len(strings.TrimSpace(username)) == 0 {
+		if (clientCertPath != "" || clientKeyPath != "" || caCertPath != "") && !enableTLS {
+			log.Fatalln("*** Client certificates require --secure. See 'steam help login'.")
+		}
+		if (clientCertPath == "") != (clientKeyPath == "") {
+			log.Fatalln("*** Both --client-cert-path and --client-key-path are required. See 'steam help login'.")
+		}
+		withCert := clientCertPath != ""
+
+		if len(strings.TrimSpace(username)) == 0 && !withCert {
 			var err error
 			reader := bufio.NewReader(os.Stdin)
 			fmt.Print("Username: ")
@@ -68,7 +85,7 @@ func login(c *context) *cobra.Command {
 			username = strings.TrimSpace(username)
 		}
 
-		if len(strings.TrimSpace(password)) == 0 {
+		if len(strings.TrimSpace(password)) == 0 && !withCert {
 			fmt.Print("Password: ")
 			passwordBytes, err := terminal.ReadPassword(int(syscall.Stdin))
 			if err != nil {
@@ -83,6 +100,9 @@ func login(c *context) *cobra.Command {
 			password,
 			authenticationMethod,
 			enableTLS,
+			resolveOptionalPath(clientCertPath),
+			resolveOptionalPath(clientKeyPath),
+			resolveOptionalPath(caCertPath),
 		}
 		c.saveConfig(c.config)
 		fmt.Println("Login credentials saved for server", address)
@@ -92,6 +112,9 @@ func login(c *context) *cobra.Command {
 	cmd.Flags().StringVar(&password, "password", "", "Login password")
 	cmd.Flags().StringVar(&authenticationMethod, "authentication", "basic", "Authentication method")
 	cmd.Flags().BoolVar(&enableTLS, "secure", false, "Enable TLS")
+	cmd.Flags().StringVar(&clientCertPath, "client-cert-path", "", "TLS client certificate file path (optional, requires --secure)")
+	cmd.Flags().StringVar(&clientKeyPath, "client-key-path", "", "TLS client key file path (optional, requires --secure)")
+	cmd.Flags().StringVar(&caCertPath, "ca-cert-path", "", "CA bundle used to verify the server certificate (optional, requires --secure)")
 
 	return cmd
 }
@@ -142,10 +165,15 @@ func serveMaster(c *context) *cobra.Command {
 		webAddress                string
 		webTLSCertPath            string
 		webTLSKeyPath             string
+		webTLSClientCAPath        string
+		webTLSClientAuth          string
+		webTLSMinVersion          string
+		webTLSCipherSuites        string
 		authProvider              string
 		authConfig                string
 		workingDirectory          string
 		clusterProxyAddress       string
+		clusterProxyDomain        string
 		compilationServiceAddress string
 		scoringServiceHost        string
 		scoringServicePortsString string
@@ -153,6 +181,7 @@ func serveMaster(c *context) *cobra.Command {
 		yarnEnableKerberos        bool
 		yarnUserName              string
 		yarnKeytab                string
+		yarnDriverOptions         string
 		dbName                    string
 		dbUserName                string
 		dbPassword                string
@@ -165,6 +194,17 @@ func serveMaster(c *context) *cobra.Command {
 		dbSSLRootCertPath         string
 		superuserName             string
 		superuserPassword         string
+		clusterHealthInterval     time.Duration
+		clusterHealthMaxBackoff   time.Duration
+		clusterIdleInterval       time.Duration
+		clusterIdleWarning        time.Duration
+		clusterMetricsInterval    time.Duration
+		clusterMetricsRetention   time.Duration
+		clusterSyncInterval       time.Duration
+		localClusterHost          string
+		localClusterBasePort      int
+		uploadMaxSize             int64
+		uploadExpiry              time.Duration
 	)
 
 	opts := master.DefaultOpts
@@ -193,10 +233,15 @@ func serveMaster(c *context) *cobra.Command {
 			webAddress,
 			webTLSCertPath,
 			webTLSKeyPath,
+			webTLSClientCAPath,
+			webTLSClientAuth,
+			webTLSMinVersion,
+			webTLSCipherSuites,
 			authProvider,
 			authConfig,
 			workingDirectory,
 			clusterProxyAddress,
+			clusterProxyDomain,
 			compilationServiceAddress,
 			scoringServiceHost,
 			scoringServicePorts,
@@ -205,6 +250,7 @@ func serveMaster(c *context) *cobra.Command {
 				yarnEnableKerberos,
 				yarnUserName,
 				yarnKeytab,
+				yarnDriverOptions,
 			},
 			master.DBOpts{
 				data.Connection{
@@ -222,16 +268,44 @@ func serveMaster(c *context) *cobra.Command {
 				superuserName,
 				superuserPassword,
 			},
+			master.ClusterHealthOpts{
+				clusterHealthInterval,
+				clusterHealthMaxBackoff,
+			},
+			master.ClusterIdleOpts{
+				clusterIdleInterval,
+				clusterIdleWarning,
+			},
+			master.ClusterMetricsOpts{
+				clusterMetricsInterval,
+				clusterMetricsRetention,
+			},
+			master.ClusterSyncOpts{
+				clusterSyncInterval,
+			},
+			master.LocalClusterOpts{
+				localClusterHost,
+				localClusterBasePort,
+			},
+			master.UploadOpts{
+				uploadMaxSize,
+				uploadExpiry,
+			},
 		})
 	})
 
 	cmd.Flags().StringVar(&webAddress, "web-address", opts.WebAddress, "Web server address (\"<ip>:<port>\" or \":<port>\").")
 	cmd.Flags().StringVar(&webTLSCertPath, "web-tls-cert-path", opts.WebTLSCertPath, "Web server TLS certificate file path (optional).")
 	cmd.Flags().StringVar(&webTLSKeyPath, "web-tls-key-path", opts.WebTLSKeyPath, "Web server TLS key file path (optional).")
-	cmd.Flags().StringVar(&authProvider, "authentication-provider", opts.AuthProvider, "Authentication mechanism for client logins (one of \"basic\", \"digest\"), or \"basic-ldap\"")
+	cmd.Flags().StringVar(&webTLSClientCAPath, "web-tls-client-ca-path", opts.WebTLSClientCAPath, "Web server TLS client CA bundle file path (optional, required for client certificates).")
+	cmd.Flags().StringVar(&webTLSClientAuth, "web-tls-client-auth", opts.WebTLSClientAuth, "Web server TLS client certificate mode (one of \"none\", \"request\", \"require\"; \"none\" means \"require\" with mtls authentication).")
+	cmd.Flags().StringVar(&webTLSMinVersion, "web-tls-min-version", opts.WebTLSMinVersion, "Web server minimum TLS version (one of \"1.0\", \"1.1\", \"1.2\", \"1.3\"; optional).")
+	cmd.Flags().StringVar(&webTLSCipherSuites, "web-tls-cipher-suites", opts.WebTLSCipherSuites, "Web server TLS cipher suites, comma-separated (optional).")
+	cmd.Flags().StringVar(&authProvider, "authentication-provider", opts.AuthProvider, "Authentication mechanism for client logins (one of \"basic\", \"digest\", \"basic-ldap\", or \"mtls\")")
 	cmd.Flags().StringVar(&authConfig, "authentication-config", opts.AuthConfig, "Configuration file for authentication (used in \"basic-ldap\")")
 	cmd.Flags().StringVar(&workingDirectory, "working-directory", opts.WorkingDirectory, "Working directory for application files.")
 	cmd.Flags().StringVar(&clusterProxyAddress, "cluster-proxy-address", opts.ClusterProxyAddress, "Cluster proxy address (\"<ip>:<port>\" or \":<port>\")")
+	cmd.Flags().StringVar(&clusterProxyDomain, "cluster-proxy-domain", opts.ClusterProxyDomain, "Cluster proxy domain; routes \"<cluster-id-or-name>.<domain>\" to the cluster (optional)")
 	cmd.Flags().StringVar(&compilationServiceAddress, "compilation-service-address", opts.CompilationServiceAddress, "Model compilation service address (\"<ip>:<port>\")")
 	cmd.Flags().StringVar(&scoringServiceHost, "scoring-service-address", opts.ScoringServiceHost, "Address to start scoring services on (\"<ip>\")")
 	// TODO: this uses a hardcoded port range, not the default const
@@ -240,6 +314,7 @@ func serveMaster(c *context) *cobra.Command {
 	cmd.Flags().BoolVar(&yarnEnableKerberos, "yarn-enable-kerberos", opts.Yarn.KerberosEnabled, "Enable Kerberos authentication. Requires username and keytab.") // FIXME: Kerberos authentication is being passed by admin to all
 	cmd.Flags().StringVar(&yarnUserName, "yarn-username", opts.Yarn.Username, "Username to enable Kerberos")
 	cmd.Flags().StringVar(&yarnKeytab, "yarn-keytab", opts.Yarn.Keytab, "Keytab file to be used with Kerberos authentication")
+	cmd.Flags().StringVar(&yarnDriverOptions, "yarn-driver-options", opts.Yarn.DriverOptions, "Comma-separated extra h2odriver options users may pass when starting clusters on Yarn (e.g. -nthreads,-Dmapreduce.map.*)")
 	cmd.Flags().StringVar(&dbName, "db-name", opts.DB.Connection.DbName, "Database name to use for application data storage (required)")
 	cmd.Flags().StringVar(&dbUserName, "db-username", opts.DB.Connection.User, "Database username (required)")
 	cmd.Flags().StringVar(&dbPassword, "db-password", opts.DB.Connection.Password, "Database password (optional)")
@@ -252,6 +327,17 @@ func serveMaster(c *context) *cobra.Command {
 	cmd.Flags().StringVar(&dbSSLRootCertPath, "db-ssl-root-cert-path", opts.DB.Connection.SSLRootCert, "Database connection SSL root certificate path (optional)")
 	cmd.Flags().StringVar(&superuserName, "superuser-name", opts.DB.SuperuserName, "Set superuser username (required for first-time-use only)")
 	cmd.Flags().StringVar(&superuserPassword, "superuser-password", opts.DB.SuperuserPassword, "Set superuser password (required for first-time-use only)")
+	cmd.Flags().DurationVar(&clusterHealthInterval, "cluster-health-interval", opts.ClusterHealth.Interval, "Interval between cluster health checks (0 disables checks)")
+	cmd.Flags().DurationVar(&clusterHealthMaxBackoff, "cluster-health-max-backoff", opts.ClusterHealth.MaxBackoff, "Maximum back-off between health checks of an unreachable cluster")
+	cmd.Flags().DurationVar(&clusterIdleInterval, "cluster-idle-interval", opts.ClusterIdle.Interval, "Interval between checks for idle clusters (0 disables idle shutdown)")
+	cmd.Flags().DurationVar(&clusterIdleWarning, "cluster-idle-warning", opts.ClusterIdle.Warning, "How long before an idle shutdown a warning is recorded")
+	cmd.Flags().DurationVar(&clusterMetricsInterval, "cluster-metrics-interval", opts.ClusterMetrics.Interval, "Interval between cluster resource usage samples (0 disables sampling)")
+	cmd.Flags().DurationVar(&clusterMetricsRetention, "cluster-metrics-retention", opts.ClusterMetrics.Retention, "How long cluster resource usage samples are kept (0 keeps them forever)")
+	cmd.Flags().DurationVar(&clusterSyncInterval, "cluster-sync-interval", opts.ClusterSync.Interval, "Interval between checks for frames and models deleted from clusters (0 disables checks)")
+	cmd.Flags().StringVar(&localClusterHost, "local-cluster-host", opts.LocalCluster.Host, "Host address of H2O nodes in local clusters")
+	cmd.Flags().IntVar(&localClusterBasePort, "local-cluster-base-port", opts.LocalCluster.BasePort, "Lowest port to start H2O nodes of local clusters on")
+	cmd.Flags().Int64Var(&uploadMaxSize, "upload-max-size", opts.Upload.MaxSize, "Largest file, in bytes, accepted through uploads (0 for no limit)")
+	cmd.Flags().DurationVar(&uploadExpiry, "upload-expiry", opts.Upload.Expiry, "Time after which unfinished dataset uploads are removed (0 keeps them)")
 
 	return cmd
 
@@ -306,11 +392,13 @@ Upload a resource of the specified type.
 Examples:
 
 	$ steam upload file
+	$ steam upload dataset
 `
 
 func upload(c *context) *cobra.Command {
 	cmd := newCmd(c, uploadHelp, nil)
 	cmd.AddCommand(uploadFile(c))
+	cmd.AddCommand(uploadDataset(c))
 	return cmd
 }
 
@@ -44,6 +44,9 @@ type Host struct {
 	Password             string
 	AuthenticationMethod string
 	EnableTLS            bool
+	ClientCertPath       string
+	ClientKeyPath        string
+	CACertPath           string
 }
 
 func newConfig() *Config {
@@ -116,8 +119,10 @@ func Steam(version, buildDate string, stdout, stderr, trace io.Writer) *cobra.Co
 		serve(c),
 		deploy(c),
 		upload(c),
+		preview(c),
 	)
 	registerGeneratedCommands(c, cmd)
+	addCreateDatasetWait(c, cmd)
 	return cmd
 }
 
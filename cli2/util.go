@@ -22,6 +22,7 @@ import (
 	"fmt"
 	"io"
 	"io/ioutil"
+	"log"
 	"mime/multipart"
 	"net/http"
 	"os"
@@ -29,7 +30,20 @@ import (
 	"github.com/h2oai/steam/lib/fs"
 )
 
-func transmitFile(url, username, password, filename string, attrs map[string]string) error {
+// resolveOptionalPath makes a non-empty path absolute, so that it stays valid
+// when stored in the client configuration.
+func resolveOptionalPath(p string) string {
+	if p == "" {
+		return p
+	}
+	p, err := fs.ResolvePath(p)
+	if err != nil {
+		log.Fatalln(err)
+	}
+	return p
+}
+
+func transmitFile(client *http.Client, url, username, password, filename string, attrs map[string]string) error {
 	filename, err := fs.ResolvePath(filename)
 	if err != nil {
 		return err
@@ -73,7 +87,7 @@ func transmitFile(url, username, password, filename string, attrs map[string]str
 	req.Header.Set("Content-type", ct)
 	req.SetBasicAuth(username, password)
 
-	res, err := http.DefaultClient.Do(req)
+	res, err := client.Do(req)
 	if err != nil {
 		return fmt.Errorf("Failed uploading file: %v", err)
 	}
@@ -89,3 +103,46 @@ func transmitFile(url, username, password, filename string, attrs map[string]str
 
 	return nil
 }
+
+// transmitChunk posts part of a file as a multipart upload, returning the
+// response status and body.
+func transmitChunk(client *http.Client, url, username, password, filename string, attrs map[string]string, chunk io.Reader) (int, []byte, error) {
+	buf := &bytes.Buffer{}
+	writer := multipart.NewWriter(buf)
+
+	for key, value := range attrs {
+		if err := writer.WriteField(key, value); err != nil {
+			return 0, nil, fmt.Errorf("Failed writing form field %s: %v", key, err)
+		}
+	}
+
+	dst, err := writer.CreateFormFile("file", filename)
+	if err != nil {
+		return 0, nil, fmt.Errorf("Failed writing to buffer: %v", err)
+	}
+	if _, err := io.Copy(dst, chunk); err != nil {
+		return 0, nil, fmt.Errorf("Failed reading file: %v", err)
+	}
+
+	ct := writer.FormDataContentType()
+	writer.Close()
+
+	req, err := http.NewRequest("POST", url, buf)
+	if err != nil {
+		return 0, nil, fmt.Errorf("Error creating request: %v", err)
+	}
+	req.Header.Set("Content-type", ct)
+	req.SetBasicAuth(username, password)
+
+	res, err := client.Do(req)
+	if err != nil {
+		return 0, nil, fmt.Errorf("Failed uploading file: %v", err)
+	}
+	defer res.Body.Close()
+
+	body, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return 0, nil, fmt.Errorf("Failed reading upload response: %v", err)
+	}
+	return res.StatusCode, body, nil
+}
@@ -51,7 +51,7 @@ interface Props {
 export class Clusters extends React.Component<Props & DispatchProps, any> {
   refs: {
     [key: string]: Element
-    keytabFilename: HTMLInputElement
+    keytabId: HTMLInputElement
   };
 
   constructor(props) {
@@ -71,8 +71,8 @@ export class Clusters extends React.Component<Props & DispatchProps, any> {
 
   removeCluster(cluster) {
     if (cluster.type_id === 2) {
-      let keytabFilename = _.get((this.refs.keytabFilename as HTMLInputElement), 'value', '');
-      this.props.stopClusterOnYarn(cluster.id, keytabFilename);
+      let keytabId = parseInt(_.get((this.refs.keytabId as HTMLInputElement), 'value', ''), 10) || 0;
+      this.props.stopClusterOnYarn(cluster.id, keytabId);
     } else {
       this.props.unregisterCluster(cluster.id);
     }
@@ -136,7 +136,7 @@ export class Clusters extends React.Component<Props & DispatchProps, any> {
                   <span><i className="fa fa-cubes mar-bot-20"/> <a href={'http://' + cluster.address} target="_blank"
                                                         rel="noopener" className="charcoal-grey semibold">{cluster.name}</a> -- {cluster.status.total_cpu_count} nodes</span>
                   <span className="remove-cluster">
-                    {_.get(this.props.config, 'kerberos_enabled', false) === true ? <input ref="keytabFilename" type="text" placeholder="Keytab filename"/> : null}
+                    {_.get(this.props.config, 'kerberos_enabled', false) === true ? <input ref="keytabId" type="number" placeholder="Keytab ID"/> : null}
 
                     <button className="remove-cluster-button" onClick={this.removeCluster.bind(this, cluster)}><i
                       className="fa fa-trash no-margin"/></button>
@@ -71,7 +71,7 @@ export class LaunchCluster extends React.Component<Props & DispatchProps, any> {
     let size = (this.refs.clusterForm.querySelector('input[name="size"]') as HTMLInputElement).value;
     let memory = (this.refs.clusterForm.querySelector('input[name="memory"]') as HTMLInputElement).value;
     let keytab = _.get((this.refs.clusterForm.querySelector('input[name="keytab"]') as HTMLInputElement), 'value', '');
-    this.props.startYarnCluster(clusterName, parseInt(engineId, 10), parseInt(size, 10), memory + this.state.memorySizeUnit, keytab);
+    this.props.startYarnCluster(clusterName, parseInt(engineId, 10), parseInt(size, 10), memory + this.state.memorySizeUnit, parseInt(keytab, 10) || 0);
   }
 
   uploadEngine(event) {
@@ -145,10 +145,10 @@ export class LaunchCluster extends React.Component<Props & DispatchProps, any> {
             </Row>
             {_.get(this.props.config, 'kerberos_enabled', false) === true ? <Row>
               <Cell>
-                Kerberos Keytab
+                Kerberos Keytab ID
               </Cell>
               <Cell>
-                <input type="text" name="keytab"/>
+                <input type="number" name="keytab"/>
               </Cell>
             </Row> : null}
           </Table>
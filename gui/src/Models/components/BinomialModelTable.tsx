@@ -109,6 +109,7 @@ export default class BinomialModelTable extends React.Component<Props, any> {
                 <div className="metadata">
                   <div className="model-name">
                     {model.name}
+                    {model.stale ? <span className="stale" title="This model is gone from its cluster">&nbsp;(stale)</span> : null}
                   </div>
                   <div>
                     <span>Created at:&nbsp;</span><span>{moment.unix(model.created_at).format('YYYY-MM-DD hh:mm:ss')}</span>
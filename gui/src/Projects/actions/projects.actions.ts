@@ -442,7 +442,7 @@ export function createProjectAndImportModelsFromCluster(projectName: string, clu
 
 export function registerCluster(address: string) {
   return (dispatch) => {
-    Remote.registerCluster(address, (error, res) => {
+    Remote.registerCluster(address, 'http', '', '', '', (error, res) => {
       if (error) {
         dispatch(registerClusterError(error.message));
         return;
@@ -464,9 +464,9 @@ export function unregisterCluster(clusterId: number) {
   };
 }
 
-export function stopClusterOnYarn(clusterId: number, keytabFilename: string) {
+export function stopClusterOnYarn(clusterId: number, keytabId: number) {
   return (dispatch) => {
-    Remote.stopClusterOnYarn(clusterId, keytabFilename, (error) => {
+    Remote.stopClusterOnYarn(clusterId, keytabId, (error) => {
       if (error) {
         dispatch(openNotification(NotificationType.Error, 'Load Error', error.toString(), null));
         return;
@@ -46,8 +46,8 @@ export function getConfig(): void {
   Proxy.Call("GetConfig", req, print);
 }
 
-export function registerCluster(address: string): void {
-  const req: any = { address: address };
+export function registerCluster(address: string, scheme: string, caCert: string, username: string, password: string): void {
+  const req: any = { address: address, scheme: scheme, ca_cert: caCert, username: username, password: password };
   Proxy.Call("RegisterCluster", req, print);
 }
 
@@ -56,16 +56,111 @@ export function unregisterCluster(clusterId: number): void {
   Proxy.Call("UnregisterCluster", req, print);
 }
 
-export function startClusterOnYarn(clusterName: string, engineId: number, size: number, memory: string, keytab: string): void {
-  const req: any = { cluster_name: clusterName, engine_id: engineId, size: size, memory: memory, keytab: keytab };
+export function startCluster(clusterName: string, clusterType: string, engineId: number, size: number, memory: string, keytabId: number): void {
+  const req: any = { cluster_name: clusterName, cluster_type: clusterType, engine_id: engineId, size: size, memory: memory, keytab_id: keytabId };
+  Proxy.Call("StartCluster", req, print);
+}
+
+export function stopCluster(clusterId: number): void {
+  const req: any = { cluster_id: clusterId };
+  Proxy.Call("StopCluster", req, print);
+}
+
+export function startClusterOnYarn(clusterName: string, engineId: number, size: number, memory: string, keytabId: number, queue: string, nodeLabel: string, driverArgs: string): void {
+  const req: any = { cluster_name: clusterName, engine_id: engineId, size: size, memory: memory, keytab_id: keytabId, queue: queue, node_label: nodeLabel, driver_args: driverArgs };
   Proxy.Call("StartClusterOnYarn", req, print);
 }
 
-export function stopClusterOnYarn(clusterId: number, keytab: string): void {
-  const req: any = { cluster_id: clusterId, keytab: keytab };
+export function stopClusterOnYarn(clusterId: number, keytabId: number): void {
+  const req: any = { cluster_id: clusterId, keytab_id: keytabId };
   Proxy.Call("StopClusterOnYarn", req, print);
 }
 
+export function getClusterLaunch(clusterId: number, logLines: number): void {
+  const req: any = { cluster_id: clusterId, log_lines: logLines };
+  Proxy.Call("GetClusterLaunch", req, print);
+}
+
+export function getClusterLogs(clusterId: number, containerId: string, tailLines: number, offset: number): void {
+  const req: any = { cluster_id: clusterId, container_id: containerId, tail_lines: tailLines, offset: offset };
+  Proxy.Call("GetClusterLogs", req, print);
+}
+
+export function cancelClusterLaunch(clusterId: number): void {
+  const req: any = { cluster_id: clusterId };
+  Proxy.Call("CancelClusterLaunch", req, print);
+}
+
+export function setClusterIdleTimeout(clusterId: number, minutes: number): void {
+  const req: any = { cluster_id: clusterId, minutes: minutes };
+  Proxy.Call("SetClusterIdleTimeout", req, print);
+}
+
+export function keepAlive(clusterId: number): void {
+  const req: any = { cluster_id: clusterId };
+  Proxy.Call("KeepAlive", req, print);
+}
+
+export function getClusterMetrics(clusterId: number, from: number, to: number, step: number): void {
+  const req: any = { cluster_id: clusterId, from: from, to: to, step: step };
+  Proxy.Call("GetClusterMetrics", req, print);
+}
+
+export function syncCluster(clusterId: number): void {
+  const req: any = { cluster_id: clusterId };
+  Proxy.Call("SyncCluster", req, print);
+}
+
+export function createClusterTemplate(name: string, engineId: number, size: number, memory: string, driverArgs: string, queue: string, idleTimeout: number, tags: string, maxClusters: number): void {
+  const req: any = { name: name, engine_id: engineId, size: size, memory: memory, driver_args: driverArgs, queue: queue, idle_timeout: idleTimeout, tags: tags, max_clusters: maxClusters };
+  Proxy.Call("CreateClusterTemplate", req, print);
+}
+
+export function getClusterTemplate(templateId: number): void {
+  const req: any = { template_id: templateId };
+  Proxy.Call("GetClusterTemplate", req, print);
+}
+
+export function getClusterTemplates(offset: number, limit: number): void {
+  const req: any = { offset: offset, limit: limit };
+  Proxy.Call("GetClusterTemplates", req, print);
+}
+
+export function updateClusterTemplate(templateId: number, name: string, engineId: number, size: number, memory: string, driverArgs: string, queue: string, idleTimeout: number, tags: string, maxClusters: number): void {
+  const req: any = { template_id: templateId, name: name, engine_id: engineId, size: size, memory: memory, driver_args: driverArgs, queue: queue, idle_timeout: idleTimeout, tags: tags, max_clusters: maxClusters };
+  Proxy.Call("UpdateClusterTemplate", req, print);
+}
+
+export function deleteClusterTemplate(templateId: number): void {
+  const req: any = { template_id: templateId };
+  Proxy.Call("DeleteClusterTemplate", req, print);
+}
+
+export function startClusterFromTemplate(templateId: number, clusterName: string, keytabId: number): void {
+  const req: any = { template_id: templateId, cluster_name: clusterName, keytab_id: keytabId };
+  Proxy.Call("StartClusterFromTemplate", req, print);
+}
+
+export function getKeytabs(): void {
+  const req: any = {  };
+  Proxy.Call("GetKeytabs", req, print);
+}
+
+export function deleteKeytab(keytabId: number): void {
+  const req: any = { keytab_id: keytabId };
+  Proxy.Call("DeleteKeytab", req, print);
+}
+
+export function getClusterProxyPolicy(clusterId: number): void {
+  const req: any = { cluster_id: clusterId };
+  Proxy.Call("GetClusterProxyPolicy", req, print);
+}
+
+export function setClusterProxyPolicy(clusterId: number, policy: string): void {
+  const req: any = { cluster_id: clusterId, policy: policy };
+  Proxy.Call("SetClusterProxyPolicy", req, print);
+}
+
 export function getCluster(clusterId: number): void {
   const req: any = { cluster_id: clusterId };
   Proxy.Call("GetCluster", req, print);
@@ -96,6 +191,11 @@ export function getJob(clusterId: number, jobName: string): void {
   Proxy.Call("GetJob", req, print);
 }
 
+export function cancelJob(clusterId: number, jobName: string): void {
+  const req: any = { cluster_id: clusterId, job_name: jobName };
+  Proxy.Call("CancelJob", req, print);
+}
+
 export function getJobs(clusterId: number): void {
   const req: any = { cluster_id: clusterId };
   Proxy.Call("GetJobs", req, print);
@@ -121,8 +221,8 @@ export function deleteProject(projectId: number): void {
   Proxy.Call("DeleteProject", req, print);
 }
 
-export function createDatasource(projectId: number, name: string, description: string, path: string): void {
-  const req: any = { project_id: projectId, name: name, description: description, path: path };
+export function createDatasource(projectId: number, name: string, description: string, kind: string, configuration: string): void {
+  const req: any = { project_id: projectId, name: name, description: description, kind: kind, configuration: configuration };
   Proxy.Call("CreateDatasource", req, print);
 }
 
@@ -136,8 +236,8 @@ export function getDatasource(datasourceId: number): void {
   Proxy.Call("GetDatasource", req, print);
 }
 
-export function updateDatasource(datasourceId: number, name: string, description: string, path: string): void {
-  const req: any = { datasource_id: datasourceId, name: name, description: description, path: path };
+export function updateDatasource(datasourceId: number, name: string, description: string, kind: string, configuration: string): void {
+  const req: any = { datasource_id: datasourceId, name: name, description: description, kind: kind, configuration: configuration };
   Proxy.Call("UpdateDatasource", req, print);
 }
 
@@ -146,11 +246,36 @@ export function deleteDatasource(datasourceId: number): void {
   Proxy.Call("DeleteDatasource", req, print);
 }
 
-export function createDataset(clusterId: number, datasourceId: number, name: string, description: string, responseColumnName: string): void {
-  const req: any = { cluster_id: clusterId, datasource_id: datasourceId, name: name, description: description, response_column_name: responseColumnName };
+export function testDatasource(datasourceId: number, clusterId: number): void {
+  const req: any = { datasource_id: datasourceId, cluster_id: clusterId };
+  Proxy.Call("TestDatasource", req, print);
+}
+
+export function createDataset(clusterId: number, datasourceId: number, name: string, description: string, responseColumnName: string, compareWithPrevious: boolean): void {
+  const req: any = { cluster_id: clusterId, datasource_id: datasourceId, name: name, description: description, response_column_name: responseColumnName, compare_with_previous: compareWithPrevious };
   Proxy.Call("CreateDataset", req, print);
 }
 
+export function getDatasetJob(jobId: number): void {
+  const req: any = { job_id: jobId };
+  Proxy.Call("GetDatasetJob", req, print);
+}
+
+export function getDatasetJobs(datasourceId: number, offset: number, limit: number): void {
+  const req: any = { datasource_id: datasourceId, offset: offset, limit: limit };
+  Proxy.Call("GetDatasetJobs", req, print);
+}
+
+export function cancelDatasetJob(jobId: number): void {
+  const req: any = { job_id: jobId };
+  Proxy.Call("CancelDatasetJob", req, print);
+}
+
+export function reimportDataset(datasetId: number): void {
+  const req: any = { dataset_id: datasetId };
+  Proxy.Call("ReimportDataset", req, print);
+}
+
 export function getDatasets(datasourceId: number, offset: number, limit: number): void {
   const req: any = { datasource_id: datasourceId, offset: offset, limit: limit };
   Proxy.Call("GetDatasets", req, print);
@@ -161,18 +286,53 @@ export function getDataset(datasetId: number): void {
   Proxy.Call("GetDataset", req, print);
 }
 
+export function getDatasetColumns(datasetId: number, refresh: boolean): void {
+  const req: any = { dataset_id: datasetId, refresh: refresh };
+  Proxy.Call("GetDatasetColumns", req, print);
+}
+
+export function getDatasetColumnRoles(datasetId: number): void {
+  const req: any = { dataset_id: datasetId };
+  Proxy.Call("GetDatasetColumnRoles", req, print);
+}
+
+export function setDatasetColumnRole(datasetId: number, columnName: string, role: string): void {
+  const req: any = { dataset_id: datasetId, column_name: columnName, role: role };
+  Proxy.Call("SetDatasetColumnRole", req, print);
+}
+
+export function previewDataset(datasetId: number, offset: number, rowCount: number, columns: string[]): void {
+  const req: any = { dataset_id: datasetId, offset: offset, row_count: rowCount, columns: columns };
+  Proxy.Call("PreviewDataset", req, print);
+}
+
+export function getDatasetVersions(datasourceId: number): void {
+  const req: any = { datasource_id: datasourceId };
+  Proxy.Call("GetDatasetVersions", req, print);
+}
+
 export function getDatasetsFromCluster(clusterId: number): void {
   const req: any = { cluster_id: clusterId };
   Proxy.Call("GetDatasetsFromCluster", req, print);
 }
 
+export function compareDatasets(baseDatasetId: number, otherDatasetId: number): void {
+  const req: any = { base_dataset_id: baseDatasetId, other_dataset_id: otherDatasetId };
+  Proxy.Call("CompareDatasets", req, print);
+}
+
+export function getDatasetComparison(datasetId: number): void {
+  const req: any = { dataset_id: datasetId };
+  Proxy.Call("GetDatasetComparison", req, print);
+}
+
 export function updateDataset(datasetId: number, name: string, description: string, responseColumnName: string): void {
   const req: any = { dataset_id: datasetId, name: name, description: description, response_column_name: responseColumnName };
   Proxy.Call("UpdateDataset", req, print);
 }
 
-export function splitDataset(datasetId: number, ratio1: number, ratio2: number): void {
-  const req: any = { dataset_id: datasetId, ratio1: ratio1, ratio2: ratio2 };
+export function splitDataset(datasetId: number, ratio1: number, ratio2: number, seed: number): void {
+  const req: any = { dataset_id: datasetId, ratio1: ratio1, ratio2: ratio2, seed: seed };
   Proxy.Call("SplitDataset", req, print);
 }
 
@@ -471,6 +631,11 @@ export function updateWorkgroup(workgroupId: number, name: string, description:
   Proxy.Call("UpdateWorkgroup", req, print);
 }
 
+export function setWorkgroupIdleTimeout(workgroupId: number, minutes: number): void {
+  const req: any = { workgroup_id: workgroupId, minutes: minutes };
+  Proxy.Call("SetWorkgroupIdleTimeout", req, print);
+}
+
 export function deleteWorkgroup(workgroupId: number): void {
   const req: any = { workgroup_id: workgroupId };
   Proxy.Call("DeleteWorkgroup", req, print);
@@ -34,6 +34,10 @@ export interface BinomialModel {
   
   validation_dataset_id: number
   
+  training_dataset_version: number
+  
+  stale: boolean
+  
   name: string
   
   cluster_name: string
@@ -94,6 +98,62 @@ export interface Cluster {
   
 }
 
+export interface ClusterLaunch {
+  
+  cluster_id: number
+  
+  state: string
+  
+  application_id: string
+  
+  size: number
+  
+  nodes_up: number
+  
+  errors: string
+  
+  log: string
+  
+}
+
+export interface ClusterLogs {
+  
+  cluster_id: number
+  
+  application_id: string
+  
+  log: string
+  
+  total_lines: number
+  
+  offset: number
+  
+}
+
+export interface ClusterMetric {
+  
+  time: number
+  
+  node: string
+  
+  healthy: boolean
+  
+  sys_load: number
+  
+  my_cpu_pct: number
+  
+  sys_cpu_pct: number
+  
+  free_mem: number
+  
+  max_mem: number
+  
+  pojo_mem: number
+  
+  mem_value_size: number
+  
+}
+
 export interface ClusterStatus {
   
   version: string
@@ -108,6 +168,48 @@ export interface ClusterStatus {
   
 }
 
+export interface ClusterSync {
+  
+  cluster_id: number
+  
+  frame_count: number
+  
+  model_count: number
+  
+  stale_datasets: StaleDataset[]
+  
+  stale_models: StaleModel[]
+  
+  synced_at: number
+  
+}
+
+export interface ClusterTemplate {
+  
+  id: number
+  
+  name: string
+  
+  engine_id: number
+  
+  size: number
+  
+  memory: string
+  
+  driver_args: string
+  
+  queue: string
+  
+  idle_timeout: number
+  
+  tags: string
+  
+  max_clusters: number
+  
+  created_at: number
+  
+}
+
 export interface ClusterType {
   
   id: number
@@ -116,6 +218,30 @@ export interface ClusterType {
   
 }
 
+export interface ColumnComparison {
+  
+  name: string
+  
+  base_type: string
+  
+  other_type: string
+  
+  new_levels: string[]
+  
+  missing_levels: string[]
+  
+  mean_delta: number
+  
+  sigma_delta: number
+  
+  p_s_i: number
+  
+  has_p_s_i: boolean
+  
+  role: string
+  
+}
+
 export interface Config {
   
   kerberos_enabled: boolean
@@ -130,6 +256,20 @@ export interface Dataset {
   
   datasource_id: number
   
+  cluster_id: number
+  
+  parent_id: number
+  
+  version: number
+  
+  previous_id: number
+  
+  row_count: number
+  
+  column_hash: string
+  
+  checksum: string
+  
   name: string
   
   description: string
@@ -142,6 +282,134 @@ export interface Dataset {
   
   created_at: number
   
+  stale: boolean
+  
+}
+
+export interface DatasetColumn {
+  
+  name: string
+  
+  type: string
+  
+  missing_count: number
+  
+  zero_count: number
+  
+  min: number
+  
+  max: number
+  
+  mean: number
+  
+  sigma: number
+  
+  domain: string[]
+  
+  domain_cardinality: number
+  
+  histogram_base: number
+  
+  histogram_stride: number
+  
+  histogram_bins: number[]
+  
+  role: string
+  
+}
+
+export interface DatasetColumnRoles {
+  
+  dataset_id: number
+  
+  response_column_name: string
+  
+  ignored_columns: string[]
+  
+  weights_column: string
+  
+  fold_column: string
+  
+  offset_column: string
+  
+}
+
+export interface DatasetComparison {
+  
+  base_dataset_id: number
+  
+  other_dataset_id: number
+  
+  added_columns: string[]
+  
+  removed_columns: string[]
+  
+  columns: ColumnComparison[]
+  
+  schema_changed: boolean
+  
+  max_p_s_i: number
+  
+  created_at: number
+  
+}
+
+export interface DatasetJob {
+  
+  id: number
+  
+  cluster_id: number
+  
+  datasource_id: number
+  
+  dataset_id: number
+  
+  name: string
+  
+  state: string
+  
+  cluster_job_name: string
+  
+  progress: number
+  
+  error: string
+  
+  created_at: number
+  
+  finished_at: number
+  
+}
+
+export interface DatasetPreview {
+  
+  dataset_id: number
+  
+  source: string
+  
+  offset: number
+  
+  total_rows: number
+  
+  columns: DatasetPreviewColumn[]
+  
+  rows: DatasetPreviewRow[]
+  
+}
+
+export interface DatasetPreviewColumn {
+  
+  name: string
+  
+  type: string
+  
+}
+
+export interface DatasetPreviewRow {
+  
+  values: string[]
+  
+  missing: boolean[]
+  
 }
 
 export interface Datasource {
@@ -156,12 +424,38 @@ export interface Datasource {
   
   kind: string
   
-  configuration: string
+  config: DatasourceConfig
   
   created_at: number
   
 }
 
+export interface DatasourceConfig {
+  
+  path: string
+  
+  uri: string
+  
+  endpoint: string
+  
+  region: string
+  
+  bucket: string
+  
+  prefix: string
+  
+  access_key: string
+  
+  url: string
+  
+  table: string
+  
+  query: string
+  
+  username: string
+  
+}
+
 export interface Engine {
   
   id: number
@@ -172,6 +466,18 @@ export interface Engine {
   
   created_at: number
   
+  version: string
+  
+  distribution: string
+  
+  checksum: string
+  
+  api_level: number
+  
+  mojo_algorithms: string[]
+  
+  deep_water: boolean
+  
 }
 
 export interface EntityHistory {
@@ -236,6 +542,18 @@ export interface Job {
   
 }
 
+export interface Keytab {
+  
+  id: number
+  
+  name: string
+  
+  principal: string
+  
+  created_at: number
+  
+}
+
 export interface Label {
   
   id: number
@@ -260,6 +578,10 @@ export interface Model {
   
   validation_dataset_id: number
   
+  training_dataset_version: number
+  
+  stale: boolean
+  
   name: string
   
   cluster_name: string
@@ -300,6 +622,10 @@ export interface MultinomialModel {
   
   validation_dataset_id: number
   
+  training_dataset_version: number
+  
+  stale: boolean
+  
   name: string
   
   cluster_name: string
@@ -370,6 +696,10 @@ export interface RegressionModel {
   
   validation_dataset_id: number
   
+  training_dataset_version: number
+  
+  stale: boolean
+  
   name: string
   
   cluster_name: string
@@ -440,6 +770,30 @@ export interface ScoringService {
   
 }
 
+export interface StaleDataset {
+  
+  dataset_id: number
+  
+  name: string
+  
+  frame_name: string
+  
+  reimportable: boolean
+  
+}
+
+export interface StaleModel {
+  
+  model_id: number
+  
+  name: string
+  
+  model_key: string
+  
+  exported: boolean
+  
+}
+
 export interface UserRole {
   
   kind: string
@@ -464,6 +818,8 @@ export interface Workgroup {
   
   created: number
   
+  idle_timeout: number
+  
 }
 
 export interface YarnCluster {
@@ -480,6 +836,16 @@ export interface YarnCluster {
   
   username: string
   
+  idle_timeout: number
+  
+  idle_deadline: number
+  
+  queue: string
+  
+  node_label: string
+  
+  driver_args: string
+  
 }
 
 
@@ -495,35 +861,95 @@ export interface Service {
   getConfig: (go: (error: Error, config: Config) => void) => void
   
   // Connect to a cluster
-  registerCluster: (address: string, go: (error: Error, clusterId: number) => void) => void
+  registerCluster: (address: string, scheme: string, caCert: string, username: string, password: string, go: (error: Error, clusterId: number) => void) => void
   
   // Disconnect from a cluster
   unregisterCluster: (clusterId: number, go: (error: Error) => void) => void
   
+  // Start a cluster using the launcher for its cluster type
+  startCluster: (clusterName: string, clusterType: string, engineId: number, size: number, memory: string, keytabId: number, go: (error: Error, clusterId: number) => void) => void
+  
+  // Stop a cluster started by Steam
+  stopCluster: (clusterId: number, go: (error: Error) => void) => void
+  
   // Start a cluster using Yarn
-  startClusterOnYarn: (clusterName: string, engineId: number, size: number, memory: string, keytab: string, go: (error: Error, clusterId: number) => void) => void
+  startClusterOnYarn: (clusterName: string, engineId: number, size: number, memory: string, keytabId: number, queue: string, nodeLabel: string, driverArgs: string, go: (error: Error, clusterId: number) => void) => void
   
   // Stop a cluster using Yarn
-  stopClusterOnYarn: (clusterId: number, keytab: string, go: (error: Error) => void) => void
+  stopClusterOnYarn: (clusterId: number, keytabId: number, go: (error: Error) => void) => void
   
-  // Get cluster details
-  getCluster: (clusterId: number, go: (error: Error, cluster: Cluster) => void) => void
+  // Get the launch progress and log of a cluster started using Yarn
+  getClusterLaunch: (clusterId: number, logLines: number, go: (error: Error, launch: ClusterLaunch) => void) => void
   
-  // Get cluster details (Yarn only)
-  getClusterOnYarn: (clusterId: number, go: (error: Error, cluster: YarnCluster) => void) => void
+  // Get the aggregated Yarn logs of a cluster started using Yarn
+  getClusterLogs: (clusterId: number, containerId: string, tailLines: number, offset: number, go: (error: Error, logs: ClusterLogs) => void) => void
   
-  // List clusters
-  getClusters: (offset: number, limit: number, go: (error: Error, clusters: Cluster[]) => void) => void
+  // Cancel a cluster launch on Yarn
+  cancelClusterLaunch: (clusterId: number, go: (error: Error) => void) => void
   
-  // Get cluster status
-  getClusterStatus: (clusterId: number, go: (error: Error, clusterStatus: ClusterStatus) => void) => void
+  // Set the idle timeout of a cluster started using Yarn
+  setClusterIdleTimeout: (clusterId: number, minutes: number, go: (error: Error) => void) => void
   
-  // Delete a cluster
-  deleteCluster: (clusterId: number, go: (error: Error) => void) => void
+  // Postpone the idle shutdown of a cluster
+  keepAlive: (clusterId: number, go: (error: Error) => void) => void
   
-  // Get job details
+  // Get resource usage samples of a cluster's nodes
+  getClusterMetrics: (clusterId: number, from: number, to: number, step: number, go: (error: Error, metrics: ClusterMetric[]) => void) => void
+  
+  // Mark datasets and models whose frames or models are gone from a cluster as stale
+  syncCluster: (clusterId: number, go: (error: Error, sync: ClusterSync) => void) => void
+  
+  // Create a cluster launch template
+  createClusterTemplate: (name: string, engineId: number, size: number, memory: string, driverArgs: string, queue: string, idleTimeout: number, tags: string, maxClusters: number, go: (error: Error, templateId: number) => void) => void
+  
+  // Get cluster launch template details
+  getClusterTemplate: (templateId: number, go: (error: Error, template: ClusterTemplate) => void) => void
+  
+  // List cluster launch templates
+  getClusterTemplates: (offset: number, limit: number, go: (error: Error, templates: ClusterTemplate[]) => void) => void
+  
+  // Update a cluster launch template
+  updateClusterTemplate: (templateId: number, name: string, engineId: number, size: number, memory: string, driverArgs: string, queue: string, idleTimeout: number, tags: string, maxClusters: number, go: (error: Error) => void) => void
+  
+  // Delete a cluster launch template
+  deleteClusterTemplate: (templateId: number, go: (error: Error) => void) => void
+  
+  // Start a cluster on Yarn from a launch template
+  startClusterFromTemplate: (templateId: number, clusterName: string, keytabId: number, go: (error: Error, clusterId: number) => void) => void
+  
+  // List the Kerberos keytabs uploaded by the current identity
+  getKeytabs: (go: (error: Error, keytabs: Keytab[]) => void) => void
+  
+  // Delete an uploaded Kerberos keytab
+  deleteKeytab: (keytabId: number, go: (error: Error) => void) => void
+  
+  // Get the proxy rules of a cluster
+  getClusterProxyPolicy: (clusterId: number, go: (error: Error, policy: string) => void) => void
+  
+  // Set the proxy rules of a cluster
+  setClusterProxyPolicy: (clusterId: number, policy: string, go: (error: Error) => void) => void
+  
+  // Get cluster details
+  getCluster: (clusterId: number, go: (error: Error, cluster: Cluster) => void) => void
+  
+  // Get cluster details (Yarn only)
+  getClusterOnYarn: (clusterId: number, go: (error: Error, cluster: YarnCluster) => void) => void
+  
+  // List clusters
+  getClusters: (offset: number, limit: number, go: (error: Error, clusters: Cluster[]) => void) => void
+  
+  // Get cluster status
+  getClusterStatus: (clusterId: number, go: (error: Error, clusterStatus: ClusterStatus) => void) => void
+  
+  // Delete a cluster
+  deleteCluster: (clusterId: number, go: (error: Error) => void) => void
+  
+  // Get job details
   getJob: (clusterId: number, jobName: string, go: (error: Error, job: Job) => void) => void
   
+  // Cancel a running job
+  cancelJob: (clusterId: number, jobName: string, go: (error: Error) => void) => void
+  
   // List jobs
   getJobs: (clusterId: number, go: (error: Error, jobs: Job[]) => void) => void
   
@@ -540,7 +966,7 @@ export interface Service {
   deleteProject: (projectId: number, go: (error: Error) => void) => void
   
   // Create a datasource
-  createDatasource: (projectId: number, name: string, description: string, path: string, go: (error: Error, datasourceId: number) => void) => void
+  createDatasource: (projectId: number, name: string, description: string, kind: string, configuration: string, go: (error: Error, datasourceId: number) => void) => void
   
   // List datasources
   getDatasources: (projectId: number, offset: number, limit: number, go: (error: Error, datasources: Datasource[]) => void) => void
@@ -549,13 +975,28 @@ export interface Service {
   getDatasource: (datasourceId: number, go: (error: Error, datasource: Datasource) => void) => void
   
   // Update a datasource
-  updateDatasource: (datasourceId: number, name: string, description: string, path: string, go: (error: Error) => void) => void
+  updateDatasource: (datasourceId: number, name: string, description: string, kind: string, configuration: string, go: (error: Error) => void) => void
   
   // Delete a datasource
   deleteDatasource: (datasourceId: number, go: (error: Error) => void) => void
   
-  // Create a dataset
-  createDataset: (clusterId: number, datasourceId: number, name: string, description: string, responseColumnName: string, go: (error: Error, datasetId: number) => void) => void
+  // Check that a datasource can be reached
+  testDatasource: (datasourceId: number, clusterId: number, go: (error: Error, message: string) => void) => void
+  
+  // Start importing a dataset
+  createDataset: (clusterId: number, datasourceId: number, name: string, description: string, responseColumnName: string, compareWithPrevious: boolean, go: (error: Error, jobId: number) => void) => void
+  
+  // Get the state of a dataset import job
+  getDatasetJob: (jobId: number, go: (error: Error, datasetJob: DatasetJob) => void) => void
+  
+  // List the dataset import jobs of a datasource, newest first
+  getDatasetJobs: (datasourceId: number, offset: number, limit: number, go: (error: Error, datasetJobs: DatasetJob[]) => void) => void
+  
+  // Cancel a dataset import job
+  cancelDatasetJob: (jobId: number, go: (error: Error) => void) => void
+  
+  // Re-import a stale dataset from its datasource into its cluster
+  reimportDataset: (datasetId: number, go: (error: Error, jobId: number) => void) => void
   
   // List datasets
   getDatasets: (datasourceId: number, offset: number, limit: number, go: (error: Error, datasets: Dataset[]) => void) => void
@@ -563,14 +1004,35 @@ export interface Service {
   // Get dataset details
   getDataset: (datasetId: number, go: (error: Error, dataset: Dataset) => void) => void
   
+  // Get the column profile of a dataset
+  getDatasetColumns: (datasetId: number, refresh: boolean, go: (error: Error, columns: DatasetColumn[]) => void) => void
+  
+  // Get the columns a dataset assigns to model parameters
+  getDatasetColumnRoles: (datasetId: number, go: (error: Error, roles: DatasetColumnRoles) => void) => void
+  
+  // Assign a role to a dataset column
+  setDatasetColumnRole: (datasetId: number, columnName: string, role: string, go: (error: Error) => void) => void
+  
+  // Get a page of a dataset's rows
+  previewDataset: (datasetId: number, offset: number, rowCount: number, columns: string[], go: (error: Error, preview: DatasetPreview) => void) => void
+  
+  // List the versions of a datasource's data, newest first
+  getDatasetVersions: (datasourceId: number, go: (error: Error, datasets: Dataset[]) => void) => void
+  
   // Get a list of datasets on a cluster
   getDatasetsFromCluster: (clusterId: number, go: (error: Error, dataset: Dataset[]) => void) => void
   
+  // Compare the schema and distributions of two datasets
+  compareDatasets: (baseDatasetId: number, otherDatasetId: number, go: (error: Error, comparison: DatasetComparison) => void) => void
+  
+  // Get the comparison recorded when a dataset was created
+  getDatasetComparison: (datasetId: number, go: (error: Error, comparison: DatasetComparison) => void) => void
+  
   // Update a dataset
   updateDataset: (datasetId: number, name: string, description: string, responseColumnName: string, go: (error: Error) => void) => void
   
   // Split a dataset
-  splitDataset: (datasetId: number, ratio1: number, ratio2: number, go: (error: Error, datasetIds: number[]) => void) => void
+  splitDataset: (datasetId: number, ratio1: number, ratio2: number, seed: number, go: (error: Error, datasetIds: number[]) => void) => void
   
   // Delete a dataset
   deleteDataset: (datasetId: number, go: (error: Error) => void) => void
@@ -749,6 +1211,9 @@ export interface Service {
   // Update a workgroup
   updateWorkgroup: (workgroupId: number, name: string, description: string, go: (error: Error) => void) => void
   
+  // Set the default idle timeout for clusters started by workgroup members
+  setWorkgroupIdleTimeout: (workgroupId: number, minutes: number, go: (error: Error) => void) => void
+  
   // Delete a workgroup
   deleteWorkgroup: (workgroupId: number, go: (error: Error) => void) => void
   
@@ -863,6 +1328,14 @@ interface RegisterClusterIn {
   
   address: string
   
+  scheme: string
+  
+  ca_cert: string
+  
+  username: string
+  
+  password: string
+  
 }
 
 interface RegisterClusterOut {
@@ -881,6 +1354,38 @@ interface UnregisterClusterOut {
   
 }
 
+interface StartClusterIn {
+  
+  cluster_name: string
+  
+  cluster_type: string
+  
+  engine_id: number
+  
+  size: number
+  
+  memory: string
+  
+  keytab_id: number
+  
+}
+
+interface StartClusterOut {
+  
+  cluster_id: number
+  
+}
+
+interface StopClusterIn {
+  
+  cluster_id: number
+  
+}
+
+interface StopClusterOut {
+  
+}
+
 interface StartClusterOnYarnIn {
   
   cluster_name: string
@@ -891,7 +1396,13 @@ interface StartClusterOnYarnIn {
   
   memory: string
   
-  keytab: string
+  keytab_id: number
+  
+  queue: string
+  
+  node_label: string
+  
+  driver_args: string
   
 }
 
@@ -905,7 +1416,7 @@ interface StopClusterOnYarnIn {
   
   cluster_id: number
   
-  keytab: string
+  keytab_id: number
   
 }
 
@@ -913,6 +1424,252 @@ interface StopClusterOnYarnOut {
   
 }
 
+interface GetClusterLaunchIn {
+  
+  cluster_id: number
+  
+  log_lines: number
+  
+}
+
+interface GetClusterLaunchOut {
+  
+  launch: ClusterLaunch
+  
+}
+
+interface GetClusterLogsIn {
+  
+  cluster_id: number
+  
+  container_id: string
+  
+  tail_lines: number
+  
+  offset: number
+  
+}
+
+interface GetClusterLogsOut {
+  
+  logs: ClusterLogs
+  
+}
+
+interface CancelClusterLaunchIn {
+  
+  cluster_id: number
+  
+}
+
+interface CancelClusterLaunchOut {
+  
+}
+
+interface SetClusterIdleTimeoutIn {
+  
+  cluster_id: number
+  
+  minutes: number
+  
+}
+
+interface SetClusterIdleTimeoutOut {
+  
+}
+
+interface KeepAliveIn {
+  
+  cluster_id: number
+  
+}
+
+interface KeepAliveOut {
+  
+}
+
+interface GetClusterMetricsIn {
+  
+  cluster_id: number
+  
+  from: number
+  
+  to: number
+  
+  step: number
+  
+}
+
+interface GetClusterMetricsOut {
+  
+  metrics: ClusterMetric[]
+  
+}
+
+interface SyncClusterIn {
+  
+  cluster_id: number
+  
+}
+
+interface SyncClusterOut {
+  
+  sync: ClusterSync
+  
+}
+
+interface CreateClusterTemplateIn {
+  
+  name: string
+  
+  engine_id: number
+  
+  size: number
+  
+  memory: string
+  
+  driver_args: string
+  
+  queue: string
+  
+  idle_timeout: number
+  
+  tags: string
+  
+  max_clusters: number
+  
+}
+
+interface CreateClusterTemplateOut {
+  
+  template_id: number
+  
+}
+
+interface GetClusterTemplateIn {
+  
+  template_id: number
+  
+}
+
+interface GetClusterTemplateOut {
+  
+  template: ClusterTemplate
+  
+}
+
+interface GetClusterTemplatesIn {
+  
+  offset: number
+  
+  limit: number
+  
+}
+
+interface GetClusterTemplatesOut {
+  
+  templates: ClusterTemplate[]
+  
+}
+
+interface UpdateClusterTemplateIn {
+  
+  template_id: number
+  
+  name: string
+  
+  engine_id: number
+  
+  size: number
+  
+  memory: string
+  
+  driver_args: string
+  
+  queue: string
+  
+  idle_timeout: number
+  
+  tags: string
+  
+  max_clusters: number
+  
+}
+
+interface UpdateClusterTemplateOut {
+  
+}
+
+interface DeleteClusterTemplateIn {
+  
+  template_id: number
+  
+}
+
+interface DeleteClusterTemplateOut {
+  
+}
+
+interface StartClusterFromTemplateIn {
+  
+  template_id: number
+  
+  cluster_name: string
+  
+  keytab_id: number
+  
+}
+
+interface StartClusterFromTemplateOut {
+  
+  cluster_id: number
+  
+}
+
+interface GetKeytabsIn {
+  
+}
+
+interface GetKeytabsOut {
+  
+  keytabs: Keytab[]
+  
+}
+
+interface DeleteKeytabIn {
+  
+  keytab_id: number
+  
+}
+
+interface DeleteKeytabOut {
+  
+}
+
+interface GetClusterProxyPolicyIn {
+  
+  cluster_id: number
+  
+}
+
+interface GetClusterProxyPolicyOut {
+  
+  policy: string
+  
+}
+
+interface SetClusterProxyPolicyIn {
+  
+  cluster_id: number
+  
+  policy: string
+  
+}
+
+interface SetClusterProxyPolicyOut {
+  
+}
+
 interface GetClusterIn {
   
   cluster_id: number
@@ -987,6 +1744,18 @@ interface GetJobOut {
   
 }
 
+interface CancelJobIn {
+  
+  cluster_id: number
+  
+  job_name: string
+  
+}
+
+interface CancelJobOut {
+  
+}
+
 interface GetJobsIn {
   
   cluster_id: number
@@ -1059,7 +1828,9 @@ interface CreateDatasourceIn {
   
   description: string
   
-  path: string
+  kind: string
+  
+  configuration: string
   
 }
 
@@ -1105,7 +1876,9 @@ interface UpdateDatasourceIn {
   
   description: string
   
-  path: string
+  kind: string
+  
+  configuration: string
   
 }
 
@@ -1123,6 +1896,20 @@ interface DeleteDatasourceOut {
   
 }
 
+interface TestDatasourceIn {
+  
+  datasource_id: number
+  
+  cluster_id: number
+  
+}
+
+interface TestDatasourceOut {
+  
+  message: string
+  
+}
+
 interface CreateDatasetIn {
   
   cluster_id: number
@@ -1135,14 +1922,66 @@ interface CreateDatasetIn {
   
   response_column_name: string
   
+  compare_with_previous: boolean
+  
 }
 
 interface CreateDatasetOut {
   
+  job_id: number
+  
+}
+
+interface GetDatasetJobIn {
+  
+  job_id: number
+  
+}
+
+interface GetDatasetJobOut {
+  
+  dataset_job: DatasetJob
+  
+}
+
+interface GetDatasetJobsIn {
+  
+  datasource_id: number
+  
+  offset: number
+  
+  limit: number
+  
+}
+
+interface GetDatasetJobsOut {
+  
+  dataset_jobs: DatasetJob[]
+  
+}
+
+interface CancelDatasetJobIn {
+  
+  job_id: number
+  
+}
+
+interface CancelDatasetJobOut {
+  
+}
+
+interface ReimportDatasetIn {
+  
   dataset_id: number
   
 }
 
+interface ReimportDatasetOut {
+  
+  job_id: number
+  
+}
+
 interface GetDatasetsIn {
   
   datasource_id: number
@@ -1171,6 +2010,76 @@ interface GetDatasetOut {
   
 }
 
+interface GetDatasetColumnsIn {
+  
+  dataset_id: number
+  
+  refresh: boolean
+  
+}
+
+interface GetDatasetColumnsOut {
+  
+  columns: DatasetColumn[]
+  
+}
+
+interface GetDatasetColumnRolesIn {
+  
+  dataset_id: number
+  
+}
+
+interface GetDatasetColumnRolesOut {
+  
+  roles: DatasetColumnRoles
+  
+}
+
+interface SetDatasetColumnRoleIn {
+  
+  dataset_id: number
+  
+  column_name: string
+  
+  role: string
+  
+}
+
+interface SetDatasetColumnRoleOut {
+  
+}
+
+interface PreviewDatasetIn {
+  
+  dataset_id: number
+  
+  offset: number
+  
+  row_count: number
+  
+  columns: string[]
+  
+}
+
+interface PreviewDatasetOut {
+  
+  preview: DatasetPreview
+  
+}
+
+interface GetDatasetVersionsIn {
+  
+  datasource_id: number
+  
+}
+
+interface GetDatasetVersionsOut {
+  
+  datasets: Dataset[]
+  
+}
+
 interface GetDatasetsFromClusterIn {
   
   cluster_id: number
@@ -1183,6 +2092,32 @@ interface GetDatasetsFromClusterOut {
   
 }
 
+interface CompareDatasetsIn {
+  
+  base_dataset_id: number
+  
+  other_dataset_id: number
+  
+}
+
+interface CompareDatasetsOut {
+  
+  comparison: DatasetComparison
+  
+}
+
+interface GetDatasetComparisonIn {
+  
+  dataset_id: number
+  
+}
+
+interface GetDatasetComparisonOut {
+  
+  comparison: DatasetComparison
+  
+}
+
 interface UpdateDatasetIn {
   
   dataset_id: number
@@ -1207,6 +2142,8 @@ interface SplitDatasetIn {
   
   ratio2: number
   
+  seed: number
+  
 }
 
 interface SplitDatasetOut {
@@ -1977,6 +2914,18 @@ interface UpdateWorkgroupOut {
   
 }
 
+interface SetWorkgroupIdleTimeoutIn {
+  
+  workgroup_id: number
+  
+  minutes: number
+  
+}
+
+interface SetWorkgroupIdleTimeoutOut {
+  
+}
+
 interface DeleteWorkgroupIn {
   
   workgroup_id: number
@@ -2374,8 +3323,8 @@ export function getConfig(go: (error: Error, config: Config) => void): void {
   });
 }
 
-export function registerCluster(address: string, go: (error: Error, clusterId: number) => void): void {
-  const req: RegisterClusterIn = { address: address };
+export function registerCluster(address: string, scheme: string, caCert: string, username: string, password: string, go: (error: Error, clusterId: number) => void): void {
+  const req: RegisterClusterIn = { address: address, scheme: scheme, ca_cert: caCert, username: username, password: password };
   Proxy.Call("RegisterCluster", req, function(error, data) {
     if (error) {
       return go(error, null);
@@ -2398,8 +3347,32 @@ export function unregisterCluster(clusterId: number, go: (error: Error) => void)
   });
 }
 
-export function startClusterOnYarn(clusterName: string, engineId: number, size: number, memory: string, keytab: string, go: (error: Error, clusterId: number) => void): void {
-  const req: StartClusterOnYarnIn = { cluster_name: clusterName, engine_id: engineId, size: size, memory: memory, keytab: keytab };
+export function startCluster(clusterName: string, clusterType: string, engineId: number, size: number, memory: string, keytabId: number, go: (error: Error, clusterId: number) => void): void {
+  const req: StartClusterIn = { cluster_name: clusterName, cluster_type: clusterType, engine_id: engineId, size: size, memory: memory, keytab_id: keytabId };
+  Proxy.Call("StartCluster", req, function(error, data) {
+    if (error) {
+      return go(error, null);
+    } else {
+      const d: StartClusterOut = <StartClusterOut> data;
+      return go(null, d.cluster_id);
+    }
+  });
+}
+
+export function stopCluster(clusterId: number, go: (error: Error) => void): void {
+  const req: StopClusterIn = { cluster_id: clusterId };
+  Proxy.Call("StopCluster", req, function(error, data) {
+    if (error) {
+      return go(error);
+    } else {
+      const d: StopClusterOut = <StopClusterOut> data;
+      return go(null);
+    }
+  });
+}
+
+export function startClusterOnYarn(clusterName: string, engineId: number, size: number, memory: string, keytabId: number, queue: string, nodeLabel: string, driverArgs: string, go: (error: Error, clusterId: number) => void): void {
+  const req: StartClusterOnYarnIn = { cluster_name: clusterName, engine_id: engineId, size: size, memory: memory, keytab_id: keytabId, queue: queue, node_label: nodeLabel, driver_args: driverArgs };
   Proxy.Call("StartClusterOnYarn", req, function(error, data) {
     if (error) {
       return go(error, null);
@@ -2410,8 +3383,8 @@ export function startClusterOnYarn(clusterName: string, engineId: number, size:
   });
 }
 
-export function stopClusterOnYarn(clusterId: number, keytab: string, go: (error: Error) => void): void {
-  const req: StopClusterOnYarnIn = { cluster_id: clusterId, keytab: keytab };
+export function stopClusterOnYarn(clusterId: number, keytabId: number, go: (error: Error) => void): void {
+  const req: StopClusterOnYarnIn = { cluster_id: clusterId, keytab_id: keytabId };
   Proxy.Call("StopClusterOnYarn", req, function(error, data) {
     if (error) {
       return go(error);
@@ -2422,6 +3395,210 @@ export function stopClusterOnYarn(clusterId: number, keytab: string, go: (error:
   });
 }
 
+export function getClusterLaunch(clusterId: number, logLines: number, go: (error: Error, launch: ClusterLaunch) => void): void {
+  const req: GetClusterLaunchIn = { cluster_id: clusterId, log_lines: logLines };
+  Proxy.Call("GetClusterLaunch", req, function(error, data) {
+    if (error) {
+      return go(error, null);
+    } else {
+      const d: GetClusterLaunchOut = <GetClusterLaunchOut> data;
+      return go(null, d.launch);
+    }
+  });
+}
+
+export function getClusterLogs(clusterId: number, containerId: string, tailLines: number, offset: number, go: (error: Error, logs: ClusterLogs) => void): void {
+  const req: GetClusterLogsIn = { cluster_id: clusterId, container_id: containerId, tail_lines: tailLines, offset: offset };
+  Proxy.Call("GetClusterLogs", req, function(error, data) {
+    if (error) {
+      return go(error, null);
+    } else {
+      const d: GetClusterLogsOut = <GetClusterLogsOut> data;
+      return go(null, d.logs);
+    }
+  });
+}
+
+export function cancelClusterLaunch(clusterId: number, go: (error: Error) => void): void {
+  const req: CancelClusterLaunchIn = { cluster_id: clusterId };
+  Proxy.Call("CancelClusterLaunch", req, function(error, data) {
+    if (error) {
+      return go(error);
+    } else {
+      const d: CancelClusterLaunchOut = <CancelClusterLaunchOut> data;
+      return go(null);
+    }
+  });
+}
+
+export function setClusterIdleTimeout(clusterId: number, minutes: number, go: (error: Error) => void): void {
+  const req: SetClusterIdleTimeoutIn = { cluster_id: clusterId, minutes: minutes };
+  Proxy.Call("SetClusterIdleTimeout", req, function(error, data) {
+    if (error) {
+      return go(error);
+    } else {
+      const d: SetClusterIdleTimeoutOut = <SetClusterIdleTimeoutOut> data;
+      return go(null);
+    }
+  });
+}
+
+export function keepAlive(clusterId: number, go: (error: Error) => void): void {
+  const req: KeepAliveIn = { cluster_id: clusterId };
+  Proxy.Call("KeepAlive", req, function(error, data) {
+    if (error) {
+      return go(error);
+    } else {
+      const d: KeepAliveOut = <KeepAliveOut> data;
+      return go(null);
+    }
+  });
+}
+
+export function getClusterMetrics(clusterId: number, from: number, to: number, step: number, go: (error: Error, metrics: ClusterMetric[]) => void): void {
+  const req: GetClusterMetricsIn = { cluster_id: clusterId, from: from, to: to, step: step };
+  Proxy.Call("GetClusterMetrics", req, function(error, data) {
+    if (error) {
+      return go(error, null);
+    } else {
+      const d: GetClusterMetricsOut = <GetClusterMetricsOut> data;
+      return go(null, d.metrics);
+    }
+  });
+}
+
+export function syncCluster(clusterId: number, go: (error: Error, sync: ClusterSync) => void): void {
+  const req: SyncClusterIn = { cluster_id: clusterId };
+  Proxy.Call("SyncCluster", req, function(error, data) {
+    if (error) {
+      return go(error, null);
+    } else {
+      const d: SyncClusterOut = <SyncClusterOut> data;
+      return go(null, d.sync);
+    }
+  });
+}
+
+export function createClusterTemplate(name: string, engineId: number, size: number, memory: string, driverArgs: string, queue: string, idleTimeout: number, tags: string, maxClusters: number, go: (error: Error, templateId: number) => void): void {
+  const req: CreateClusterTemplateIn = { name: name, engine_id: engineId, size: size, memory: memory, driver_args: driverArgs, queue: queue, idle_timeout: idleTimeout, tags: tags, max_clusters: maxClusters };
+  Proxy.Call("CreateClusterTemplate", req, function(error, data) {
+    if (error) {
+      return go(error, null);
+    } else {
+      const d: CreateClusterTemplateOut = <CreateClusterTemplateOut> data;
+      return go(null, d.template_id);
+    }
+  });
+}
+
+export function getClusterTemplate(templateId: number, go: (error: Error, template: ClusterTemplate) => void): void {
+  const req: GetClusterTemplateIn = { template_id: templateId };
+  Proxy.Call("GetClusterTemplate", req, function(error, data) {
+    if (error) {
+      return go(error, null);
+    } else {
+      const d: GetClusterTemplateOut = <GetClusterTemplateOut> data;
+      return go(null, d.template);
+    }
+  });
+}
+
+export function getClusterTemplates(offset: number, limit: number, go: (error: Error, templates: ClusterTemplate[]) => void): void {
+  const req: GetClusterTemplatesIn = { offset: offset, limit: limit };
+  Proxy.Call("GetClusterTemplates", req, function(error, data) {
+    if (error) {
+      return go(error, null);
+    } else {
+      const d: GetClusterTemplatesOut = <GetClusterTemplatesOut> data;
+      return go(null, d.templates);
+    }
+  });
+}
+
+export function updateClusterTemplate(templateId: number, name: string, engineId: number, size: number, memory: string, driverArgs: string, queue: string, idleTimeout: number, tags: string, maxClusters: number, go: (error: Error) => void): void {
+  const req: UpdateClusterTemplateIn = { template_id: templateId, name: name, engine_id: engineId, size: size, memory: memory, driver_args: driverArgs, queue: queue, idle_timeout: idleTimeout, tags: tags, max_clusters: maxClusters };
+  Proxy.Call("UpdateClusterTemplate", req, function(error, data) {
+    if (error) {
+      return go(error);
+    } else {
+      const d: UpdateClusterTemplateOut = <UpdateClusterTemplateOut> data;
+      return go(null);
+    }
+  });
+}
+
+export function deleteClusterTemplate(templateId: number, go: (error: Error) => void): void {
+  const req: DeleteClusterTemplateIn = { template_id: templateId };
+  Proxy.Call("DeleteClusterTemplate", req, function(error, data) {
+    if (error) {
+      return go(error);
+    } else {
+      const d: DeleteClusterTemplateOut = <DeleteClusterTemplateOut> data;
+      return go(null);
+    }
+  });
+}
+
+export function startClusterFromTemplate(templateId: number, clusterName: string, keytabId: number, go: (error: Error, clusterId: number) => void): void {
+  const req: StartClusterFromTemplateIn = { template_id: templateId, cluster_name: clusterName, keytab_id: keytabId };
+  Proxy.Call("StartClusterFromTemplate", req, function(error, data) {
+    if (error) {
+      return go(error, null);
+    } else {
+      const d: StartClusterFromTemplateOut = <StartClusterFromTemplateOut> data;
+      return go(null, d.cluster_id);
+    }
+  });
+}
+
+export function getKeytabs(go: (error: Error, keytabs: Keytab[]) => void): void {
+  const req: GetKeytabsIn = {  };
+  Proxy.Call("GetKeytabs", req, function(error, data) {
+    if (error) {
+      return go(error, null);
+    } else {
+      const d: GetKeytabsOut = <GetKeytabsOut> data;
+      return go(null, d.keytabs);
+    }
+  });
+}
+
+export function deleteKeytab(keytabId: number, go: (error: Error) => void): void {
+  const req: DeleteKeytabIn = { keytab_id: keytabId };
+  Proxy.Call("DeleteKeytab", req, function(error, data) {
+    if (error) {
+      return go(error);
+    } else {
+      const d: DeleteKeytabOut = <DeleteKeytabOut> data;
+      return go(null);
+    }
+  });
+}
+
+export function getClusterProxyPolicy(clusterId: number, go: (error: Error, policy: string) => void): void {
+  const req: GetClusterProxyPolicyIn = { cluster_id: clusterId };
+  Proxy.Call("GetClusterProxyPolicy", req, function(error, data) {
+    if (error) {
+      return go(error, null);
+    } else {
+      const d: GetClusterProxyPolicyOut = <GetClusterProxyPolicyOut> data;
+      return go(null, d.policy);
+    }
+  });
+}
+
+export function setClusterProxyPolicy(clusterId: number, policy: string, go: (error: Error) => void): void {
+  const req: SetClusterProxyPolicyIn = { cluster_id: clusterId, policy: policy };
+  Proxy.Call("SetClusterProxyPolicy", req, function(error, data) {
+    if (error) {
+      return go(error);
+    } else {
+      const d: SetClusterProxyPolicyOut = <SetClusterProxyPolicyOut> data;
+      return go(null);
+    }
+  });
+}
+
 export function getCluster(clusterId: number, go: (error: Error, cluster: Cluster) => void): void {
   const req: GetClusterIn = { cluster_id: clusterId };
   Proxy.Call("GetCluster", req, function(error, data) {
@@ -2494,6 +3671,18 @@ export function getJob(clusterId: number, jobName: string, go: (error: Error, jo
   });
 }
 
+export function cancelJob(clusterId: number, jobName: string, go: (error: Error) => void): void {
+  const req: CancelJobIn = { cluster_id: clusterId, job_name: jobName };
+  Proxy.Call("CancelJob", req, function(error, data) {
+    if (error) {
+      return go(error);
+    } else {
+      const d: CancelJobOut = <CancelJobOut> data;
+      return go(null);
+    }
+  });
+}
+
 export function getJobs(clusterId: number, go: (error: Error, jobs: Job[]) => void): void {
   const req: GetJobsIn = { cluster_id: clusterId };
   Proxy.Call("GetJobs", req, function(error, data) {
@@ -2554,8 +3743,8 @@ export function deleteProject(projectId: number, go: (error: Error) => void): vo
   });
 }
 
-export function createDatasource(projectId: number, name: string, description: string, path: string, go: (error: Error, datasourceId: number) => void): void {
-  const req: CreateDatasourceIn = { project_id: projectId, name: name, description: description, path: path };
+export function createDatasource(projectId: number, name: string, description: string, kind: string, configuration: string, go: (error: Error, datasourceId: number) => void): void {
+  const req: CreateDatasourceIn = { project_id: projectId, name: name, description: description, kind: kind, configuration: configuration };
   Proxy.Call("CreateDatasource", req, function(error, data) {
     if (error) {
       return go(error, null);
@@ -2590,8 +3779,8 @@ export function getDatasource(datasourceId: number, go: (error: Error, datasourc
   });
 }
 
-export function updateDatasource(datasourceId: number, name: string, description: string, path: string, go: (error: Error) => void): void {
-  const req: UpdateDatasourceIn = { datasource_id: datasourceId, name: name, description: description, path: path };
+export function updateDatasource(datasourceId: number, name: string, description: string, kind: string, configuration: string, go: (error: Error) => void): void {
+  const req: UpdateDatasourceIn = { datasource_id: datasourceId, name: name, description: description, kind: kind, configuration: configuration };
   Proxy.Call("UpdateDatasource", req, function(error, data) {
     if (error) {
       return go(error);
@@ -2614,14 +3803,74 @@ export function deleteDatasource(datasourceId: number, go: (error: Error) => voi
   });
 }
 
-export function createDataset(clusterId: number, datasourceId: number, name: string, description: string, responseColumnName: string, go: (error: Error, datasetId: number) => void): void {
-  const req: CreateDatasetIn = { cluster_id: clusterId, datasource_id: datasourceId, name: name, description: description, response_column_name: responseColumnName };
+export function testDatasource(datasourceId: number, clusterId: number, go: (error: Error, message: string) => void): void {
+  const req: TestDatasourceIn = { datasource_id: datasourceId, cluster_id: clusterId };
+  Proxy.Call("TestDatasource", req, function(error, data) {
+    if (error) {
+      return go(error, null);
+    } else {
+      const d: TestDatasourceOut = <TestDatasourceOut> data;
+      return go(null, d.message);
+    }
+  });
+}
+
+export function createDataset(clusterId: number, datasourceId: number, name: string, description: string, responseColumnName: string, compareWithPrevious: boolean, go: (error: Error, jobId: number) => void): void {
+  const req: CreateDatasetIn = { cluster_id: clusterId, datasource_id: datasourceId, name: name, description: description, response_column_name: responseColumnName, compare_with_previous: compareWithPrevious };
   Proxy.Call("CreateDataset", req, function(error, data) {
     if (error) {
       return go(error, null);
     } else {
       const d: CreateDatasetOut = <CreateDatasetOut> data;
-      return go(null, d.dataset_id);
+      return go(null, d.job_id);
+    }
+  });
+}
+
+export function getDatasetJob(jobId: number, go: (error: Error, datasetJob: DatasetJob) => void): void {
+  const req: GetDatasetJobIn = { job_id: jobId };
+  Proxy.Call("GetDatasetJob", req, function(error, data) {
+    if (error) {
+      return go(error, null);
+    } else {
+      const d: GetDatasetJobOut = <GetDatasetJobOut> data;
+      return go(null, d.dataset_job);
+    }
+  });
+}
+
+export function getDatasetJobs(datasourceId: number, offset: number, limit: number, go: (error: Error, datasetJobs: DatasetJob[]) => void): void {
+  const req: GetDatasetJobsIn = { datasource_id: datasourceId, offset: offset, limit: limit };
+  Proxy.Call("GetDatasetJobs", req, function(error, data) {
+    if (error) {
+      return go(error, null);
+    } else {
+      const d: GetDatasetJobsOut = <GetDatasetJobsOut> data;
+      return go(null, d.dataset_jobs);
+    }
+  });
+}
+
+export function cancelDatasetJob(jobId: number, go: (error: Error) => void): void {
+  const req: CancelDatasetJobIn = { job_id: jobId };
+  Proxy.Call("CancelDatasetJob", req, function(error, data) {
+    if (error) {
+      return go(error);
+    } else {
+      const d: CancelDatasetJobOut = <CancelDatasetJobOut> data;
+      return go(null);
+    }
+  });
+}
+
+export function reimportDataset(datasetId: number, go: (error: Error, jobId: number) => void): void {
+  const req: ReimportDatasetIn = { dataset_id: datasetId };
+  Proxy.Call("ReimportDataset", req, function(error, data) {
+    if (error) {
+      return go(error, null);
+    } else {
+      const d: ReimportDatasetOut = <ReimportDatasetOut> data;
+      return go(null, d.job_id);
     }
   });
 }
@@ -2650,6 +3899,66 @@ export function getDataset(datasetId: number, go: (error: Error, dataset: Datase
   });
 }
 
+export function getDatasetColumns(datasetId: number, refresh: boolean, go: (error: Error, columns: DatasetColumn[]) => void): void {
+  const req: GetDatasetColumnsIn = { dataset_id: datasetId, refresh: refresh };
+  Proxy.Call("GetDatasetColumns", req, function(error, data) {
+    if (error) {
+      return go(error, null);
+    } else {
+      const d: GetDatasetColumnsOut = <GetDatasetColumnsOut> data;
+      return go(null, d.columns);
+    }
+  });
+}
+
+export function getDatasetColumnRoles(datasetId: number, go: (error: Error, roles: DatasetColumnRoles) => void): void {
+  const req: GetDatasetColumnRolesIn = { dataset_id: datasetId };
+  Proxy.Call("GetDatasetColumnRoles", req, function(error, data) {
+    if (error) {
+      return go(error, null);
+    } else {
+      const d: GetDatasetColumnRolesOut = <GetDatasetColumnRolesOut> data;
+      return go(null, d.roles);
+    }
+  });
+}
+
+export function setDatasetColumnRole(datasetId: number, columnName: string, role: string, go: (error: Error) => void): void {
+  const req: SetDatasetColumnRoleIn = { dataset_id: datasetId, column_name: columnName, role: role };
+  Proxy.Call("SetDatasetColumnRole", req, function(error, data) {
+    if (error) {
+      return go(error);
+    } else {
+      const d: SetDatasetColumnRoleOut = <SetDatasetColumnRoleOut> data;
+      return go(null);
+    }
+  });
+}
+
+export function previewDataset(datasetId: number, offset: number, rowCount: number, columns: string[], go: (error: Error, preview: DatasetPreview) => void): void {
+  const req: PreviewDatasetIn = { dataset_id: datasetId, offset: offset, row_count: rowCount, columns: columns };
+  Proxy.Call("PreviewDataset", req, function(error, data) {
+    if (error) {
+      return go(error, null);
+    } else {
+      const d: PreviewDatasetOut = <PreviewDatasetOut> data;
+      return go(null, d.preview);
+    }
+  });
+}
+
+export function getDatasetVersions(datasourceId: number, go: (error: Error, datasets: Dataset[]) => void): void {
+  const req: GetDatasetVersionsIn = { datasource_id: datasourceId };
+  Proxy.Call("GetDatasetVersions", req, function(error, data) {
+    if (error) {
+      return go(error, null);
+    } else {
+      const d: GetDatasetVersionsOut = <GetDatasetVersionsOut> data;
+      return go(null, d.datasets);
+    }
+  });
+}
+
 export function getDatasetsFromCluster(clusterId: number, go: (error: Error, dataset: Dataset[]) => void): void {
   const req: GetDatasetsFromClusterIn = { cluster_id: clusterId };
   Proxy.Call("GetDatasetsFromCluster", req, function(error, data) {
@@ -2662,6 +3971,30 @@ export function getDatasetsFromCluster(clusterId: number, go: (error: Error, dat
   });
 }
 
+export function compareDatasets(baseDatasetId: number, otherDatasetId: number, go: (error: Error, comparison: DatasetComparison) => void): void {
+  const req: CompareDatasetsIn = { base_dataset_id: baseDatasetId, other_dataset_id: otherDatasetId };
+  Proxy.Call("CompareDatasets", req, function(error, data) {
+    if (error) {
+      return go(error, null);
+    } else {
+      const d: CompareDatasetsOut = <CompareDatasetsOut> data;
+      return go(null, d.comparison);
+    }
+  });
+}
+
+export function getDatasetComparison(datasetId: number, go: (error: Error, comparison: DatasetComparison) => void): void {
+  const req: GetDatasetComparisonIn = { dataset_id: datasetId };
+  Proxy.Call("GetDatasetComparison", req, function(error, data) {
+    if (error) {
+      return go(error, null);
+    } else {
+      const d: GetDatasetComparisonOut = <GetDatasetComparisonOut> data;
+      return go(null, d.comparison);
+    }
+  });
+}
+
 export function updateDataset(datasetId: number, name: string, description: string, responseColumnName: string, go: (error: Error) => void): void {
   const req: UpdateDatasetIn = { dataset_id: datasetId, name: name, description: description, response_column_name: responseColumnName };
   Proxy.Call("UpdateDataset", req, function(error, data) {
@@ -2674,8 +4007,8 @@ export function updateDataset(datasetId: number, name: string, description: stri
   });
 }
 
-export function splitDataset(datasetId: number, ratio1: number, ratio2: number, go: (error: Error, datasetIds: number[]) => void): void {
-  const req: SplitDatasetIn = { dataset_id: datasetId, ratio1: ratio1, ratio2: ratio2 };
+export function splitDataset(datasetId: number, ratio1: number, ratio2: number, seed: number, go: (error: Error, datasetIds: number[]) => void): void {
+  const req: SplitDatasetIn = { dataset_id: datasetId, ratio1: ratio1, ratio2: ratio2, seed: seed };
   Proxy.Call("SplitDataset", req, function(error, data) {
     if (error) {
       return go(error, null);
@@ -3394,6 +4727,18 @@ export function updateWorkgroup(workgroupId: number, name: string, description:
   });
 }
 
+export function setWorkgroupIdleTimeout(workgroupId: number, minutes: number, go: (error: Error) => void): void {
+  const req: SetWorkgroupIdleTimeoutIn = { workgroup_id: workgroupId, minutes: minutes };
+  Proxy.Call("SetWorkgroupIdleTimeout", req, function(error, data) {
+    if (error) {
+      return go(error);
+    } else {
+      const d: SetWorkgroupIdleTimeoutOut = <SetWorkgroupIdleTimeoutOut> data;
+      return go(null);
+    }
+  });
+}
+
 export function deleteWorkgroup(workgroupId: number, go: (error: Error) => void): void {
   const req: DeleteWorkgroupIn = { workgroup_id: workgroupId };
   Proxy.Call("DeleteWorkgroup", req, function(error, data) {
@@ -0,0 +1,245 @@
+/*
+  Copyright (C) 2016 H2O.ai, Inc. <http://h2o.ai/>
+
+  This program is free software: you can redistribute it and/or modify
+  it under the terms of the GNU Affero General Public License as
+  published by the Free Software Foundation, either version 3 of the
+  License, or (at your option) any later version.
+
+  This program is distributed in the hope that it will be useful,
+  but WITHOUT ANY WARRANTY; without even the implied warranty of
+  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+  GNU Affero General Public License for more details.
+
+  You should have received a copy of the GNU Affero General Public License
+  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+// Package engine identifies H2O engine jars and the features each H2O release
+// supports.
+package engine
+
+import (
+	"archive/zip"
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// Algorithm names, as reported by H2O.
+const (
+	GBM       = "Gradient Boosting Method"
+	DRF       = "Distributed Random Forest"
+	DeepWater = "Deep Water"
+)
+
+// Info describes an engine jar.
+type Info struct {
+	Version      string // H2O version, e.g. 3.10.0.7; empty if unknown
+	Distribution string // Hadoop distribution targeted, e.g. cdh5.8; empty for standalone jars
+	Checksum     string // SHA-256 of the jar, hex-encoded
+	DeepWater    bool   // Whether the jar bundles Deep Water
+}
+
+// Driver reports whether the jar is a Hadoop driver, i.e. can be started on
+// YARN.
+func (i Info) Driver() bool {
+	return i.Distribution != ""
+}
+
+// Capabilities returns what the engine supports.
+func (i Info) Capabilities() Capabilities {
+	return CapabilitiesOf(i.Version, i.DeepWater)
+}
+
+// Capabilities lists the features of an H2O release that Steam relies on.
+type Capabilities struct {
+	APILevel       int      // REST API version
+	MojoAlgorithms []string // Algorithms whose models can be exported as MOJOs
+	DeepWater      bool
+}
+
+// CanMojo reports whether models of the given algorithm can be exported as
+// MOJOs.
+func (c Capabilities) CanMojo(algo string) bool {
+	for _, a := range c.MojoAlgorithms {
+		if a == algo {
+			return true
+		}
+	}
+	return false
+}
+
+// MinAPILevel is the oldest REST API Steam can talk to.
+const MinAPILevel = 3
+
+// capabilityTable lists releases that changed capabilities, oldest first; a
+// release has the capabilities of the latest entry at or below it.
+var capabilityTable = []struct {
+	since string
+	caps  Capabilities
+}{
+	{"3.0.0.0", Capabilities{3, nil, false}},
+	{"3.10.0.7", Capabilities{3, []string{GBM, DRF, DeepWater}, true}},
+}
+
+// CapabilitiesOf returns the capabilities of an H2O version. Deep Water (and
+// Deep Water MOJOs) additionally require a build that bundles it. An empty
+// version yields the capabilities of the latest known release.
+func CapabilitiesOf(version string, deepWaterBuild bool) Capabilities {
+	var caps Capabilities
+	for _, entry := range capabilityTable {
+		if version == "" || CompareVersions(version, entry.since) >= 0 {
+			caps = entry.caps
+		}
+	}
+	if !deepWaterBuild {
+		caps.DeepWater = false
+		algos := make([]string, 0, len(caps.MojoAlgorithms))
+		for _, a := range caps.MojoAlgorithms {
+			if a != DeepWater {
+				algos = append(algos, a)
+			}
+		}
+		caps.MojoAlgorithms = algos
+	}
+	return caps
+}
+
+// CompareVersions compares dotted version strings numerically, returning -1, 0
+// or 1. Missing or non-numeric components count as 0.
+func CompareVersions(a, b string) int {
+	as, bs := strings.Split(a, "."), strings.Split(b, ".")
+	for i := 0; i < len(as) || i < len(bs); i++ {
+		var x, y int
+		if i < len(as) {
+			x, _ = strconv.Atoi(as[i])
+		}
+		if i < len(bs) {
+			y, _ = strconv.Atoi(bs[i])
+		}
+		switch {
+		case x < y:
+			return -1
+		case x > y:
+			return 1
+		}
+	}
+	return 0
+}
+
+var nameRegexp = regexp.MustCompile(`h2o-(\d+(?:\.\d+)+)(?:-([a-z]+[\d.]*\d))?`)
+
+// Inspect reads the version, target distribution and checksum of an engine
+// jar. name is the name the engine was uploaded under (e.g.
+// h2o-3.10.0.7-cdh5.8.zip) and is used when the jar itself doesn't say.
+func Inspect(jarPath, name string) (Info, error) {
+	var info Info
+
+	checksum, err := sha256File(jarPath)
+	if err != nil {
+		return info, err
+	}
+	info.Checksum = checksum
+
+	r, err := zip.OpenReader(jarPath)
+	if err != nil {
+		return info, errors.Wrap(err, "opening engine jar")
+	}
+	defer r.Close()
+
+	driver := false
+	for _, f := range r.File {
+		switch {
+		case f.Name == "META-INF/MANIFEST.MF":
+			if info.Version, err = manifestVersion(f); err != nil {
+				return info, err
+			}
+		case f.Name == "water/hadoop/h2odriver.class":
+			driver = true
+		case strings.HasPrefix(f.Name, "hex/deepwater/"):
+			info.DeepWater = true
+		}
+	}
+
+	var distribution string
+	if m := nameRegexp.FindStringSubmatch(path.Base(name)); m != nil {
+		if info.Version == "" {
+			info.Version = m[1]
+		}
+		distribution = m[2]
+	}
+	if driver {
+		if distribution == "" {
+			distribution = "hadoop"
+		}
+		info.Distribution = distribution
+	}
+
+	return info, nil
+}
+
+func manifestVersion(f *zip.File) (string, error) {
+	rc, err := f.Open()
+	if err != nil {
+		return "", errors.Wrap(err, "opening jar manifest")
+	}
+	defer rc.Close()
+
+	scanner := bufio.NewScanner(rc)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if strings.HasPrefix(line, "Implementation-Version:") {
+			return strings.TrimSpace(strings.TrimPrefix(line, "Implementation-Version:")), nil
+		}
+	}
+	return "", errors.Wrap(scanner.Err(), "reading jar manifest")
+}
+
+func sha256File(p string) (string, error) {
+	f, err := os.Open(p)
+	if err != nil {
+		return "", errors.Wrap(err, "opening engine jar")
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", errors.Wrap(err, "computing engine checksum")
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// CheckCompatible returns an error if an engine cannot be started with the
+// given launcher kind ("yarn" or "local"). Engines of unknown version (e.g.
+// added before engines were inspected) are not rejected.
+func CheckCompatible(info Info, clusterType string) error {
+	if info.Version != "" {
+		if caps := info.Capabilities(); caps.APILevel < MinAPILevel {
+			return fmt.Errorf("H2O %s is not supported: REST API level %d required", info.Version, MinAPILevel)
+		}
+	}
+	if info.Checksum == "" {
+		return nil
+	}
+	switch clusterType {
+	case "yarn":
+		if !info.Driver() {
+			return fmt.Errorf("Engine is a standalone H2O build and cannot be started on YARN")
+		}
+	case "local":
+		if info.Driver() {
+			return fmt.Errorf("Engine is an H2O Hadoop driver (%s) and cannot be started locally", info.Distribution)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,123 @@
+/*
+  Copyright (C) 2016 H2O.ai, Inc. <http://h2o.ai/>
+
+  This program is free software: you can redistribute it and/or modify
+  it under the terms of the GNU Affero General Public License as
+  published by the Free Software Foundation, either version 3 of the
+  License, or (at your option) any later version.
+
+  This program is distributed in the hope that it will be useful,
+  but WITHOUT ANY WARRANTY; without even the implied warranty of
+  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+  GNU Affero General Public License for more details.
+
+  You should have received a copy of the GNU Affero General Public License
+  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package engine
+
+import (
+	"archive/zip"
+	"io/ioutil"
+	"os"
+	"path"
+	"testing"
+)
+
+func writeJar(t *testing.T, dir, name string, files map[string]string) string {
+	p := path.Join(dir, name)
+	f, err := os.Create(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	w := zip.NewWriter(f)
+	for name, content := range files {
+		fw, err := w.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		fw.Write([]byte(content))
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return p
+}
+
+func TestInspect(t *testing.T) {
+	dir, err := ioutil.TempDir("", "steam-engine")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	driver := writeJar(t, dir, "h2o-3.10.0.7-cdh5.8.jar", map[string]string{
+		"META-INF/MANIFEST.MF":         "Manifest-Version: 1.0\nImplementation-Version: 3.10.0.8\n",
+		"water/hadoop/h2odriver.class": "",
+	})
+	info, err := Inspect(driver, "h2o-3.10.0.7-cdh5.8.jar")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Version != "3.10.0.8" || info.Distribution != "cdh5.8" || len(info.Checksum) != 64 || info.DeepWater {
+		t.Fatalf("unexpected driver info: %+v", info)
+	}
+	if err := CheckCompatible(info, "yarn"); err != nil {
+		t.Fatal(err)
+	}
+	if err := CheckCompatible(info, "local"); err == nil {
+		t.Fatal("expected a driver to be rejected locally")
+	}
+
+	standalone := writeJar(t, dir, "h2o-3.8.3.3.jar", map[string]string{
+		"water/H2O.class":        "",
+		"hex/deepwater/DW.class": "",
+	})
+	info, err = Inspect(standalone, "h2o-3.8.3.3.zip")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Version != "3.8.3.3" || info.Driver() || !info.DeepWater {
+		t.Fatalf("unexpected standalone info: %+v", info)
+	}
+	if err := CheckCompatible(info, "yarn"); err == nil {
+		t.Fatal("expected a standalone jar to be rejected on YARN")
+	}
+	if caps := info.Capabilities(); caps.CanMojo(GBM) || caps.DeepWater {
+		t.Fatalf("3.8.3.3 should not support MOJOs or Deep Water: %+v", caps)
+	}
+}
+
+func TestCapabilitiesOf(t *testing.T) {
+	if caps := CapabilitiesOf("3.10.0.7", true); !caps.CanMojo(GBM) || !caps.CanMojo(DeepWater) || caps.APILevel != 3 {
+		t.Fatalf("unexpected capabilities: %+v", caps)
+	}
+	if caps := CapabilitiesOf("3.10.1.1", false); !caps.CanMojo(DRF) || caps.CanMojo(DeepWater) || caps.DeepWater {
+		t.Fatalf("unexpected capabilities without Deep Water: %+v", caps)
+	}
+	if caps := CapabilitiesOf("2.8.6", false); caps.APILevel >= MinAPILevel {
+		t.Fatalf("H2O 2 should be unsupported: %+v", caps)
+	}
+	if err := CheckCompatible(Info{"2.8.6", "", "abc", false}, "local"); err == nil {
+		t.Fatal("expected H2O 2 to be rejected")
+	}
+}
+
+func TestCompareVersions(t *testing.T) {
+	cases := []struct {
+		a, b string
+		want int
+	}{
+		{"3.10.0.7", "3.10.0.7", 0},
+		{"3.10.0.7", "3.9.1.1", 1},
+		{"3.8", "3.8.0.0", 0},
+		{"3.10.0.6", "3.10.0.7", -1},
+	}
+	for _, c := range cases {
+		if got := CompareVersions(c.a, c.b); got != c.want {
+			t.Errorf("CompareVersions(%s, %s) = %d, want %d", c.a, c.b, got, c.want)
+		}
+	}
+}
@@ -47,6 +47,7 @@ const (
 	DbDir          = "db"
 	KTDir          = "kt"
 	ProjectDir     = "project"
+	DataDir        = "data"
 	ModelDir       = "model"
 	LibDir         = "lib"
 	OutDir         = "out"
@@ -55,10 +56,13 @@ const (
 	DirPerm        = 0755
 	FilePerm       = 0666
 	KTPerm         = 0600
+	KTDirPerm      = 0700
 	PackExt        = ".steam"
 	KindEngine     = "engine"
 	KindFile       = "file"
 	KindExperiment = "module"
+	KindKeytab     = "keytab"
+	KindDataset    = "dataset"
 )
 
 func NewID() (string, error) {
@@ -226,6 +230,13 @@ func GetProjectPath(wd string, projectId int64) string {
 	return path.Join(wd, ProjectDir, location)
 }
 
+// GetProjectDataPath returns the directory holding the files uploaded to a
+// project as datasets.
+func GetProjectDataPath(wd string, projectId int64) string {
+	location := strconv.FormatInt(projectId, 10)
+	return path.Join(wd, DataDir, location)
+}
+
 func GetPackagePath(wd string, projectId int64, packageName string) string {
 	return path.Join(GetProjectPath(wd, projectId), packageName)
 }
@@ -463,7 +474,13 @@ func GetExternalHost() (string, error) {
 }
 
 func Download(p, u string, preserveFilename bool) (int64, string, error) {
-	res, err := http.Get(u)
+	return DownloadWith(http.Get, p, u, preserveFilename)
+}
+
+// DownloadWith downloads u to p like Download, using get to make the request
+// (e.g. to use a client with credentials).
+func DownloadWith(get func(string) (*http.Response, error), p, u string, preserveFilename bool) (int64, string, error) {
+	res, err := get(u)
 	if err != nil {
 		return 0, "", fmt.Errorf("File download failed: %s: %v", u, err)
 	}
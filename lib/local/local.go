@@ -0,0 +1,237 @@
+/*
+  Copyright (C) 2016 H2O.ai, Inc. <http://h2o.ai/>
+
+  This program is free software: you can redistribute it and/or modify
+  it under the terms of the GNU Affero General Public License as
+  published by the Free Software Foundation, either version 3 of the
+  License, or (at your option) any later version.
+
+  This program is distributed in the hope that it will be useful,
+  but WITHOUT ANY WARRANTY; without even the implied warranty of
+  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+  GNU Affero General Public License for more details.
+
+  You should have received a copy of the GNU Affero General Public License
+  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+// Package local starts and stops H2O clouds as processes on the Steam host.
+package local
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"os"
+	"os/exec"
+	"path"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/h2oai/steam/srv/h2ov3"
+	"github.com/pkg/errors"
+)
+
+const (
+	cloudTimeout = 5 * time.Minute
+	pollInterval = time.Second
+	stopTimeout  = 10 * time.Second
+)
+
+// syncWriter serializes writes from the output of several nodes.
+type syncWriter struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+func (s *syncWriter) Write(p []byte) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.w.Write(p)
+}
+
+// freePorts finds size H2O ports on host, starting at basePort. Each H2O node
+// needs its port and the port above it, so candidates are taken in steps of 2.
+func freePorts(host string, basePort, size int) ([]int, error) {
+	var ports []int
+	for port := basePort; len(ports) < size && port < 65535; port += 2 {
+		if portFree(host, port) && portFree(host, port+1) {
+			ports = append(ports, port)
+		}
+	}
+	if len(ports) < size {
+		return nil, fmt.Errorf("Found only %d of %d free ports from %d", len(ports), size, basePort)
+	}
+	return ports, nil
+}
+
+func portFree(host string, port int) bool {
+	l, err := net.Listen("tcp", net.JoinHostPort(host, strconv.Itoa(port)))
+	if err != nil {
+		return false
+	}
+	l.Close()
+	return true
+}
+
+// StartCloudContext starts an H2O cloud of size nodes from the engine jar at
+// enginePath. The nodes find each other through a flatfile written to dir,
+// which also serves as their ice root. Node output is streamed to w.
+//
+// Returns the process IDs of the nodes and the address of the first node once
+// the cloud has formed. Cancelling ctx stops a launch in progress.
+func StartCloudContext(ctx context.Context, w io.Writer, java, host string, basePort, size int, mem, name, enginePath, dir string) ([]int, string, error) {
+	if size < 1 {
+		return nil, "", fmt.Errorf("Invalid cluster size %d", size)
+	}
+
+	ports, err := freePorts(host, basePort, size)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, "", errors.Wrap(err, "failed creating cluster directory")
+	}
+
+	flatfile := path.Join(dir, "flatfile.txt")
+	nodes := make([]string, len(ports))
+	for i, port := range ports {
+		nodes[i] = net.JoinHostPort(host, strconv.Itoa(port))
+	}
+	if err := ioutil.WriteFile(flatfile, []byte(strings.Join(nodes, "\n")+"\n"), 0644); err != nil {
+		return nil, "", errors.Wrap(err, "failed writing flatfile")
+	}
+
+	out := &syncWriter{w: w}
+	exited := make(chan error, size)
+	var pids []int
+	for _, port := range ports {
+		argv := []string{"-jar", enginePath, "-name", name, "-flatfile", flatfile, "-ip", host, "-port", strconv.Itoa(port), "-ice_root", dir}
+		if mem != "" {
+			argv = append([]string{"-Xmx" + mem}, argv...)
+		}
+
+		cmd := exec.Command(java, argv...)
+		cmd.Stdout = out
+		cmd.Stderr = out
+		cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+
+		fmt.Fprintf(out, "Starting node %s: %s %s\n", net.JoinHostPort(host, strconv.Itoa(port)), java, strings.Join(argv, " "))
+		if err := cmd.Start(); err != nil {
+			StopCloud(name, pids)
+			return nil, "", errors.Wrap(err, "failed starting H2O node")
+		}
+		pids = append(pids, cmd.Process.Pid)
+		go func() { exited <- cmd.Wait() }()
+	}
+
+	address := nodes[0]
+	if err := waitForCloud(ctx, out, address, size, exited); err != nil {
+		StopCloud(name, pids)
+		return nil, "", err
+	}
+
+	fmt.Fprintf(out, "Cloud of size %d formed at %s\n", size, address)
+	return pids, address, nil
+}
+
+func waitForCloud(ctx context.Context, w io.Writer, address string, size int, exited <-chan error) error {
+	timeout := time.NewTimer(cloudTimeout)
+	defer timeout.Stop()
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	h2o := h2ov3.NewClient(address)
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-timeout.C:
+			return fmt.Errorf("Cloud did not form within %s", cloudTimeout)
+		case err := <-exited:
+			return fmt.Errorf("H2O node exited during launch: %v", err)
+		case <-ticker.C:
+			cloud, err := h2o.GetCloudStatus()
+			if err != nil {
+				continue
+			}
+			if int(cloud.CloudSize) == size && cloud.CloudHealthy {
+				return nil
+			}
+		}
+	}
+}
+
+// StopCloud stops the nodes of a local cloud, first with SIGTERM and then, if
+// they linger, with SIGKILL. Processes that are no longer nodes of the named
+// cloud (e.g. a reused pid) are left alone.
+func StopCloud(name string, pids []int) error {
+	var running []int
+	for _, pid := range pids {
+		if isNode(pid, name) {
+			running = append(running, pid)
+		}
+	}
+
+	for _, pid := range running {
+		if err := syscall.Kill(-pid, syscall.SIGTERM); err != nil && err != syscall.ESRCH {
+			return errors.Wrapf(err, "failed stopping H2O node %d", pid)
+		}
+	}
+
+	deadline := time.Now().Add(stopTimeout)
+	for _, pid := range running {
+		for alive(pid) && time.Now().Before(deadline) {
+			time.Sleep(100 * time.Millisecond)
+		}
+		if alive(pid) {
+			if err := syscall.Kill(-pid, syscall.SIGKILL); err != nil && err != syscall.ESRCH {
+				return errors.Wrapf(err, "failed killing H2O node %d", pid)
+			}
+		}
+	}
+	return nil
+}
+
+func alive(pid int) bool {
+	return syscall.Kill(pid, 0) == nil
+}
+
+func isNode(pid int, name string) bool {
+	out, err := exec.Command("/bin/ps", "-o", "command=", "-p", strconv.Itoa(pid)).Output()
+	if err != nil {
+		return false
+	}
+	return strings.Contains(string(out), "-name "+name+" ")
+}
+
+// FormatPids encodes node process IDs as an application ID.
+func FormatPids(pids []int) string {
+	s := make([]string, len(pids))
+	for i, pid := range pids {
+		s[i] = strconv.Itoa(pid)
+	}
+	return strings.Join(s, ",")
+}
+
+// ParsePids decodes an application ID created with FormatPids.
+func ParsePids(appId string) ([]int, error) {
+	if appId == "" {
+		return nil, nil
+	}
+	var pids []int
+	for _, s := range strings.Split(appId, ",") {
+		pid, err := strconv.Atoi(s)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid local application ID %q", appId)
+		}
+		pids = append(pids, pid)
+	}
+	return pids, nil
+}
@@ -0,0 +1,60 @@
+/*
+  Copyright (C) 2016 H2O.ai, Inc. <http://h2o.ai/>
+
+  This program is free software: you can redistribute it and/or modify
+  it under the terms of the GNU Affero General Public License as
+  published by the Free Software Foundation, either version 3 of the
+  License, or (at your option) any later version.
+
+  This program is distributed in the hope that it will be useful,
+  but WITHOUT ANY WARRANTY; without even the implied warranty of
+  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+  GNU Affero General Public License for more details.
+
+  You should have received a copy of the GNU Affero General Public License
+  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package local
+
+import (
+	"net"
+	"testing"
+)
+
+func TestPids(t *testing.T) {
+	appId := FormatPids([]int{101, 102, 103})
+	if appId != "101,102,103" {
+		t.Fatalf("unexpected application ID %q", appId)
+	}
+	pids, err := ParsePids(appId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pids) != 3 || pids[0] != 101 || pids[2] != 103 {
+		t.Fatalf("unexpected pids %v", pids)
+	}
+	if _, err := ParsePids("application_1_2"); err == nil {
+		t.Fatal("expected an error for a YARN application ID")
+	}
+}
+
+func TestFreePorts(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+	busy := l.Addr().(*net.TCPAddr).Port
+
+	// A node can't use a busy port, nor the port below it
+	ports, err := freePorts("127.0.0.1", busy-1, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, port := range ports {
+		if port == busy || port+1 == busy {
+			t.Fatalf("port %d allocated although busy", busy)
+		}
+	}
+}
@@ -19,6 +19,8 @@ package rpc
 
 import (
 	"bytes"
+	"crypto/tls"
+	"crypto/x509"
 	"fmt"
 	grpc "github.com/gorilla/rpc"
 	"github.com/gorilla/rpc/json"
@@ -46,17 +48,64 @@ type Proc struct {
 }
 
 func NewProc(scheme, path, namespace, address, username, password string) *Proc {
+	return NewSecureProc(scheme, path, namespace, address, username, password, nil)
+}
+
+// NewSecureProc is like NewProc, but makes requests using the supplied TLS
+// configuration (e.g. to present a client certificate).
+func NewSecureProc(scheme, path, namespace, address, username, password string, tlsConfig *tls.Config) *Proc {
 	u := url.URL{Scheme: scheme, Host: address, Path: path}
+	client := &http.Client{}
+	if tlsConfig != nil {
+		client.Transport = &http.Transport{
+			Proxy:           http.ProxyFromEnvironment,
+			TLSClientConfig: tlsConfig,
+		}
+	}
 	return &Proc{
 		address,
 		username,
 		password,
-		&http.Client{},
+		client,
 		u.String(),
 		namespace + ".",
 	}
 }
 
+// Client returns the HTTP client used by this proc, for making non-RPC
+// requests (uploads, downloads) with the same transport settings.
+func (proc *Proc) Client() *http.Client {
+	return proc.client
+}
+
+// NewClientTLSConfig builds a client TLS configuration from an optional client
+// certificate/key pair and an optional CA bundle used to verify the server.
+func NewClientTLSConfig(certPath, keyPath, caPath string) (*tls.Config, error) {
+	config := &tls.Config{}
+
+	if certPath != "" || keyPath != "" {
+		cert, err := tls.LoadX509KeyPair(certPath, keyPath)
+		if err != nil {
+			return nil, fmt.Errorf("Error loading client certificate: %v", err)
+		}
+		config.Certificates = []tls.Certificate{cert}
+	}
+
+	if caPath != "" {
+		b, err := ioutil.ReadFile(caPath)
+		if err != nil {
+			return nil, fmt.Errorf("Error reading CA bundle: %v", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(b) {
+			return nil, fmt.Errorf("Error reading CA bundle: no PEM certificates found in %s", caPath)
+		}
+		config.RootCAs = pool
+	}
+
+	return config, nil
+}
+
 func (proc *Proc) Call(method string, in, out interface{}) error {
 	buf, err := json.EncodeClientRequest(proc.namespace+method, in)
 	if err != nil {
@@ -0,0 +1,258 @@
+/*
+  Copyright (C) 2016 H2O.ai, Inc. <http://h2o.ai/>
+
+  This program is free software: you can redistribute it and/or modify
+  it under the terms of the GNU Affero General Public License as
+  published by the Free Software Foundation, either version 3 of the
+  License, or (at your option) any later version.
+
+  This program is distributed in the hope that it will be useful,
+  but WITHOUT ANY WARRANTY; without even the implied warranty of
+  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+  GNU Affero General Public License for more details.
+
+  You should have received a copy of the GNU Affero General Public License
+  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+// Package s3 is a minimal client for S3-compatible object stores: it lists
+// objects and presigns downloads, using path-style requests so that it works
+// with stores other than AWS (e.g. MinIO or Ceph).
+package s3
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	DefaultEndpoint = "https://s3.amazonaws.com"
+	DefaultRegion   = "us-east-1"
+
+	emptyPayloadHash = "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+	timeFormat       = "20060102T150405Z"
+)
+
+type Client struct {
+	endpoint  *url.URL
+	region    string
+	accessKey string
+	secretKey string
+	client    *http.Client
+}
+
+// New returns a client for the store at endpoint. Requests are anonymous if
+// no access key is given.
+func New(endpoint, region, accessKey, secretKey string) (*Client, error) {
+	if endpoint == "" {
+		endpoint = DefaultEndpoint
+	}
+	if region == "" {
+		region = DefaultRegion
+	}
+
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return nil, errors.Wrap(err, "parsing endpoint")
+	}
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, fmt.Errorf("endpoint must be an http or https URL: %s", endpoint)
+	}
+
+	return &Client{u, region, accessKey, secretKey, &http.Client{Timeout: 30 * time.Second}}, nil
+}
+
+type Object struct {
+	Key  string
+	Size int64
+	ETag string
+}
+
+type listBucketResult struct {
+	Contents []struct {
+		Key  string
+		Size int64
+		ETag string
+	}
+	IsTruncated           bool
+	NextContinuationToken string
+}
+
+// List returns up to max objects under prefix in bucket.
+func (c *Client) List(bucket, prefix string, max int) ([]Object, error) {
+	var objects []Object
+	token := ""
+	for {
+		q := url.Values{"list-type": {"2"}}
+		if prefix != "" {
+			q.Set("prefix", prefix)
+		}
+		if token != "" {
+			q.Set("continuation-token", token)
+		}
+
+		res, err := c.do("GET", "/"+bucket, q)
+		if err != nil {
+			return nil, err
+		}
+		body, err := ioutil.ReadAll(res.Body)
+		res.Body.Close()
+		if err != nil {
+			return nil, errors.Wrap(err, "reading bucket listing")
+		}
+		if res.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("listing bucket %s failed: %s: %s", bucket, res.Status, strings.TrimSpace(string(body)))
+		}
+
+		var result listBucketResult
+		if err := xml.Unmarshal(body, &result); err != nil {
+			return nil, errors.Wrap(err, "parsing bucket listing")
+		}
+		for _, o := range result.Contents {
+			if len(objects) == max {
+				return objects, nil
+			}
+			objects = append(objects, Object{o.Key, o.Size, strings.Trim(o.ETag, `"`)})
+		}
+
+		if !result.IsTruncated || result.NextContinuationToken == "" {
+			return objects, nil
+		}
+		token = result.NextContinuationToken
+	}
+}
+
+// PresignGet returns a URL that downloads an object without further
+// credentials until it expires.
+func (c *Client) PresignGet(bucket, key string, expires time.Duration) string {
+	uri := "/" + bucket + "/" + key
+	if c.accessKey == "" {
+		return c.endpoint.Scheme + "://" + c.endpoint.Host + escapePath(uri)
+	}
+	q := presign("GET", c.endpoint.Host, uri, c.region, c.accessKey, c.secretKey, time.Now(), expires)
+	return c.endpoint.Scheme + "://" + c.endpoint.Host + escapePath(uri) + "?" + q
+}
+
+func (c *Client) do(method, uri string, q url.Values) (*http.Response, error) {
+	req, err := http.NewRequest(method, c.endpoint.Scheme+"://"+c.endpoint.Host+escapePath(uri)+"?"+canonicalQuery(q), nil)
+	if err != nil {
+		return nil, err
+	}
+	if c.accessKey != "" {
+		now := time.Now().UTC()
+		req.Header.Set("x-amz-date", now.Format(timeFormat))
+		req.Header.Set("x-amz-content-sha256", emptyPayloadHash)
+		req.Header.Set("Authorization", authorization(method, c.endpoint.Host, uri, q, c.region, c.accessKey, c.secretKey, now))
+	}
+
+	res, err := c.client.Do(req)
+	if err != nil {
+		return nil, errors.Wrapf(err, "requesting %s", uri)
+	}
+	return res, nil
+}
+
+// --- Signature Version 4 ---
+
+// authorization returns the Authorization header of a request signed with
+// the host, x-amz-content-sha256 and x-amz-date headers.
+func authorization(method, host, uri string, q url.Values, region, accessKey, secretKey string, now time.Time) string {
+	amzDate := now.UTC().Format(timeFormat)
+	signedHeaders := "host;x-amz-content-sha256;x-amz-date"
+	canonicalHeaders := "host:" + host + "\nx-amz-content-sha256:" + emptyPayloadHash + "\nx-amz-date:" + amzDate + "\n"
+
+	scope := credentialScope(now, region)
+	request := strings.Join([]string{method, escapePath(uri), canonicalQuery(q), canonicalHeaders, signedHeaders, emptyPayloadHash}, "\n")
+	signature := sign(request, amzDate, scope, secretKey, now, region)
+
+	return "AWS4-HMAC-SHA256 Credential=" + accessKey + "/" + scope + ", SignedHeaders=" + signedHeaders + ", Signature=" + signature
+}
+
+// presign returns the query string of a presigned request.
+func presign(method, host, uri, region, accessKey, secretKey string, now time.Time, expires time.Duration) string {
+	amzDate := now.UTC().Format(timeFormat)
+	scope := credentialScope(now, region)
+
+	q := url.Values{
+		"X-Amz-Algorithm":     {"AWS4-HMAC-SHA256"},
+		"X-Amz-Credential":    {accessKey + "/" + scope},
+		"X-Amz-Date":          {amzDate},
+		"X-Amz-Expires":       {strconv.Itoa(int(expires.Seconds()))},
+		"X-Amz-SignedHeaders": {"host"},
+	}
+	request := strings.Join([]string{method, escapePath(uri), canonicalQuery(q), "host:" + host + "\n", "host", "UNSIGNED-PAYLOAD"}, "\n")
+	signature := sign(request, amzDate, scope, secretKey, now, region)
+
+	return canonicalQuery(q) + "&X-Amz-Signature=" + signature
+}
+
+func credentialScope(now time.Time, region string) string {
+	return now.UTC().Format("20060102") + "/" + region + "/s3/aws4_request"
+}
+
+func sign(request, amzDate, scope, secretKey string, now time.Time, region string) string {
+	hash := sha256.Sum256([]byte(request))
+	stringToSign := "AWS4-HMAC-SHA256\n" + amzDate + "\n" + scope + "\n" + hex.EncodeToString(hash[:])
+
+	key := hmacSHA256([]byte("AWS4"+secretKey), now.UTC().Format("20060102"))
+	key = hmacSHA256(key, region)
+	key = hmacSHA256(key, "s3")
+	key = hmacSHA256(key, "aws4_request")
+	return hex.EncodeToString(hmacSHA256(key, stringToSign))
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(data))
+	return h.Sum(nil)
+}
+
+func canonicalQuery(q url.Values) string {
+	keys := make([]string, 0, len(q))
+	for k := range q {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var parts []string
+	for _, k := range keys {
+		for _, v := range q[k] {
+			parts = append(parts, escape(k, true)+"="+escape(v, true))
+		}
+	}
+	return strings.Join(parts, "&")
+}
+
+func escapePath(p string) string {
+	return escape(p, false)
+}
+
+// escape percent-encodes everything but unreserved characters, as SigV4
+// requires; slashes are kept unless encodeSlash is set.
+func escape(s string, encodeSlash bool) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case 'A' <= c && c <= 'Z', 'a' <= c && c <= 'z', '0' <= c && c <= '9', c == '-', c == '_', c == '.', c == '~':
+			b.WriteByte(c)
+		case c == '/' && !encodeSlash:
+			b.WriteByte(c)
+		default:
+			fmt.Fprintf(&b, "%%%02X", c)
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,106 @@
+/*
+  Copyright (C) 2016 H2O.ai, Inc. <http://h2o.ai/>
+
+  This program is free software: you can redistribute it and/or modify
+  it under the terms of the GNU Affero General Public License as
+  published by the Free Software Foundation, either version 3 of the
+  License, or (at your option) any later version.
+
+  This program is distributed in the hope that it will be useful,
+  but WITHOUT ANY WARRANTY; without even the implied warranty of
+  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+  GNU Affero General Public License for more details.
+
+  You should have received a copy of the GNU Affero General Public License
+  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package yarn
+
+import (
+	"bufio"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// Klist is the command used to list the principals in a keytab.
+var Klist = "klist"
+
+// KeytabPrincipals returns the distinct principals stored in a keytab, as
+// reported by klist -kt.
+func KeytabPrincipals(keytab string) ([]string, error) {
+	out, err := exec.Command(Klist, "-kt", keytab).CombinedOutput()
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed executing klist: %s", strings.TrimSpace(string(out)))
+	}
+
+	principals := parseKlist(string(out))
+	if len(principals) == 0 {
+		return nil, errors.New("keytab contains no principals")
+	}
+	return principals, nil
+}
+
+// parseKlist extracts principals from klist -kt output, which lists one key
+// per line below a dashed header:
+//
+//	KVNO Timestamp         Principal
+//	---- ----------------- ----------------------------
+//	   2 08/01/16 10:00:00 alice@EXAMPLE.COM
+func parseKlist(out string) []string {
+	var principals []string
+	seen := make(map[string]bool)
+
+	in := bufio.NewScanner(strings.NewReader(out))
+	header := true
+	for in.Scan() {
+		line := strings.TrimSpace(in.Text())
+		if header {
+			header = !strings.HasPrefix(line, "----")
+			continue
+		}
+
+		// Drop the encryption type klist -e appends, e.g. "(aes256-cts-hmac-sha1-96)"
+		fields := strings.Fields(line)
+		if n := len(fields); n > 0 && strings.HasPrefix(fields[n-1], "(") {
+			fields = fields[:n-1]
+		}
+		if len(fields) < 2 {
+			continue
+		}
+		p := fields[len(fields)-1]
+		if !seen[p] {
+			seen[p] = true
+			principals = append(principals, p)
+		}
+	}
+	return principals
+}
+
+// PrincipalUser returns the primary component of a Kerberos principal, e.g.
+// "alice" for "alice/host.example.com@EXAMPLE.COM".
+func PrincipalUser(principal string) string {
+	if i := strings.IndexAny(principal, "/@"); i >= 0 {
+		return principal[:i]
+	}
+	return principal
+}
+
+// ChownKeytab hands a keytab to the local account of the given user so that
+// kinit, which runs under that account, can read it. Nothing is done if
+// Steam is not running as root or the user has no local account.
+func ChownKeytab(keytab, username string) error {
+	if os.Geteuid() != 0 {
+		return nil
+	}
+
+	uid, gid, err := getUser(username)
+	if err != nil {
+		return nil
+	}
+
+	return errors.Wrap(os.Chown(keytab, int(uid), int(gid)), "changing keytab owner")
+}
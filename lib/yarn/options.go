@@ -0,0 +1,141 @@
+/*
+  Copyright (C) 2016 H2O.ai, Inc. <http://h2o.ai/>
+
+  This program is free software: you can redistribute it and/or modify
+  it under the terms of the GNU Affero General Public License as
+  published by the Free Software Foundation, either version 3 of the
+  License, or (at your option) any later version.
+
+  This program is distributed in the hope that it will be useful,
+  but WITHOUT ANY WARRANTY; without even the implied warranty of
+  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+  GNU Affero General Public License for more details.
+
+  You should have received a copy of the GNU Affero General Public License
+  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package yarn
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// DefaultDriverOptions are the extra h2odriver options users may pass unless
+// the administrator configures otherwise.
+const DefaultDriverOptions = "-nthreads,-extramempercent,-context_path"
+
+// reservedDriverOptions are set by Steam itself and can never be overridden.
+var reservedDriverOptions = map[string]bool{
+	"-jobname":    true,
+	"-n":          true,
+	"-nodes":      true,
+	"-mapperXmx":  true,
+	"-output":     true,
+	"-disown":     true,
+	"-notify":     true,
+	"-driverif":   true,
+	"-driverport": true,
+}
+
+var (
+	reDriverValue = regexp.MustCompile(`^[A-Za-z0-9_.:/,=+@%-]+$`)
+	reNodeLabel   = regexp.MustCompile(`^[A-Za-z0-9_.&|!() -]+$`)
+)
+
+// DriverOptionPolicy is an allowlist of the extra h2odriver options users may
+// pass when starting a cluster. Entries are option names such as -nthreads,
+// which take exactly one value, or Hadoop properties such as
+// -Dmapreduce.map.memory.mb; a property entry ending in * allows every
+// property with that prefix.
+type DriverOptionPolicy struct {
+	options    map[string]bool
+	properties []string
+}
+
+// ParseDriverOptionPolicy parses a comma-separated allowlist.
+func ParseDriverOptionPolicy(s string) (*DriverOptionPolicy, error) {
+	p := &DriverOptionPolicy{options: make(map[string]bool)}
+	for _, entry := range strings.Split(s, ",") {
+		entry = strings.TrimSpace(entry)
+		switch {
+		case entry == "":
+		case strings.HasPrefix(entry, "-D") && len(entry) > 2:
+			p.properties = append(p.properties, strings.TrimPrefix(entry, "-D"))
+		case strings.HasPrefix(entry, "-") && len(entry) > 1 && !strings.Contains(entry, "*"):
+			if reservedDriverOptions[entry] {
+				return nil, fmt.Errorf("Driver option %s is set by Steam and cannot be allowed", entry)
+			}
+			p.options[entry] = true
+		default:
+			return nil, fmt.Errorf("Invalid driver option allowlist entry %q", entry)
+		}
+	}
+	return p, nil
+}
+
+func (p *DriverOptionPolicy) allowsProperty(key string) bool {
+	for _, prop := range p.properties {
+		if strings.HasSuffix(prop, "*") {
+			if strings.HasPrefix(key, strings.TrimSuffix(prop, "*")) {
+				return true
+			}
+		} else if key == prop {
+			return true
+		}
+	}
+	return false
+}
+
+// Validate checks extra driver arguments against the allowlist, returning them
+// in canonical form: -Dkey=value properties first, then options and values.
+func (p *DriverOptionPolicy) Validate(args []string) ([]string, error) {
+	var properties, options []string
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		switch {
+		case arg == "-D":
+			if i+1 == len(args) {
+				return nil, fmt.Errorf("Missing property after -D")
+			}
+			i++
+			arg = "-D" + args[i]
+			fallthrough
+		case strings.HasPrefix(arg, "-D"):
+			kv := strings.SplitN(strings.TrimPrefix(arg, "-D"), "=", 2)
+			if len(kv) != 2 || kv[0] == "" {
+				return nil, fmt.Errorf("Invalid Hadoop property %s: expected -Dkey=value", arg)
+			}
+			if !p.allowsProperty(kv[0]) {
+				return nil, fmt.Errorf("Hadoop property %s is not allowed", kv[0])
+			}
+			if !reDriverValue.MatchString(kv[1]) {
+				return nil, fmt.Errorf("Invalid value for Hadoop property %s", kv[0])
+			}
+			properties = append(properties, "-D"+kv[0]+"="+kv[1])
+		case p.options[arg]:
+			if i+1 == len(args) {
+				return nil, fmt.Errorf("Missing value for driver option %s", arg)
+			}
+			i++
+			value := args[i]
+			if strings.HasPrefix(value, "-") || !reDriverValue.MatchString(value) {
+				return nil, fmt.Errorf("Invalid value for driver option %s", arg)
+			}
+			options = append(options, arg, value)
+		default:
+			return nil, fmt.Errorf("Driver option %s is not allowed", arg)
+		}
+	}
+	return append(properties, options...), nil
+}
+
+// ValidateNodeLabel checks a YARN node label expression.
+func ValidateNodeLabel(label string) error {
+	if label != "" && !reNodeLabel.MatchString(label) {
+		return fmt.Errorf("Invalid node label expression %q", label)
+	}
+	return nil
+}
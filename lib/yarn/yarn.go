@@ -19,9 +19,11 @@ package yarn
 
 import (
 	"bufio"
+	"bytes"
 	"context"
 	"fmt"
 	"io"
+	"io/ioutil"
 	"log"
 	"math/rand"
 	"os/exec"
@@ -29,6 +31,7 @@ import (
 	"regexp"
 	"strconv"
 	"strings"
+	"sync"
 	"syscall"
 	"time"
 
@@ -95,36 +98,45 @@ func getUser(username string) (uint32, uint32, error) {
 	return uint32(uid64), uint32(gid64), nil
 }
 
-func yarnScan(r io.Reader, name, username string, appID, address, err *string, cancel context.CancelFunc) {
-	// Scan for ip and app_id
-	reNode := regexp.MustCompile(`H2O node (\d+\.\d+\.\d+\.\d+:\d+)`)
-	reApID := regexp.MustCompile(`application_(\d+_\d+)`)
+var (
+	reNode  = regexp.MustCompile(`H2O node (\d+\.\d+\.\d+\.\d+:\d+)`)
+	reAppID = regexp.MustCompile(`application_(\d+_\d+)`)
+)
+
+func isErrorLine(line string) bool {
+	return strings.Contains(line, "ERROR") || strings.Contains(line, "Exception")
+}
 
+func yarnScan(r io.Reader, w io.Writer, name, username string, appID, address, err *string, onAppID func(string), cancel context.CancelFunc) {
+	// Scan for ip and app_id
 	in := bufio.NewScanner(r)
 	for in.Scan() {
 		if in.Text() != "" {
 			// Log output
 			log.Println("YARN", name, username, in.Text())
-			// Find application id
-			if appID != nil {
+			if w != nil {
+				fmt.Fprintln(w, in.Text())
+			}
+			// Find IP address
+			if address != nil {
 				if s := reNode.FindSubmatch(in.Bytes()); s != nil {
 					*address = string(s[1])
 				}
 			}
-			// Find IP address
-			if address != nil {
-				if s := reApID.FindSubmatch(in.Bytes()); s != nil {
+			// Find application id
+			if appID != nil {
+				if s := reAppID.FindSubmatch(in.Bytes()); s != nil && *appID != string(s[1]) {
 					*appID = string(s[1])
+					if onAppID != nil {
+						onAppID(*appID)
+					}
 				}
 			}
 			// Scan for errors
-			if err != nil {
-				if strings.Contains(in.Text(), "ERROR") {
-					*err += in.Text() + "\n"
-				}
+			if err != nil && isErrorLine(in.Text()) {
+				*err += in.Text() + "\n"
+				// Exception should kill process
 				if strings.Contains(in.Text(), "Exception") {
-					*err += in.Text() + "\n"
-					// Exception should kill process
 					cancel()
 				}
 			}
@@ -132,9 +144,21 @@ func yarnScan(r io.Reader, name, username string, appID, address, err *string, c
 	}
 }
 
-func yarnCommand(uid, gid uint32, name, username string, args ...string) (string, string, error) {
+// syncWriter serializes writes from the stdout and stderr scanners.
+type syncWriter struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+func (s *syncWriter) Write(p []byte) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.w.Write(p)
+}
+
+func yarnCommand(ctx context.Context, w io.Writer, onAppID func(string), uid, gid uint32, name, username string, args ...string) (string, string, error) {
 	// Create context for killing process if exception encountered
-	ctx, cancel := context.WithCancel(context.Background())
+	ctx, cancel := context.WithCancel(ctx)
 	defer cancel()
 
 	// Set up hadoop job with user impersonation
@@ -147,22 +171,39 @@ func yarnCommand(uid, gid uint32, name, username string, args ...string) (string
 	if err != nil {
 		return "", "", errors.Wrap(err, "failed setting standard out")
 	}
-	defer stdOut.Close()
 	stdErr, err := cmd.StderrPipe()
 	if err != nil {
 		return "", "", errors.Wrap(err, "failed setting standard err")
 	}
 
-	defer stdErr.Close()
-
-	// Log output and scan
-	var appID, address, cmdErr string
-	go yarnScan(stdOut, name, username, &appID, &address, &cmdErr, cancel)
-	go yarnScan(stdErr, name, username, nil, nil, &cmdErr, cancel)
+	if w != nil {
+		w = &syncWriter{w: w}
+	}
 
 	// Execute command
-	if err := cmd.Run(); err != nil {
-		return "", "", errors.Wrapf(err, "failed running command %s: %v", cmd.Args, cmdErr)
+	if err := cmd.Start(); err != nil {
+		return "", "", errors.Wrapf(err, "failed starting command %s", cmd.Args)
+	}
+
+	// Log output and scan
+	var (
+		appID, address string
+		outErr, errErr string
+		wg             sync.WaitGroup
+	)
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		yarnScan(stdOut, w, name, username, &appID, &address, &outErr, onAppID, cancel)
+	}()
+	go func() {
+		defer wg.Done()
+		yarnScan(stdErr, w, name, username, nil, nil, &errErr, nil, cancel)
+	}()
+	wg.Wait()
+
+	if err := cmd.Wait(); err != nil {
+		return appID, "", errors.Wrapf(err, "failed running command %s: %v", cmd.Args, outErr+errErr)
 	}
 
 	return appID, address, nil
@@ -172,6 +213,23 @@ func yarnCommand(uid, gid uint32, name, username string, args ...string) (string
 //
 // This process needs to store the job-ID to kill the process in the future
 func StartCloud(size int, kerberos bool, mem, name, enginePath, username, keytab string) (string, string, string, error) {
+	return StartCloudContext(context.Background(), nil, nil, size, kerberos, mem, name, enginePath, username, keytab, "", "", nil)
+}
+
+// StartCloudContext is like StartCloud, but the launch is killed if ctx is
+// done, the h2odriver output is copied to w, and onAppID is called as soon as
+// the YARN application ID is known (so the application can be killed even if
+// the launch does not complete). The cloud is submitted to queue and placed on
+// nodes matching nodeLabel, if set. extraArgs are passed to the h2odriver and
+// must have been vetted (see DriverOptionPolicy); options Steam sets itself
+// are refused regardless.
+func StartCloudContext(ctx context.Context, w io.Writer, onAppID func(string), size int, kerberos bool, mem, name, enginePath, username, keytab, queue, nodeLabel string, extraArgs []string) (string, string, string, error) {
+	for _, arg := range extraArgs {
+		if reservedDriverOptions[arg] {
+			return "", "", "", fmt.Errorf("Driver option %s is set by Steam", arg)
+		}
+	}
+
 	// Get user information for Kerberos and Yarn reasons
 	uid, gid, err := getUser(username)
 	if err != nil {
@@ -189,23 +247,69 @@ func StartCloud(size int, kerberos bool, mem, name, enginePath, username, keytab
 	// Randomize outfile name
 	out := "steam/" + name + "_" + randStr(5) + "_out"
 
-	cmdArgs := []string{
-		"jar", enginePath,
-		"-jobname", "STEAM_" + name,
+	cmdArgs := []string{"jar", enginePath}
+	// Generic Hadoop options must precede the h2odriver's own
+	if queue != "" {
+		cmdArgs = append(cmdArgs, "-Dmapreduce.job.queuename="+queue)
+	}
+	if nodeLabel != "" {
+		cmdArgs = append(cmdArgs, "-Dmapreduce.job.node-label-expression="+nodeLabel)
+	}
+	var driverArgs []string
+	for _, arg := range extraArgs {
+		if strings.HasPrefix(arg, "-D") {
+			cmdArgs = append(cmdArgs, arg)
+		} else {
+			driverArgs = append(driverArgs, arg)
+		}
+	}
+	cmdArgs = append(cmdArgs,
+		"-jobname", "STEAM_"+name,
 		"-n", strconv.Itoa(size),
 		"-mapperXmx", mem,
 		"-output", out,
 		"-disown",
-	}
-	appID, address, err := yarnCommand(uid, gid, name, username, cmdArgs...)
+	)
+	cmdArgs = append(cmdArgs, driverArgs...)
+	appID, address, err := yarnCommand(ctx, w, onAppID, uid, gid, name, username, cmdArgs...)
 	if err != nil {
 		cleanDir(out, uid, gid)
-		return "", "", "", errors.Wrap(err, "failed executing command")
+		return appID, "", "", errors.Wrap(err, "failed executing command")
 	}
 
 	return appID, address, out, nil
 }
 
+// LaunchReport summarizes the h2odriver output of a cloud launch.
+type LaunchReport struct {
+	ApplicationID string
+	Nodes         []string
+	Errors        []string
+}
+
+// ParseLaunchLog extracts the application ID, the addresses of the nodes that
+// came up, and any ERROR/Exception lines from h2odriver output.
+func ParseLaunchLog(r io.Reader) (LaunchReport, error) {
+	var report LaunchReport
+	seen := make(map[string]bool)
+
+	in := bufio.NewScanner(r)
+	for in.Scan() {
+		line := in.Text()
+		if s := reAppID.FindStringSubmatch(line); s != nil {
+			report.ApplicationID = s[1]
+		}
+		if s := reNode.FindStringSubmatch(line); s != nil && !seen[s[1]] {
+			seen[s[1]] = true
+			report.Nodes = append(report.Nodes, s[1])
+		}
+		if isErrorLine(line) {
+			report.Errors = append(report.Errors, line)
+		}
+	}
+	return report, in.Err()
+}
+
 // StopCloud kills a hadoop cloud by shelling out a command based on the job-ID
 func StopCloud(kerberos bool, name, id, outdir, username, keytab string) error {
 	uid, gid, err := getUser(username)
@@ -221,10 +325,154 @@ func StopCloud(kerberos bool, name, id, outdir, username, keytab string) error {
 		defer kDest(uid, gid)
 	}
 
-	if _, _, err := yarnCommand(uid, gid, name, username, "job", "-kill", "job_"+id); err != nil {
+	if _, _, err := yarnCommand(context.Background(), nil, nil, uid, gid, name, username, "job", "-kill", "job_"+id); err != nil {
 		return errors.Wrap(err, "failed executing command")
 	}
 
 	cleanDir(outdir, uid, gid)
 	return nil
 }
+
+// ApplicationState reports the YARN state (e.g. RUNNING, FINISHED, FAILED,
+// KILLED) of an application by shelling out to yarn
+func ApplicationState(kerberos bool, id, username, keytab string) (string, error) {
+	uid, gid, err := getUser(username)
+	if err != nil {
+		return "", errors.Wrap(err, "failed getting user")
+	}
+
+	// If kerberos enabled, initialize and defer destroy
+	if kerberos {
+		if err := kInit(username, keytab, uid, gid); err != nil {
+			return "", errors.Wrap(err, "failed initializing kerberos")
+		}
+		defer kDest(uid, gid)
+	}
+
+	cmd := exec.Command("yarn", "application", "-status", "application_"+id)
+	cmd.SysProcAttr = &syscall.SysProcAttr{}
+	cmd.SysProcAttr.Credential = &syscall.Credential{Uid: uid, Gid: gid}
+
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		return "", errors.Wrapf(err, "failed running command %s: %s", cmd.Args, string(out))
+	}
+
+	return parseApplicationState(string(out))
+}
+
+func parseApplicationState(report string) (string, error) {
+	reState := regexp.MustCompile(`(?m)^\s*State\s*:\s*(\w+)`)
+	if s := reState.FindStringSubmatch(report); s != nil {
+		return s[1], nil
+	}
+	return "", errors.New("failed parsing application state from yarn report")
+}
+
+var reContainerID = regexp.MustCompile(`^container_(e\d+_)?\d+_\d+_\d+_\d+$`)
+
+// LogPage is a window of an application's aggregated logs.
+type LogPage struct {
+	Lines []string
+	Total int // Number of lines in the whole log
+}
+
+// ApplicationLogs fetches the aggregated logs of an application (or of one of
+// its containers, if containerID is set) by shelling out to yarn logs as the
+// given user. Only a window of the log is kept: limit lines, ending skip lines
+// before the end of the log.
+func ApplicationLogs(kerberos bool, id, containerID, username, keytab string, skip, limit int) (LogPage, error) {
+	if containerID != "" && !reContainerID.MatchString(containerID) {
+		return LogPage{}, errors.Errorf("invalid container ID %s", containerID)
+	}
+	if err := checkLogWindow(skip, limit); err != nil {
+		return LogPage{}, err
+	}
+
+	uid, gid, err := getUser(username)
+	if err != nil {
+		return LogPage{}, errors.Wrap(err, "failed getting user")
+	}
+
+	// If kerberos enabled, initialize and defer destroy
+	if kerberos {
+		if err := kInit(username, keytab, uid, gid); err != nil {
+			return LogPage{}, errors.Wrap(err, "failed initializing kerberos")
+		}
+		defer kDest(uid, gid)
+	}
+
+	args := []string{"logs", "-applicationId", "application_" + id}
+	if containerID != "" {
+		args = append(args, "-containerId", containerID)
+	}
+	cmd := exec.Command("yarn", args...)
+	cmd.SysProcAttr = &syscall.SysProcAttr{}
+	cmd.SysProcAttr.Credential = &syscall.Credential{Uid: uid, Gid: gid}
+
+	var stdErr bytes.Buffer
+	cmd.Stderr = &stdErr
+	stdOut, err := cmd.StdoutPipe()
+	if err != nil {
+		return LogPage{}, errors.Wrap(err, "failed setting standard out")
+	}
+	if err := cmd.Start(); err != nil {
+		return LogPage{}, errors.Wrapf(err, "failed starting command %s", cmd.Args)
+	}
+
+	page, scanErr := tailPage(stdOut, skip, limit)
+	io.Copy(ioutil.Discard, stdOut) // Drain whatever a failed scan left behind
+	if err := cmd.Wait(); err != nil {
+		return LogPage{}, errors.Wrapf(err, "failed running command %s: %s", cmd.Args, strings.TrimSpace(stdErr.String()))
+	}
+	return page, errors.Wrap(scanErr, "failed reading logs")
+}
+
+// Pages of logs hold at most MaxLogLines lines, ending at most MaxLogOffset
+// lines before the end of the log; the lines in between are held in memory
+// while the log is read.
+const (
+	MaxLogLines  = 1000
+	MaxLogOffset = 100000
+)
+
+func checkLogWindow(skip, limit int) error {
+	if skip < 0 || skip > MaxLogOffset {
+		return errors.Errorf("log offset must be between 0 and %d", MaxLogOffset)
+	}
+	if limit < 0 || limit > MaxLogLines {
+		return errors.Errorf("log pages hold between 0 and %d lines", MaxLogLines)
+	}
+	return nil
+}
+
+// tailPage reads r to the end, keeping only the limit lines that end skip
+// lines before the end, so memory use is bounded by skip+limit lines.
+func tailPage(r io.Reader, skip, limit int) (LogPage, error) {
+	if err := checkLogWindow(skip, limit); err != nil {
+		return LogPage{}, err
+	}
+	window := skip + limit
+	ring := make([]string, window)
+	total := 0
+
+	in := bufio.NewScanner(r)
+	in.Buffer(make([]byte, 64*1024), 1024*1024)
+	for in.Scan() {
+		if window > 0 {
+			ring[total%window] = in.Text()
+		}
+		total++
+	}
+
+	// Oldest kept line first
+	kept := total
+	if kept > window {
+		kept = window
+	}
+	var lines []string
+	for i := total - kept; i < total-skip; i++ {
+		lines = append(lines, ring[i%window])
+	}
+	return LogPage{lines, total}, in.Err()
+}
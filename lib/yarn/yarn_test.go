@@ -0,0 +1,204 @@
+/*
+  Copyright (C) 2016 H2O.ai, Inc. <http://h2o.ai/>
+
+  This program is free software: you can redistribute it and/or modify
+  it under the terms of the GNU Affero General Public License as
+  published by the Free Software Foundation, either version 3 of the
+  License, or (at your option) any later version.
+
+  This program is distributed in the hope that it will be useful,
+  but WITHOUT ANY WARRANTY; without even the implied warranty of
+  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+  GNU Affero General Public License for more details.
+
+  You should have received a copy of the GNU Affero General Public License
+  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package yarn
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"os/user"
+	"path"
+	"strings"
+	"testing"
+)
+
+const launchLog = `Determining driver host interface for mapper->driver callback...
+    [Possible callback IP address: 10.0.0.5]
+Using mapper->driver callback IP address and port: 10.0.0.5:38287
+16/11/02 10:00:01 INFO impl.YarnClientImpl: Submitted application application_1478000000000_0042
+Waiting for H2O cluster to come up...
+H2O node 10.0.0.7:54321 reports H2O cluster size 1
+H2O node 10.0.0.8:54321 reports H2O cluster size 1
+H2O node 10.0.0.7:54321 reports H2O cluster size 2
+16/11/02 10:00:09 ERROR mapred.ClientServiceDelegate: Unexpected response
+java.io.IOException: Connection refused
+`
+
+func TestParseLaunchLog(t *testing.T) {
+	report, err := ParseLaunchLog(strings.NewReader(launchLog))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.ApplicationID != "1478000000000_0042" {
+		t.Fatalf("unexpected application ID: %s", report.ApplicationID)
+	}
+	if len(report.Nodes) != 2 {
+		t.Fatalf("expected 2 nodes, got %v", report.Nodes)
+	}
+	if len(report.Errors) != 2 {
+		t.Fatalf("expected 2 error lines, got %v", report.Errors)
+	}
+}
+
+func TestParseApplicationState(t *testing.T) {
+	report := `Application Report :
+	Application-Id : application_1478000000000_0042
+	Application-Name : STEAM_test
+	Application-Type : MAPREDUCE
+	State : KILLED
+	Final-State : KILLED
+`
+	state, err := parseApplicationState(report)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if state != "KILLED" {
+		t.Fatalf("unexpected state: %s", state)
+	}
+
+	if _, err := parseApplicationState("Application with id 'x' doesn't exist in RM."); err == nil {
+		t.Fatal("expected error for a report without state")
+	}
+}
+
+func TestDriverOptionPolicy(t *testing.T) {
+	if _, err := ParseDriverOptionPolicy("-nthreads,-output"); err == nil {
+		t.Fatal("expected reserved options to be rejected")
+	}
+
+	p, err := ParseDriverOptionPolicy(DefaultDriverOptions + ",-Dmapreduce.map.*")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	args, err := p.Validate([]string{"-nthreads", "4", "-D", "mapreduce.map.memory.mb=4096", "-context_path", "/h2o"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "-Dmapreduce.map.memory.mb=4096 -nthreads 4 -context_path /h2o"
+	if got := strings.Join(args, " "); got != want {
+		t.Fatalf("expected %q, got %q", want, got)
+	}
+
+	for _, bad := range [][]string{
+		{"-libjars", "evil.jar"},
+		{"-nthreads"},
+		{"-nthreads", "-output"},
+		{"-extramempercent", "10;rm"},
+		{"-Dyarn.app.mapreduce.am.command-opts=-Xmx1g"},
+		{"-Dmapreduce.map.memory.mb"},
+		{"-n", "100"},
+	} {
+		if _, err := p.Validate(bad); err == nil {
+			t.Errorf("expected %v to be rejected", bad)
+		}
+	}
+
+	// Options Steam sets are refused even if a caller skipped the policy
+	if _, _, _, err := StartCloudContext(context.Background(), nil, nil, 1, false, "1g", "c1", "/tmp/h2odriver.jar", "nobody", "", "", "", []string{"-output", "/tmp"}); err == nil || !strings.Contains(err.Error(), "-output") {
+		t.Fatalf("expected a reserved option to be refused, got %v", err)
+	}
+
+	if err := ValidateNodeLabel("gpu&&ssd"); err != nil {
+		t.Fatal(err)
+	}
+	if err := ValidateNodeLabel("gpu;ls"); err == nil {
+		t.Fatal("expected an invalid node label to be rejected")
+	}
+}
+
+func TestParseKlist(t *testing.T) {
+	out := `Keytab name: FILE:/tmp/alice.keytab
+KVNO Timestamp         Principal
+---- ----------------- --------------------------------------------------------
+   2 08/01/16 10:00:00 alice@EXAMPLE.COM (aes256-cts-hmac-sha1-96)
+   2 08/01/16 10:00:00 alice@EXAMPLE.COM
+   1 08/01/16 10:00:00 alice/edge.example.com@EXAMPLE.COM
+`
+	principals := parseKlist(out)
+	if len(principals) != 2 {
+		t.Fatalf("expected 2 principals, got %v", principals)
+	}
+	for _, p := range principals {
+		if PrincipalUser(p) != "alice" {
+			t.Fatalf("unexpected principal user for %s: %s", p, PrincipalUser(p))
+		}
+	}
+}
+
+func TestApplicationLogs(t *testing.T) {
+	if os.Geteuid() != 0 {
+		t.Skip("impersonating the cluster user requires root")
+	}
+	u, err := user.Current()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// A fake yarn that echoes its arguments, followed by numbered lines
+	bin, err := ioutil.TempDir("", "steam-yarn")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(bin)
+	script := "#!/bin/sh\necho \"$@\"\nfor i in 1 2 3 4 5 6 7 8 9; do echo line $i; done\n"
+	if err := ioutil.WriteFile(path.Join(bin, "yarn"), []byte(script), 0755); err != nil {
+		t.Fatal(err)
+	}
+	defer os.Setenv("PATH", os.Getenv("PATH"))
+	os.Setenv("PATH", bin+":"+os.Getenv("PATH"))
+
+	page, err := ApplicationLogs(false, "1478000000000_0042", "container_1478000000000_0042_01_000002", u.Username, "", 2, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if page.Total != 10 {
+		t.Fatalf("expected 10 lines, got %d", page.Total)
+	}
+	if strings.Join(page.Lines, ",") != "line 5,line 6,line 7" {
+		t.Fatalf("unexpected page: %v", page.Lines)
+	}
+
+	page, err = ApplicationLogs(false, "1478000000000_0042", "", u.Username, "", 8, 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(page.Lines) != 2 || page.Lines[0] != "logs -applicationId application_1478000000000_0042" {
+		t.Fatalf("unexpected first page: %v", page.Lines)
+	}
+
+	if _, err := ApplicationLogs(false, "1478000000000_0042", "-help", u.Username, "", 0, 5); err == nil {
+		t.Fatal("expected an invalid container ID to be rejected")
+	}
+}
+
+func TestTailPageWindow(t *testing.T) {
+	page, err := tailPage(strings.NewReader("a\nb\nc\nd\n"), 1, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if page.Total != 4 || strings.Join(page.Lines, ",") != "b,c" {
+		t.Fatalf("unexpected page: %+v", page)
+	}
+
+	for _, w := range [][2]int{{MaxLogOffset + 1, 10}, {0, MaxLogLines + 1}, {-1, 10}, {MaxLogOffset * 10, 10}} {
+		if _, err := tailPage(strings.NewReader("a\n"), w[0], w[1]); err == nil {
+			t.Errorf("expected a window of %v to be rejected", w)
+		}
+	}
+}
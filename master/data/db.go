@@ -23,6 +23,7 @@ import (
 	"fmt"
 	"log"
 	"strconv"
+	"time"
 
 	"github.com/h2oai/steam/master/auth"
 	"github.com/h2oai/steam/master/az"
@@ -83,7 +84,7 @@ const (
 )
 
 const (
-	Version = "1.1.0"
+	Version = "1.17.0"
 
 	SuperuserRoleName = "Superuser"
 
@@ -105,9 +106,20 @@ const (
 	ModelEntity      = "model"
 	LabelEntity      = "label"
 	ServiceEntity    = "service"
+	TemplateEntity   = "cluster_template"
+	KeytabEntity     = "keytab"
 
 	ClusterExternal = "external"
 	ClusterYarn     = "yarn"
+	ClusterLocal    = "local"
+
+	DatasourceFile     = "file"
+	DatasourceHDFS     = "hdfs"
+	DatasourceS3       = "s3"
+	DatasourceJDBC     = "jdbc"
+	DatasourceUpload   = "upload"   // Files uploaded to the master; see UploadDatasetChunk
+	DatasourceImplicit = "Implicit" // Created for models imported from a cluster
+	DatasourceCSV      = "CSV"      // Before typed kinds; configured like file
 )
 
 const (
@@ -124,6 +136,26 @@ const (
 	CompletedState    = "completed"
 )
 
+// Dataset import states
+const (
+	ImportQueuedState    = "queued"
+	ImportImportingState = "importing"
+	ImportParsingState   = "parsing"
+	ImportDoneState      = "done"
+	ImportFailedState    = "failed"
+	ImportCancelledState = "cancelled"
+)
+
+// Dataset column roles. A dataset's response column is recorded on the
+// dataset itself; the other roles are recorded per column.
+const (
+	ResponseColumnRole = "response"
+	IgnoredColumnRole  = "ignored"
+	WeightColumnRole   = "weight"
+	FoldColumnRole     = "fold"
+	OffsetColumnRole   = "offset"
+)
+
 const (
 	ManageRole       = "ManageRole"
 	ViewRole         = "ViewRole"
@@ -200,11 +232,14 @@ func init() {
 		{0, ModelEntity},
 		{0, LabelEntity},
 		{0, ServiceEntity},
+		{0, TemplateEntity},
+		{0, KeytabEntity},
 	}
 
 	ClusterTypes = []ClusterType{
 		{0, ClusterExternal},
 		{0, ClusterYarn},
+		{0, ClusterLocal},
 	}
 }
 
@@ -247,11 +282,14 @@ type EntityTypeKeys struct {
 	Model      int64
 	Label      int64
 	Service    int64
+	Template   int64
+	Keytab     int64
 }
 
 type ClusterTypeKeys struct {
 	External int64
 	Yarn     int64
+	Local    int64
 }
 
 func toPermissionKeys(permissions []Permission) *PermissionKeys {
@@ -304,6 +342,8 @@ func toEntityTypeKeys(entityTypes []EntityType) *EntityTypeKeys {
 		m[ModelEntity],
 		m[LabelEntity],
 		m[ServiceEntity],
+		m[TemplateEntity],
+		m[KeytabEntity],
 	}
 }
 
@@ -315,6 +355,7 @@ func toClusterTypeKeys(clusterTypes []ClusterType) *ClusterTypeKeys {
 	return &ClusterTypeKeys{
 		m[ClusterExternal],
 		m[ClusterYarn],
+		m[ClusterLocal],
 	}
 }
 
@@ -332,6 +373,7 @@ type Datastore struct {
 	ClusterTypes      *ClusterTypeKeys
 	ViewPermissions   map[int64]int64
 	ManagePermissions map[int64]int64
+	secretKey         []byte
 }
 
 func Create(dbPath, suname, supass string) (*Datastore, error) {
@@ -547,6 +589,7 @@ func newDatastore(db *sql.DB) (*Datastore, error) {
 		entityTypeKeys.Identity:   permissionKeys.ViewIdentity,
 		entityTypeKeys.Role:       permissionKeys.ViewRole,
 		entityTypeKeys.Workgroup:  permissionKeys.ViewWorkgroup,
+		entityTypeKeys.Template:   permissionKeys.ViewCluster,
 	}
 
 	managePermissions := map[int64]int64{
@@ -561,6 +604,7 @@ func newDatastore(db *sql.DB) (*Datastore, error) {
 		entityTypeKeys.Identity:   permissionKeys.ManageIdentity,
 		entityTypeKeys.Role:       permissionKeys.ManageRole,
 		entityTypeKeys.Workgroup:  permissionKeys.ManageWorkgroup,
+		entityTypeKeys.Template:   permissionKeys.ManageCluster,
 	}
 
 	return &Datastore{
@@ -577,6 +621,7 @@ func newDatastore(db *sql.DB) (*Datastore, error) {
 		toClusterTypeKeys(clusterTypes),
 		viewPermissions,
 		managePermissions,
+		nil,
 	}, nil
 }
 
@@ -710,6 +755,54 @@ func upgrade(db *sql.DB, currentVersion string) error {
 		case currentVersion == "1":
 			log.Println("Upgrading database to 1.1.0")
 			currentVersion, err = upgradeTo_1_1_0(db)
+		case currentVersion == "1.1.0":
+			log.Println("Upgrading database to 1.2.0")
+			currentVersion, err = upgradeTo_1_2_0(db)
+		case currentVersion == "1.2.0":
+			log.Println("Upgrading database to 1.3.0")
+			currentVersion, err = upgradeTo_1_3_0(db)
+		case currentVersion == "1.3.0":
+			log.Println("Upgrading database to 1.4.0")
+			currentVersion, err = upgradeTo_1_4_0(db)
+		case currentVersion == "1.4.0":
+			log.Println("Upgrading database to 1.5.0")
+			currentVersion, err = upgradeTo_1_5_0(db)
+		case currentVersion == "1.5.0":
+			log.Println("Upgrading database to 1.6.0")
+			currentVersion, err = upgradeTo_1_6_0(db)
+		case currentVersion == "1.6.0":
+			log.Println("Upgrading database to 1.7.0")
+			currentVersion, err = upgradeTo_1_7_0(db)
+		case currentVersion == "1.7.0":
+			log.Println("Upgrading database to 1.8.0")
+			currentVersion, err = upgradeTo_1_8_0(db)
+		case currentVersion == "1.8.0":
+			log.Println("Upgrading database to 1.9.0")
+			currentVersion, err = upgradeTo_1_9_0(db)
+		case currentVersion == "1.9.0":
+			log.Println("Upgrading database to 1.10.0")
+			currentVersion, err = upgradeTo_1_10_0(db)
+		case currentVersion == "1.10.0":
+			log.Println("Upgrading database to 1.11.0")
+			currentVersion, err = upgradeTo_1_11_0(db)
+		case currentVersion == "1.11.0":
+			log.Println("Upgrading database to 1.12.0")
+			currentVersion, err = upgradeTo_1_12_0(db)
+		case currentVersion == "1.12.0":
+			log.Println("Upgrading database to 1.13.0")
+			currentVersion, err = upgradeTo_1_13_0(db)
+		case currentVersion == "1.13.0":
+			log.Println("Upgrading database to 1.14.0")
+			currentVersion, err = upgradeTo_1_14_0(db)
+		case currentVersion == "1.14.0":
+			log.Println("Upgrading database to 1.15.0")
+			currentVersion, err = upgradeTo_1_15_0(db)
+		case currentVersion == "1.15.0":
+			log.Println("Upgrading database to 1.16.0")
+			currentVersion, err = upgradeTo_1_16_0(db)
+		case currentVersion == "1.16.0":
+			log.Println("Upgrading database to 1.17.0")
+			currentVersion, err = upgradeTo_1_17_0(db)
 		}
 
 		if err != nil {
@@ -730,6 +823,7 @@ func truncate(db *sql.DB) error {
 			"role_permission",
 			"identity_role",
 			"identity_workgroup",
+			"keytab",
 			"identity",
 			"workgroup",
 			"role",
@@ -741,11 +835,17 @@ func truncate(db *sql.DB) error {
 			"multinomial_model",
 			"regression_model",
 			"model",
+			"dataset_job",
+			"dataset_comparison",
+			"dataset_column_role",
+			"dataset_column",
 			"dataset",
 			"datasource",
 			"project",
+			"cluster_metric",
 			"cluster",
 			"cluster_yarn",
+			"cluster_template",
 			"cluster_type",
 			"engine",
 			"meta",
@@ -981,6 +1081,9 @@ const (
 	UnshareOp string = "unshare"
 	LinkOp    string = "link"
 	UnlinkOp  string = "unlink"
+	WarnOp    string = "warn"
+	ProxyOp   string = "proxy"
+	CancelOp  string = "cancel"
 )
 
 func (ds *Datastore) audit(pz az.Principal, tx *sql.Tx, action string, entityTypeId, entityId int64, metadata metadata) error {
@@ -1020,9 +1123,9 @@ func (ds *Datastore) ReadHistoryForEntity(pz az.Principal, entityTypeId, entityI
 			entity_type_id = $2
 		ORDER BY
 			created DESC
-		OFFSET $3
-		LIMIT $4
-	`, entityId, entityTypeId, offset, limit)
+		LIMIT $3
+		OFFSET $4
+	`, entityId, entityTypeId, limit, offset)
 
 	if err != nil {
 		return nil, err
@@ -1477,7 +1580,7 @@ func (ds *Datastore) CreateWorkgroup(pz az.Principal, name, description string)
 func (ds *Datastore) ReadWorkgroups(pz az.Principal, offset, limit int64) ([]Workgroup, error) {
 	rows, err := ds.db.Query(`
 		SELECT
-			id, type, name, description, created
+			id, type, name, description, created, idle_timeout
 		FROM
 			workgroup
 		WHERE
@@ -1517,7 +1620,7 @@ func (ds *Datastore) ReadWorkgroupsForIdentity(pz az.Principal, identityId int64
 
 	rows, err := ds.db.Query(`
 		SELECT
-			w.id, w.type, w.name, w.description, w.created
+			w.id, w.type, w.name, w.description, w.created, w.idle_timeout
 		FROM
 			workgroup w,
 			identity_workgroup iw
@@ -1560,7 +1663,7 @@ func (ds *Datastore) ReadWorkgroup(pz az.Principal, workgroupId int64) (Workgrou
 
 	row := ds.db.QueryRow(`
 		SELECT
-			id, type, name, description, created
+			id, type, name, description, created, idle_timeout
 		FROM
 			workgroup
 		WHERE
@@ -1576,7 +1679,7 @@ func (ds *Datastore) ReadWorkgroup(pz az.Principal, workgroupId int64) (Workgrou
 func (ds *Datastore) ReadWorkgroupByName(pz az.Principal, name string) (Workgroup, error) {
 	row := ds.db.QueryRow(`
 		SELECT
-			id, type, name, description, created
+			id, type, name, description, created, idle_timeout
 		FROM
 			workgroup
 		WHERE
@@ -1618,6 +1721,49 @@ func (ds *Datastore) UpdateWorkgroup(pz az.Principal, workgroupId int64, name, d
 	})
 }
 
+func (ds *Datastore) UpdateWorkgroupIdleTimeout(pz az.Principal, workgroupId, idleTimeout int64) error {
+	if err := pz.CheckEdit(ds.EntityTypes.Workgroup, workgroupId); err != nil {
+		return err
+	}
+	return ds.exec(func(tx *sql.Tx) error {
+		if _, err := tx.Exec(`
+			UPDATE
+				workgroup
+			SET
+				idle_timeout = $1
+			WHERE
+				id = $2
+			`, idleTimeout, workgroupId); err != nil {
+			return err
+		}
+		return ds.audit(pz, tx, UpdateOp, ds.EntityTypes.Workgroup, workgroupId, metadata{
+			"idleTimeout": strconv.FormatInt(idleTimeout, 10),
+		})
+	})
+}
+
+// ReadDefaultIdleTimeout returns the strictest idle timeout (in minutes) set on
+// the workgroups an identity belongs to, or 0 if none is set.
+func (ds *Datastore) ReadDefaultIdleTimeout(pz az.Principal, identityId int64) (int64, error) {
+	row := ds.db.QueryRow(`
+		SELECT
+			min(w.idle_timeout)
+		FROM
+			workgroup w,
+			identity_workgroup iw
+		WHERE
+			iw.identity_id = $1 AND
+			iw.workgroup_id = w.id AND
+			w.idle_timeout > 0
+		`, identityId)
+
+	var idleTimeout sql.NullInt64
+	if err := row.Scan(&idleTimeout); err != nil {
+		return 0, err
+	}
+	return idleTimeout.Int64, nil
+}
+
 func (ds *Datastore) DeleteWorkgroup(pz az.Principal, workgroupId int64) error {
 	if err := pz.CheckOwns(ds.EntityTypes.Workgroup, workgroupId); err != nil {
 		return err
@@ -2288,15 +2434,21 @@ func deletePrivilegesFor(tx *sql.Tx, identityType string, identityId int64) erro
 // --- Engine ---
 
 func (ds *Datastore) CreateEngine(pz az.Principal, name, location string) (int64, error) {
+	return ds.CreateCataloguedEngine(pz, name, location, "", "", "", false)
+}
+
+// CreateCataloguedEngine adds an engine along with what was learned by
+// inspecting its jar.
+func (ds *Datastore) CreateCataloguedEngine(pz az.Principal, name, location, version, distribution, checksum string, deepWater bool) (int64, error) {
 	var id int64
 	err := ds.exec(func(tx *sql.Tx) error {
 		res, err := tx.Exec(`
 			INSERT INTO
 				engine
-				(name, location, created)
+				(name, location, created,         version, distribution, checksum, deep_water)
 			VALUES
-				($1,   $2,       datetime('now'))
-			`, name, location)
+				($1,   $2,       datetime('now'), $3,      $4,           $5,       $6)
+			`, name, location, version, distribution, checksum, deepWater)
 		if err != nil {
 			return err
 		}
@@ -2318,6 +2470,8 @@ func (ds *Datastore) CreateEngine(pz az.Principal, name, location string) (int64
 		return ds.audit(pz, tx, CreateOp, ds.EntityTypes.Engine, id, metadata{
 			"name":     name,
 			"location": location,
+			"version":  version,
+			"checksum": checksum,
 		})
 
 	})
@@ -2327,7 +2481,7 @@ func (ds *Datastore) CreateEngine(pz az.Principal, name, location string) (int64
 func (ds *Datastore) ReadEngines(pz az.Principal) ([]Engine, error) {
 	rows, err := ds.db.Query(`
 		SELECT
-			id, name, location, created
+			id, name, location, created, version, distribution, checksum, deep_water
 		FROM
 			engine
 		WHERE
@@ -2364,7 +2518,7 @@ func (ds *Datastore) ReadEngine(pz az.Principal, engineId int64) (Engine, error)
 
 	row := ds.db.QueryRow(`
 		SELECT
-			id, name, location, created
+			id, name, location, created, version, distribution, checksum, deep_water
 		FROM
 			engine
 		WHERE
@@ -2397,15 +2551,27 @@ func (ds *Datastore) DeleteEngine(pz az.Principal, engineId int64) error {
 // --- Cluster ---
 
 func (ds *Datastore) CreateExternalCluster(pz az.Principal, name, address, state string) (int64, error) {
+	return ds.CreateSecureExternalCluster(pz, name, address, state, "http", "", "", "")
+}
+
+// CreateSecureExternalCluster registers an external cluster reached with the
+// given scheme, CA bundle and credentials. The password is encrypted before it
+// is stored.
+func (ds *Datastore) CreateSecureExternalCluster(pz az.Principal, name, address, state, scheme, caCert, username, password string) (int64, error) {
+	encrypted, err := ds.EncryptSecret(password)
+	if err != nil {
+		return 0, errors.Wrap(err, "encrypting cluster password")
+	}
+
 	var id int64
-	err := ds.exec(func(tx *sql.Tx) error {
+	err = ds.exec(func(tx *sql.Tx) error {
 		res, err := tx.Exec(`
 			INSERT INTO
 				cluster
-				(name, type_id, detail_id, address, state, created)
+				(name, type_id, detail_id, address, state, created,         scheme, ca_cert, username, password)
 			VALUES
-				($1,   $2,      0,         $3,      $4,    datetime('now'))
-			`, name, ds.ClusterTypes.External, address, state)
+				($1,   $2,      0,         $3,      $4,    datetime('now'), $5,     $6,      $7,       $8)
+			`, name, ds.ClusterTypes.External, address, state, scheme, caCert, username, encrypted)
 		if err != nil {
 			return err
 		}
@@ -2429,21 +2595,33 @@ func (ds *Datastore) CreateExternalCluster(pz az.Principal, name, address, state
 			"type":    ClusterExternal,
 			"address": address,
 			"state":   state,
+			"scheme":  scheme,
 		})
 	})
 	return id, err
 }
 
 func (ds *Datastore) CreateYarnCluster(pz az.Principal, name, address, state string, cluster YarnCluster) (int64, error) {
+	return ds.createLaunchedCluster(pz, ds.ClusterTypes.Yarn, ClusterYarn, name, address, state, cluster)
+}
+
+// CreateLocalCluster records a cluster of H2O processes started on the Steam
+// host. Local clusters share the launch details of YARN clusters; the
+// application ID holds the process IDs of the cluster's nodes.
+func (ds *Datastore) CreateLocalCluster(pz az.Principal, name, address, state string, cluster YarnCluster) (int64, error) {
+	return ds.createLaunchedCluster(pz, ds.ClusterTypes.Local, ClusterLocal, name, address, state, cluster)
+}
+
+func (ds *Datastore) createLaunchedCluster(pz az.Principal, typeId int64, typeName, name, address, state string, cluster YarnCluster) (int64, error) {
 	var clusterId int64
 	err := ds.exec(func(tx *sql.Tx) error {
 		var yarnClusterId int64
 		if res, err := tx.Exec(`
 			INSERT INTO
 				cluster_yarn
-				(engine_id, size, application_id, memory, username, output_dir)
+				(engine_id, size, application_id, memory, username, output_dir, keytab, idle_timeout, last_activity, template_id, queue, node_label, driver_args)
 			VALUES
-				($1,        $2,   $3,             $4,     $5,       $6)
+				($1,        $2,   $3,             $4,     $5,       $6,         $7,     $8,           $9,            $10,         $11,   $12,        $13)
 			`,
 			cluster.EngineId,
 			cluster.Size,
@@ -2451,6 +2629,13 @@ func (ds *Datastore) CreateYarnCluster(pz az.Principal, name, address, state str
 			cluster.Memory,
 			cluster.Username,
 			cluster.OutputDir,
+			cluster.Keytab,
+			cluster.IdleTimeout,
+			cluster.LastActivity,
+			cluster.TemplateId,
+			cluster.Queue,
+			cluster.NodeLabel,
+			cluster.DriverArgs,
 		); err != nil {
 			return err
 		} else {
@@ -2466,7 +2651,7 @@ func (ds *Datastore) CreateYarnCluster(pz az.Principal, name, address, state str
 				(name, type_id, detail_id, address, state, created)
 			VALUES
 				($1,   $2,      $3,        $4,      $5,    datetime('now'))
-			`, name, ds.ClusterTypes.Yarn, yarnClusterId, address, state)
+			`, name, typeId, yarnClusterId, address, state)
 		if err != nil {
 			return err
 		}
@@ -2487,7 +2672,7 @@ func (ds *Datastore) CreateYarnCluster(pz az.Principal, name, address, state str
 
 		return ds.audit(pz, tx, CreateOp, ds.EntityTypes.Cluster, clusterId, metadata{
 			"name":            name,
-			"type":            ClusterYarn,
+			"type":            typeName,
 			"address":         address,
 			"state":           state,
 			"engineId":        strconv.FormatInt(cluster.EngineId, 10),
@@ -2496,6 +2681,10 @@ func (ds *Datastore) CreateYarnCluster(pz az.Principal, name, address, state str
 			"memory":          cluster.Memory,
 			"username":        cluster.Username,
 			"outputDirectory": cluster.OutputDir,
+			"idleTimeout":     strconv.FormatInt(cluster.IdleTimeout, 10),
+			"queue":           cluster.Queue,
+			"nodeLabel":       cluster.NodeLabel,
+			"driverArgs":      cluster.DriverArgs,
 		})
 	})
 	return clusterId, err
@@ -2508,7 +2697,7 @@ func (ds *Datastore) ReadClusterTypes(pz az.Principal) []ClusterType {
 func (ds *Datastore) ReadClusters(pz az.Principal, offset, limit int64) ([]Cluster, error) {
 	rows, err := ds.db.Query(`
 		SELECT
-			id, name, type_id, detail_id, address, state, created
+			id, name, type_id, detail_id, address, state, created, scheme, ca_cert, username, password
 		FROM
 			cluster
 		WHERE
@@ -2541,13 +2730,49 @@ func (ds *Datastore) ReadClusters(pz az.Principal, offset, limit int64) ([]Clust
 	return ScanClusters(rows)
 }
 
+// ReadAllClusters returns every cluster the principal can view, unpaginated,
+// for background workers that go through all clusters.
+func (ds *Datastore) ReadAllClusters(pz az.Principal) ([]Cluster, error) {
+	rows, err := ds.db.Query(`
+		SELECT
+			id, name, type_id, detail_id, address, state, created, scheme, ca_cert, username, password
+		FROM
+			cluster
+		WHERE
+			id IN
+			(
+				SELECT DISTINCT
+					entity_id
+				FROM
+					privilege
+				WHERE
+					$1 OR
+					(
+						workgroup_id IN
+						(
+							SELECT workgroup_id FROM identity_workgroup WHERE identity_id = $2
+						) AND
+						entity_type_id = $3
+					)
+			)
+		ORDER BY
+			id
+		`, pz.IsSuperuser(), pz.Id(), ds.EntityTypes.Cluster)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return ScanClusters(rows)
+}
+
 func (ds *Datastore) ReadCluster(pz az.Principal, clusterId int64) (Cluster, error) {
 	if err := pz.CheckView(ds.EntityTypes.Cluster, clusterId); err != nil {
 		return Cluster{}, err
 	}
 	row := ds.db.QueryRow(`
 		SELECT
-			id, name, type_id, detail_id, address, state, created
+			id, name, type_id, detail_id, address, state, created, scheme, ca_cert, username, password
 		FROM
 			cluster
 		WHERE
@@ -2561,7 +2786,7 @@ func (ds *Datastore) ReadClusterByAddress(pz az.Principal, address string) (Clus
 	var cluster Cluster
 	rows, err := ds.db.Query(`
 		SELECT
-			id, name, type_id, detail_id, address, state, created
+			id, name, type_id, detail_id, address, state, created, scheme, ca_cert, username, password
 		FROM
 			cluster
 		WHERE
@@ -2580,7 +2805,7 @@ func (ds *Datastore) ReadClusterByName(pz az.Principal, name string) (Cluster, b
 	var cluster Cluster
 	rows, err := ds.db.Query(`
 		SELECT
-			id, name, type_id, detail_id, address, state, created
+			id, name, type_id, detail_id, address, state, created, scheme, ca_cert, username, password
 		FROM
 			cluster
 		WHERE
@@ -2617,7 +2842,7 @@ func (ds *Datastore) ReadYarnCluster(pz az.Principal, clusterId int64) (YarnClus
 
 	row := ds.db.QueryRow(`
 		SELECT
-			y.id, y.engine_id, y.size, y.application_id, y.memory, y.username, y.output_dir
+			y.id, y.engine_id, y.size, y.application_id, y.memory, y.username, y.output_dir, y.keytab, y.idle_timeout, y.last_activity, y.template_id, y.queue, y.node_label, y.driver_args
 		FROM
 			cluster c,
 			cluster_yarn y
@@ -2649,165 +2874,789 @@ func (ds *Datastore) UpdateClusterState(pz az.Principal, clusterId int64, state
 	})
 }
 
-func (ds *Datastore) DeleteCluster(pz az.Principal, clusterId int64) error {
-	if err := pz.CheckOwns(ds.EntityTypes.Cluster, clusterId); err != nil {
+// UpdateClusterStaleness records the staleness of datasets and models of a
+// cluster, keyed by id. Only the given datasets and models the principal can
+// view are updated; a superuser, syncing the whole cluster, also clears the
+// mark from the cluster's datasets and models that are not given.
+func (ds *Datastore) UpdateClusterStaleness(pz az.Principal, clusterId int64, datasets, models map[int64]bool) error {
+	if err := pz.CheckEdit(ds.EntityTypes.Cluster, clusterId); err != nil {
 		return err
 	}
 
-	cluster, err := ds.ReadCluster(pz, clusterId)
-	if err != nil {
-		return err
+	entities := []struct {
+		table        string
+		entityTypeId int64
+		stale        map[int64]bool
+	}{
+		{"dataset", ds.EntityTypes.Dataset, datasets},
+		{"model", ds.EntityTypes.Model, models},
 	}
 
 	return ds.exec(func(tx *sql.Tx) error {
-		if cluster.TypeId == ds.ClusterTypes.Yarn {
-			if _, err := tx.Exec(`
-				DELETE FROM
-					cluster_yarn
-				WHERE
-					id = $1
-				`, cluster.DetailId); err != nil {
-				return err
+		var staleCounts [2]int
+		for i, e := range entities {
+			if pz.IsSuperuser() {
+				if _, err := tx.Exec(`
+					UPDATE
+						`+e.table+`
+					SET
+						stale = 0
+					WHERE
+						cluster_id = $1
+					`, clusterId); err != nil {
+					return err
+				}
+			}
+			for id, stale := range e.stale {
+				if err := pz.CheckView(e.entityTypeId, id); err != nil {
+					return err
+				}
+				if _, err := tx.Exec(`
+					UPDATE
+						`+e.table+`
+					SET
+						stale = $1
+					WHERE
+						id = $2 AND cluster_id = $3
+					`, stale, id, clusterId); err != nil {
+					return err
+				}
+				if stale {
+					staleCounts[i]++
+				}
 			}
 		}
-
-		if _, err := tx.Exec(`
-			DELETE FROM
-				cluster
-			WHERE
-				id = $1
-			`, clusterId); err != nil {
-			return err
-		}
-
-		if err := deletePrivilegesOn(tx, ds.EntityTypes.Cluster, clusterId); err != nil {
-			return err
-		}
-		return ds.audit(pz, tx, DeleteOp, ds.EntityTypes.Cluster, clusterId, metadata{})
+		return ds.audit(pz, tx, UpdateOp, ds.EntityTypes.Cluster, clusterId, metadata{
+			"staleDatasets": strconv.Itoa(staleCounts[0]),
+			"staleModels":   strconv.Itoa(staleCounts[1]),
+		})
 	})
 }
 
-// --- Project ---
+// ReadClusterProxyPolicy returns the proxy rules of a cluster as JSON, or an
+// empty string if the cluster uses the default proxy policy.
+func (ds *Datastore) ReadClusterProxyPolicy(pz az.Principal, clusterId int64) (string, error) {
+	if err := pz.CheckView(ds.EntityTypes.Cluster, clusterId); err != nil {
+		return "", err
+	}
 
-func (ds *Datastore) CreateProject(pz az.Principal, name, description, modelCategory string) (int64, error) {
-	var id int64
-	err := ds.exec(func(tx *sql.Tx) error {
-		res, err := tx.Exec(`
-			INSERT INTO
-				project
-				(name, description, model_category, created)
-			VALUES
-				($1,   $2,          $3,             datetime('now'))
-			`, name, description, modelCategory)
-		if err != nil {
-			return err
-		}
+	row := ds.db.QueryRow(`
+		SELECT
+			proxy_policy
+		FROM
+			cluster
+		WHERE
+			id = $1
+		`, clusterId)
 
-		id, err = res.LastInsertId()
-		if err != nil {
-			return err
-		}
+	var policy string
+	if err := row.Scan(&policy); err != nil {
+		return "", err
+	}
+	return policy, nil
+}
 
-		if err := createPrivilege(tx, Privilege{
-			Owns,
-			pz.WorkgroupId(),
-			ds.EntityTypes.Project,
-			id,
-		}); err != nil {
+func (ds *Datastore) UpdateClusterProxyPolicy(pz az.Principal, clusterId int64, policy string) error {
+	if err := pz.CheckOwns(ds.EntityTypes.Cluster, clusterId); err != nil {
+		return err
+	}
+
+	return ds.exec(func(tx *sql.Tx) error {
+		if _, err := tx.Exec(`
+			UPDATE
+				cluster
+			SET
+				proxy_policy = $1
+			WHERE
+				id = $2
+			`, policy, clusterId); err != nil {
 			return err
 		}
+		return ds.audit(pz, tx, UpdateOp, ds.EntityTypes.Cluster, clusterId, metadata{"proxyPolicy": policy})
+	})
+}
 
-		return ds.audit(pz, tx, CreateOp, ds.EntityTypes.Project, id, metadata{
-			"name":           name,
-			"description":    description,
-			"model_category": modelCategory,
+// AuditProxyRequest records a mutating request proxied to a cluster.
+func (ds *Datastore) AuditProxyRequest(pz az.Principal, clusterId int64, method, path string, status int) error {
+	return ds.exec(func(tx *sql.Tx) error {
+		return ds.audit(pz, tx, ProxyOp, ds.EntityTypes.Cluster, clusterId, metadata{
+			"method": method,
+			"path":   path,
+			"status": strconv.Itoa(status),
 		})
 	})
-	return id, err
 }
 
-func (ds *Datastore) ReadProjects(pz az.Principal, offset, limit int64) ([]Project, error) {
-	rows, err := ds.db.Query(`
-		SELECT
-			id, name, description, model_category, created
-		FROM
-			project
-		WHERE
-			id IN
-			(
-				SELECT DISTINCT
-					entity_id
-				FROM 
-					privilege
-				WHERE
-				  	$1 OR
-					(
-						workgroup_id IN 
-						(
-							SELECT workgroup_id FROM identity_workgroup WHERE identity_id = $2
-						) AND 
-						entity_type_id = $3
-					)
-			)
-		ORDER BY
-			name
-		LIMIT $4
-		OFFSET $5
-		`, pz.IsSuperuser(), pz.Id(), ds.EntityTypes.Project, limit, offset)
-	if err != nil {
-		return nil, err
-	}
-	defer rows.Close()
-	return ScanProjects(rows)
+// AuditJobCancel records the cancellation of an H2O job on a cluster.
+func (ds *Datastore) AuditJobCancel(pz az.Principal, clusterId int64, jobName string) error {
+	return ds.exec(func(tx *sql.Tx) error {
+		return ds.audit(pz, tx, CancelOp, ds.EntityTypes.Cluster, clusterId, metadata{
+			"job": jobName,
+		})
+	})
 }
 
-func (ds *Datastore) ReadProject(pz az.Principal, projectId int64) (Project, error) {
-	if err := pz.CheckView(ds.EntityTypes.Project, projectId); err != nil {
-		return Project{}, err
+// UpdateYarnClusterApplication records the YARN application ID of a cluster
+// that is still starting, so the application can be killed if the launch is
+// cancelled or interrupted.
+func (ds *Datastore) UpdateYarnClusterApplication(pz az.Principal, clusterId int64, applicationId string) error {
+	if err := pz.CheckEdit(ds.EntityTypes.Cluster, clusterId); err != nil {
+		return err
 	}
 
-	row := ds.db.QueryRow(`
-		SELECT
-			id, name, description, model_category, created
-		FROM
-			project
-		WHERE
-			id = $1
-		`, projectId)
-	return ScanProject(row)
+	return ds.exec(func(tx *sql.Tx) error {
+		if _, err := tx.Exec(`
+			UPDATE
+				cluster_yarn
+			SET
+				application_id = $1
+			WHERE
+				id = (SELECT detail_id FROM cluster WHERE id = $2)
+			`, applicationId, clusterId); err != nil {
+			return err
+		}
+		return ds.audit(pz, tx, UpdateOp, ds.EntityTypes.Cluster, clusterId, metadata{
+			"applicationId": applicationId,
+		})
+	})
 }
 
-func (ds *Datastore) DeleteProject(pz az.Principal, projectId int64) error {
-	if err := pz.CheckOwns(ds.EntityTypes.Project, projectId); err != nil {
+// UpdateYarnClusterLaunch records the outcome of a successful YARN launch.
+func (ds *Datastore) UpdateYarnClusterLaunch(pz az.Principal, clusterId int64, address, state, applicationId, outputDir string) error {
+	if err := pz.CheckEdit(ds.EntityTypes.Cluster, clusterId); err != nil {
 		return err
 	}
 
 	return ds.exec(func(tx *sql.Tx) error {
 		if _, err := tx.Exec(`
-			DELETE FROM
-				project
+			UPDATE
+				cluster_yarn
+			SET
+				application_id = $1,
+				output_dir = $2,
+				last_activity = datetime('now')
 			WHERE
-				id = $1
-			`, projectId); err != nil {
+				id = (SELECT detail_id FROM cluster WHERE id = $3)
+			`, applicationId, outputDir, clusterId); err != nil {
 			return err
 		}
-		if err := deletePrivilegesOn(tx, ds.EntityTypes.Project, projectId); err != nil {
+		if _, err := tx.Exec(`
+			UPDATE
+				cluster
+			SET
+				address = $1,
+				state = $2
+			WHERE
+				id = $3
+			`, address, state, clusterId); err != nil {
 			return err
 		}
-		return ds.audit(pz, tx, DeleteOp, ds.EntityTypes.Project, projectId, metadata{})
+		return ds.audit(pz, tx, UpdateOp, ds.EntityTypes.Cluster, clusterId, metadata{
+			"address":         address,
+			"state":           state,
+			"applicationId":   applicationId,
+			"outputDirectory": outputDir,
+		})
 	})
 }
 
-// --- Datasource ---
-func (ds *Datastore) CreateDatasource(pz az.Principal, datasource Datasource) (int64, error) {
-	var id int64
-	err := ds.exec(func(tx *sql.Tx) error {
-		res, err := tx.Exec(`
-			INSERT INTO
-				datasource
-				(project_id, name, description, kind, configuration, created)
-			VALUES
-				($1,         $2,   $3,          $4,   $5,            datetime('now'))
+func (ds *Datastore) UpdateYarnClusterIdleTimeout(pz az.Principal, clusterId, idleTimeout int64) error {
+	if err := pz.CheckEdit(ds.EntityTypes.Cluster, clusterId); err != nil {
+		return err
+	}
+
+	return ds.exec(func(tx *sql.Tx) error {
+		if _, err := tx.Exec(`
+			UPDATE
+				cluster_yarn
+			SET
+				idle_timeout = $1
+			WHERE
+				id = (SELECT detail_id FROM cluster WHERE id = $2)
+			`, idleTimeout, clusterId); err != nil {
+			return err
+		}
+		return ds.audit(pz, tx, UpdateOp, ds.EntityTypes.Cluster, clusterId, metadata{
+			"idleTimeout": strconv.FormatInt(idleTimeout, 10),
+		})
+	})
+}
+
+// UpdateYarnClusterActivity records the last time a cluster was seen in use.
+// Activity is sampled frequently, so it is not audited.
+func (ds *Datastore) UpdateYarnClusterActivity(pz az.Principal, clusterId int64, lastActivity time.Time) error {
+	if err := pz.CheckEdit(ds.EntityTypes.Cluster, clusterId); err != nil {
+		return err
+	}
+
+	return ds.exec(func(tx *sql.Tx) error {
+		_, err := tx.Exec(`
+			UPDATE
+				cluster_yarn
+			SET
+				last_activity = $1
+			WHERE
+				id = (SELECT detail_id FROM cluster WHERE id = $2)
+			`, lastActivity.UTC(), clusterId)
+		return err
+	})
+}
+
+// KeepAliveYarnCluster marks a cluster as in use now, postponing its idle
+// shutdown.
+func (ds *Datastore) KeepAliveYarnCluster(pz az.Principal, clusterId int64) error {
+	if err := pz.CheckEdit(ds.EntityTypes.Cluster, clusterId); err != nil {
+		return err
+	}
+
+	return ds.exec(func(tx *sql.Tx) error {
+		if _, err := tx.Exec(`
+			UPDATE
+				cluster_yarn
+			SET
+				last_activity = datetime('now')
+			WHERE
+				id = (SELECT detail_id FROM cluster WHERE id = $1)
+			`, clusterId); err != nil {
+			return err
+		}
+		return ds.audit(pz, tx, UpdateOp, ds.EntityTypes.Cluster, clusterId, metadata{
+			"keepAlive": "true",
+		})
+	})
+}
+
+// WarnCluster records a warning about a cluster in its audit trail.
+func (ds *Datastore) WarnCluster(pz az.Principal, clusterId int64, message string) error {
+	return ds.exec(func(tx *sql.Tx) error {
+		return ds.audit(pz, tx, WarnOp, ds.EntityTypes.Cluster, clusterId, metadata{
+			"message": message,
+		})
+	})
+}
+
+// CreateClusterMetrics records resource usage samples of a cluster's nodes.
+// Samples are system records, and are not audited.
+func (ds *Datastore) CreateClusterMetrics(pz az.Principal, clusterId int64, sampled time.Time, metrics []ClusterMetric) error {
+	if err := pz.CheckEdit(ds.EntityTypes.Cluster, clusterId); err != nil {
+		return err
+	}
+
+	return ds.exec(func(tx *sql.Tx) error {
+		for _, m := range metrics {
+			if _, err := tx.Exec(`
+				INSERT INTO
+					cluster_metric
+					(cluster_id, node, healthy, sys_load, my_cpu_pct, sys_cpu_pct, free_mem, max_mem, pojo_mem, mem_value_size, sampled)
+				VALUES
+					($1,         $2,   $3,      $4,       $5,         $6,          $7,       $8,      $9,       $10,            $11)
+				`, clusterId, m.Node, m.Healthy, m.SysLoad, m.MyCpuPct, m.SysCpuPct, m.FreeMem, m.MaxMem, m.PojoMem, m.MemValueSize, sampled.UTC()); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// ReadClusterMetrics returns the samples of a cluster taken in [from, to),
+// ordered by time.
+func (ds *Datastore) ReadClusterMetrics(pz az.Principal, clusterId int64, from, to time.Time) ([]ClusterMetric, error) {
+	if err := pz.CheckView(ds.EntityTypes.Cluster, clusterId); err != nil {
+		return nil, err
+	}
+
+	rows, err := ds.db.Query(`
+		SELECT
+			id, cluster_id, node, healthy, sys_load, my_cpu_pct, sys_cpu_pct, free_mem, max_mem, pojo_mem, mem_value_size, sampled
+		FROM
+			cluster_metric
+		WHERE
+			cluster_id = $1 AND
+			sampled >= $2 AND
+			sampled < $3
+		ORDER BY
+			sampled, node
+		`, clusterId, from.UTC(), to.UTC())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	return ScanClusterMetrics(rows)
+}
+
+// DeleteClusterMetricsBefore drops samples older than t.
+func (ds *Datastore) DeleteClusterMetricsBefore(t time.Time) error {
+	return ds.exec(func(tx *sql.Tx) error {
+		_, err := tx.Exec(`
+			DELETE FROM
+				cluster_metric
+			WHERE
+				sampled < $1
+			`, t.UTC())
+		return err
+	})
+}
+
+func (ds *Datastore) DeleteCluster(pz az.Principal, clusterId int64) error {
+	if err := pz.CheckOwns(ds.EntityTypes.Cluster, clusterId); err != nil {
+		return err
+	}
+
+	cluster, err := ds.ReadCluster(pz, clusterId)
+	if err != nil {
+		return err
+	}
+
+	return ds.exec(func(tx *sql.Tx) error {
+		if cluster.TypeId == ds.ClusterTypes.Yarn || cluster.TypeId == ds.ClusterTypes.Local {
+			if _, err := tx.Exec(`
+				DELETE FROM
+					cluster_yarn
+				WHERE
+					id = $1
+				`, cluster.DetailId); err != nil {
+				return err
+			}
+		}
+
+		if _, err := tx.Exec(`
+			DELETE FROM
+				cluster_metric
+			WHERE
+				cluster_id = $1
+			`, clusterId); err != nil {
+			return err
+		}
+
+		if _, err := tx.Exec(`
+			DELETE FROM
+				cluster
+			WHERE
+				id = $1
+			`, clusterId); err != nil {
+			return err
+		}
+
+		if err := deletePrivilegesOn(tx, ds.EntityTypes.Cluster, clusterId); err != nil {
+			return err
+		}
+		return ds.audit(pz, tx, DeleteOp, ds.EntityTypes.Cluster, clusterId, metadata{})
+	})
+}
+
+// --- Cluster Template ---
+
+func (ds *Datastore) CreateClusterTemplate(pz az.Principal, t ClusterTemplate) (int64, error) {
+	var id int64
+	err := ds.exec(func(tx *sql.Tx) error {
+		res, err := tx.Exec(`
+			INSERT INTO
+				cluster_template
+				(name, engine_id, size, memory, driver_args, queue, idle_timeout, tags, max_clusters, created)
+			VALUES
+				($1,   $2,        $3,   $4,     $5,          $6,    $7,           $8,   $9,           datetime('now'))
+			`, t.Name, t.EngineId, t.Size, t.Memory, t.DriverArgs, t.Queue, t.IdleTimeout, t.Tags, t.MaxClusters)
+		if err != nil {
+			return err
+		}
+
+		id, err = res.LastInsertId()
+		if err != nil {
+			return err
+		}
+
+		if err := createPrivilege(tx, Privilege{
+			Owns,
+			pz.WorkgroupId(),
+			ds.EntityTypes.Template,
+			id,
+		}); err != nil {
+			return err
+		}
+
+		return ds.audit(pz, tx, CreateOp, ds.EntityTypes.Template, id, t.metadata())
+	})
+	return id, err
+}
+
+func (t ClusterTemplate) metadata() metadata {
+	return metadata{
+		"name":        t.Name,
+		"engineId":    strconv.FormatInt(t.EngineId, 10),
+		"size":        strconv.FormatInt(t.Size, 10),
+		"memory":      t.Memory,
+		"driverArgs":  t.DriverArgs,
+		"queue":       t.Queue,
+		"idleTimeout": strconv.FormatInt(t.IdleTimeout, 10),
+		"tags":        t.Tags,
+		"maxClusters": strconv.FormatInt(t.MaxClusters, 10),
+	}
+}
+
+func (ds *Datastore) ReadClusterTemplates(pz az.Principal, offset, limit int64) ([]ClusterTemplate, error) {
+	rows, err := ds.db.Query(`
+		SELECT
+			id, name, engine_id, size, memory, driver_args, queue, idle_timeout, tags, max_clusters, created
+		FROM
+			cluster_template
+		WHERE
+			id IN
+			(
+				SELECT DISTINCT
+					entity_id
+				FROM
+					privilege
+				WHERE
+					$1 OR
+					(
+						workgroup_id IN
+						(
+							SELECT workgroup_id FROM identity_workgroup WHERE identity_id = $2
+						) AND
+						entity_type_id = $3
+					)
+			)
+		ORDER BY
+			name
+		LIMIT $4
+		OFFSET $5
+		`, pz.IsSuperuser(), pz.Id(), ds.EntityTypes.Template, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return ScanClusterTemplates(rows)
+}
+
+func (ds *Datastore) ReadClusterTemplate(pz az.Principal, templateId int64) (ClusterTemplate, error) {
+	if err := pz.CheckView(ds.EntityTypes.Template, templateId); err != nil {
+		return ClusterTemplate{}, err
+	}
+
+	row := ds.db.QueryRow(`
+		SELECT
+			id, name, engine_id, size, memory, driver_args, queue, idle_timeout, tags, max_clusters, created
+		FROM
+			cluster_template
+		WHERE
+			id = $1
+		`, templateId)
+	return ScanClusterTemplate(row)
+}
+
+func (ds *Datastore) UpdateClusterTemplate(pz az.Principal, t ClusterTemplate) error {
+	if err := pz.CheckEdit(ds.EntityTypes.Template, t.Id); err != nil {
+		return err
+	}
+
+	return ds.exec(func(tx *sql.Tx) error {
+		if _, err := tx.Exec(`
+			UPDATE
+				cluster_template
+			SET
+				name = $1,
+				engine_id = $2,
+				size = $3,
+				memory = $4,
+				driver_args = $5,
+				queue = $6,
+				idle_timeout = $7,
+				tags = $8,
+				max_clusters = $9
+			WHERE
+				id = $10
+			`, t.Name, t.EngineId, t.Size, t.Memory, t.DriverArgs, t.Queue, t.IdleTimeout, t.Tags, t.MaxClusters, t.Id); err != nil {
+			return err
+		}
+		return ds.audit(pz, tx, UpdateOp, ds.EntityTypes.Template, t.Id, t.metadata())
+	})
+}
+
+func (ds *Datastore) DeleteClusterTemplate(pz az.Principal, templateId int64) error {
+	if err := pz.CheckOwns(ds.EntityTypes.Template, templateId); err != nil {
+		return err
+	}
+
+	return ds.exec(func(tx *sql.Tx) error {
+		if _, err := tx.Exec(`
+			DELETE FROM
+				cluster_template
+			WHERE
+				id = $1
+			`, templateId); err != nil {
+			return err
+		}
+		if err := deletePrivilegesOn(tx, ds.EntityTypes.Template, templateId); err != nil {
+			return err
+		}
+		return ds.audit(pz, tx, DeleteOp, ds.EntityTypes.Template, templateId, metadata{})
+	})
+}
+
+// CountActiveTemplateClusters returns the number of clusters started from a
+// template that are starting or running.
+func (ds *Datastore) CountActiveTemplateClusters(pz az.Principal, templateId int64) (int64, error) {
+	if err := pz.CheckView(ds.EntityTypes.Template, templateId); err != nil {
+		return 0, err
+	}
+
+	row := ds.db.QueryRow(`
+		SELECT
+			count(1)
+		FROM
+			cluster c,
+			cluster_yarn y
+		WHERE
+			y.template_id = $1 AND
+			c.detail_id = y.id AND
+			c.type_id IN ($2, $3) AND
+			c.state IN ($4, $5)
+		`, templateId, ds.ClusterTypes.Yarn, ds.ClusterTypes.Local, StartingState, StartedState)
+	return scanInt(row)
+}
+
+// --- Keytab ---
+
+func (ds *Datastore) CreateKeytab(pz az.Principal, name, principal, location string) (int64, error) {
+	var id int64
+	err := ds.exec(func(tx *sql.Tx) error {
+		res, err := tx.Exec(`
+			INSERT INTO
+				keytab
+				(identity_id, name, principal, location, created)
+			VALUES
+				($1,          $2,   $3,        $4,       datetime('now'))
+			`, pz.Id(), name, principal, location)
+		if err != nil {
+			return err
+		}
+
+		id, err = res.LastInsertId()
+		if err != nil {
+			return err
+		}
+
+		if err := createPrivilege(tx, Privilege{
+			Owns,
+			pz.WorkgroupId(),
+			ds.EntityTypes.Keytab,
+			id,
+		}); err != nil {
+			return err
+		}
+
+		return ds.audit(pz, tx, CreateOp, ds.EntityTypes.Keytab, id, metadata{
+			"name":      name,
+			"principal": principal,
+		})
+	})
+	return id, err
+}
+
+// ReadKeytabs returns the keytabs uploaded by the calling identity.
+func (ds *Datastore) ReadKeytabs(pz az.Principal) ([]Keytab, error) {
+	rows, err := ds.db.Query(`
+		SELECT
+			id, identity_id, name, principal, location, created
+		FROM
+			keytab
+		WHERE
+			identity_id = $1
+		ORDER BY
+			name
+		`, pz.Id())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return ScanKeytabs(rows)
+}
+
+// ReadKeytab returns a keytab owned by the calling identity.
+func (ds *Datastore) ReadKeytab(pz az.Principal, keytabId int64) (Keytab, error) {
+	if err := pz.CheckOwns(ds.EntityTypes.Keytab, keytabId); err != nil {
+		return Keytab{}, err
+	}
+
+	row := ds.db.QueryRow(`
+		SELECT
+			id, identity_id, name, principal, location, created
+		FROM
+			keytab
+		WHERE
+			id = $1
+		`, keytabId)
+	return ScanKeytab(row)
+}
+
+func (ds *Datastore) DeleteKeytab(pz az.Principal, keytabId int64) error {
+	if err := pz.CheckOwns(ds.EntityTypes.Keytab, keytabId); err != nil {
+		return err
+	}
+
+	return ds.exec(func(tx *sql.Tx) error {
+		if _, err := tx.Exec(`
+			DELETE FROM
+				keytab
+			WHERE
+				id = $1
+			`, keytabId); err != nil {
+			return err
+		}
+		if err := deletePrivilegesOn(tx, ds.EntityTypes.Keytab, keytabId); err != nil {
+			return err
+		}
+		return ds.audit(pz, tx, DeleteOp, ds.EntityTypes.Keytab, keytabId, metadata{})
+	})
+}
+
+// CountActiveKeytabClusters returns the number of clusters launched with a
+// keytab that are starting or running.
+func (ds *Datastore) CountActiveKeytabClusters(pz az.Principal, keytabId int64) (int64, error) {
+	if err := pz.CheckOwns(ds.EntityTypes.Keytab, keytabId); err != nil {
+		return 0, err
+	}
+
+	row := ds.db.QueryRow(`
+		SELECT
+			count(1)
+		FROM
+			cluster c,
+			cluster_yarn y,
+			keytab k
+		WHERE
+			k.id = $1 AND
+			y.keytab = k.location AND
+			c.detail_id = y.id AND
+			c.type_id IN ($2, $3) AND
+			c.state IN ($4, $5)
+		`, keytabId, ds.ClusterTypes.Yarn, ds.ClusterTypes.Local, StartingState, StartedState)
+	return scanInt(row)
+}
+
+// --- Project ---
+
+func (ds *Datastore) CreateProject(pz az.Principal, name, description, modelCategory string) (int64, error) {
+	var id int64
+	err := ds.exec(func(tx *sql.Tx) error {
+		res, err := tx.Exec(`
+			INSERT INTO
+				project
+				(name, description, model_category, created)
+			VALUES
+				($1,   $2,          $3,             datetime('now'))
+			`, name, description, modelCategory)
+		if err != nil {
+			return err
+		}
+
+		id, err = res.LastInsertId()
+		if err != nil {
+			return err
+		}
+
+		if err := createPrivilege(tx, Privilege{
+			Owns,
+			pz.WorkgroupId(),
+			ds.EntityTypes.Project,
+			id,
+		}); err != nil {
+			return err
+		}
+
+		return ds.audit(pz, tx, CreateOp, ds.EntityTypes.Project, id, metadata{
+			"name":           name,
+			"description":    description,
+			"model_category": modelCategory,
+		})
+	})
+	return id, err
+}
+
+func (ds *Datastore) ReadProjects(pz az.Principal, offset, limit int64) ([]Project, error) {
+	rows, err := ds.db.Query(`
+		SELECT
+			id, name, description, model_category, created
+		FROM
+			project
+		WHERE
+			id IN
+			(
+				SELECT DISTINCT
+					entity_id
+				FROM 
+					privilege
+				WHERE
+				  	$1 OR
+					(
+						workgroup_id IN 
+						(
+							SELECT workgroup_id FROM identity_workgroup WHERE identity_id = $2
+						) AND 
+						entity_type_id = $3
+					)
+			)
+		ORDER BY
+			name
+		LIMIT $4
+		OFFSET $5
+		`, pz.IsSuperuser(), pz.Id(), ds.EntityTypes.Project, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	return ScanProjects(rows)
+}
+
+func (ds *Datastore) ReadProject(pz az.Principal, projectId int64) (Project, error) {
+	if err := pz.CheckView(ds.EntityTypes.Project, projectId); err != nil {
+		return Project{}, err
+	}
+
+	row := ds.db.QueryRow(`
+		SELECT
+			id, name, description, model_category, created
+		FROM
+			project
+		WHERE
+			id = $1
+		`, projectId)
+	return ScanProject(row)
+}
+
+func (ds *Datastore) DeleteProject(pz az.Principal, projectId int64) error {
+	if err := pz.CheckOwns(ds.EntityTypes.Project, projectId); err != nil {
+		return err
+	}
+
+	return ds.exec(func(tx *sql.Tx) error {
+		if _, err := tx.Exec(`
+			DELETE FROM
+				project
+			WHERE
+				id = $1
+			`, projectId); err != nil {
+			return err
+		}
+		if err := deletePrivilegesOn(tx, ds.EntityTypes.Project, projectId); err != nil {
+			return err
+		}
+		return ds.audit(pz, tx, DeleteOp, ds.EntityTypes.Project, projectId, metadata{})
+	})
+}
+
+// --- Datasource ---
+func (ds *Datastore) CreateDatasource(pz az.Principal, datasource Datasource) (int64, error) {
+	var id int64
+	err := ds.exec(func(tx *sql.Tx) error {
+		res, err := tx.Exec(`
+			INSERT INTO
+				datasource
+				(project_id, name, description, kind, configuration, created)
+			VALUES
+				($1,         $2,   $3,          $4,   $5,            datetime('now'))
 			`,
 			datasource.ProjectId,
 			datasource.Name,
@@ -2978,70 +3827,448 @@ func (ds *Datastore) DeleteDatasource(pz az.Principal, datasourceId int64) error
 	return ds.exec(func(tx *sql.Tx) error {
 		if _, err := tx.Exec(`
 			DELETE FROM
-				datasource
+				datasource
+			WHERE
+				id = $1
+			`, datasourceId); err != nil {
+			return err
+		}
+		if err := deletePrivilegesOn(tx, ds.EntityTypes.Datasource, datasourceId); err != nil {
+			return err
+		}
+		return ds.audit(pz, tx, DeleteOp, ds.EntityTypes.Datasource, datasourceId, metadata{})
+	})
+}
+
+// --- Dataset ---
+
+// CreateDataset records a dataset. Imports become the next version of their
+// datasource's data; splits share the version of the dataset they split.
+func (ds *Datastore) CreateDataset(pz az.Principal, dataset Dataset) (int64, error) {
+	var id int64
+	err := ds.exec(func(tx *sql.Tx) error {
+		var (
+			version    int64
+			previousId sql.NullInt64
+		)
+		if dataset.ParentId.Valid {
+			if err := tx.QueryRow(`
+				SELECT
+					version
+				FROM
+					dataset
+				WHERE
+					id = $1
+				`, dataset.ParentId).Scan(&version); err != nil {
+				return errors.Wrap(err, "reading split dataset")
+			}
+		} else {
+			err := tx.QueryRow(`
+				SELECT
+					id, version
+				FROM
+					dataset
+				WHERE
+					datasource_id = $1 AND
+					parent_id IS NULL
+				ORDER BY
+					version DESC
+				LIMIT 1
+				`, dataset.DatasourceId).Scan(&previousId, &version)
+			if err != nil && err != sql.ErrNoRows {
+				return errors.Wrap(err, "reading previous dataset version")
+			}
+			version++
+		}
+
+		res, err := tx.Exec(`
+			INSERT INTO
+				dataset
+				(datasource_id, cluster_id, parent_id, version, previous_id, row_count, column_hash, checksum, name, description, frame_name, response_column_name, properties, properties_version, created)
+			VALUES
+				($1,            $2,         $3,        $4,      $5,          $6,        $7,          $8,       $9,   $10,         $11,        $12,                  $13,        $14,                datetime('now'))
+			`,
+			dataset.DatasourceId,
+			dataset.ClusterId,
+			dataset.ParentId,
+			version,
+			previousId,
+			dataset.RowCount,
+			dataset.ColumnHash,
+			dataset.Checksum,
+			dataset.Name,
+			dataset.Description,
+			dataset.FrameName,
+			dataset.ResponseColumnName,
+			dataset.Properties,
+			dataset.PropertiesVersion,
+		)
+		if err != nil {
+			return err
+		}
+
+		id, err = res.LastInsertId()
+		if err != nil {
+			return err
+		}
+
+		if err := createPrivilege(tx, Privilege{
+			Owns,
+			pz.WorkgroupId(),
+			ds.EntityTypes.Dataset,
+			id,
+		}); err != nil {
+			return err
+		}
+
+		meta := metadata{
+			"name":               dataset.Name,
+			"description":        dataset.Description,
+			"responseColumnName": dataset.ResponseColumnName,
+			"version":            strconv.FormatInt(version, 10),
+		}
+		if dataset.ParentId.Valid {
+			meta["parentId"] = strconv.FormatInt(dataset.ParentId.Int64, 10)
+		}
+		return ds.audit(pz, tx, CreateOp, ds.EntityTypes.Dataset, id, meta)
+	})
+	return id, err
+}
+
+func (ds *Datastore) ReadDatasets(pz az.Principal, datasourceId, offset, limit int64) ([]Dataset, error) {
+	rows, err := ds.db.Query(`
+			SELECT
+				id, datasource_id, cluster_id, parent_id, version, previous_id, row_count, column_hash, checksum, name, description, frame_name, response_column_name, properties, properties_version, created, stale
+			FROM
+				dataset
+			WHERE
+				id IN
+				(
+					SELECT DISTINCT
+						entity_id
+					FROM
+						privilege
+					WHERE
+						$1
+						OR
+						(
+							workgroup_id IN
+							(
+								SELECT 
+									workgroup_id 
+								FROM
+									identity_workgroup
+								WHERE
+									identity_id = $2
+							)
+							AND
+							entity_type_id = $3
+						)
+				)
+				AND
+				datasource_id = $4
+			ORDER BY
+				name
+			LIMIT $5
+			OFFSET $6
+			`, pz.IsSuperuser(), pz.Id(), ds.EntityTypes.Dataset, datasourceId, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	return ScanDatasets(rows)
+}
+
+func (ds *Datastore) ReadDataset(pz az.Principal, datasetId int64) (Dataset, error) {
+	if err := pz.CheckView(ds.EntityTypes.Dataset, datasetId); err != nil {
+		return Dataset{}, err
+	}
+
+	row := ds.db.QueryRow(`
+		SELECT
+			id, datasource_id, cluster_id, parent_id, version, previous_id, row_count, column_hash, checksum, name, description, frame_name, response_column_name, properties, properties_version, created, stale
+		FROM
+			dataset
+		WHERE
+			id = $1
+		`, datasetId)
+	return ScanDataset(row)
+}
+
+func (ds *Datastore) ReadDatasetByDatasource(pz az.Principal, datasourceId int64) (Dataset, bool, error) {
+	var dataset Dataset
+	rows, err := ds.db.Query(`
+		SELECT
+			id, datasource_id, cluster_id, parent_id, version, previous_id, row_count, column_hash, checksum, name, description, frame_name, response_column_name, properties, properties_version, created, stale
+		FROM
+			dataset
+		WHERE
+			datasource_id = $1
+		`, datasourceId)
+	if err != nil {
+		return dataset, false, err
+	}
+	defer rows.Close()
+
+	return scanDatasets(rows)
+}
+
+// ReadDatasetsForCluster returns the datasets the principal can view whose
+// frames were loaded into a cluster.
+func (ds *Datastore) ReadDatasetsForCluster(pz az.Principal, clusterId int64) ([]Dataset, error) {
+	if err := pz.CheckView(ds.EntityTypes.Cluster, clusterId); err != nil {
+		return nil, err
+	}
+
+	rows, err := ds.db.Query(`
+		SELECT
+			id, datasource_id, cluster_id, parent_id, version, previous_id, row_count, column_hash, checksum, name, description, frame_name, response_column_name, properties, properties_version, created, stale
+		FROM
+			dataset
+		WHERE
+			cluster_id = $1
+		ORDER BY
+			id
+		`, clusterId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	datasets, err := ScanDatasets(rows)
+	if err != nil {
+		return nil, err
+	}
+	// Datasets on a cluster are not necessarily shared with it
+	visible := datasets[:0]
+	for _, d := range datasets {
+		if err := pz.CheckView(ds.EntityTypes.Dataset, d.Id); err == nil {
+			visible = append(visible, d)
+		}
+	}
+	return visible, nil
+}
+
+// ReadDatasetByFrame looks up the dataset recorded for a frame on a cluster.
+func (ds *Datastore) ReadDatasetByFrame(pz az.Principal, clusterId int64, frameName string) (Dataset, bool, error) {
+	var dataset Dataset
+	rows, err := ds.db.Query(`
+		SELECT
+			id, datasource_id, cluster_id, parent_id, version, previous_id, row_count, column_hash, checksum, name, description, frame_name, response_column_name, properties, properties_version, created, stale
+		FROM
+			dataset
+		WHERE
+			cluster_id = $1 AND
+			frame_name = $2
+		ORDER BY
+			id DESC
+		`, clusterId, frameName)
+	if err != nil {
+		return dataset, false, err
+	}
+	defer rows.Close()
+
+	dataset, ok, err := scanDatasets(rows)
+	if err != nil || !ok {
+		return dataset, ok, err
+	}
+	// Datasets the principal cannot see are treated as missing
+	if err := pz.CheckView(ds.EntityTypes.Dataset, dataset.Id); err != nil {
+		return Dataset{}, false, nil
+	}
+	return dataset, true, nil
+}
+
+// CountDerivedDatasets counts the datasets split from a dataset.
+func (ds *Datastore) CountDerivedDatasets(pz az.Principal, datasetId int64) (int64, error) {
+	if err := pz.CheckView(ds.EntityTypes.Dataset, datasetId); err != nil {
+		return 0, err
+	}
+
+	row := ds.db.QueryRow(`
+		SELECT
+			count(1)
+		FROM
+			dataset
+		WHERE
+			parent_id = $1
+		`, datasetId)
+	return scanInt(row)
+}
+
+// ReadDatasetColumns returns the column profile of a dataset in frame order.
+func (ds *Datastore) ReadDatasetColumns(pz az.Principal, datasetId int64) ([]DatasetColumn, error) {
+	if err := pz.CheckView(ds.EntityTypes.Dataset, datasetId); err != nil {
+		return nil, err
+	}
+
+	rows, err := ds.db.Query(`
+		SELECT
+			dataset_id, position, name, type, missing_count, zero_count, min, max, mean, sigma, domain, domain_cardinality, histogram_base, histogram_stride, histogram_bins
+		FROM
+			dataset_column
+		WHERE
+			dataset_id = $1
+		ORDER BY
+			position
+		`, datasetId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	return ScanDatasetColumns(rows)
+}
+
+// ReadDatasetColumnRoles returns the roles assigned to a dataset's columns,
+// other than its response column, by column name.
+func (ds *Datastore) ReadDatasetColumnRoles(pz az.Principal, datasetId int64) ([]DatasetColumnRole, error) {
+	if err := pz.CheckView(ds.EntityTypes.Dataset, datasetId); err != nil {
+		return nil, err
+	}
+
+	rows, err := ds.db.Query(`
+		SELECT
+			dataset_id, name, role
+		FROM
+			dataset_column_role
+		WHERE
+			dataset_id = $1
+		ORDER BY
+			name
+		`, datasetId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	return ScanDatasetColumnRoles(rows)
+}
+
+// UpdateDatasetColumnRole assigns a role to a dataset column, replacing any
+// role it had; an empty role clears it. Assigning the response, weight, fold
+// or offset role takes it from the column that held it.
+func (ds *Datastore) UpdateDatasetColumnRole(pz az.Principal, datasetId int64, name, role string) error {
+	if err := pz.CheckEdit(ds.EntityTypes.Dataset, datasetId); err != nil {
+		return err
+	}
+
+	return ds.exec(func(tx *sql.Tx) error {
+		if _, err := tx.Exec(`
+			DELETE FROM
+				dataset_column_role
 			WHERE
-				id = $1
-			`, datasourceId); err != nil {
+				dataset_id = $1 AND (name = $2 OR (role = $3 AND role != $4))
+			`, datasetId, name, role, IgnoredColumnRole); err != nil {
 			return err
 		}
-		if err := deletePrivilegesOn(tx, ds.EntityTypes.Datasource, datasourceId); err != nil {
-			return err
+
+		switch role {
+		case ResponseColumnRole:
+			if _, err := tx.Exec(`
+				UPDATE
+					dataset
+				SET
+					response_column_name = $1
+				WHERE
+					id = $2
+				`, name, datasetId); err != nil {
+				return err
+			}
+		default:
+			if _, err := tx.Exec(`
+				UPDATE
+					dataset
+				SET
+					response_column_name = ''
+				WHERE
+					id = $1 AND response_column_name = $2
+				`, datasetId, name); err != nil {
+				return err
+			}
+			if role != "" {
+				if _, err := tx.Exec(`
+					INSERT INTO
+						dataset_column_role
+						(dataset_id, name, role)
+					VALUES
+						($1, $2, $3)
+					`, datasetId, name, role); err != nil {
+					return err
+				}
+			}
 		}
-		return ds.audit(pz, tx, DeleteOp, ds.EntityTypes.Datasource, datasourceId, metadata{})
+
+		return ds.audit(pz, tx, UpdateOp, ds.EntityTypes.Dataset, datasetId, metadata{
+			"column": name,
+			"role":   role,
+		})
 	})
 }
 
-// --- Dataset ---
+// UpdateDatasetProfile replaces a dataset's frame properties and the column
+// profile decoded from them.
+func (ds *Datastore) UpdateDatasetProfile(pz az.Principal, datasetId int64, properties string, columns []DatasetColumn) error {
+	if err := pz.CheckEdit(ds.EntityTypes.Dataset, datasetId); err != nil {
+		return err
+	}
 
-func (ds *Datastore) CreateDataset(pz az.Principal, dataset Dataset) (int64, error) {
-	var id int64
-	err := ds.exec(func(tx *sql.Tx) error {
-		res, err := tx.Exec(`
-			INSERT INTO
+	return ds.exec(func(tx *sql.Tx) error {
+		if _, err := tx.Exec(`
+			UPDATE
 				dataset
-				(datasource_id, name, description, frame_name, response_column_name, properties, properties_version, created)
-			VALUES
-				($1,            $2,   $3,          $4,         $5,                   $6,         $7,                 datetime('now'))
-			`,
-			dataset.DatasourceId,
-			dataset.Name,
-			dataset.Description,
-			dataset.FrameName,
-			dataset.ResponseColumnName,
-			dataset.Properties,
-			dataset.PropertiesVersion,
-		)
-		if err != nil {
+			SET
+				properties = $1
+			WHERE
+				id = $2
+			`, properties, datasetId); err != nil {
 			return err
 		}
-
-		id, err = res.LastInsertId()
-		if err != nil {
+		if _, err := tx.Exec(`
+			DELETE FROM
+				dataset_column
+			WHERE
+				dataset_id = $1
+			`, datasetId); err != nil {
 			return err
 		}
-
-		if err := createPrivilege(tx, Privilege{
-			Owns,
-			pz.WorkgroupId(),
-			ds.EntityTypes.Dataset,
-			id,
-		}); err != nil {
-			return err
+		for _, c := range columns {
+			if _, err := tx.Exec(`
+				INSERT INTO
+					dataset_column
+					(dataset_id, position, name, type, missing_count, zero_count, min, max, mean, sigma, domain, domain_cardinality, histogram_base, histogram_stride, histogram_bins)
+				VALUES
+					($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15)
+				`,
+				datasetId,
+				c.Position,
+				c.Name,
+				c.Type,
+				c.MissingCount,
+				c.ZeroCount,
+				c.Min,
+				c.Max,
+				c.Mean,
+				c.Sigma,
+				c.Domain,
+				c.DomainCardinality,
+				c.HistogramBase,
+				c.HistogramStride,
+				c.HistogramBins,
+			); err != nil {
+				return err
+			}
 		}
-
-		return ds.audit(pz, tx, CreateOp, ds.EntityTypes.Dataset, id, metadata{
-			"name":               dataset.Name,
-			"description":        dataset.Description,
-			"responseColumnName": dataset.ResponseColumnName,
+		return ds.audit(pz, tx, UpdateOp, ds.EntityTypes.Dataset, datasetId, metadata{
+			"columns": strconv.Itoa(len(columns)),
 		})
 	})
-	return id, err
 }
 
-func (ds *Datastore) ReadDatasets(pz az.Principal, datasourceId, offset, limit int64) ([]Dataset, error) {
+// ReadDatasetVersions returns the datasets imported from a datasource,
+// newest first.
+func (ds *Datastore) ReadDatasetVersions(pz az.Principal, datasourceId int64) ([]Dataset, error) {
 	rows, err := ds.db.Query(`
 			SELECT
-				id, datasource_id, name, description, frame_name, response_column_name, properties, properties_version, created
+				id, datasource_id, cluster_id, parent_id, version, previous_id, row_count, column_hash, checksum, name, description, frame_name, response_column_name, properties, properties_version, created, stale
 			FROM
 				dataset
 			WHERE
@@ -3057,8 +4284,8 @@ func (ds *Datastore) ReadDatasets(pz az.Principal, datasourceId, offset, limit i
 						(
 							workgroup_id IN
 							(
-								SELECT 
-									workgroup_id 
+								SELECT
+									workgroup_id
 								FROM
 									identity_workgroup
 								WHERE
@@ -3070,11 +4297,11 @@ func (ds *Datastore) ReadDatasets(pz az.Principal, datasourceId, offset, limit i
 				)
 				AND
 				datasource_id = $4
+				AND
+				parent_id IS NULL
 			ORDER BY
-				name
-			LIMIT $5
-			OFFSET $6
-			`, pz.IsSuperuser(), pz.Id(), ds.EntityTypes.Dataset, datasourceId, limit, offset)
+				version DESC
+			`, pz.IsSuperuser(), pz.Id(), ds.EntityTypes.Dataset, datasourceId)
 	if err != nil {
 		return nil, err
 	}
@@ -3082,38 +4309,269 @@ func (ds *Datastore) ReadDatasets(pz az.Principal, datasourceId, offset, limit i
 	return ScanDatasets(rows)
 }
 
-func (ds *Datastore) ReadDataset(pz az.Principal, datasetId int64) (Dataset, error) {
+// ReadDatasetsByFingerprint lists the imported datasets in a project, latest
+// first, whose row count and columns match.
+func (ds *Datastore) ReadDatasetsByFingerprint(pz az.Principal, projectId, rowCount int64, columnHash string) ([]Dataset, error) {
+	rows, err := ds.db.Query(`
+		SELECT
+			d.id, d.datasource_id, d.cluster_id, d.parent_id, d.version, d.previous_id, d.row_count, d.column_hash, d.checksum, d.name, d.description, d.frame_name, d.response_column_name, d.properties, d.properties_version, d.created, d.stale
+		FROM
+			dataset d,
+			datasource s
+		WHERE
+			s.project_id = $1 AND
+			d.datasource_id = s.id AND
+			d.row_count = $2 AND
+			d.column_hash = $3 AND
+			d.parent_id IS NULL
+		ORDER BY
+			d.id DESC
+		`, projectId, rowCount, columnHash)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	datasets, err := ScanDatasets(rows)
+	if err != nil {
+		return nil, err
+	}
+	// Datasets the principal cannot see are treated as missing
+	visible := datasets[:0]
+	for _, d := range datasets {
+		if err := pz.CheckView(ds.EntityTypes.Dataset, d.Id); err == nil {
+			visible = append(visible, d)
+		}
+	}
+	return visible, nil
+}
+
+// CreateDatasetComparison records the comparison of a dataset with a base
+// dataset, replacing any earlier one.
+func (ds *Datastore) CreateDatasetComparison(pz az.Principal, datasetId, baseDatasetId int64, report string) error {
+	if err := pz.CheckEdit(ds.EntityTypes.Dataset, datasetId); err != nil {
+		return err
+	}
+
+	return ds.exec(func(tx *sql.Tx) error {
+		if _, err := tx.Exec(`
+			INSERT OR REPLACE INTO
+				dataset_comparison
+				(dataset_id, base_dataset_id, report, created)
+			VALUES
+				($1,         $2,              $3,     datetime('now'))
+			`, datasetId, baseDatasetId, report); err != nil {
+			return err
+		}
+		return ds.audit(pz, tx, UpdateOp, ds.EntityTypes.Dataset, datasetId, metadata{
+			"comparedWith": strconv.FormatInt(baseDatasetId, 10),
+		})
+	})
+}
+
+func (ds *Datastore) ReadDatasetComparison(pz az.Principal, datasetId int64) (DatasetComparison, bool, error) {
 	if err := pz.CheckView(ds.EntityTypes.Dataset, datasetId); err != nil {
-		return Dataset{}, err
+		return DatasetComparison{}, false, err
+	}
+
+	rows, err := ds.db.Query(`
+		SELECT
+			dataset_id, base_dataset_id, report, created
+		FROM
+			dataset_comparison
+		WHERE
+			dataset_id = $1
+		`, datasetId)
+	if err != nil {
+		return DatasetComparison{}, false, err
+	}
+	defer rows.Close()
+
+	comparisons, err := ScanDatasetComparisons(rows)
+	if err != nil || len(comparisons) == 0 {
+		return DatasetComparison{}, false, err
+	}
+	return comparisons[0], true, nil
+}
+
+// --- Dataset Import ---
+
+// CreateDatasetJob records a dataset import in ImportQueuedState.
+func (ds *Datastore) CreateDatasetJob(pz az.Principal, di DatasetJob) (int64, error) {
+	if err := pz.CheckView(ds.EntityTypes.Datasource, di.DatasourceId); err != nil {
+		return 0, err
 	}
 
+	var id int64
+	err := ds.exec(func(tx *sql.Tx) error {
+		res, err := tx.Exec(`
+			INSERT INTO
+				dataset_job
+				(datasource_id, cluster_id, name, description, response_column_name, compare_with_previous, state, job_name, progress, error, created)
+			VALUES
+				($1,            $2,         $3,   $4,          $5,                   $6,                    $7,    '',       0,        '',    datetime('now'))
+			`,
+			di.DatasourceId,
+			di.ClusterId,
+			di.Name,
+			di.Description,
+			di.ResponseColumnName,
+			di.CompareWithPrevious,
+			ImportQueuedState,
+		)
+		if err != nil {
+			return err
+		}
+
+		id, err = res.LastInsertId()
+		if err != nil {
+			return err
+		}
+
+		return ds.audit(pz, tx, CreateOp, ds.EntityTypes.Datasource, di.DatasourceId, metadata{
+			"job":     strconv.FormatInt(id, 10),
+			"name":    di.Name,
+			"cluster": strconv.FormatInt(di.ClusterId, 10),
+		})
+	})
+	return id, err
+}
+
+func (ds *Datastore) ReadDatasetJob(pz az.Principal, jobId int64) (DatasetJob, error) {
 	row := ds.db.QueryRow(`
 		SELECT
-			id, datasource_id, name, description, frame_name, response_column_name, properties, properties_version, created
+			id, datasource_id, cluster_id, dataset_id, name, description, response_column_name, compare_with_previous, state, job_name, progress, error, created, finished
 		FROM
-			dataset
+			dataset_job
 		WHERE
 			id = $1
-		`, datasetId)
-	return ScanDataset(row)
+		`, jobId)
+	di, err := ScanDatasetJob(row)
+	if err != nil {
+		return di, err
+	}
+
+	if err := pz.CheckView(ds.EntityTypes.Datasource, di.DatasourceId); err != nil {
+		return DatasetJob{}, err
+	}
+	return di, nil
 }
 
-func (ds *Datastore) ReadDatasetByDatasource(pz az.Principal, datasourceId int64) (Dataset, bool, error) {
-	var dataset Dataset
+// ReadDatasetJobs returns the imports of a datasource, newest first.
+func (ds *Datastore) ReadDatasetJobs(pz az.Principal, datasourceId, offset, limit int64) ([]DatasetJob, error) {
+	if err := pz.CheckView(ds.EntityTypes.Datasource, datasourceId); err != nil {
+		return nil, err
+	}
+
 	rows, err := ds.db.Query(`
 		SELECT
-			id, datasource_id, name, description, frame_name, response_column_name, properties, properties_version, created
+			id, datasource_id, cluster_id, dataset_id, name, description, response_column_name, compare_with_previous, state, job_name, progress, error, created, finished
 		FROM
-			dataset
+			dataset_job
 		WHERE
 			datasource_id = $1
-		`, datasourceId)
+		ORDER BY
+			id DESC
+		LIMIT $2
+		OFFSET $3
+		`, datasourceId, limit, offset)
 	if err != nil {
-		return dataset, false, err
+		return nil, err
 	}
 	defer rows.Close()
+	return ScanDatasetJobs(rows)
+}
 
-	return scanDatasets(rows)
+// ReadUnfinishedDatasetJobs returns the imports visible to the principal
+// that are queued or running.
+func (ds *Datastore) ReadUnfinishedDatasetJobs(pz az.Principal) ([]DatasetJob, error) {
+	rows, err := ds.db.Query(`
+		SELECT
+			id, datasource_id, cluster_id, dataset_id, name, description, response_column_name, compare_with_previous, state, job_name, progress, error, created, finished
+		FROM
+			dataset_job
+		WHERE
+			state IN ($1, $2, $3)
+		`, ImportQueuedState, ImportImportingState, ImportParsingState)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	imports, err := ScanDatasetJobs(rows)
+	if err != nil {
+		return nil, err
+	}
+	visible := imports[:0]
+	for _, di := range imports {
+		if err := pz.CheckView(ds.EntityTypes.Datasource, di.DatasourceId); err == nil {
+			visible = append(visible, di)
+		}
+	}
+	return visible, nil
+}
+
+// UpdateDatasetJob records the state, progress and outcome of an import.
+// Imports that have already finished are left as they are; the return value
+// reports whether the import was updated.
+func (ds *Datastore) UpdateDatasetJob(pz az.Principal, di DatasetJob) (bool, error) {
+	if err := pz.CheckView(ds.EntityTypes.Datasource, di.DatasourceId); err != nil {
+		return false, err
+	}
+
+	var updated bool
+	err := ds.exec(func(tx *sql.Tx) error {
+		finished := di.State == ImportDoneState || di.State == ImportFailedState || di.State == ImportCancelledState
+		res, err := tx.Exec(`
+			UPDATE
+				dataset_job
+			SET
+				dataset_id = $1, state = $2, job_name = $3, progress = $4, error = $5, finished = CASE WHEN $6 THEN datetime('now') END
+			WHERE
+				id = $7 AND
+				state IN ($8, $9, $10)
+			`,
+			di.DatasetId,
+			di.State,
+			di.JobName,
+			di.Progress,
+			di.Error,
+			finished,
+			di.Id,
+			ImportQueuedState, ImportImportingState, ImportParsingState,
+		)
+		if err != nil {
+			return err
+		}
+		n, err := res.RowsAffected()
+		if err != nil {
+			return err
+		}
+		updated = n > 0
+
+		// Progress is too chatty for the history
+		if !updated || !finished {
+			return nil
+		}
+		meta := metadata{
+			"job":   strconv.FormatInt(di.Id, 10),
+			"state": di.State,
+		}
+		if di.Error != "" {
+			meta["error"] = di.Error
+		}
+		return ds.audit(pz, tx, UpdateOp, ds.EntityTypes.Datasource, di.DatasourceId, meta)
+	})
+	return updated, err
+}
+
+// AuditDatasetJobCancel records a request to cancel a dataset import.
+func (ds *Datastore) AuditDatasetJobCancel(pz az.Principal, datasourceId, jobId int64) error {
+	return ds.exec(func(tx *sql.Tx) error {
+		return ds.audit(pz, tx, CancelOp, ds.EntityTypes.Datasource, datasourceId, metadata{
+			"job": strconv.FormatInt(jobId, 10),
+		})
+	})
 }
 
 func scanDatasets(rows *sql.Rows) (Dataset, bool, error) {
@@ -3143,7 +4601,7 @@ func (ds *Datastore) UpdateDataset(pz az.Principal, datasetId int64, dataset Dat
 			SET
 				name = $1,
 				description = $2,
-				responseColumnName = $3
+				response_column_name = $3
 			WHERE
 				id = $4
 			`,
@@ -3153,6 +4611,15 @@ func (ds *Datastore) UpdateDataset(pz az.Principal, datasetId int64, dataset Dat
 			datasetId); err != nil {
 			return err
 		}
+		// A column has one role, and the response takes precedence
+		if _, err := tx.Exec(`
+			DELETE FROM
+				dataset_column_role
+			WHERE
+				dataset_id = $1 AND name = $2
+			`, datasetId, dataset.ResponseColumnName); err != nil {
+			return err
+		}
 		return ds.audit(pz, tx, UpdateOp, ds.EntityTypes.Dataset, datasetId, metadata{
 			"name":               dataset.Name,
 			"description":        dataset.Description,
@@ -3167,6 +4634,17 @@ func (ds *Datastore) DeleteDataset(pz az.Principal, datasetId int64) error {
 	}
 
 	return ds.exec(func(tx *sql.Tx) error {
+		// Link the next version to the previous one
+		if _, err := tx.Exec(`
+			UPDATE
+				dataset
+			SET
+				previous_id = (SELECT previous_id FROM dataset WHERE id = $1)
+			WHERE
+				previous_id = $1
+			`, datasetId); err != nil {
+			return err
+		}
 		if _, err := tx.Exec(`
 			DELETE FROM
 				dataset
@@ -3207,10 +4685,11 @@ func (ds *Datastore) CreateModel(pz az.Principal, model Model) (int64, error) {
 					max_run_time,
 					metrics,
 					metrics_version,
-					created
+					created,
+					training_dataset_version
 				)
 			VALUES
-				($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, datetime('now'))
+				($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, datetime('now'), COALESCE((SELECT version FROM dataset WHERE id = $2), 0))
 			`,
 			model.ProjectId,           //$1
 			model.TrainingDatasetId,   //$2
@@ -3802,6 +5281,46 @@ func (ds *Datastore) ReadModel(pz az.Principal, modelId int64) (Model, error) {
 	return ScanModel(row)
 }
 
+// ReadModelsForCluster returns the models the principal can view imported
+// from a cluster.
+func (ds *Datastore) ReadModelsForCluster(pz az.Principal, clusterId int64) ([]Model, error) {
+	if err := pz.CheckView(ds.EntityTypes.Cluster, clusterId); err != nil {
+		return nil, err
+	}
+
+	rows, err := ds.db.Query(`
+		SELECT
+			model.*,
+			label.id,
+			label.name
+		FROM
+			model
+		LEFT OUTER JOIN
+			label ON label.model_id = model.id
+		WHERE
+			model.cluster_id = $1
+		ORDER BY
+			model.id
+		`, clusterId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	models, err := ScanModels(rows)
+	if err != nil {
+		return nil, err
+	}
+	// Models on a cluster are not necessarily shared with it
+	visible := models[:0]
+	for _, m := range models {
+		if err := pz.CheckView(ds.EntityTypes.Model, m.Id); err == nil {
+			visible = append(visible, m)
+		}
+	}
+	return visible, nil
+}
+
 func (ds *Datastore) UpdateModelLocation(pz az.Principal, modelId int64, location, logicalName string) error {
 	if err := pz.CheckEdit(ds.EntityTypes.Model, modelId); err != nil {
 		return err
@@ -59,3 +59,28 @@ func (ds *Datastore) Lookup(name string) (az.Principal, error) {
 
 	return &Principal{ds, identity, permissions, isSuperuser}, nil
 }
+
+// LookupSuperuser returns the principal of the oldest superuser identity. It
+// is used by background tasks that act on behalf of the system.
+func (ds *Datastore) LookupSuperuser() (az.Principal, error) {
+	row := ds.db.QueryRow(`
+		SELECT
+			i.name
+		FROM
+			identity i,
+			identity_role ir,
+			role r
+		WHERE
+			i.id = ir.identity_id AND
+			ir.role_id = r.id AND
+			r.name = $1
+		ORDER BY
+			i.id
+		LIMIT 1
+		`, SuperuserRoleName)
+	name, err := scanString(row)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed reading superuser name")
+	}
+	return ds.Lookup(name)
+}
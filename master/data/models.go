@@ -77,6 +77,7 @@ type Workgroup struct {
 	Name        string
 	Description string
 	Created     time.Time
+	IdleTimeout int64
 }
 
 type Identity struct {
@@ -106,10 +107,14 @@ type IdentityAndRole struct {
 }
 
 type Engine struct {
-	Id       int64
-	Name     string
-	Location string
-	Created  time.Time
+	Id           int64
+	Name         string
+	Location     string
+	Created      time.Time
+	Version      string
+	Distribution string
+	Checksum     string
+	DeepWater    bool
 }
 
 type ClusterType struct {
@@ -125,6 +130,25 @@ type Cluster struct {
 	Address  string
 	State    string
 	Created  time.Time
+	Scheme   string
+	CaCert   string
+	Username string
+	Password string // Encrypted; see Datastore.DecryptSecret
+}
+
+type ClusterMetric struct {
+	Id           int64
+	ClusterId    int64
+	Node         string
+	Healthy      bool
+	SysLoad      float64
+	MyCpuPct     int64
+	SysCpuPct    int64
+	FreeMem      int64
+	MaxMem       int64
+	PojoMem      int64
+	MemValueSize int64
+	Sampled      time.Time
 }
 
 type YarnCluster struct {
@@ -135,6 +159,36 @@ type YarnCluster struct {
 	Memory        string
 	Username      string
 	OutputDir     string
+	Keytab        string
+	IdleTimeout   int64
+	LastActivity  pq.NullTime
+	TemplateId    int64
+	Queue         string
+	NodeLabel     string
+	DriverArgs    string
+}
+
+type ClusterTemplate struct {
+	Id          int64
+	Name        string
+	EngineId    int64
+	Size        int64
+	Memory      string
+	DriverArgs  string
+	Queue       string
+	IdleTimeout int64
+	Tags        string
+	MaxClusters int64
+	Created     time.Time
+}
+
+type Keytab struct {
+	Id         int64
+	IdentityId int64
+	Name       string
+	Principal  string
+	Location   string
+	Created    time.Time
 }
 
 type Project struct {
@@ -158,6 +212,13 @@ type Datasource struct {
 type Dataset struct {
 	Id                 int64
 	DatasourceId       int64
+	ClusterId          int64
+	ParentId           sql.NullInt64
+	Version            int64
+	PreviousId         sql.NullInt64
+	RowCount           int64
+	ColumnHash         string
+	Checksum           string
 	Name               string
 	Description        string
 	FrameName          string
@@ -165,113 +226,170 @@ type Dataset struct {
 	Properties         string
 	PropertiesVersion  string
 	Created            time.Time
+	Stale              bool
 }
 
-type Model struct {
+type DatasetColumn struct {
+	DatasetId         int64
+	Position          int64
+	Name              string
+	Type              string
+	MissingCount      int64
+	ZeroCount         int64
+	Min               sql.NullFloat64
+	Max               sql.NullFloat64
+	Mean              sql.NullFloat64
+	Sigma             sql.NullFloat64
+	Domain            string
+	DomainCardinality int64
+	HistogramBase     sql.NullFloat64
+	HistogramStride   sql.NullFloat64
+	HistogramBins     string
+}
+
+type DatasetColumnRole struct {
+	DatasetId int64
+	Name      string
+	Role      string
+}
+
+type DatasetComparison struct {
+	DatasetId     int64
+	BaseDatasetId int64
+	Report        string
+	Created       time.Time
+}
+
+type DatasetJob struct {
 	Id                  int64
-	ProjectId           int64
-	TrainingDatasetId   int64
-	ValidationDatasetId sql.NullInt64
-	Name                string
+	DatasourceId        int64
 	ClusterId           int64
-	ClusterName         string
-	ModelKey            string
-	Algorithm           string
-	ModelCategory       string
-	DatasetName         string
+	DatasetId           sql.NullInt64
+	Name                string
+	Description         string
 	ResponseColumnName  string
-	LogicalName         sql.NullString
-	Location            string
-	ModelObjectType     sql.NullString
-	MaxRunTime          int64
-	Metrics             string
-	MetricsVersion      string
+	CompareWithPrevious bool
+	State               string
+	JobName             string
+	Progress            float64
+	Error               string
 	Created             time.Time
-	LabelId             sql.NullInt64
-	LabelName           sql.NullString
+	Finished            pq.NullTime
+}
+
+type Model struct {
+	Id                     int64
+	ProjectId              int64
+	TrainingDatasetId      int64
+	ValidationDatasetId    sql.NullInt64
+	Name                   string
+	ClusterId              int64
+	ClusterName            string
+	ModelKey               string
+	Algorithm              string
+	ModelCategory          string
+	DatasetName            string
+	ResponseColumnName     string
+	LogicalName            sql.NullString
+	Location               string
+	ModelObjectType        sql.NullString
+	MaxRunTime             int64
+	Metrics                string
+	MetricsVersion         string
+	Created                time.Time
+	TrainingDatasetVersion int64
+	Stale                  bool
+	LabelId                sql.NullInt64
+	LabelName              sql.NullString
 }
 
 type BinomialModel struct {
-	Id                  int64
-	ProjectId           int64
-	TrainingDatasetId   int64
-	ValidationDatasetId sql.NullInt64
-	Name                string
-	ClusterId           int64
-	ClusterName         string
-	ModelKey            string
-	Algorithm           string
-	ModelCategory       string
-	DatasetName         string
-	ResponseColumnName  string
-	LogicalName         sql.NullString
-	Location            string
-	ModelObjectType     sql.NullString
-	MaxRunTime          int64
-	Metrics             string
-	MetricsVersion      string
-	Created             time.Time
-	LabelId             sql.NullInt64
-	LabelName           sql.NullString
-	Mse                 float64
-	RSquared            float64
-	Logloss             float64
-	Auc                 float64
-	Gini                float64
+	Id                     int64
+	ProjectId              int64
+	TrainingDatasetId      int64
+	ValidationDatasetId    sql.NullInt64
+	Name                   string
+	ClusterId              int64
+	ClusterName            string
+	ModelKey               string
+	Algorithm              string
+	ModelCategory          string
+	DatasetName            string
+	ResponseColumnName     string
+	LogicalName            sql.NullString
+	Location               string
+	ModelObjectType        sql.NullString
+	MaxRunTime             int64
+	Metrics                string
+	MetricsVersion         string
+	Created                time.Time
+	TrainingDatasetVersion int64
+	Stale                  bool
+	LabelId                sql.NullInt64
+	LabelName              sql.NullString
+	Mse                    float64
+	RSquared               float64
+	Logloss                float64
+	Auc                    float64
+	Gini                   float64
 }
 
 type MultinomialModel struct {
-	Id                  int64
-	ProjectId           int64
-	TrainingDatasetId   int64
-	ValidationDatasetId sql.NullInt64
-	Name                string
-	ClusterId           int64
-	ClusterName         string
-	ModelKey            string
-	Algorithm           string
-	ModelCategory       string
-	DatasetName         string
-	ResponseColumnName  string
-	LogicalName         sql.NullString
-	Location            string
-	ModelObjectType     sql.NullString
-	MaxRunTime          int64
-	Metrics             string
-	MetricsVersion      string
-	Created             time.Time
-	LabelId             sql.NullInt64
-	LabelName           sql.NullString
-	Mse                 float64
-	RSquared            float64
-	Logloss             float64
+	Id                     int64
+	ProjectId              int64
+	TrainingDatasetId      int64
+	ValidationDatasetId    sql.NullInt64
+	Name                   string
+	ClusterId              int64
+	ClusterName            string
+	ModelKey               string
+	Algorithm              string
+	ModelCategory          string
+	DatasetName            string
+	ResponseColumnName     string
+	LogicalName            sql.NullString
+	Location               string
+	ModelObjectType        sql.NullString
+	MaxRunTime             int64
+	Metrics                string
+	MetricsVersion         string
+	Created                time.Time
+	TrainingDatasetVersion int64
+	Stale                  bool
+	LabelId                sql.NullInt64
+	LabelName              sql.NullString
+	Mse                    float64
+	RSquared               float64
+	Logloss                float64
 }
 
 type RegressionModel struct {
-	Id                   int64
-	ProjectId            int64
-	TrainingDatasetId    int64
-	ValidationDatasetId  sql.NullInt64
-	Name                 string
-	ClusterId            int64
-	ClusterName          string
-	ModelKey             string
-	Algorithm            string
-	ModelCategory        string
-	DatasetName          string
-	ResponseColumnName   string
-	LogicalName          sql.NullString
-	Location             string
-	ModelObjectType      sql.NullString
-	MaxRunTime           int64
-	Metrics              string
-	MetricsVersion       string
-	Created              time.Time
-	LabelId              sql.NullInt64
-	LabelName            sql.NullString
-	Mse                  float64
-	RSquared             float64
-	MeanResidualDeviance float64
+	Id                     int64
+	ProjectId              int64
+	TrainingDatasetId      int64
+	ValidationDatasetId    sql.NullInt64
+	Name                   string
+	ClusterId              int64
+	ClusterName            string
+	ModelKey               string
+	Algorithm              string
+	ModelCategory          string
+	DatasetName            string
+	ResponseColumnName     string
+	LogicalName            sql.NullString
+	Location               string
+	ModelObjectType        sql.NullString
+	MaxRunTime             int64
+	Metrics                string
+	MetricsVersion         string
+	Created                time.Time
+	TrainingDatasetVersion int64
+	Stale                  bool
+	LabelId                sql.NullInt64
+	LabelName              sql.NullString
+	Mse                    float64
+	RSquared               float64
+	MeanResidualDeviance   float64
 }
 
 type Label struct {
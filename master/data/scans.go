@@ -242,6 +242,7 @@ func ScanWorkgroup(r *sql.Row) (Workgroup, error) {
 		&s.Name,
 		&s.Description,
 		&s.Created,
+		&s.IdleTimeout,
 	); err != nil {
 		return Workgroup{}, err
 	}
@@ -259,6 +260,7 @@ func ScanWorkgroups(rs *sql.Rows) ([]Workgroup, error) {
 			&s.Name,
 			&s.Description,
 			&s.Created,
+			&s.IdleTimeout,
 		); err != nil {
 			return nil, err
 		}
@@ -389,6 +391,10 @@ func ScanEngine(r *sql.Row) (Engine, error) {
 		&s.Name,
 		&s.Location,
 		&s.Created,
+		&s.Version,
+		&s.Distribution,
+		&s.Checksum,
+		&s.DeepWater,
 	); err != nil {
 		return Engine{}, err
 	}
@@ -405,6 +411,10 @@ func ScanEngines(rs *sql.Rows) ([]Engine, error) {
 			&s.Name,
 			&s.Location,
 			&s.Created,
+			&s.Version,
+			&s.Distribution,
+			&s.Checksum,
+			&s.DeepWater,
 		); err != nil {
 			return nil, err
 		}
@@ -456,6 +466,10 @@ func ScanCluster(r *sql.Row) (Cluster, error) {
 		&s.Address,
 		&s.State,
 		&s.Created,
+		&s.Scheme,
+		&s.CaCert,
+		&s.Username,
+		&s.Password,
 	); err != nil {
 		return Cluster{}, err
 	}
@@ -475,6 +489,60 @@ func ScanClusters(rs *sql.Rows) ([]Cluster, error) {
 			&s.Address,
 			&s.State,
 			&s.Created,
+			&s.Scheme,
+			&s.CaCert,
+			&s.Username,
+			&s.Password,
+		); err != nil {
+			return nil, err
+		}
+		structs = append(structs, s)
+	}
+	if err = rs.Err(); err != nil {
+		return nil, err
+	}
+	return structs, nil
+}
+
+func ScanClusterMetric(r *sql.Row) (ClusterMetric, error) {
+	var s ClusterMetric
+	if err := r.Scan(
+		&s.Id,
+		&s.ClusterId,
+		&s.Node,
+		&s.Healthy,
+		&s.SysLoad,
+		&s.MyCpuPct,
+		&s.SysCpuPct,
+		&s.FreeMem,
+		&s.MaxMem,
+		&s.PojoMem,
+		&s.MemValueSize,
+		&s.Sampled,
+	); err != nil {
+		return ClusterMetric{}, err
+	}
+	return s, nil
+}
+
+func ScanClusterMetrics(rs *sql.Rows) ([]ClusterMetric, error) {
+	structs := make([]ClusterMetric, 0, 16)
+	var err error
+	for rs.Next() {
+		var s ClusterMetric
+		if err = rs.Scan(
+			&s.Id,
+			&s.ClusterId,
+			&s.Node,
+			&s.Healthy,
+			&s.SysLoad,
+			&s.MyCpuPct,
+			&s.SysCpuPct,
+			&s.FreeMem,
+			&s.MaxMem,
+			&s.PojoMem,
+			&s.MemValueSize,
+			&s.Sampled,
 		); err != nil {
 			return nil, err
 		}
@@ -496,6 +564,13 @@ func ScanYarnCluster(r *sql.Row) (YarnCluster, error) {
 		&s.Memory,
 		&s.Username,
 		&s.OutputDir,
+		&s.Keytab,
+		&s.IdleTimeout,
+		&s.LastActivity,
+		&s.TemplateId,
+		&s.Queue,
+		&s.NodeLabel,
+		&s.DriverArgs,
 	); err != nil {
 		return YarnCluster{}, err
 	}
@@ -515,6 +590,99 @@ func ScanYarnClusters(rs *sql.Rows) ([]YarnCluster, error) {
 			&s.Memory,
 			&s.Username,
 			&s.OutputDir,
+			&s.Keytab,
+			&s.IdleTimeout,
+			&s.LastActivity,
+			&s.TemplateId,
+			&s.Queue,
+			&s.NodeLabel,
+			&s.DriverArgs,
+		); err != nil {
+			return nil, err
+		}
+		structs = append(structs, s)
+	}
+	if err = rs.Err(); err != nil {
+		return nil, err
+	}
+	return structs, nil
+}
+
+func ScanClusterTemplate(r *sql.Row) (ClusterTemplate, error) {
+	var s ClusterTemplate
+	if err := r.Scan(
+		&s.Id,
+		&s.Name,
+		&s.EngineId,
+		&s.Size,
+		&s.Memory,
+		&s.DriverArgs,
+		&s.Queue,
+		&s.IdleTimeout,
+		&s.Tags,
+		&s.MaxClusters,
+		&s.Created,
+	); err != nil {
+		return ClusterTemplate{}, err
+	}
+	return s, nil
+}
+
+func ScanClusterTemplates(rs *sql.Rows) ([]ClusterTemplate, error) {
+	structs := make([]ClusterTemplate, 0, 16)
+	var err error
+	for rs.Next() {
+		var s ClusterTemplate
+		if err = rs.Scan(
+			&s.Id,
+			&s.Name,
+			&s.EngineId,
+			&s.Size,
+			&s.Memory,
+			&s.DriverArgs,
+			&s.Queue,
+			&s.IdleTimeout,
+			&s.Tags,
+			&s.MaxClusters,
+			&s.Created,
+		); err != nil {
+			return nil, err
+		}
+		structs = append(structs, s)
+	}
+	if err = rs.Err(); err != nil {
+		return nil, err
+	}
+	return structs, nil
+}
+
+func ScanKeytab(r *sql.Row) (Keytab, error) {
+	var s Keytab
+	if err := r.Scan(
+		&s.Id,
+		&s.IdentityId,
+		&s.Name,
+		&s.Principal,
+		&s.Location,
+		&s.Created,
+	); err != nil {
+		return Keytab{}, err
+	}
+	return s, nil
+}
+
+func ScanKeytabs(rs *sql.Rows) ([]Keytab, error) {
+	structs := make([]Keytab, 0, 16)
+	var err error
+	for rs.Next() {
+		var s Keytab
+		if err = rs.Scan(
+			&s.Id,
+			&s.IdentityId,
+			&s.Name,
+			&s.Principal,
+			&s.Location,
+			&s.Created,
 		); err != nil {
 			return nil, err
 		}
@@ -607,6 +775,13 @@ func ScanDataset(r *sql.Row) (Dataset, error) {
 	if err := r.Scan(
 		&s.Id,
 		&s.DatasourceId,
+		&s.ClusterId,
+		&s.ParentId,
+		&s.Version,
+		&s.PreviousId,
+		&s.RowCount,
+		&s.ColumnHash,
+		&s.Checksum,
 		&s.Name,
 		&s.Description,
 		&s.FrameName,
@@ -614,6 +789,7 @@ func ScanDataset(r *sql.Row) (Dataset, error) {
 		&s.Properties,
 		&s.PropertiesVersion,
 		&s.Created,
+		&s.Stale,
 	); err != nil {
 		return Dataset{}, err
 	}
@@ -628,6 +804,13 @@ func ScanDatasets(rs *sql.Rows) ([]Dataset, error) {
 		if err = rs.Scan(
 			&s.Id,
 			&s.DatasourceId,
+			&s.ClusterId,
+			&s.ParentId,
+			&s.Version,
+			&s.PreviousId,
+			&s.RowCount,
+			&s.ColumnHash,
+			&s.Checksum,
 			&s.Name,
 			&s.Description,
 			&s.FrameName,
@@ -635,6 +818,183 @@ func ScanDatasets(rs *sql.Rows) ([]Dataset, error) {
 			&s.Properties,
 			&s.PropertiesVersion,
 			&s.Created,
+			&s.Stale,
+		); err != nil {
+			return nil, err
+		}
+		structs = append(structs, s)
+	}
+	if err = rs.Err(); err != nil {
+		return nil, err
+	}
+	return structs, nil
+}
+
+func ScanDatasetColumn(r *sql.Row) (DatasetColumn, error) {
+	var s DatasetColumn
+	if err := r.Scan(
+		&s.DatasetId,
+		&s.Position,
+		&s.Name,
+		&s.Type,
+		&s.MissingCount,
+		&s.ZeroCount,
+		&s.Min,
+		&s.Max,
+		&s.Mean,
+		&s.Sigma,
+		&s.Domain,
+		&s.DomainCardinality,
+		&s.HistogramBase,
+		&s.HistogramStride,
+		&s.HistogramBins,
+	); err != nil {
+		return DatasetColumn{}, err
+	}
+	return s, nil
+}
+
+func ScanDatasetColumns(rs *sql.Rows) ([]DatasetColumn, error) {
+	structs := make([]DatasetColumn, 0, 16)
+	var err error
+	for rs.Next() {
+		var s DatasetColumn
+		if err = rs.Scan(
+			&s.DatasetId,
+			&s.Position,
+			&s.Name,
+			&s.Type,
+			&s.MissingCount,
+			&s.ZeroCount,
+			&s.Min,
+			&s.Max,
+			&s.Mean,
+			&s.Sigma,
+			&s.Domain,
+			&s.DomainCardinality,
+			&s.HistogramBase,
+			&s.HistogramStride,
+			&s.HistogramBins,
+		); err != nil {
+			return nil, err
+		}
+		structs = append(structs, s)
+	}
+	if err = rs.Err(); err != nil {
+		return nil, err
+	}
+	return structs, nil
+}
+
+func ScanDatasetColumnRole(r *sql.Row) (DatasetColumnRole, error) {
+	var s DatasetColumnRole
+	if err := r.Scan(
+		&s.DatasetId,
+		&s.Name,
+		&s.Role,
+	); err != nil {
+		return DatasetColumnRole{}, err
+	}
+	return s, nil
+}
+
+func ScanDatasetColumnRoles(rs *sql.Rows) ([]DatasetColumnRole, error) {
+	structs := make([]DatasetColumnRole, 0, 16)
+	var err error
+	for rs.Next() {
+		var s DatasetColumnRole
+		if err = rs.Scan(
+			&s.DatasetId,
+			&s.Name,
+			&s.Role,
+		); err != nil {
+			return nil, err
+		}
+		structs = append(structs, s)
+	}
+	if err = rs.Err(); err != nil {
+		return nil, err
+	}
+	return structs, nil
+}
+
+func ScanDatasetComparison(r *sql.Row) (DatasetComparison, error) {
+	var s DatasetComparison
+	if err := r.Scan(
+		&s.DatasetId,
+		&s.BaseDatasetId,
+		&s.Report,
+		&s.Created,
+	); err != nil {
+		return DatasetComparison{}, err
+	}
+	return s, nil
+}
+
+func ScanDatasetComparisons(rs *sql.Rows) ([]DatasetComparison, error) {
+	structs := make([]DatasetComparison, 0, 16)
+	var err error
+	for rs.Next() {
+		var s DatasetComparison
+		if err = rs.Scan(
+			&s.DatasetId,
+			&s.BaseDatasetId,
+			&s.Report,
+			&s.Created,
+		); err != nil {
+			return nil, err
+		}
+		structs = append(structs, s)
+	}
+	if err = rs.Err(); err != nil {
+		return nil, err
+	}
+	return structs, nil
+}
+
+func ScanDatasetJob(r *sql.Row) (DatasetJob, error) {
+	var s DatasetJob
+	if err := r.Scan(
+		&s.Id,
+		&s.DatasourceId,
+		&s.ClusterId,
+		&s.DatasetId,
+		&s.Name,
+		&s.Description,
+		&s.ResponseColumnName,
+		&s.CompareWithPrevious,
+		&s.State,
+		&s.JobName,
+		&s.Progress,
+		&s.Error,
+		&s.Created,
+		&s.Finished,
+	); err != nil {
+		return DatasetJob{}, err
+	}
+	return s, nil
+}
+
+func ScanDatasetJobs(rs *sql.Rows) ([]DatasetJob, error) {
+	structs := make([]DatasetJob, 0, 16)
+	var err error
+	for rs.Next() {
+		var s DatasetJob
+		if err = rs.Scan(
+			&s.Id,
+			&s.DatasourceId,
+			&s.ClusterId,
+			&s.DatasetId,
+			&s.Name,
+			&s.Description,
+			&s.ResponseColumnName,
+			&s.CompareWithPrevious,
+			&s.State,
+			&s.JobName,
+			&s.Progress,
+			&s.Error,
+			&s.Created,
+			&s.Finished,
 		); err != nil {
 			return nil, err
 		}
@@ -668,6 +1028,8 @@ func ScanModel(r *sql.Row) (Model, error) {
 		&s.Metrics,
 		&s.MetricsVersion,
 		&s.Created,
+		&s.TrainingDatasetVersion,
+		&s.Stale,
 		&s.LabelId,
 		&s.LabelName,
 	); err != nil {
@@ -701,6 +1063,8 @@ func ScanModels(rs *sql.Rows) ([]Model, error) {
 			&s.Metrics,
 			&s.MetricsVersion,
 			&s.Created,
+			&s.TrainingDatasetVersion,
+			&s.Stale,
 			&s.LabelId,
 			&s.LabelName,
 		); err != nil {
@@ -736,6 +1100,8 @@ func ScanBinomialModel(r *sql.Row) (BinomialModel, error) {
 		&s.Metrics,
 		&s.MetricsVersion,
 		&s.Created,
+		&s.TrainingDatasetVersion,
+		&s.Stale,
 		&s.LabelId,
 		&s.LabelName,
 		&s.Mse,
@@ -774,6 +1140,8 @@ func ScanBinomialModels(rs *sql.Rows) ([]BinomialModel, error) {
 			&s.Metrics,
 			&s.MetricsVersion,
 			&s.Created,
+			&s.TrainingDatasetVersion,
+			&s.Stale,
 			&s.LabelId,
 			&s.LabelName,
 			&s.Mse,
@@ -814,6 +1182,8 @@ func ScanMultinomialModel(r *sql.Row) (MultinomialModel, error) {
 		&s.Metrics,
 		&s.MetricsVersion,
 		&s.Created,
+		&s.TrainingDatasetVersion,
+		&s.Stale,
 		&s.LabelId,
 		&s.LabelName,
 		&s.Mse,
@@ -850,6 +1220,8 @@ func ScanMultinomialModels(rs *sql.Rows) ([]MultinomialModel, error) {
 			&s.Metrics,
 			&s.MetricsVersion,
 			&s.Created,
+			&s.TrainingDatasetVersion,
+			&s.Stale,
 			&s.LabelId,
 			&s.LabelName,
 			&s.Mse,
@@ -888,6 +1260,8 @@ func ScanRegressionModel(r *sql.Row) (RegressionModel, error) {
 		&s.Metrics,
 		&s.MetricsVersion,
 		&s.Created,
+		&s.TrainingDatasetVersion,
+		&s.Stale,
 		&s.LabelId,
 		&s.LabelName,
 		&s.Mse,
@@ -924,6 +1298,8 @@ func ScanRegressionModels(rs *sql.Rows) ([]RegressionModel, error) {
 			&s.Metrics,
 			&s.MetricsVersion,
 			&s.Created,
+			&s.TrainingDatasetVersion,
+			&s.Stale,
 			&s.LabelId,
 			&s.LabelName,
 			&s.Mse,
@@ -0,0 +1,115 @@
+/*
+  Copyright (C) 2016 H2O.ai, Inc. <http://h2o.ai/>
+
+  This program is free software: you can redistribute it and/or modify
+  it under the terms of the GNU Affero General Public License as
+  published by the Free Software Foundation, either version 3 of the
+  License, or (at your option) any later version.
+
+  This program is distributed in the hope that it will be useful,
+  but WITHOUT ANY WARRANTY; without even the implied warranty of
+  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+  GNU Affero General Public License for more details.
+
+  You should have received a copy of the GNU Affero General Public License
+  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package data
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"io"
+	"io/ioutil"
+	"os"
+
+	"github.com/pkg/errors"
+)
+
+const secretKeySize = 32
+
+// LoadOrCreateSecretKey reads the key used to encrypt secrets at rest from
+// path, generating and saving a new random key if the file does not exist.
+func LoadOrCreateSecretKey(path string) ([]byte, error) {
+	key, err := ioutil.ReadFile(path)
+	if err == nil {
+		if len(key) != secretKeySize {
+			return nil, errors.Errorf("invalid secret key in %s: expected %d bytes, found %d", path, secretKeySize, len(key))
+		}
+		return key, nil
+	}
+	if !os.IsNotExist(err) {
+		return nil, errors.Wrap(err, "reading secret key")
+	}
+
+	key = make([]byte, secretKeySize)
+	if _, err := io.ReadFull(rand.Reader, key); err != nil {
+		return nil, errors.Wrap(err, "generating secret key")
+	}
+	if err := ioutil.WriteFile(path, key, 0600); err != nil {
+		return nil, errors.Wrap(err, "writing secret key")
+	}
+	return key, nil
+}
+
+// SetSecretKey sets the key used to encrypt secrets (e.g. cluster
+// credentials) before they are stored.
+func (ds *Datastore) SetSecretKey(key []byte) {
+	ds.secretKey = key
+}
+
+// EncryptSecret encrypts a secret with AES-GCM, returning it base64-encoded.
+// Empty secrets are stored as-is.
+func (ds *Datastore) EncryptSecret(plaintext string) (string, error) {
+	if plaintext == "" {
+		return "", nil
+	}
+	gcm, err := ds.secretCipher()
+	if err != nil {
+		return "", err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return "", errors.Wrap(err, "generating nonce")
+	}
+	sealed := gcm.Seal(nonce, nonce, []byte(plaintext), nil)
+	return base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+// DecryptSecret reverses EncryptSecret.
+func (ds *Datastore) DecryptSecret(ciphertext string) (string, error) {
+	if ciphertext == "" {
+		return "", nil
+	}
+	gcm, err := ds.secretCipher()
+	if err != nil {
+		return "", err
+	}
+	sealed, err := base64.StdEncoding.DecodeString(ciphertext)
+	if err != nil {
+		return "", errors.Wrap(err, "decoding secret")
+	}
+	if len(sealed) < gcm.NonceSize() {
+		return "", errors.New("secret is too short")
+	}
+	nonce, sealed := sealed[:gcm.NonceSize()], sealed[gcm.NonceSize():]
+	plaintext, err := gcm.Open(nil, nonce, sealed, nil)
+	if err != nil {
+		return "", errors.Wrap(err, "decrypting secret")
+	}
+	return string(plaintext), nil
+}
+
+func (ds *Datastore) secretCipher() (cipher.AEAD, error) {
+	if ds.secretKey == nil {
+		return nil, errors.New("no secret key configured")
+	}
+	block, err := aes.NewCipher(ds.secretKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "creating cipher")
+	}
+	return cipher.NewGCM(block)
+}
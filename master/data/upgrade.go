@@ -328,6 +328,536 @@ func upgradeTo_1_1_0(db *sql.DB) (string, error) {
 	return "1.1.0", errors.Wrap(tx.Commit(), "commiting changes")
 }
 
+func upgradeTo_1_2_0(db *sql.DB) (string, error) {
+	tx, err := db.Begin()
+	if err != nil {
+		return "", errors.Wrap(err, "starting transaction")
+	}
+	defer tx.Rollback()
+
+	stmts := []string{
+		`ALTER TABLE cluster_yarn ADD COLUMN keytab text NOT NULL DEFAULT ''`,
+		`ALTER TABLE cluster_yarn ADD COLUMN idle_timeout integer NOT NULL DEFAULT 0`,
+		`ALTER TABLE cluster_yarn ADD COLUMN last_activity datetime`,
+		`ALTER TABLE workgroup ADD COLUMN idle_timeout integer NOT NULL DEFAULT 0`,
+		// Idle time of existing clusters counts from the upgrade
+		`UPDATE cluster_yarn SET last_activity = datetime('now') WHERE last_activity IS NULL`,
+	}
+	for _, stmt := range stmts {
+		if _, err := tx.Exec(stmt); err != nil {
+			return "", errors.Wrapf(err, "executing %s", stmt)
+		}
+	}
+
+	if _, err := tx.Exec(`UPDATE meta SET value = $1 WHERE id = 1`, "1.2.0"); err != nil {
+		return "", errors.Wrap(err, "updating database version")
+	}
+
+	return "1.2.0", errors.Wrap(tx.Commit(), "commiting changes")
+}
+
+func upgradeTo_1_3_0(db *sql.DB) (string, error) {
+	tx, err := db.Begin()
+	if err != nil {
+		return "", errors.Wrap(err, "starting transaction")
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec(`INSERT INTO cluster_type (name) VALUES ($1)`, ClusterLocal); err != nil {
+		return "", errors.Wrap(err, "adding local cluster type")
+	}
+
+	if _, err := tx.Exec(`UPDATE meta SET value = $1 WHERE id = 1`, "1.3.0"); err != nil {
+		return "", errors.Wrap(err, "updating database version")
+	}
+
+	return "1.3.0", errors.Wrap(tx.Commit(), "commiting changes")
+}
+
+func upgradeTo_1_4_0(db *sql.DB) (string, error) {
+	tx, err := db.Begin()
+	if err != nil {
+		return "", errors.Wrap(err, "starting transaction")
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec(`ALTER TABLE cluster ADD COLUMN proxy_policy text NOT NULL DEFAULT ''`); err != nil {
+		return "", errors.Wrap(err, "adding cluster proxy policy")
+	}
+
+	if _, err := tx.Exec(`UPDATE meta SET value = $1 WHERE id = 1`, "1.4.0"); err != nil {
+		return "", errors.Wrap(err, "updating database version")
+	}
+
+	return "1.4.0", errors.Wrap(tx.Commit(), "commiting changes")
+}
+
+func upgradeTo_1_5_0(db *sql.DB) (string, error) {
+	tx, err := db.Begin()
+	if err != nil {
+		return "", errors.Wrap(err, "starting transaction")
+	}
+	defer tx.Rollback()
+
+	stmts := []string{
+		`CREATE TABLE cluster_metric (
+			id integer PRIMARY KEY AUTOINCREMENT,
+			cluster_id integer NOT NULL,
+			node text NOT NULL,
+			healthy boolean NOT NULL,
+			sys_load double precision NOT NULL,
+			my_cpu_pct integer NOT NULL,
+			sys_cpu_pct integer NOT NULL,
+			free_mem bigint NOT NULL,
+			max_mem bigint NOT NULL,
+			pojo_mem bigint NOT NULL,
+			mem_value_size bigint NOT NULL,
+			sampled datetime NOT NULL,
+
+			FOREIGN KEY (cluster_id) REFERENCES cluster(id) ON DELETE CASCADE
+		)`,
+		`CREATE INDEX cluster_metric_cluster_sampled ON cluster_metric (cluster_id, sampled)`,
+	}
+	for _, stmt := range stmts {
+		if _, err := tx.Exec(stmt); err != nil {
+			return "", errors.Wrapf(err, "executing %s", stmt)
+		}
+	}
+
+	if _, err := tx.Exec(`UPDATE meta SET value = $1 WHERE id = 1`, "1.5.0"); err != nil {
+		return "", errors.Wrap(err, "updating database version")
+	}
+
+	return "1.5.0", errors.Wrap(tx.Commit(), "commiting changes")
+}
+
+func upgradeTo_1_6_0(db *sql.DB) (string, error) {
+	tx, err := db.Begin()
+	if err != nil {
+		return "", errors.Wrap(err, "starting transaction")
+	}
+	defer tx.Rollback()
+
+	stmts := []string{
+		`ALTER TABLE cluster ADD COLUMN scheme text NOT NULL DEFAULT 'http'`,
+		`ALTER TABLE cluster ADD COLUMN ca_cert text NOT NULL DEFAULT ''`,
+		`ALTER TABLE cluster ADD COLUMN username text NOT NULL DEFAULT ''`,
+		`ALTER TABLE cluster ADD COLUMN password text NOT NULL DEFAULT ''`,
+	}
+	for _, stmt := range stmts {
+		if _, err := tx.Exec(stmt); err != nil {
+			return "", errors.Wrapf(err, "executing %s", stmt)
+		}
+	}
+
+	if _, err := tx.Exec(`UPDATE meta SET value = $1 WHERE id = 1`, "1.6.0"); err != nil {
+		return "", errors.Wrap(err, "updating database version")
+	}
+
+	return "1.6.0", errors.Wrap(tx.Commit(), "commiting changes")
+}
+
+func upgradeTo_1_7_0(db *sql.DB) (string, error) {
+	tx, err := db.Begin()
+	if err != nil {
+		return "", errors.Wrap(err, "starting transaction")
+	}
+	defer tx.Rollback()
+
+	// Existing engines keep empty details; they are treated as unknown
+	stmts := []string{
+		`ALTER TABLE engine ADD COLUMN version text NOT NULL DEFAULT ''`,
+		`ALTER TABLE engine ADD COLUMN distribution text NOT NULL DEFAULT ''`,
+		`ALTER TABLE engine ADD COLUMN checksum text NOT NULL DEFAULT ''`,
+		`ALTER TABLE engine ADD COLUMN deep_water boolean NOT NULL DEFAULT 0`,
+	}
+	for _, stmt := range stmts {
+		if _, err := tx.Exec(stmt); err != nil {
+			return "", errors.Wrapf(err, "executing %s", stmt)
+		}
+	}
+
+	if _, err := tx.Exec(`UPDATE meta SET value = $1 WHERE id = 1`, "1.7.0"); err != nil {
+		return "", errors.Wrap(err, "updating database version")
+	}
+
+	return "1.7.0", errors.Wrap(tx.Commit(), "commiting changes")
+}
+
+func upgradeTo_1_8_0(db *sql.DB) (string, error) {
+	tx, err := db.Begin()
+	if err != nil {
+		return "", errors.Wrap(err, "starting transaction")
+	}
+	defer tx.Rollback()
+
+	stmts := []string{
+		`CREATE TABLE cluster_template (
+			id integer PRIMARY KEY AUTOINCREMENT,
+			name text NOT NULL,
+			engine_id integer NOT NULL,
+			size integer NOT NULL,
+			memory text NOT NULL,
+			keytab text NOT NULL,
+			driver_args text NOT NULL,
+			queue text NOT NULL,
+			idle_timeout integer NOT NULL,
+			tags text NOT NULL,
+			max_clusters integer NOT NULL,
+			created datetime NOT NULL,
+
+			FOREIGN KEY (engine_id) REFERENCES engine(id)
+		)`,
+		`ALTER TABLE cluster_yarn ADD COLUMN template_id integer NOT NULL DEFAULT 0`,
+	}
+	for _, stmt := range stmts {
+		if _, err := tx.Exec(stmt); err != nil {
+			return "", errors.Wrapf(err, "executing %s", stmt)
+		}
+	}
+
+	if _, err := tx.Exec(`INSERT INTO entity_type (name) VALUES ($1)`, TemplateEntity); err != nil {
+		return "", errors.Wrap(err, "adding cluster template entity type")
+	}
+
+	if _, err := tx.Exec(`UPDATE meta SET value = $1 WHERE id = 1`, "1.8.0"); err != nil {
+		return "", errors.Wrap(err, "updating database version")
+	}
+
+	return "1.8.0", errors.Wrap(tx.Commit(), "commiting changes")
+}
+
+func upgradeTo_1_9_0(db *sql.DB) (string, error) {
+	tx, err := db.Begin()
+	if err != nil {
+		return "", errors.Wrap(err, "starting transaction")
+	}
+	defer tx.Rollback()
+
+	stmts := []string{
+		`ALTER TABLE cluster_yarn ADD COLUMN queue text NOT NULL DEFAULT ''`,
+		`ALTER TABLE cluster_yarn ADD COLUMN node_label text NOT NULL DEFAULT ''`,
+		`ALTER TABLE cluster_yarn ADD COLUMN driver_args text NOT NULL DEFAULT ''`,
+	}
+	for _, stmt := range stmts {
+		if _, err := tx.Exec(stmt); err != nil {
+			return "", errors.Wrapf(err, "executing %s", stmt)
+		}
+	}
+
+	if _, err := tx.Exec(`UPDATE meta SET value = $1 WHERE id = 1`, "1.9.0"); err != nil {
+		return "", errors.Wrap(err, "updating database version")
+	}
+
+	return "1.9.0", errors.Wrap(tx.Commit(), "commiting changes")
+}
+
+func upgradeTo_1_10_0(db *sql.DB) (string, error) {
+	tx, err := db.Begin()
+	if err != nil {
+		return "", errors.Wrap(err, "starting transaction")
+	}
+	defer tx.Rollback()
+
+	stmts := []string{
+		`CREATE TABLE keytab (
+			id integer PRIMARY KEY AUTOINCREMENT,
+			identity_id integer NOT NULL,
+			name text NOT NULL,
+			principal text NOT NULL,
+			location text NOT NULL,
+			created datetime NOT NULL,
+
+			UNIQUE (identity_id, name),
+			FOREIGN KEY (identity_id) REFERENCES identity(id) ON DELETE CASCADE
+		)`,
+	}
+	for _, stmt := range stmts {
+		if _, err := tx.Exec(stmt); err != nil {
+			return "", errors.Wrapf(err, "executing %s", stmt)
+		}
+	}
+
+	if _, err := tx.Exec(`INSERT INTO entity_type (name) VALUES ($1)`, KeytabEntity); err != nil {
+		return "", errors.Wrap(err, "adding keytab entity type")
+	}
+
+	// Templates no longer carry a keytab; keytabs belong to the identity
+	// starting the cluster.
+	if err := createTemp(tx, "cluster_template"); err != nil {
+		return "", errors.Wrap(err, "renaming cluster_template")
+	}
+	if err := createNew(tx, "cluster_template", `
+			id integer PRIMARY KEY AUTOINCREMENT,
+			name text NOT NULL,
+			engine_id integer NOT NULL,
+			size integer NOT NULL,
+			memory text NOT NULL,
+			driver_args text NOT NULL,
+			queue text NOT NULL,
+			idle_timeout integer NOT NULL,
+			tags text NOT NULL,
+			max_clusters integer NOT NULL,
+			created datetime NOT NULL,
+
+			FOREIGN KEY (engine_id) REFERENCES engine(id)
+		`); err != nil {
+		return "", errors.Wrap(err, "creating cluster_template")
+	}
+	if err := copyTable(tx, "cluster_template",
+		"id", "name", "engine_id", "size", "memory", "driver_args", "queue", "idle_timeout", "tags", "max_clusters", "created",
+	); err != nil {
+		return "", errors.Wrap(err, "copying cluster_template")
+	}
+	if err := dropTemp(tx, "cluster_template"); err != nil {
+		return "", errors.Wrap(err, "dropping old cluster_template")
+	}
+
+	if _, err := tx.Exec(`UPDATE meta SET value = $1 WHERE id = 1`, "1.10.0"); err != nil {
+		return "", errors.Wrap(err, "updating database version")
+	}
+
+	return "1.10.0", errors.Wrap(tx.Commit(), "commiting changes")
+}
+
+func upgradeTo_1_11_0(db *sql.DB) (string, error) {
+	tx, err := db.Begin()
+	if err != nil {
+		return "", errors.Wrap(err, "starting transaction")
+	}
+	defer tx.Rollback()
+
+	stmts := []string{
+		`ALTER TABLE dataset ADD COLUMN cluster_id integer NOT NULL DEFAULT 0`,
+		`ALTER TABLE dataset ADD COLUMN parent_id integer REFERENCES dataset(id)`,
+	}
+	for _, stmt := range stmts {
+		if _, err := tx.Exec(stmt); err != nil {
+			return "", errors.Wrapf(err, "executing %s", stmt)
+		}
+	}
+
+	if _, err := tx.Exec(`UPDATE meta SET value = $1 WHERE id = 1`, "1.11.0"); err != nil {
+		return "", errors.Wrap(err, "updating database version")
+	}
+
+	return "1.11.0", errors.Wrap(tx.Commit(), "commiting changes")
+}
+
+func upgradeTo_1_12_0(db *sql.DB) (string, error) {
+	tx, err := db.Begin()
+	if err != nil {
+		return "", errors.Wrap(err, "starting transaction")
+	}
+	defer tx.Rollback()
+
+	stmts := []string{
+		`CREATE TABLE dataset_column (
+			dataset_id integer NOT NULL,
+			position integer NOT NULL,
+			name text NOT NULL,
+			type text NOT NULL,
+			missing_count integer NOT NULL,
+			zero_count integer NOT NULL,
+			min double precision,
+			max double precision,
+			mean double precision,
+			sigma double precision,
+			domain text NOT NULL,
+			domain_cardinality integer NOT NULL,
+			histogram_base double precision,
+			histogram_stride double precision,
+			histogram_bins text NOT NULL,
+
+			PRIMARY KEY (dataset_id, position),
+			FOREIGN KEY (dataset_id) REFERENCES dataset(id) ON DELETE CASCADE
+		)`,
+	}
+	for _, stmt := range stmts {
+		if _, err := tx.Exec(stmt); err != nil {
+			return "", errors.Wrapf(err, "executing %s", stmt)
+		}
+	}
+
+	if _, err := tx.Exec(`UPDATE meta SET value = $1 WHERE id = 1`, "1.12.0"); err != nil {
+		return "", errors.Wrap(err, "updating database version")
+	}
+
+	return "1.12.0", errors.Wrap(tx.Commit(), "commiting changes")
+}
+
+func upgradeTo_1_13_0(db *sql.DB) (string, error) {
+	tx, err := db.Begin()
+	if err != nil {
+		return "", errors.Wrap(err, "starting transaction")
+	}
+	defer tx.Rollback()
+
+	stmts := []string{
+		`CREATE TABLE dataset_comparison (
+			dataset_id integer PRIMARY KEY,
+			base_dataset_id integer NOT NULL,
+			report text NOT NULL,
+			created datetime NOT NULL,
+
+			FOREIGN KEY (dataset_id) REFERENCES dataset(id) ON DELETE CASCADE,
+			FOREIGN KEY (base_dataset_id) REFERENCES dataset(id) ON DELETE CASCADE
+		)`,
+	}
+	for _, stmt := range stmts {
+		if _, err := tx.Exec(stmt); err != nil {
+			return "", errors.Wrapf(err, "executing %s", stmt)
+		}
+	}
+
+	if _, err := tx.Exec(`UPDATE meta SET value = $1 WHERE id = 1`, "1.13.0"); err != nil {
+		return "", errors.Wrap(err, "updating database version")
+	}
+
+	return "1.13.0", errors.Wrap(tx.Commit(), "commiting changes")
+}
+
+func upgradeTo_1_14_0(db *sql.DB) (string, error) {
+	tx, err := db.Begin()
+	if err != nil {
+		return "", errors.Wrap(err, "starting transaction")
+	}
+	defer tx.Rollback()
+
+	stmts := []string{
+		`ALTER TABLE dataset ADD COLUMN version integer NOT NULL DEFAULT 0`,
+		`ALTER TABLE dataset ADD COLUMN previous_id integer REFERENCES dataset(id)`,
+		`ALTER TABLE dataset ADD COLUMN row_count integer NOT NULL DEFAULT 0`,
+		`ALTER TABLE dataset ADD COLUMN column_hash text NOT NULL DEFAULT ''`,
+		`ALTER TABLE dataset ADD COLUMN checksum text NOT NULL DEFAULT ''`,
+		`ALTER TABLE model ADD COLUMN training_dataset_version integer NOT NULL DEFAULT 0`,
+
+		// Number existing imports of each datasource in creation order
+		`UPDATE dataset SET
+			version = (
+				SELECT count(1) FROM dataset d
+				WHERE d.datasource_id = dataset.datasource_id AND d.parent_id IS NULL AND d.id <= dataset.id
+			),
+			previous_id = (
+				SELECT max(d.id) FROM dataset d
+				WHERE d.datasource_id = dataset.datasource_id AND d.parent_id IS NULL AND d.id < dataset.id
+			)
+		WHERE parent_id IS NULL`,
+		`UPDATE dataset SET
+			version = COALESCE((SELECT d.version FROM dataset d WHERE d.id = dataset.parent_id), 0)
+		WHERE parent_id IS NOT NULL`,
+		`UPDATE model SET
+			training_dataset_version = COALESCE((SELECT d.version FROM dataset d WHERE d.id = model.training_dataset_id), 0)`,
+	}
+	for _, stmt := range stmts {
+		if _, err := tx.Exec(stmt); err != nil {
+			return "", errors.Wrapf(err, "executing %s", stmt)
+		}
+	}
+
+	if _, err := tx.Exec(`UPDATE meta SET value = $1 WHERE id = 1`, "1.14.0"); err != nil {
+		return "", errors.Wrap(err, "updating database version")
+	}
+
+	return "1.14.0", errors.Wrap(tx.Commit(), "commiting changes")
+}
+
+func upgradeTo_1_15_0(db *sql.DB) (string, error) {
+	tx, err := db.Begin()
+	if err != nil {
+		return "", errors.Wrap(err, "starting transaction")
+	}
+	defer tx.Rollback()
+
+	stmts := []string{
+		`CREATE TABLE dataset_job (
+			id integer PRIMARY KEY AUTOINCREMENT,
+			datasource_id integer NOT NULL,
+			cluster_id integer NOT NULL,
+			dataset_id integer,
+			name text NOT NULL,
+			description text NOT NULL,
+			response_column_name text NOT NULL,
+			compare_with_previous boolean NOT NULL,
+			state text NOT NULL,
+			job_name text NOT NULL,
+			progress double precision NOT NULL,
+			error text NOT NULL,
+			created datetime NOT NULL,
+			finished datetime,
+
+			FOREIGN KEY (datasource_id) REFERENCES datasource(id) ON DELETE CASCADE,
+			FOREIGN KEY (dataset_id) REFERENCES dataset(id) ON DELETE SET NULL
+		)`,
+		`CREATE INDEX dataset_job_datasource ON dataset_job (datasource_id)`,
+	}
+	for _, stmt := range stmts {
+		if _, err := tx.Exec(stmt); err != nil {
+			return "", errors.Wrapf(err, "executing %s", stmt)
+		}
+	}
+
+	if _, err := tx.Exec(`UPDATE meta SET value = $1 WHERE id = 1`, "1.15.0"); err != nil {
+		return "", errors.Wrap(err, "updating database version")
+	}
+
+	return "1.15.0", errors.Wrap(tx.Commit(), "commiting changes")
+}
+
+func upgradeTo_1_16_0(db *sql.DB) (string, error) {
+	tx, err := db.Begin()
+	if err != nil {
+		return "", errors.Wrap(err, "starting transaction")
+	}
+	defer tx.Rollback()
+
+	stmts := []string{
+		`CREATE TABLE dataset_column_role (
+			dataset_id integer NOT NULL,
+			name text NOT NULL,
+			role text NOT NULL,
+
+			PRIMARY KEY (dataset_id, name),
+			FOREIGN KEY (dataset_id) REFERENCES dataset(id) ON DELETE CASCADE
+		)`,
+	}
+	for _, stmt := range stmts {
+		if _, err := tx.Exec(stmt); err != nil {
+			return "", errors.Wrapf(err, "executing %s", stmt)
+		}
+	}
+
+	if _, err := tx.Exec(`UPDATE meta SET value = $1 WHERE id = 1`, "1.16.0"); err != nil {
+		return "", errors.Wrap(err, "updating database version")
+	}
+
+	return "1.16.0", errors.Wrap(tx.Commit(), "commiting changes")
+}
+
+func upgradeTo_1_17_0(db *sql.DB) (string, error) {
+	tx, err := db.Begin()
+	if err != nil {
+		return "", errors.Wrap(err, "starting transaction")
+	}
+	defer tx.Rollback()
+
+	stmts := []string{
+		`ALTER TABLE dataset ADD COLUMN stale boolean NOT NULL DEFAULT 0`,
+		`ALTER TABLE model ADD COLUMN stale boolean NOT NULL DEFAULT 0`,
+	}
+	for _, stmt := range stmts {
+		if _, err := tx.Exec(stmt); err != nil {
+			return "", errors.Wrapf(err, "executing %s", stmt)
+		}
+	}
+
+	if _, err := tx.Exec(`UPDATE meta SET value = $1 WHERE id = 1`, "1.17.0"); err != nil {
+		return "", errors.Wrap(err, "updating database version")
+	}
+
+	return "1.17.0", errors.Wrap(tx.Commit(), "commiting changes")
+}
+
 func createTable(tx *sql.Tx, table string, cols ...string) error {
 	var colStr string
 	for i, col := range cols {
@@ -0,0 +1,117 @@
+/*
+  Copyright (C) 2016 H2O.ai, Inc. <http://h2o.ai/>
+
+  This program is free software: you can redistribute it and/or modify
+  it under the terms of the GNU Affero General Public License as
+  published by the Free Software Foundation, either version 3 of the
+  License, or (at your option) any later version.
+
+  This program is distributed in the hope that it will be useful,
+  but WITHOUT ANY WARRANTY; without even the implied warranty of
+  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+  GNU Affero General Public License for more details.
+
+  You should have received a copy of the GNU Affero General Public License
+  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package master
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/h2oai/steam/master/az"
+	"github.com/h2oai/steam/master/web"
+	"github.com/rs/xid"
+)
+
+const (
+	paramClusterId = "cluster-id"
+	paramJobName   = "job-name"
+
+	jobEventsInterval = time.Second
+)
+
+// JobEventsHandler streams the progress of an H2O job as server-sent events:
+// a "progress" event whenever the job's status or progress changes, then a
+// "done" event once it completes. Failures end the stream with an "error"
+// event.
+type JobEventsHandler struct {
+	az         az.Az
+	webService *web.Service
+}
+
+func newJobEventsHandler(az az.Az, webService *web.Service) *JobEventsHandler {
+	return &JobEventsHandler{
+		az,
+		webService,
+	}
+}
+
+func (s *JobEventsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	guid := xid.New().String()
+
+	pz, azerr := s.az.Identify(r)
+	if azerr != nil {
+		log.Println(guid, "ERR", "?", "JobEvents", azerr)
+		http.Error(w, fmt.Sprintf("Authentication failed: %s", azerr), http.StatusUnauthorized)
+		return
+	}
+
+	values := r.URL.Query()
+	log.Println(guid, "REQ", pz, "JobEvents", values)
+
+	clusterIdValue := values.Get(paramClusterId)
+	clusterId, err := strconv.ParseInt(clusterIdValue, 10, 64)
+	if err != nil || clusterId <= 0 {
+		http.Error(w, fmt.Sprintf("Invalid %s: %s", paramClusterId, clusterIdValue), http.StatusBadRequest)
+		return
+	}
+	jobName := values.Get(paramJobName)
+	if len(jobName) == 0 {
+		http.Error(w, fmt.Sprintf("Missing %s", paramJobName), http.StatusBadRequest)
+		return
+	}
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "Streaming not supported", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+
+	send := func(event string, v interface{}) error {
+		b, err := json.Marshal(v)
+		if err != nil {
+			return err
+		}
+		if _, err := fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event, b); err != nil {
+			return err
+		}
+		flusher.Flush()
+		return nil
+	}
+
+	var final web.JobProgress
+	err = s.webService.WatchJob(r.Context(), pz, clusterId, jobName, jobEventsInterval, func(p web.JobProgress) error {
+		final = p
+		return send("progress", p)
+	})
+	if err != nil {
+		if r.Context().Err() == nil {
+			log.Println(guid, "ERR", pz, "JobEvents", err)
+			send("error", map[string]string{"error": err.Error()})
+		}
+		return
+	}
+	send("done", final)
+}
@@ -26,11 +26,13 @@ import (
 	"path"
 	"strings"
 	"syscall"
+	"time"
 
 	"github.com/gorilla/context"
 	"github.com/h2oai/steam/lib/fs"
 	"github.com/h2oai/steam/lib/ldap"
 	"github.com/h2oai/steam/lib/rpc"
+	"github.com/h2oai/steam/lib/yarn"
 	"github.com/h2oai/steam/master/data"
 	"github.com/h2oai/steam/master/proxy"
 	"github.com/h2oai/steam/master/web"
@@ -43,6 +45,14 @@ const (
 	defaultCompilationAddress        = ":8080"
 	defaultScoringServiceHost        = ""
 	DefaultScoringServicePortsString = "1025:65535"
+	defaultClusterHealthInterval     = 30 * time.Second
+	defaultClusterHealthMaxBackoff   = 10 * time.Minute
+	defaultClusterIdleInterval       = time.Minute
+	defaultClusterIdleWarning        = 10 * time.Minute
+	defaultClusterMetricsInterval    = time.Minute
+	defaultClusterMetricsRetention   = 7 * 24 * time.Hour
+	defaultClusterSyncInterval       = 5 * time.Minute
+	uploadExpiryInterval             = time.Hour
 )
 
 var defaultScoringServicePorts = [...]int{1025, 65535}
@@ -57,22 +67,63 @@ type YarnOpts struct {
 	KerberosEnabled bool
 	Username        string
 	Keytab          string
+	DriverOptions   string // Comma-separated allowlist of extra h2odriver options
+}
+
+type ClusterHealthOpts struct {
+	Interval   time.Duration
+	MaxBackoff time.Duration
+}
+
+type ClusterIdleOpts struct {
+	Interval time.Duration
+	Warning  time.Duration
+}
+
+type ClusterMetricsOpts struct {
+	Interval  time.Duration
+	Retention time.Duration
+}
+
+type ClusterSyncOpts struct {
+	Interval time.Duration
+}
+
+type UploadOpts struct {
+	MaxSize int64         // Bytes; 0 for no limit
+	Expiry  time.Duration // 0 keeps unfinished uploads
+}
+
+type LocalClusterOpts struct {
+	Host     string
+	BasePort int
 }
 
 type Opts struct {
 	WebAddress                string
 	WebTLSCertPath            string
 	WebTLSKeyPath             string
+	WebTLSClientCAPath        string
+	WebTLSClientAuth          string
+	WebTLSMinVersion          string
+	WebTLSCipherSuites        string
 	AuthProvider              string
 	AuthConfig                string
 	WorkingDirectory          string
 	ClusterProxyAddress       string
+	ClusterProxyDomain        string
 	CompilationServiceAddress string
 	ScoringServiceHost        string
 	ScoringServicePorts       [2]int
 	EnableProfiler            bool
 	Yarn                      YarnOpts
 	DB                        DBOpts
+	ClusterHealth             ClusterHealthOpts
+	ClusterIdle               ClusterIdleOpts
+	ClusterMetrics            ClusterMetricsOpts
+	ClusterSync               ClusterSyncOpts
+	LocalCluster              LocalClusterOpts
+	Upload                    UploadOpts
 }
 
 var DefaultConnection = data.Connection{
@@ -92,16 +143,27 @@ var DefaultOpts = &Opts{
 	defaultWebAddress,
 	"",
 	"",
+	"",
+	ClientAuthNone,
+	"",
+	"",
 	"basic",
 	"ldap.toml",
 	path.Join(".", fs.VarDir, "master"),
 	defaultClusterProxyAddress,
+	"",
 	defaultCompilationAddress,
 	defaultScoringServiceHost,
 	defaultScoringServicePorts,
 	false,
-	YarnOpts{false, "", ""},
+	YarnOpts{false, "", "", yarn.DefaultDriverOptions},
 	DBOpts{DefaultConnection, "", ""},
+	ClusterHealthOpts{defaultClusterHealthInterval, defaultClusterHealthMaxBackoff},
+	ClusterIdleOpts{defaultClusterIdleInterval, defaultClusterIdleWarning},
+	ClusterMetricsOpts{defaultClusterMetricsInterval, defaultClusterMetricsRetention},
+	ClusterSyncOpts{defaultClusterSyncInterval},
+	LocalClusterOpts{web.DefaultLocalClusterHost, web.DefaultLocalClusterBasePort},
+	UploadOpts{web.DefaultDatasetUploadLimit, web.DefaultDatasetUploadExpiry},
 }
 
 type AuthProvider interface {
@@ -143,6 +205,13 @@ func Run(version, buildDate string, opts Opts) {
 		log.Fatalln(err)
 	}
 
+	// Cluster credentials are encrypted at rest with a key kept next to the database
+	secretKey, err := data.LoadOrCreateSecretKey(path.Join(wd, fs.DbDir, "secret.key"))
+	if err != nil {
+		log.Fatalln(err)
+	}
+	ds.SetSecretKey(secretKey)
+
 	// --- create basic auth service ---
 	defaultAz := NewDefaultAz(ds)
 	var authProvider AuthProvider
@@ -156,6 +225,11 @@ func Run(version, buildDate string, opts Opts) {
 		}
 
 		authProvider = NewBasicLdapAuthProvider(webAddress, conn)
+	case "mtls":
+		if strings.TrimSpace(opts.WebTLSClientCAPath) == "" {
+			log.Fatalln("Please provide a client CA bundle for mtls authentication")
+		}
+		authProvider = newMTLSAuthProvider(defaultAz, ds, webAddress)
 	default: // "basic"
 		authProvider = newBasicAuthProvider(defaultAz, webAddress)
 	}
@@ -187,11 +261,64 @@ func Run(version, buildDate string, opts Opts) {
 		opts.Yarn.Username,
 		opts.Yarn.Keytab,
 	)
+	webService.SetClusterLauncher(ds.ClusterTypes.Local, web.NewLocalLauncher(wd, "java", opts.LocalCluster.Host, opts.LocalCluster.BasePort))
+	driverOptions, err := yarn.ParseDriverOptionPolicy(opts.Yarn.DriverOptions)
+	if err != nil {
+		log.Fatalln(err)
+	}
+	webService.SetDriverOptionPolicy(driverOptions)
+	webService.SetDatasetUploadLimit(opts.Upload.MaxSize)
 	webServiceImpl := &srvweb.Impl{webService, defaultAz}
 
+	if err := webService.RecoverClusterLaunches(); err != nil {
+		log.Fatalln(err)
+	}
+	if err := webService.RecoverDatasetJobs(); err != nil {
+		log.Fatalln(err)
+	}
+
+	// --- start cluster health reconciler ---
+
+	stopChan := make(chan struct{})
+	defer close(stopChan)
+	if opts.ClusterHealth.Interval > 0 {
+		reconciler := web.NewClusterHealthReconciler(webService, opts.ClusterHealth.Interval, opts.ClusterHealth.MaxBackoff)
+		go reconciler.Run(stopChan)
+	}
+
+	// --- start cluster metrics sampler ---
+
+	if opts.ClusterMetrics.Interval > 0 {
+		sampler := web.NewClusterMetricsSampler(webService, opts.ClusterMetrics.Interval, opts.ClusterMetrics.Retention)
+		go sampler.Run(stopChan)
+	}
+
+	// --- start cluster syncer ---
+
+	if opts.ClusterSync.Interval > 0 {
+		syncer := web.NewClusterSyncer(webService, opts.ClusterSync.Interval)
+		go syncer.Run(stopChan)
+	}
+
+	// --- start upload expiry ---
+
+	if opts.Upload.Expiry > 0 {
+		go webService.RunDatasetUploadExpiry(uploadExpiryInterval, opts.Upload.Expiry, stopChan)
+	}
+
+	// --- start idle cluster monitor ---
+
+	clusterProxy := proxy.NewProxyHandler(defaultAz, ds, opts.ClusterProxyDomain)
+	webService.OnClusterDeleted(clusterProxy.Evict)
+	if opts.ClusterIdle.Interval > 0 {
+		monitor := web.NewIdleClusterMonitor(webService, opts.ClusterIdle.Interval, opts.ClusterIdle.Warning, clusterProxy.LastActivity)
+		go monitor.Run(stopChan)
+	}
+
 	webServeMux.Handle("/logout", authProvider.Logout())
 	webServeMux.Handle("/web", authProvider.Secure(rpc.NewServer(rpc.NewService("web", webServiceImpl))))
-	webServeMux.Handle("/upload", authProvider.Secure(newUploadHandler(defaultAz, wd, webServiceImpl.Service, ds)))
+	webServeMux.Handle("/upload", authProvider.Secure(newUploadHandler(defaultAz, wd, webService, ds, opts.Upload.MaxSize)))
+	webServeMux.Handle("/jobs/events", authProvider.Secure(newJobEventsHandler(defaultAz, webService)))
 	webServeMux.Handle("/download", authProvider.Secure(newDownloadHandler(defaultAz, wd, webServiceImpl.Service, opts.CompilationServiceAddress)))
 	webServeMux.Handle("/", authProvider.Secure(http.FileServer(http.Dir(path.Join(wd, "/www")))))
 
@@ -213,6 +340,14 @@ func Run(version, buildDate string, opts Opts) {
 	keyFile := strings.TrimSpace(opts.WebTLSKeyPath)
 	enableTLS := !(len(certFile) == 0 && len(keyFile) == 0)
 
+	tlsConfig, err := newServerTLSConfig(opts)
+	if err != nil {
+		log.Fatalln(err)
+	}
+	if !enableTLS && opts.AuthProvider == "mtls" {
+		log.Fatalln("mtls authentication requires a web server TLS certificate and key")
+	}
+
 	go func() {
 		log.Println("Web server listening at", webAddress)
 		prefix := ""
@@ -221,7 +356,8 @@ func Run(version, buildDate string, opts Opts) {
 		}
 		if enableTLS {
 			log.Printf("Point your web browser to https://%s%s/\n", prefix, webAddress)
-			if err := http.ListenAndServeTLS(webAddress, certFile, keyFile, context.ClearHandler(webServeMux)); err != nil {
+			server := &http.Server{Addr: webAddress, Handler: context.ClearHandler(webServeMux), TLSConfig: tlsConfig}
+			if err := server.ListenAndServeTLS(certFile, keyFile); err != nil {
 				serverFailChan <- err
 			}
 		} else {
@@ -234,7 +370,7 @@ func Run(version, buildDate string, opts Opts) {
 
 	// --- start reverse proxy ---
 
-	proxyHandler := authProvider.Secure(proxy.NewProxyHandler(defaultAz, ds))
+	proxyHandler := authProvider.Secure(clusterProxy)
 	proxyFailChan := make(chan error)
 	go func() {
 		log.Println("Cluster reverse proxy listening at", proxyAddress)
@@ -244,7 +380,8 @@ func Run(version, buildDate string, opts Opts) {
 		}
 		if enableTLS {
 			log.Printf("Point H2O client libraries to https://%s%s/\n", prefix, proxyAddress)
-			if err := http.ListenAndServeTLS(proxyAddress, certFile, keyFile, proxyHandler); err != nil {
+			server := &http.Server{Addr: proxyAddress, Handler: proxyHandler, TLSConfig: tlsConfig}
+			if err := server.ListenAndServeTLS(certFile, keyFile); err != nil {
 				proxyFailChan <- err
 			}
 
@@ -0,0 +1,98 @@
+/*
+  Copyright (C) 2016 H2O.ai, Inc. <http://h2o.ai/>
+
+  This program is free software: you can redistribute it and/or modify
+  it under the terms of the GNU Affero General Public License as
+  published by the Free Software Foundation, either version 3 of the
+  License, or (at your option) any later version.
+
+  This program is distributed in the hope that it will be useful,
+  but WITHOUT ANY WARRANTY; without even the implied warranty of
+  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+  GNU Affero General Public License for more details.
+
+  You should have received a copy of the GNU Affero General Public License
+  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package master
+
+import (
+	"crypto/x509"
+	"log"
+	"net/http"
+
+	"github.com/abbot/go-http-auth"
+	"github.com/h2oai/steam/master/az"
+)
+
+// MTLSAuthProvider identifies clients by their verified TLS client certificate.
+// The certificate's subject common name is tried first, followed by its email,
+// DNS and URI SANs; the first name matching an active Steam identity wins.
+// Requests without a verified certificate fall back to basic auth, so browser
+// users can still sign in when client certificates are optional.
+type MTLSAuthProvider struct {
+	az        az.Az
+	directory az.Directory
+	realm     string
+}
+
+func (p *MTLSAuthProvider) Secure(handler http.Handler) http.Handler {
+	authenticator := auth.NewBasicAuthenticator(p.realm, func(user, realm string) string {
+		return p.az.Authenticate(user)
+	})
+	fallback := auth.JustCheck(authenticator, handler.ServeHTTP)
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if username := p.identify(r); username != "" {
+			r.Header.Set(auth.AuthUsernameHeader, username)
+			handler.ServeHTTP(w, r)
+			return
+		}
+		fallback(w, r)
+	})
+}
+
+// Basic/Digest auth have no notion of logouts, so these handlers simply fail auth,
+// causing a 401 on the original realm, forcing the browser to re-auth.
+
+func (p *MTLSAuthProvider) Logout() http.Handler {
+	authenticator := auth.NewBasicAuthenticator(p.realm, authNoop)
+	return auth.JustCheck(authenticator, serveNoop)
+}
+
+func (p *MTLSAuthProvider) identify(r *http.Request) string {
+	if r.TLS == nil || len(r.TLS.VerifiedChains) == 0 || len(r.TLS.VerifiedChains[0]) == 0 {
+		return ""
+	}
+	cert := r.TLS.VerifiedChains[0][0]
+	for _, name := range certNames(cert) {
+		pz, err := p.directory.Lookup(name)
+		if err != nil {
+			log.Printf("User %s read failed: %s\n", name, err)
+			continue
+		}
+		if pz != nil && pz.IsActive() {
+			return pz.Name()
+		}
+	}
+	log.Printf("No active identity found for client certificate %s\n", cert.Subject)
+	return ""
+}
+
+func certNames(cert *x509.Certificate) []string {
+	var names []string
+	if cert.Subject.CommonName != "" {
+		names = append(names, cert.Subject.CommonName)
+	}
+	names = append(names, cert.EmailAddresses...)
+	names = append(names, cert.DNSNames...)
+	for _, u := range cert.URIs {
+		names = append(names, u.String())
+	}
+	return names
+}
+
+func newMTLSAuthProvider(az az.Az, directory az.Directory, realm string) AuthProvider {
+	return &MTLSAuthProvider{az, directory, realm}
+}
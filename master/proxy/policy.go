@@ -0,0 +1,214 @@
+/*
+  Copyright (C) 2016 H2O.ai, Inc. <http://h2o.ai/>
+
+  This program is free software: you can redistribute it and/or modify
+  it under the terms of the GNU Affero General Public License as
+  published by the Free Software Foundation, either version 3 of the
+  License, or (at your option) any later version.
+
+  This program is distributed in the hope that it will be useful,
+  but WITHOUT ANY WARRANTY; without even the implied warranty of
+  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+  GNU Affero General Public License for more details.
+
+  You should have received a copy of the GNU Affero General Public License
+  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package proxy
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"path"
+	"strings"
+
+	"github.com/h2oai/steam/master/data"
+)
+
+// Deny is the access level of requests that are never proxied.
+const Deny = "deny"
+
+// Rule maps H2O REST requests to the privilege on the cluster required to
+// make them (one of data.CanView, data.CanEdit, data.Owns or Deny).
+//
+// Path is a pattern of slash-separated segments, where "*" matches any single
+// segment and a trailing "**" matches any remainder. An empty Method matches
+// any method.
+type Rule struct {
+	Method    string `json:"method,omitempty"`
+	Path      string `json:"path"`
+	Privilege string `json:"privilege"`
+}
+
+func (r Rule) matches(method, p string) bool {
+	if r.Method != "" && !strings.EqualFold(r.Method, method) {
+		return false
+	}
+	return matchPath(r.Path, p)
+}
+
+func matchPath(pattern, p string) bool {
+	ps := apiSegments(pattern)
+	ss := apiSegments(p)
+	for i, seg := range ps {
+		if seg == "**" && i == len(ps)-1 {
+			return true
+		}
+		if i >= len(ss) || (seg != "*" && seg != ss[i]) {
+			return false
+		}
+	}
+	return len(ps) == len(ss)
+}
+
+// anyVersion stands in for the version segment of H2O REST paths.
+const anyVersion = "{version}"
+
+// apiSegments splits a path into segments. H2O serves each endpoint under
+// every version number as well as the LATEST and EXPERIMENTAL aliases, so a
+// leading version is replaced by anyVersion and rules apply to all versions.
+func apiSegments(p string) []string {
+	segs := strings.Split(strings.Trim(p, "/"), "/")
+	if isVersion(segs[0]) {
+		segs[0] = anyVersion
+	}
+	return segs
+}
+
+func isVersion(seg string) bool {
+	if strings.EqualFold(seg, "LATEST") || strings.EqualFold(seg, "EXPERIMENTAL") {
+		return true
+	}
+	if seg == "" {
+		return false
+	}
+	for _, c := range seg {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// adminRules deny H2O endpoints that affect the cluster process itself, or
+// that expose other users' work. They are checked after the default rules, so
+// anything under an admin prefix not explicitly allowed is refused.
+var adminRules = []Rule{
+	{"", "/3/KillMinus3", Deny},
+	{"", "/3/GarbageCollect", Deny},
+	{"", "/3/UnlockKeys", Deny},
+	{"", "/3/Profiler/**", Deny},
+	{"", "/3/JStack/**", Deny},
+	{"", "/3/NetworkTest", Deny},
+	{"", "/3/Logs/**", Deny},
+	{"", "/3/Timeline", Deny},
+	{"", "/3/WaterMeterCpuTicks/**", Deny},
+	{"", "/3/WaterMeterIo/**", Deny},
+	{"", "/3/InitID", Deny},
+	{"", "/3/Shutdown", Deny},
+}
+
+// defaultRules are the built-in proxy rules: reads need view access, anything
+// that builds or changes data needs edit access, and shutdown, removal of data
+// or writes to the cluster's file system need ownership. Requests matching no
+// rule are refused.
+var defaultRules = []Rule{
+	{"POST", "/3/Shutdown", data.Owns},
+	{"DELETE", "/3/Frames/**", data.Owns},
+	{"DELETE", "/3/DKV/**", data.Owns},
+	{"DELETE", "/3/Models/**", data.Owns},
+	{"DELETE", "/3/NodePersistentStorage/**", data.Owns},
+	// Rapids expressions can remove or overwrite any key
+	{"", "/99/Rapids", data.Owns},
+	{"", "/3/Frames/*/export/**", data.Owns},
+	{"", "/99/Models.bin/**", data.Owns},
+	{"POST", "/3/ModelBuilders/**", data.CanEdit},
+	{"POST", "/99/Grid/**", data.CanEdit},
+	{"POST", "/3/Parse", data.CanEdit},
+	{"POST", "/3/ParseSetup", data.CanEdit},
+	{"POST", "/3/ImportFiles", data.CanEdit},
+	{"POST", "/3/PostFile", data.CanEdit},
+	{"POST", "/3/Predictions/**", data.CanEdit},
+	{"POST", "/3/ModelMetrics/**", data.CanEdit},
+	{"POST", "/3/SplitFrame", data.CanEdit},
+	{"POST", "/3/CreateFrame", data.CanEdit},
+	{"POST", "/3/Interaction", data.CanEdit},
+	{"POST", "/3/MissingInserter", data.CanEdit},
+	{"POST", "/3/NodePersistentStorage/**", data.CanEdit},
+	{"POST", "/3/Jobs/*/cancel", data.CanEdit},
+	{"GET", "/3/About", data.CanView},
+	{"GET", "/3/Cloud", data.CanView},
+	{"GET", "/3/Capabilities/**", data.CanView},
+	{"GET", "/3/Metadata/**", data.CanView},
+	{"GET", "/3/Jobs/**", data.CanView},
+	{"GET", "/3/Frames/**", data.CanView},
+	{"GET", "/3/Models/**", data.CanView},
+	{"GET", "/3/Models.java/**", data.CanView},
+	{"GET", "/3/ModelBuilders/**", data.CanView},
+	{"GET", "/3/ModelMetrics/**", data.CanView},
+	{"GET", "/99/Grids/**", data.CanView},
+	{"GET", "/3/NodePersistentStorage/**", data.CanView},
+	{"GET", "/3/Typeahead/**", data.CanView},
+	{"GET", "/3/DownloadDataset", data.CanView},
+	{"GET", "/3/DownloadDataset.bin", data.CanView},
+	{"GET", "/flow/**", data.CanView},
+	{"GET", "/", data.CanView},
+}
+
+// Policy decides the privilege needed for each proxied request. Cluster rules
+// take precedence over the default rules.
+type Policy struct {
+	rules []Rule
+}
+
+// ParsePolicy reads a cluster's proxy rules, given as a JSON array of rules.
+// An empty string yields the default policy.
+func ParsePolicy(s string) (*Policy, error) {
+	var rules []Rule
+	if strings.TrimSpace(s) != "" {
+		if err := json.Unmarshal([]byte(s), &rules); err != nil {
+			return nil, fmt.Errorf("Invalid proxy policy: %v", err)
+		}
+	}
+	for _, r := range rules {
+		switch r.Privilege {
+		case data.CanView, data.CanEdit, data.Owns, Deny:
+		default:
+			return nil, fmt.Errorf("Invalid proxy policy: unknown privilege %q for %s", r.Privilege, r.Path)
+		}
+		if !strings.HasPrefix(r.Path, "/") {
+			return nil, fmt.Errorf("Invalid proxy policy: path %q must be absolute", r.Path)
+		}
+	}
+
+	all := make([]Rule, 0, len(rules)+len(defaultRules)+len(adminRules))
+	all = append(all, rules...)
+	all = append(all, defaultRules...)
+	all = append(all, adminRules...)
+	return &Policy{all}, nil
+}
+
+// Privilege returns the privilege required for a request. Requests matching no
+// rule are denied.
+func (p *Policy) Privilege(method, urlPath string) string {
+	if method == http.MethodHead {
+		method = http.MethodGet
+	}
+	clean := path.Clean("/" + urlPath)
+	for _, r := range p.rules {
+		if r.matches(method, clean) {
+			return r.Privilege
+		}
+	}
+	return Deny
+}
+
+func isRead(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return true
+	}
+	return false
+}
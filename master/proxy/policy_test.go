@@ -0,0 +1,114 @@
+/*
+  Copyright (C) 2016 H2O.ai, Inc. <http://h2o.ai/>
+
+  This program is free software: you can redistribute it and/or modify
+  it under the terms of the GNU Affero General Public License as
+  published by the Free Software Foundation, either version 3 of the
+  License, or (at your option) any later version.
+
+  This program is distributed in the hope that it will be useful,
+  but WITHOUT ANY WARRANTY; without even the implied warranty of
+  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+  GNU Affero General Public License for more details.
+
+  You should have received a copy of the GNU Affero General Public License
+  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package proxy
+
+import (
+	"testing"
+
+	"github.com/h2oai/steam/master/data"
+)
+
+func TestDefaultPolicy(t *testing.T) {
+	p, err := ParsePolicy("")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		method, path, privilege string
+	}{
+		{"GET", "/3/Frames", data.CanView},
+		{"GET", "/flow/index.html", data.CanView},
+		{"POST", "/3/ModelBuilders/gbm", data.CanEdit},
+		{"POST", "/3/Jobs/$03017f00000132d4ffffffff$_a1/cancel", data.CanEdit},
+		{"HEAD", "/3/Frames/iris.hex", data.CanView},
+		{"POST", "/3/Shutdown", data.Owns},
+		{"DELETE", "/3/Frames/iris.hex", data.Owns},
+		{"DELETE", "/3/Frames", data.Owns},
+		{"GET", "/3/Shutdown", Deny},
+		{"GET", "/3/JStack", Deny},
+		{"POST", "/3/GarbageCollect", Deny},
+		{"GET", "/3/Logs/nodes/0/files/default", Deny},
+		{"GET", "/3/../3/KillMinus3", Deny},
+	}
+	for _, c := range cases {
+		if priv := p.Privilege(c.method, c.path); priv != c.privilege {
+			t.Errorf("%s %s: expected %s, got %s", c.method, c.path, c.privilege, priv)
+		}
+	}
+}
+
+func TestPolicyBypasses(t *testing.T) {
+	p, err := ParsePolicy("")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		method, path, privilege string
+	}{
+		// Rapids can remove any key, so it needs the same ownership as deletes
+		{"POST", "/99/Rapids", data.Owns},
+		{"POST", "/3/Rapids", data.Owns},
+		// Version aliases and other version numbers reach the same endpoints
+		{"DELETE", "/LATEST/Frames/iris.hex", data.Owns},
+		{"DELETE", "/EXPERIMENTAL/Frames/iris.hex", data.Owns},
+		{"DELETE", "/4/Models/gbm", data.Owns},
+		{"POST", "/LATEST/Shutdown", data.Owns},
+		{"GET", "/LATEST/KillMinus3", Deny},
+		{"GET", "/99/JStack", Deny},
+		{"POST", "/EXPERIMENTAL/Rapids", data.Owns},
+		// Unknown endpoints are refused
+		{"POST", "/3/SomethingNew", Deny},
+		{"GET", "/3/SomethingNew", Deny},
+		{"PUT", "/3/Frames/iris.hex", Deny},
+		{"GET", "/internal/admin", Deny},
+	}
+	for _, c := range cases {
+		if priv := p.Privilege(c.method, c.path); priv != c.privilege {
+			t.Errorf("%s %s: expected %s, got %s", c.method, c.path, c.privilege, priv)
+		}
+	}
+}
+
+func TestClusterPolicy(t *testing.T) {
+	p, err := ParsePolicy(`[
+		{"method": "GET", "path": "/3/Logs/**", "privilege": "own"},
+		{"path": "/99/Rapids", "privilege": "deny"}
+	]`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if priv := p.Privilege("GET", "/3/Logs/nodes/0/files/default"); priv != data.Owns {
+		t.Fatalf("expected cluster rule to allow logs to owners, got %s", priv)
+	}
+	if priv := p.Privilege("POST", "/99/Rapids"); priv != Deny {
+		t.Fatalf("expected cluster rule to deny rapids, got %s", priv)
+	}
+	if priv := p.Privilege("POST", "/3/Shutdown"); priv != data.Owns {
+		t.Fatalf("expected default rules to apply, got %s", priv)
+	}
+
+	if _, err := ParsePolicy(`[{"path": "/3/Frames", "privilege": "admin"}]`); err == nil {
+		t.Fatal("expected an error for an unknown privilege")
+	}
+	if _, err := ParsePolicy(`[{"path": "3/Frames", "privilege": "view"}]`); err == nil {
+		t.Fatal("expected an error for a relative path")
+	}
+}
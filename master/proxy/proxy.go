@@ -18,31 +18,90 @@
 package proxy
 
 import (
+	"context"
+	"fmt"
+	"log"
+	"net"
 	"net/http"
 	"net/http/httputil"
 	"net/url"
 	"strconv"
+	"strings"
 	"sync"
+	"time"
 
 	"github.com/h2oai/steam/master/az"
 	"github.com/h2oai/steam/master/data"
+	"github.com/h2oai/steam/srv/h2ov3"
 )
 
+// clustersPrefix is the path prefix for routing requests to a cluster by ID or
+// name, e.g. /clusters/42/flow/index.html or /clusters/my-cluster/3/Cloud.
+const clustersPrefix = "/clusters/"
+
+type prefixKey struct{}
+
 type reverseProxy struct {
 	clusterId int64
 	host      string
+	conn      h2ov3.Connection
 	proxy     *httputil.ReverseProxy
 }
 
-func newReverseProxy(clusterId int64, host string) *reverseProxy {
+func newReverseProxy(clusterId int64, host string, conn h2ov3.Connection) (*reverseProxy, error) {
+	transport, err := conn.Transport()
+	if err != nil {
+		return nil, err
+	}
+	scheme := conn.Scheme
+	if scheme == "" {
+		scheme = "http"
+	}
+	proxy := httputil.NewSingleHostReverseProxy(&url.URL{
+		Scheme: scheme,
+		Host:   host,
+	})
+	proxy.Transport = transport
+	director := proxy.Director
+	proxy.Director = func(req *http.Request) {
+		director(req)
+		// Never forward the caller's Steam credentials to the cluster
+		req.Header.Del("Authorization")
+		conn.Authorize(req)
+	}
+	proxy.ModifyResponse = func(resp *http.Response) error {
+		if loc := resp.Header.Get("Location"); loc != "" {
+			prefix, _ := resp.Request.Context().Value(prefixKey{}).(string)
+			resp.Header.Set("Location", rewriteLocation(loc, prefix, host))
+		}
+		return nil
+	}
 	return &reverseProxy{
 		clusterId,
 		host,
-		httputil.NewSingleHostReverseProxy(&url.URL{
-			Scheme: "http",
-			Host:   host,
-		}),
+		conn,
+		proxy,
+	}, nil
+}
+
+// rewriteLocation maps a redirect issued by a cluster back onto the proxy:
+// absolute paths are placed under the routing prefix, and URLs pointing at the
+// cluster itself are made relative to the proxy.
+func rewriteLocation(loc, prefix, host string) string {
+	u, err := url.Parse(loc)
+	if err != nil {
+		return loc
 	}
+	if u.Host != "" {
+		if u.Host != host {
+			return loc
+		}
+		u.Scheme, u.Host, u.User = "", "", nil
+	}
+	if !strings.HasPrefix(u.Path, "/") {
+		return loc
+	}
+	return prefix + u.RequestURI()
 }
 
 type ProxyHandler struct {
@@ -50,52 +109,151 @@ type ProxyHandler struct {
 	proxies map[int64]*reverseProxy
 	az      az.Az
 	ds      *data.Datastore
+	domain  string
+
+	activityMu *sync.Mutex
+	activity   map[int64]time.Time
 }
 
-func NewProxyHandler(az az.Az, ds *data.Datastore) *ProxyHandler {
+// NewProxyHandler creates a cluster proxy. If domain is set, requests for
+// <id-or-name>.<domain> are routed to the cluster with that ID or name.
+func NewProxyHandler(az az.Az, ds *data.Datastore, domain string) *ProxyHandler {
 	return &ProxyHandler{
 		&sync.RWMutex{},
 		make(map[int64]*reverseProxy),
 		az,
 		ds,
+		strings.TrimPrefix(domain, "."),
+		&sync.Mutex{},
+		make(map[int64]time.Time),
 	}
 }
 
-func (pm *ProxyHandler) getOrCreateReverseProxy(clusterId int64, host string) *reverseProxy {
+// LastActivity returns the time of the most recent request proxied to a
+// cluster, or the zero time if there has been none since startup.
+func (pm *ProxyHandler) LastActivity(clusterId int64) time.Time {
+	pm.activityMu.Lock()
+	defer pm.activityMu.Unlock()
+	return pm.activity[clusterId]
+}
+
+func (pm *ProxyHandler) recordActivity(clusterId int64) {
+	pm.activityMu.Lock()
+	pm.activity[clusterId] = time.Now()
+	pm.activityMu.Unlock()
+}
+
+func (pm *ProxyHandler) getOrCreateReverseProxy(clusterId int64, host string, conn h2ov3.Connection) (*reverseProxy, error) {
 	pm.mu.RLock()
 	rp, ok := pm.proxies[clusterId]
 	pm.mu.RUnlock()
 
-	if ok {
-		return rp
+	// A cluster that was relaunched can come back at a different address
+	if ok && rp.host == host && rp.conn == conn {
+		return rp, nil
 	}
 
-	rp = newReverseProxy(clusterId, host)
+	rp, err := newReverseProxy(clusterId, host, conn)
+	if err != nil {
+		return nil, err
+	}
 	pm.mu.Lock()
 	pm.proxies[clusterId] = rp
 	pm.mu.Unlock()
-	return rp
+	return rp, nil
 }
 
-func (pm *ProxyHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+// Evict drops the cached proxy and activity of a cluster, e.g. once the
+// cluster has been deleted.
+func (pm *ProxyHandler) Evict(clusterId int64) {
+	pm.mu.Lock()
+	delete(pm.proxies, clusterId)
+	pm.mu.Unlock()
 
-	// All proxy requests require a header with key=X-Cluster; value=cluster-id (in)
+	pm.activityMu.Lock()
+	delete(pm.activity, clusterId)
+	pm.activityMu.Unlock()
+}
 
-	clusterHeader := r.Header.Get("X-Cluster")
-	if clusterHeader == "" {
-		clusterId := r.URL.Query().Get("cluster_id")
-		if r.URL.Path == "/flow/" && clusterId != "" {
-			r.Header.Set("X-Cluster", clusterId)
-			clusterHeader = clusterId
-		} else {
-			http.Error(w, "Cluster requests via Steam requires a valid X-Cluster HTTP header", http.StatusBadRequest)
-			return
+// splitClusterPath splits /clusters/{ref}/rest into ref and /rest.
+func splitClusterPath(p string) (ref, rest string, ok bool) {
+	if !strings.HasPrefix(p, clustersPrefix) {
+		return "", "", false
+	}
+	ref = p[len(clustersPrefix):]
+	if i := strings.Index(ref, "/"); i >= 0 {
+		ref, rest = ref[:i], ref[i:]
+	}
+	if ref == "" {
+		return "", "", false
+	}
+	return ref, rest, true
+}
+
+// route determines which cluster a request is for, and the path prefix the
+// request was routed through, if any. Requests are routed by, in order: the
+// X-Cluster header, a /clusters/{id-or-name}/ path prefix, a subdomain of the
+// proxy domain, the Referer of a page loaded through a path prefix (for Flow's
+// requests to absolute paths), and the cluster_id parameter of /flow/.
+func (pm *ProxyHandler) route(r *http.Request) (ref, prefix string) {
+	if ref := r.Header.Get("X-Cluster"); ref != "" {
+		return ref, ""
+	}
+
+	if ref, _, ok := splitClusterPath(r.URL.Path); ok {
+		return ref, clustersPrefix + ref
+	}
+
+	if pm.domain != "" {
+		host := r.Host
+		if h, _, err := net.SplitHostPort(host); err == nil {
+			host = h
+		}
+		if strings.HasSuffix(host, "."+pm.domain) {
+			if ref := strings.TrimSuffix(host, "."+pm.domain); !strings.Contains(ref, ".") {
+				return ref, ""
+			}
+		}
+	}
+
+	if referer, err := url.Parse(r.Referer()); err == nil && referer.Host == r.Host {
+		if ref, _, ok := splitClusterPath(referer.Path); ok {
+			return ref, clustersPrefix + ref
 		}
 	}
 
-	clusterId, err := strconv.ParseInt(clusterHeader, 10, 64)
+	if r.URL.Path == "/flow/" {
+		if ref := r.URL.Query().Get("cluster_id"); ref != "" {
+			r.Header.Set("X-Cluster", ref)
+			return ref, ""
+		}
+	}
+
+	return "", ""
+}
+
+// readCluster reads a cluster by ID or name.
+func (pm *ProxyHandler) readCluster(pz az.Principal, ref string) (data.Cluster, error) {
+	if clusterId, err := strconv.ParseInt(ref, 10, 64); err == nil {
+		return pm.ds.ReadCluster(pz, clusterId)
+	}
+	cluster, ok, err := pm.ds.ReadClusterByName(pz, ref)
 	if err != nil {
-		http.Error(w, "Invalid X-Cluster HTTP header, expected integer", http.StatusBadRequest)
+		return data.Cluster{}, err
+	}
+	if !ok {
+		return data.Cluster{}, fmt.Errorf("Cluster %s does not exist", ref)
+	}
+	return cluster, nil
+}
+
+func (pm *ProxyHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+
+	// Proxy requests identify their cluster by header, path prefix or host
+
+	ref, prefix := pm.route(r)
+	if ref == "" {
+		http.Error(w, "Cluster requests via Steam require an X-Cluster HTTP header or a /clusters/<id-or-name>/ path", http.StatusBadRequest)
 		return
 	}
 
@@ -117,7 +275,53 @@ func (pm *ProxyHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	// Read cluster from database.
 	// This also checks if the principal has privileges to view this specific cluster.
 
-	cluster, err := pm.ds.ReadCluster(pz, clusterId)
+	cluster, err := pm.readCluster(pz, ref)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusForbidden)
+		return
+	}
+	clusterId := cluster.Id
+
+	// Strip the routing prefix; pages under it need a trailing slash for their
+	// relative links to resolve under the prefix too.
+
+	if prefix != "" && strings.HasPrefix(r.URL.Path, prefix) {
+		rest := r.URL.Path[len(prefix):]
+		if rest == "" {
+			target := prefix + "/"
+			if r.URL.RawQuery != "" {
+				target += "?" + r.URL.RawQuery
+			}
+			http.Redirect(w, r, target, http.StatusMovedPermanently)
+			return
+		}
+		r.URL.Path, r.URL.RawPath = rest, ""
+	}
+	if prefix != "" {
+		r = r.WithContext(context.WithValue(r.Context(), prefixKey{}, prefix))
+	}
+
+	// Check the request against the cluster's proxy policy.
+
+	rules, err := pm.ds.ReadClusterProxyPolicy(pz, clusterId)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusForbidden)
+		return
+	}
+	policy, err := ParsePolicy(rules)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	switch policy.Privilege(r.Method, r.URL.Path) {
+	case Deny:
+		err = fmt.Errorf("%s %s is not allowed through the cluster proxy", r.Method, r.URL.Path)
+	case data.CanEdit:
+		err = pz.CheckEdit(pm.ds.EntityTypes.Cluster, clusterId)
+	case data.Owns:
+		err = pz.CheckOwns(pm.ds.EntityTypes.Cluster, clusterId)
+	}
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusForbidden)
 		return
@@ -125,9 +329,48 @@ func (pm *ProxyHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 
 	// Get existing proxy, or create one if missing.
 
-	rp := pm.getOrCreateReverseProxy(clusterId, cluster.Address)
+	password, err := pm.ds.DecryptSecret(cluster.Password)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	conn := h2ov3.Connection{cluster.Scheme, cluster.CaCert, cluster.Username, password}
+	rp, err := pm.getOrCreateReverseProxy(clusterId, cluster.Address, conn)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
 
 	// Forward
 
-	rp.proxy.ServeHTTP(w, r)
+	pm.recordActivity(clusterId)
+	if isRead(r.Method) {
+		rp.proxy.ServeHTTP(w, r)
+		return
+	}
+
+	// Audit requests that may change the cluster
+
+	sw := &statusWriter{w, http.StatusOK}
+	rp.proxy.ServeHTTP(sw, r)
+	if err := pm.ds.AuditProxyRequest(pz, clusterId, r.Method, r.URL.Path, sw.status); err != nil {
+		log.Printf("Failed auditing proxy request to cluster %d: %v\n", clusterId, err)
+	}
+}
+
+// statusWriter records the status code of a response.
+type statusWriter struct {
+	http.ResponseWriter
+	status int
+}
+
+func (w *statusWriter) WriteHeader(status int) {
+	w.status = status
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *statusWriter) Flush() {
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
 }
@@ -0,0 +1,76 @@
+/*
+  Copyright (C) 2016 H2O.ai, Inc. <http://h2o.ai/>
+
+  This program is free software: you can redistribute it and/or modify
+  it under the terms of the GNU Affero General Public License as
+  published by the Free Software Foundation, either version 3 of the
+  License, or (at your option) any later version.
+
+  This program is distributed in the hope that it will be useful,
+  but WITHOUT ANY WARRANTY; without even the implied warranty of
+  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+  GNU Affero General Public License for more details.
+
+  You should have received a copy of the GNU Affero General Public License
+  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package proxy
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestRoute(t *testing.T) {
+	pm := &ProxyHandler{domain: "h2o.example.com"}
+
+	cases := []struct {
+		url, host, header, referer string
+		ref, prefix                string
+	}{
+		{"/3/Cloud", "steam:9001", "42", "", "42", ""},
+		{"/clusters/42/flow/index.html", "steam:9001", "", "", "42", "/clusters/42"},
+		{"/clusters/my-cluster", "steam:9001", "", "", "my-cluster", "/clusters/my-cluster"},
+		{"/3/Cloud", "my-cluster.h2o.example.com:9001", "", "", "my-cluster", ""},
+		{"/3/Cloud", "a.b.h2o.example.com", "", "", "", ""},
+		{"/3/Cloud", "steam:9001", "", "http://steam:9001/clusters/7/flow/index.html", "7", "/clusters/7"},
+		{"/3/Cloud", "steam:9001", "", "http://elsewhere/clusters/7/flow/index.html", "", ""},
+		{"/flow/?cluster_id=3", "steam:9001", "", "", "3", ""},
+		{"/3/Cloud", "steam:9001", "", "", "", ""},
+		{"/clusters/", "steam:9001", "", "", "", ""},
+	}
+	for _, c := range cases {
+		r, err := http.NewRequest("GET", c.url, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		r.Host = c.host
+		if c.header != "" {
+			r.Header.Set("X-Cluster", c.header)
+		}
+		if c.referer != "" {
+			r.Header.Set("Referer", c.referer)
+		}
+		if ref, prefix := pm.route(r); ref != c.ref || prefix != c.prefix {
+			t.Errorf("%s (host %s): expected %q %q, got %q %q", c.url, c.host, c.ref, c.prefix, ref, prefix)
+		}
+	}
+}
+
+func TestRewriteLocation(t *testing.T) {
+	cases := []struct {
+		loc, prefix, expected string
+	}{
+		{"/flow/index.html", "/clusters/42", "/clusters/42/flow/index.html"},
+		{"/flow/index.html", "", "/flow/index.html"},
+		{"http://10.0.0.1:54321/flow/index.html?x=1", "/clusters/42", "/clusters/42/flow/index.html?x=1"},
+		{"http://example.com/flow/index.html", "/clusters/42", "http://example.com/flow/index.html"},
+		{"index.html", "/clusters/42", "index.html"},
+	}
+	for _, c := range cases {
+		if loc := rewriteLocation(c.loc, c.prefix, "10.0.0.1:54321"); loc != c.expected {
+			t.Errorf("%s: expected %s, got %s", c.loc, c.expected, loc)
+		}
+	}
+}
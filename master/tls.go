@@ -0,0 +1,128 @@
+/*
+  Copyright (C) 2016 H2O.ai, Inc. <http://h2o.ai/>
+
+  This program is free software: you can redistribute it and/or modify
+  it under the terms of the GNU Affero General Public License as
+  published by the Free Software Foundation, either version 3 of the
+  License, or (at your option) any later version.
+
+  This program is distributed in the hope that it will be useful,
+  but WITHOUT ANY WARRANTY; without even the implied warranty of
+  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+  GNU Affero General Public License for more details.
+
+  You should have received a copy of the GNU Affero General Public License
+  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package master
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io/ioutil"
+	"strings"
+)
+
+const (
+	ClientAuthNone    = "none"
+	ClientAuthRequest = "request"
+	ClientAuthRequire = "require"
+)
+
+var tlsVersions = map[string]uint16{
+	"1.0": tls.VersionTLS10,
+	"1.1": tls.VersionTLS11,
+	"1.2": tls.VersionTLS12,
+	"1.3": tls.VersionTLS13,
+}
+
+// newServerTLSConfig builds the TLS configuration shared by the web server and
+// the cluster reverse proxy.
+func newServerTLSConfig(opts Opts) (*tls.Config, error) {
+	config := &tls.Config{}
+
+	if v := strings.TrimSpace(opts.WebTLSMinVersion); v != "" {
+		version, ok := tlsVersions[v]
+		if !ok {
+			return nil, fmt.Errorf("Invalid minimum TLS version %q: expected one of 1.0, 1.1, 1.2, 1.3", v)
+		}
+		config.MinVersion = version
+	}
+
+	if s := strings.TrimSpace(opts.WebTLSCipherSuites); s != "" {
+		suites, err := parseCipherSuites(s)
+		if err != nil {
+			return nil, err
+		}
+		config.CipherSuites = suites
+	}
+
+	if p := strings.TrimSpace(opts.WebTLSClientCAPath); p != "" {
+		pool, err := loadCertPool(p)
+		if err != nil {
+			return nil, err
+		}
+		config.ClientCAs = pool
+	}
+
+	switch strings.TrimSpace(opts.WebTLSClientAuth) {
+	case "", ClientAuthNone:
+		config.ClientAuth = tls.NoClientCert
+	case ClientAuthRequest:
+		config.ClientAuth = tls.VerifyClientCertIfGiven
+	case ClientAuthRequire:
+		config.ClientAuth = tls.RequireAndVerifyClientCert
+	default:
+		return nil, fmt.Errorf("Invalid client authentication mode %q: expected one of %s, %s, %s",
+			opts.WebTLSClientAuth, ClientAuthNone, ClientAuthRequest, ClientAuthRequire)
+	}
+
+	// mtls authentication needs the server to ask for client certificates;
+	// only optional certificates ("request") fall back to basic auth
+	if opts.AuthProvider == "mtls" && config.ClientAuth == tls.NoClientCert {
+		config.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+
+	if config.ClientAuth != tls.NoClientCert && config.ClientCAs == nil {
+		return nil, fmt.Errorf("Client certificate authentication requires a client CA bundle")
+	}
+
+	return config, nil
+}
+
+// parseCipherSuites converts a comma-separated list of IANA cipher suite names
+// (e.g. "TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256") into suite IDs.
+func parseCipherSuites(s string) ([]uint16, error) {
+	known := make(map[string]uint16)
+	for _, suite := range tls.CipherSuites() {
+		known[suite.Name] = suite.ID
+	}
+
+	var suites []uint16
+	for _, name := range strings.Split(s, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		id, ok := known[name]
+		if !ok {
+			return nil, fmt.Errorf("Unsupported TLS cipher suite %q", name)
+		}
+		suites = append(suites, id)
+	}
+	return suites, nil
+}
+
+func loadCertPool(caPath string) (*x509.CertPool, error) {
+	b, err := ioutil.ReadFile(caPath)
+	if err != nil {
+		return nil, fmt.Errorf("Failed reading CA bundle %s: %v", caPath, err)
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(b) {
+		return nil, fmt.Errorf("No PEM certificates found in CA bundle %s", caPath)
+	}
+	return pool, nil
+}
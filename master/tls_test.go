@@ -0,0 +1,195 @@
+/*
+  Copyright (C) 2016 H2O.ai, Inc. <http://h2o.ai/>
+
+  This program is free software: you can redistribute it and/or modify
+  it under the terms of the GNU Affero General Public License as
+  published by the Free Software Foundation, either version 3 of the
+  License, or (at your option) any later version.
+
+  This program is distributed in the hope that it will be useful,
+  but WITHOUT ANY WARRANTY; without even the implied warranty of
+  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+  GNU Affero General Public License for more details.
+
+  You should have received a copy of the GNU Affero General Public License
+  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package master
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path"
+	"testing"
+	"time"
+
+	"github.com/abbot/go-http-auth"
+	"github.com/h2oai/steam/master/az"
+)
+
+// testCA issues client certificates for handshake tests.
+type testCA struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+	pem  []byte
+}
+
+func newTestCA(t *testing.T) *testCA {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "Steam Test CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &testCA{cert, key, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})}
+}
+
+func (ca *testCA) issue(t *testing.T, commonName string) tls.Certificate {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		Subject:      pkix.Name{CommonName: commonName},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, ca.cert, &key.PublicKey, ca.key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key}
+}
+
+type testPrincipal struct {
+	az.Principal
+	name string
+}
+
+func (p testPrincipal) Name() string   { return p.name }
+func (p testPrincipal) IsActive() bool { return true }
+
+// testDirectory knows the identities in the map.
+type testDirectory map[string]bool
+
+func (d testDirectory) Lookup(name string) (az.Principal, error) {
+	if d[name] {
+		return testPrincipal{nil, name}, nil
+	}
+	return nil, nil
+}
+
+func (d testDirectory) Authenticate(username string) string            { return "" }
+func (d testDirectory) Identify(r *http.Request) (az.Principal, error) { return nil, nil }
+
+func writeCA(t *testing.T, ca *testCA) (string, func()) {
+	dir, err := ioutil.TempDir("", "steam-tls")
+	if err != nil {
+		t.Fatal(err)
+	}
+	caPath := path.Join(dir, "ca.pem")
+	if err := ioutil.WriteFile(caPath, ca.pem, 0600); err != nil {
+		os.RemoveAll(dir)
+		t.Fatal(err)
+	}
+	return caPath, func() { os.RemoveAll(dir) }
+}
+
+func TestServerTLSConfigClientAuth(t *testing.T) {
+	caPath, cleanup := writeCA(t, newTestCA(t))
+	defer cleanup()
+
+	cases := []struct {
+		authProvider, clientAuth string
+		expected                 tls.ClientAuthType
+	}{
+		{"basic", ClientAuthNone, tls.NoClientCert},
+		{"basic", ClientAuthRequest, tls.VerifyClientCertIfGiven},
+		{"mtls", "", tls.RequireAndVerifyClientCert},
+		{"mtls", ClientAuthNone, tls.RequireAndVerifyClientCert},
+		{"mtls", ClientAuthRequest, tls.VerifyClientCertIfGiven},
+	}
+	for _, c := range cases {
+		config, err := newServerTLSConfig(Opts{WebTLSClientCAPath: caPath, WebTLSClientAuth: c.clientAuth, AuthProvider: c.authProvider})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if config.ClientAuth != c.expected {
+			t.Errorf("%s/%q: expected %v, got %v", c.authProvider, c.clientAuth, c.expected, config.ClientAuth)
+		}
+	}
+
+	if _, err := newServerTLSConfig(Opts{WebTLSClientAuth: ClientAuthNone, AuthProvider: "mtls"}); err == nil {
+		t.Fatal("expected mtls without a client CA bundle to be rejected")
+	}
+}
+
+func TestMTLSHandshake(t *testing.T) {
+	ca := newTestCA(t)
+	caPath, cleanup := writeCA(t, ca)
+	defer cleanup()
+
+	config, err := newServerTLSConfig(Opts{WebTLSClientCAPath: caPath, WebTLSClientAuth: ClientAuthNone, AuthProvider: "mtls"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	directory := testDirectory{"alice": true}
+	provider := newMTLSAuthProvider(directory, directory, "steam")
+	srv := httptest.NewUnstartedServer(provider.Secure(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(r.Header.Get(auth.AuthUsernameHeader)))
+	})))
+	srv.TLS = config
+	srv.StartTLS()
+	defer srv.Close()
+
+	// Clients with a certificate are identified by it
+	transport := srv.Client().Transport.(*http.Transport).Clone()
+	transport.TLSClientConfig.Certificates = []tls.Certificate{ca.issue(t, "alice")}
+	res, err := (&http.Client{Transport: transport}).Get(srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, err := ioutil.ReadAll(res.Body)
+	res.Body.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.StatusCode != http.StatusOK || string(body) != "alice" {
+		t.Fatalf("expected alice to be identified, got %d %q", res.StatusCode, body)
+	}
+
+	// Clients without a certificate fail the handshake
+	if res, err := srv.Client().Get(srv.URL); err == nil {
+		res.Body.Close()
+		t.Fatalf("expected a handshake failure without a client certificate, got %d", res.StatusCode)
+	}
+}
@@ -19,6 +19,7 @@ package master
 
 import (
 	"archive/zip"
+	"encoding/json"
 	"fmt"
 	"io"
 	"log"
@@ -29,21 +30,26 @@ import (
 	"strings"
 
 	"github.com/h2oai/steam/lib/fs"
+	"github.com/h2oai/steam/lib/yarn"
 	"github.com/h2oai/steam/master/az"
 	"github.com/h2oai/steam/master/data"
-	srvweb "github.com/h2oai/steam/srv/web"
+	"github.com/h2oai/steam/master/web"
 	"github.com/pkg/errors"
 )
 
+// uploadFormOverhead allows for the multipart framing around an uploaded file.
+const uploadFormOverhead = 1 << 20
+
 type UploadHandler struct {
 	az               az.Az
 	workingDirectory string
-	webService       srvweb.Service
+	webService       *web.Service
 	ds               *data.Datastore
+	maxSize          int64
 }
 
-func newUploadHandler(az az.Az, wd string, webService srvweb.Service, ds *data.Datastore) *UploadHandler {
-	return &UploadHandler{az, wd, webService, ds}
+func newUploadHandler(az az.Az, wd string, webService *web.Service, ds *data.Datastore, maxSize int64) *UploadHandler {
+	return &UploadHandler{az, wd, webService, ds, maxSize}
 }
 
 func (s *UploadHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
@@ -56,6 +62,14 @@ func (s *UploadHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if s.maxSize > 0 {
+		if r.ContentLength > s.maxSize+uploadFormOverhead {
+			http.Error(w, fmt.Sprintf("Uploads are limited to %d bytes", s.maxSize), http.StatusRequestEntityTooLarge)
+			return
+		}
+		r.Body = http.MaxBytesReader(w, r.Body, s.maxSize+uploadFormOverhead)
+	}
+
 	r.ParseMultipartForm(0)
 
 	src, handler, err := r.FormFile("file")
@@ -68,6 +82,7 @@ func (s *UploadHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 
 	typ := r.FormValue("type")
 	var dstDir string
+	var dirPerm, filePerm os.FileMode = fs.DirPerm, fs.FilePerm
 
 	switch typ {
 	case fs.KindEngine:
@@ -118,6 +133,30 @@ func (s *UploadHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 			http.Error(w, fmt.Sprintf("Invalid relative path: %s", err), http.StatusBadRequest)
 		}
 
+	case fs.KindDataset:
+		s.handleDataset(w, r, pz, handler.Filename, src)
+		return
+
+	case fs.KindKeytab:
+		if err := pz.CheckPermission(s.ds.Permissions.ManageCluster); err != nil {
+			http.Error(w, err.Error(), http.StatusForbidden)
+			return
+		}
+
+		if err := fs.ValidateName(path.Base(handler.Filename)); err != nil {
+			http.Error(w, fmt.Sprintf("Invalid keytab name: %s", err), http.StatusBadRequest)
+			return
+		}
+
+		// Keytabs are kept per identity, readable only by Steam
+		dstDir = path.Join(s.workingDirectory, fs.KTDir, strconv.FormatInt(pz.Id(), 10))
+		dirPerm, filePerm = fs.KTDirPerm, fs.KTPerm
+
+		if fs.FileExists(path.Join(dstDir, path.Base(handler.Filename))) {
+			http.Error(w, fmt.Sprintf("A keytab named %s already exists", path.Base(handler.Filename)), http.StatusConflict)
+			return
+		}
+
 	default:
 		http.Error(w, fmt.Sprintf("Invalid upload type: %s", typ), http.StatusBadRequest)
 		return
@@ -128,13 +167,13 @@ func (s *UploadHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	fileBaseName := path.Base(handler.Filename)
 	dstPath := path.Join(dstDir, fileBaseName)
 
-	if err := os.MkdirAll(path.Dir(dstPath), fs.DirPerm); err != nil {
+	if err := os.MkdirAll(path.Dir(dstPath), dirPerm); err != nil {
 		log.Println(err)
 		http.Error(w, fmt.Sprintf("%v", err), http.StatusInternalServerError)
 		return
 	}
 
-	dst, err := os.OpenFile(dstPath, os.O_WRONLY|os.O_CREATE, fs.FilePerm)
+	dst, err := os.OpenFile(dstPath, os.O_WRONLY|os.O_CREATE, filePerm)
 	if err != nil {
 		log.Println("Upload file open operation failed:", err)
 		http.Error(w, fmt.Sprintf("Error writing uploaded file to disk: %s", err), http.StatusInternalServerError)
@@ -151,9 +190,98 @@ func (s *UploadHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 			log.Println("Failed saving engine to disk:", err)
 			return
 		}
+
+	case fs.KindKeytab:
+		if err := s.handleKeytab(w, pz, fileBaseName, dstPath); err != nil {
+			os.Remove(dstPath)
+			log.Println("Failed saving keytab:", err)
+			return
+		}
 	}
 }
 
+// handleDataset appends a chunk to a dataset upload, replying with the number
+// of bytes received so far and, once the file is complete, the ID of its
+// datasource. A chunk that does not continue the upload is refused with 409
+// Conflict, carrying the offset to resume from.
+func (s *UploadHandler) handleDataset(w http.ResponseWriter, r *http.Request, pz az.Principal, fileName string, src io.Reader) {
+	projectIdValue := r.FormValue("project-id")
+	projectId, err := strconv.ParseInt(projectIdValue, 10, 64)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid project id: %s", projectIdValue), http.StatusBadRequest)
+		return
+	}
+	size, err := strconv.ParseInt(r.FormValue("size"), 10, 64)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid size: %s", r.FormValue("size")), http.StatusBadRequest)
+		return
+	}
+	var offset int64
+	if v := r.FormValue("offset"); v != "" {
+		if offset, err = strconv.ParseInt(v, 10, 64); err != nil {
+			http.Error(w, fmt.Sprintf("Invalid offset: %s", v), http.StatusBadRequest)
+			return
+		}
+	}
+
+	received, datasourceId, err := s.webService.UploadDatasetChunk(pz, projectId, r.FormValue("name"), path.Base(fileName), size, offset, src)
+	status := http.StatusOK
+	if err != nil {
+		log.Println("Dataset upload failed:", err)
+		switch err.(type) {
+		case *web.UploadOffsetError:
+			status = http.StatusConflict
+		case *web.UploadTooLargeError:
+			http.Error(w, err.Error(), http.StatusRequestEntityTooLarge)
+			return
+		default:
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(map[string]int64{
+		"offset":       received,
+		"datasourceId": datasourceId,
+	})
+}
+
+// handleKeytab accepts an uploaded keytab only if it holds a principal of the
+// uploading identity.
+func (s *UploadHandler) handleKeytab(w http.ResponseWriter, pz az.Principal, fileName, filePath string) error {
+	principals, err := yarn.KeytabPrincipals(filePath)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid keytab: %v", err), http.StatusBadRequest)
+		return errors.Wrap(err, "failed reading keytab")
+	}
+
+	var principal string
+	for _, p := range principals {
+		if yarn.PrincipalUser(p) == pz.Name() {
+			principal = p
+			break
+		}
+	}
+	if principal == "" {
+		http.Error(w, fmt.Sprintf("Keytab holds no principal for user %s", pz.Name()), http.StatusForbidden)
+		return fmt.Errorf("keytab principals %v do not match user %s", principals, pz.Name())
+	}
+
+	if err := yarn.ChownKeytab(filePath, pz.Name()); err != nil {
+		http.Error(w, fmt.Sprintf("Error saving keytab: %v", err), http.StatusInternalServerError)
+		return err
+	}
+
+	if _, err := s.ds.CreateKeytab(pz, fileName, principal, filePath); err != nil {
+		http.Error(w, fmt.Sprintf("Error saving keytab to datastore: %v", err), http.StatusInternalServerError)
+		return errors.Wrap(err, "failed saving keytab to datastore")
+	}
+
+	return nil
+}
+
 func (s *UploadHandler) handleEngine(w http.ResponseWriter, pz az.Principal, fileName, fileDir, filePath string) error {
 	// Open zip file and defer close
 	r, err := zip.OpenReader(filePath)
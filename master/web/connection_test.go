@@ -0,0 +1,77 @@
+/*
+  Copyright (C) 2016 H2O.ai, Inc. <http://h2o.ai/>
+
+  This program is free software: you can redistribute it and/or modify
+  it under the terms of the GNU Affero General Public License as
+  published by the Free Software Foundation, either version 3 of the
+  License, or (at your option) any later version.
+
+  This program is distributed in the hope that it will be useful,
+  but WITHOUT ANY WARRANTY; without even the implied warranty of
+  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+  GNU Affero General Public License for more details.
+
+  You should have received a copy of the GNU Affero General Public License
+  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package web
+
+import (
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/h2oai/steam/master/data"
+)
+
+func TestRegisterSecureCluster(t *testing.T) {
+	svc, su, cleanup := newSandbox(t)
+	defer cleanup()
+
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if u, p, ok := r.BasicAuth(); !ok || u != "h2o" || p != "s3cret" {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"cloud_name":    "secure",
+			"cloud_healthy": true,
+			"nodes":         []interface{}{},
+		})
+	}))
+	defer srv.Close()
+
+	address := strings.TrimPrefix(srv.URL, "https://")
+	caCert := string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: srv.Certificate().Raw}))
+
+	if _, err := svc.RegisterCluster(su, address, "https", caCert, "h2o", "wrong"); err == nil {
+		t.Fatal("expected registration with bad credentials to fail")
+	}
+	if _, err := svc.RegisterCluster(su, address, "https", "", "h2o", "s3cret"); err == nil {
+		t.Fatal("expected registration with an untrusted certificate to fail")
+	}
+
+	clusterId, err := svc.RegisterCluster(su, address, "https", caCert, "h2o", "s3cret")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cluster, err := svc.ds.ReadCluster(su, clusterId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cluster.Scheme != "https" || cluster.Username != "h2o" {
+		t.Fatalf("connection settings not stored: %+v", cluster)
+	}
+	if cluster.Password == "" || cluster.Password == "s3cret" {
+		t.Fatalf("password stored unencrypted: %q", cluster.Password)
+	}
+
+	if state := svc.probeCluster(su, cluster); state != data.StartedState {
+		t.Fatalf("probe using stored connection: got %s, want %s", state, data.StartedState)
+	}
+}
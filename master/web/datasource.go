@@ -0,0 +1,528 @@
+/*
+  Copyright (C) 2016 H2O.ai, Inc. <http://h2o.ai/>
+
+  This program is free software: you can redistribute it and/or modify
+  it under the terms of the GNU Affero General Public License as
+  published by the Free Software Foundation, either version 3 of the
+  License, or (at your option) any later version.
+
+  This program is distributed in the hope that it will be useful,
+  but WITHOUT ANY WARRANTY; without even the implied warranty of
+  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+  GNU Affero General Public License for more details.
+
+  You should have received a copy of the GNU Affero General Public License
+  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package web
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"os"
+	"path"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/h2oai/steam/bindings"
+	"github.com/h2oai/steam/lib/s3"
+	"github.com/h2oai/steam/master/az"
+	"github.com/h2oai/steam/master/data"
+	"github.com/h2oai/steam/srv/h2ov3"
+	"github.com/h2oai/steam/srv/web"
+	"github.com/pkg/errors"
+)
+
+// datasourceConfig is the configuration of a datasource, stored as JSON. Each
+// kind uses its own subset of the fields:
+//
+//	file: path (absolute, as seen by the cluster nodes)
+//	hdfs: uri (hdfs://namenode[:port]/path)
+//	s3:   endpoint, region, bucket, prefix, accessKey, secretKey
+//	jdbc: url, table or query, username, password
+//
+// Upload datasources hold the path and size of a file uploaded to the master;
+// they are only created through uploads, never configured by clients.
+//
+// Secrets are stored encrypted and never returned through the API.
+type datasourceConfig struct {
+	Path      string `json:"path,omitempty"`
+	URI       string `json:"uri,omitempty"`
+	Endpoint  string `json:"endpoint,omitempty"`
+	Region    string `json:"region,omitempty"`
+	Bucket    string `json:"bucket,omitempty"`
+	Prefix    string `json:"prefix,omitempty"`
+	AccessKey string `json:"accessKey,omitempty"`
+	SecretKey string `json:"secretKey,omitempty"`
+	URL       string `json:"url,omitempty"`
+	Table     string `json:"table,omitempty"`
+	Query     string `json:"query,omitempty"`
+	Username  string `json:"username,omitempty"`
+	Password  string `json:"password,omitempty"`
+	Size      int64  `json:"size,omitempty"`
+}
+
+var (
+	reS3Bucket = regexp.MustCompile(`^[a-z0-9][a-z0-9.-]{1,61}[a-z0-9]$`)
+	reSQLTable = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_$]*(\.[A-Za-z_][A-Za-z0-9_$]*)?$`)
+	reJDBCHost = regexp.MustCompile(`^jdbc:(\w+):(?:[^/]*)//([^/:;?]+)(?::(\d+))?`)
+
+	// Default ports of common JDBC subprotocols, for testing connectivity
+	jdbcPorts = map[string]string{
+		"postgresql": "5432",
+		"mysql":      "3306",
+		"mariadb":    "3306",
+		"sqlserver":  "1433",
+		"oracle":     "1521",
+		"hive2":      "10000",
+	}
+)
+
+// datasourceSettings lists the configuration fields of each kind.
+var datasourceSettings = map[string][]string{
+	data.DatasourceFile: {"path"},
+	data.DatasourceHDFS: {"uri"},
+	data.DatasourceS3:   {"endpoint", "region", "bucket", "prefix", "accessKey", "secretKey"},
+	data.DatasourceJDBC: {"url", "table", "query", "username", "password"},
+}
+
+// s3ObjectLimit caps the number of objects imported from an S3 prefix.
+const s3ObjectLimit = 1000
+
+// decodeDatasourceConfig decodes the configuration of a kind, rejecting
+// settings of other kinds.
+func decodeDatasourceConfig(kind, configuration string) (datasourceConfig, error) {
+	var c datasourceConfig
+
+	settings, ok := datasourceSettings[kind]
+	if !ok {
+		return c, fmt.Errorf("Unknown datasource kind %s; use %s, %s, %s or %s", kind, data.DatasourceFile, data.DatasourceHDFS, data.DatasourceS3, data.DatasourceJDBC)
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal([]byte(configuration), &fields); err != nil {
+		return c, fmt.Errorf("Invalid %s datasource configuration: %v", kind, err)
+	}
+	for field := range fields {
+		if !containsString(settings, field) {
+			return c, fmt.Errorf("Invalid %s datasource configuration: unknown setting %s; use %s", kind, field, strings.Join(settings, ", "))
+		}
+	}
+
+	if err := json.Unmarshal([]byte(configuration), &c); err != nil {
+		return c, fmt.Errorf("Invalid %s datasource configuration: %v", kind, err)
+	}
+	return c, nil
+}
+
+func containsString(ss []string, s string) bool {
+	for _, x := range ss {
+		if x == s {
+			return true
+		}
+	}
+	return false
+}
+
+// validate checks and normalizes the settings of a kind.
+func (c *datasourceConfig) validate(kind string) error {
+	switch kind {
+	case data.DatasourceFile:
+		p := strings.TrimSpace(c.Path)
+		if !path.IsAbs(p) {
+			return fmt.Errorf("path must be absolute")
+		}
+		c.Path = path.Clean(p)
+
+	case data.DatasourceHDFS:
+		u, err := url.Parse(strings.TrimSpace(c.URI))
+		if err != nil {
+			return fmt.Errorf("uri: %v", err)
+		}
+		if u.Scheme != "hdfs" || u.Path == "" {
+			return fmt.Errorf("uri must look like hdfs://namenode/path or hdfs:///path")
+		}
+		c.URI = u.String()
+
+	case data.DatasourceS3:
+		c.Endpoint = strings.TrimSpace(c.Endpoint)
+		if c.Endpoint != "" {
+			if _, err := s3.New(c.Endpoint, "", "", ""); err != nil {
+				return err
+			}
+		}
+		c.Region = strings.TrimSpace(c.Region)
+		c.Bucket = strings.TrimSpace(c.Bucket)
+		if !reS3Bucket.MatchString(c.Bucket) {
+			return fmt.Errorf("bucket must match regexp %s", reS3Bucket.String())
+		}
+		c.Prefix = strings.TrimLeft(strings.TrimSpace(c.Prefix), "/")
+		c.AccessKey = strings.TrimSpace(c.AccessKey)
+		if (c.AccessKey == "") != (c.SecretKey == "") {
+			return fmt.Errorf("accessKey and secretKey must be given together")
+		}
+
+	case data.DatasourceJDBC:
+		c.URL = strings.TrimSpace(c.URL)
+		if !strings.HasPrefix(c.URL, "jdbc:") {
+			return fmt.Errorf("url must be a JDBC URL (jdbc:...)")
+		}
+		c.Table = strings.TrimSpace(c.Table)
+		c.Query = strings.TrimSpace(c.Query)
+		if (c.Table == "") == (c.Query == "") {
+			return fmt.Errorf("exactly one of table and query must be given")
+		}
+		if c.Table != "" && !reSQLTable.MatchString(c.Table) {
+			return fmt.Errorf("table must match regexp %s", reSQLTable.String())
+		}
+		if c.Query != "" && !strings.HasPrefix(strings.ToUpper(c.Query), "SELECT ") {
+			return fmt.Errorf("query must be a SELECT statement")
+		}
+		c.Username = strings.TrimSpace(c.Username)
+	}
+	return nil
+}
+
+// sealDatasourceConfig encodes a configuration for storage, encrypting its
+// secrets.
+func (s *Service) sealDatasourceConfig(c datasourceConfig) (string, error) {
+	var err error
+	if c.SecretKey, err = s.ds.EncryptSecret(c.SecretKey); err != nil {
+		return "", err
+	}
+	if c.Password, err = s.ds.EncryptSecret(c.Password); err != nil {
+		return "", err
+	}
+
+	b, err := json.Marshal(c)
+	if err != nil {
+		return "", errors.Wrap(err, "encoding datasource configuration")
+	}
+	return string(b), nil
+}
+
+// openDatasourceConfig decodes a stored configuration, decrypting its secrets.
+func (s *Service) openDatasourceConfig(datasource data.Datasource) (datasourceConfig, error) {
+	var c datasourceConfig
+	if err := json.Unmarshal([]byte(datasource.Configuration), &c); err != nil {
+		return c, errors.Wrap(err, "decoding datasource configuration")
+	}
+
+	var err error
+	if c.SecretKey, err = s.ds.DecryptSecret(c.SecretKey); err != nil {
+		return c, err
+	}
+	if c.Password, err = s.ds.DecryptSecret(c.Password); err != nil {
+		return c, err
+	}
+	return c, nil
+}
+
+// newDatasourceConfig validates a configuration for a datasource. Secrets left
+// out are carried over from the current configuration of the same kind, so
+// that clients can update a datasource without knowing its secrets.
+func (s *Service) newDatasourceConfig(kind, configuration string, current *data.Datasource) (string, error) {
+	c, err := decodeDatasourceConfig(kind, configuration)
+	if err != nil {
+		return "", err
+	}
+
+	if current != nil && current.Kind == kind {
+		old, err := s.openDatasourceConfig(*current)
+		if err != nil {
+			return "", err
+		}
+		if c.SecretKey == "" && strings.TrimSpace(c.AccessKey) == old.AccessKey {
+			c.SecretKey = old.SecretKey
+		}
+		if c.Password == "" && strings.TrimSpace(c.Username) == old.Username {
+			c.Password = old.Password
+		}
+	}
+
+	if err := c.validate(kind); err != nil {
+		return "", fmt.Errorf("Invalid %s datasource configuration: %v", kind, err)
+	}
+	return s.sealDatasourceConfig(c)
+}
+
+func normalizeDatasourceKind(kind string) string {
+	return strings.ToLower(strings.TrimSpace(kind))
+}
+
+// TestDatasource checks that a datasource can be reached: file and HDFS
+// datasources from a cluster, which is what reads them, and object stores
+// and databases from Steam. Testing connects with the datasource's
+// credentials, so it takes edit rights on the datasource.
+func (s *Service) TestDatasource(pz az.Principal, datasourceId, clusterId int64) (string, error) {
+	if err := pz.CheckPermission(s.ds.Permissions.ViewDatasource); err != nil {
+		return "", err
+	}
+	if err := pz.CheckEdit(s.ds.EntityTypes.Datasource, datasourceId); err != nil {
+		return "", err
+	}
+
+	datasource, err := s.ds.ReadDatasource(pz, datasourceId)
+	if err != nil {
+		return "", err
+	}
+	c, err := s.openDatasourceConfig(datasource)
+	if err != nil {
+		return "", err
+	}
+
+	switch datasource.Kind {
+	case data.DatasourceFile, data.DatasourceCSV, data.DatasourceHDFS:
+		location := c.Path
+		if datasource.Kind == data.DatasourceHDFS {
+			location = c.URI
+		}
+		if clusterId == 0 {
+			return "", fmt.Errorf("A cluster is required to test %s datasources", datasource.Kind)
+		}
+		cluster, err := s.ds.ReadCluster(pz, clusterId)
+		if err != nil {
+			return "", err
+		}
+		h2o, err := s.h2oClient(cluster)
+		if err != nil {
+			return "", err
+		}
+		found, err := h2o.GetTypeaheadFiles(location, 10)
+		if err != nil {
+			return "", errors.Wrap(err, "failed looking up files on cluster")
+		}
+		for _, m := range found.Matches {
+			if strings.TrimRight(m, "/") == strings.TrimRight(location, "/") {
+				return fmt.Sprintf("Found %s from cluster %s", location, cluster.Name), nil
+			}
+		}
+		return "", fmt.Errorf("Cluster %s cannot find %s", cluster.Name, location)
+
+	case data.DatasourceS3:
+		client, err := s3.New(c.Endpoint, c.Region, c.AccessKey, c.SecretKey)
+		if err != nil {
+			return "", err
+		}
+		objects, err := client.List(c.Bucket, c.Prefix, s3ObjectLimit)
+		if err != nil {
+			return "", err
+		}
+		if len(objects) == 0 {
+			return "", fmt.Errorf("No objects found under %s/%s", c.Bucket, c.Prefix)
+		}
+		return fmt.Sprintf("Found %d objects under %s/%s", len(objects), c.Bucket, c.Prefix), nil
+
+	case data.DatasourceJDBC:
+		address, err := jdbcAddress(c.URL)
+		if err != nil {
+			return "", err
+		}
+		conn, err := net.DialTimeout("tcp", address, 10*time.Second)
+		if err != nil {
+			return "", fmt.Errorf("Cannot reach database at %s: %v", address, err)
+		}
+		conn.Close()
+		return fmt.Sprintf("Reached database at %s", address), nil
+
+	case data.DatasourceUpload:
+		info, err := os.Stat(c.Path)
+		if err != nil {
+			return "", fmt.Errorf("Uploaded file %s is missing", path.Base(c.Path))
+		}
+		if info.Size() != c.Size {
+			return "", fmt.Errorf("Uploaded file %s has %d bytes; expected %d", path.Base(c.Path), info.Size(), c.Size)
+		}
+		return fmt.Sprintf("Found %s (%d bytes) on Steam", path.Base(c.Path), info.Size()), nil
+	}
+
+	return "", fmt.Errorf("%s datasources cannot be tested", datasource.Kind)
+}
+
+// jdbcAddress returns the host and port a JDBC URL connects to.
+func jdbcAddress(jdbcURL string) (string, error) {
+	m := reJDBCHost.FindStringSubmatch(jdbcURL)
+	if m == nil {
+		return "", fmt.Errorf("Cannot find a host in JDBC URL %s", jdbcURL)
+	}
+	port := m[3]
+	if port == "" {
+		if port = jdbcPorts[m[1]]; port == "" {
+			return "", fmt.Errorf("JDBC URL %s has no port", jdbcURL)
+		}
+	}
+	return net.JoinHostPort(m[2], port), nil
+}
+
+// importDatasource loads a datasource into a cluster. Files are imported
+// (or, if uploaded to Steam, pushed to the cluster) unparsed and their frames
+// returned; tables are imported as a parsed frame,
+// whose name is returned instead; wait follows the H2O job importing them.
+func (s *Service) importDatasource(h2o *h2ov3.H2O, datasource data.Datasource, wait func(jobName string) (*bindings.JobV3, error)) ([]string, string, error) {
+	c, err := s.openDatasourceConfig(datasource)
+	if err != nil {
+		return nil, "", err
+	}
+
+	var paths []string
+	switch datasource.Kind {
+	case data.DatasourceFile, data.DatasourceCSV:
+		paths = []string{c.Path}
+
+	case data.DatasourceHDFS:
+		paths = []string{c.URI}
+
+	case data.DatasourceS3:
+		// The cluster downloads presigned URLs, so credentials stay with Steam
+		client, err := s3.New(c.Endpoint, c.Region, c.AccessKey, c.SecretKey)
+		if err != nil {
+			return nil, "", err
+		}
+		objects, err := client.List(c.Bucket, c.Prefix, s3ObjectLimit)
+		if err != nil {
+			return nil, "", err
+		}
+		for _, o := range objects {
+			if !strings.HasSuffix(o.Key, "/") {
+				paths = append(paths, client.PresignGet(c.Bucket, o.Key, time.Hour))
+			}
+		}
+		if len(paths) == 0 {
+			return nil, "", fmt.Errorf("No objects found under %s/%s", c.Bucket, c.Prefix)
+		}
+
+	case data.DatasourceJDBC:
+		in := bindings.NewImportSQLTableV99()
+		in.ConnectionUrl = c.URL
+		in.Table = c.Table
+		in.SelectQuery = c.Query
+		in.Username = c.Username
+		in.Password = c.Password
+		job, err := h2o.PostImportSQLTableImportsqltable(in)
+		if err != nil {
+			return nil, "", err
+		}
+		if job, err = wait(job.Key.Name); err != nil {
+			return nil, "", err
+		}
+		return nil, job.Dest.Name, nil
+
+	case data.DatasourceUpload:
+		f, err := os.Open(c.Path)
+		if err != nil {
+			return nil, "", errors.Wrap(err, "failed opening uploaded file")
+		}
+		defer f.Close()
+		fileName := path.Base(c.Path)
+		posted, err := h2o.PostFile(fmt.Sprintf("upload_%d_%s", datasource.Id, fileName), fileName, f)
+		if err != nil {
+			return nil, "", errors.Wrap(err, "failed sending file to cluster")
+		}
+		return []string{posted.DestinationFrame}, "", nil
+
+	default:
+		return nil, "", fmt.Errorf("%s datasources cannot be imported", datasource.Kind)
+	}
+
+	var frames []string
+	for _, p := range paths {
+		imported, err := h2o.PostImportFilesImportfiles(p)
+		if err != nil {
+			return nil, "", err
+		}
+		if len(imported.Fails) > 0 {
+			// Leave out the signatures of presigned URLs
+			fails := make([]string, len(imported.Fails))
+			for i, f := range imported.Fails {
+				fails[i] = strings.SplitN(f, "?", 2)[0]
+			}
+			return nil, "", fmt.Errorf("Failed importing %s", strings.Join(fails, ", "))
+		}
+		frames = append(frames, imported.DestinationFrames...)
+	}
+	if len(frames) == 0 {
+		return nil, "", fmt.Errorf("No files found at %s", strings.Join(paths, ", "))
+	}
+
+	return frames, "", nil
+}
+
+// datasourceChecksum fingerprints the source data of an import. Steam hashes
+// only what it owns or is told by the store: uploads, and object stores, whose
+// listings carry an ETag per object. File paths are paths on the cluster, so
+// file datasources are fingerprinted by the statistics of the parsed frame,
+// given by its properties. Elsewhere it is empty.
+func (s *Service) datasourceChecksum(datasource data.Datasource, properties string) string {
+	switch datasource.Kind {
+	case data.DatasourceFile, data.DatasourceCSV:
+		if stats := datasetStatistics(properties); stats != "" {
+			return "stats:" + stats
+		}
+		return ""
+	}
+
+	c, err := s.openDatasourceConfig(datasource)
+	if err != nil {
+		return ""
+	}
+
+	h := sha256.New()
+	switch datasource.Kind {
+	case data.DatasourceUpload:
+		f, err := os.Open(c.Path)
+		if err != nil {
+			return ""
+		}
+		defer f.Close()
+		if _, err := io.Copy(h, f); err != nil {
+			return ""
+		}
+
+	case data.DatasourceS3:
+		client, err := s3.New(c.Endpoint, c.Region, c.AccessKey, c.SecretKey)
+		if err != nil {
+			return ""
+		}
+		objects, err := client.List(c.Bucket, c.Prefix, s3ObjectLimit)
+		if err != nil || len(objects) == 0 {
+			return ""
+		}
+		for _, o := range objects {
+			if o.ETag == "" {
+				return ""
+			}
+			fmt.Fprintf(h, "%s\t%s\n", o.Key, o.ETag)
+		}
+
+	default:
+		return ""
+	}
+
+	return "sha256:" + hex.EncodeToString(h.Sum(nil))
+}
+
+func toDatasourceConfig(configuration string) *web.DatasourceConfig {
+	var c datasourceConfig
+	json.Unmarshal([]byte(configuration), &c) // Implicit datasources have none
+
+	return &web.DatasourceConfig{
+		c.Path,
+		c.URI,
+		c.Endpoint,
+		c.Region,
+		c.Bucket,
+		c.Prefix,
+		c.AccessKey,
+		c.URL,
+		c.Table,
+		c.Query,
+		c.Username,
+	}
+}
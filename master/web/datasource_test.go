@@ -0,0 +1,215 @@
+/*
+  Copyright (C) 2016 H2O.ai, Inc. <http://h2o.ai/>
+
+  This program is free software: you can redistribute it and/or modify
+  it under the terms of the GNU Affero General Public License as
+  published by the Free Software Foundation, either version 3 of the
+  License, or (at your option) any later version.
+
+  This program is distributed in the hope that it will be useful,
+  but WITHOUT ANY WARRANTY; without even the implied warranty of
+  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+  GNU Affero General Public License for more details.
+
+  You should have received a copy of the GNU Affero General Public License
+  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package web
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/h2oai/steam/master/data"
+)
+
+func TestDatasourceKinds(t *testing.T) {
+	svc, su, cleanup := newSandbox(t)
+	defer cleanup()
+
+	projectId, err := svc.CreateProject(su, "p1", "d1", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	invalid := []struct{ kind, config string }{
+		{"file", `{"path": "relative/path.csv"}`},
+		{"file", `{"uri": "hdfs:///data.csv"}`},
+		{"hdfs", `{"uri": "s3://bucket/data.csv"}`},
+		{"s3", `{"bucket": "Not_A_Bucket"}`},
+		{"s3", `{"bucket": "data", "accessKey": "key"}`},
+		{"jdbc", `{"url": "postgresql://db/steam", "table": "t"}`},
+		{"jdbc", `{"url": "jdbc:postgresql://db/steam", "table": "t", "query": "SELECT 1"}`},
+		{"jdbc", `{"url": "jdbc:postgresql://db/steam", "query": "DROP TABLE t"}`},
+		{"csv", `{"path": "/data.csv"}`},
+	}
+	for _, c := range invalid {
+		if _, err := svc.CreateDatasource(su, projectId, "d", "", c.kind, c.config); err == nil {
+			t.Errorf("expected %s datasource %s to be rejected", c.kind, c.config)
+		}
+	}
+
+	fileId, err := svc.CreateDatasource(su, projectId, "file", "", "File", `{"path": "/data//airlines.csv"}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	file, err := svc.GetDatasource(su, fileId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if file.Kind != "file" || file.Config.Path != "/data/airlines.csv" {
+		t.Fatalf("unexpected datasource: %+v %+v", file, file.Config)
+	}
+	if _, err := svc.TestDatasource(su, fileId, 0); err == nil {
+		t.Fatal("expected testing a file datasource without a cluster to fail")
+	}
+
+	// Secrets are stored encrypted, never returned, and kept across updates
+	jdbcId, err := svc.CreateDatasource(su, projectId, "jdbc", "", "jdbc", `{"url": "jdbc:postgresql://db:5432/steam", "table": "flights", "username": "steam", "password": "s3cret"}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	stored, err := svc.ds.ReadDatasource(su, jdbcId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(stored.Configuration, "s3cret") {
+		t.Fatal("expected the password to be stored encrypted")
+	}
+	if err := svc.UpdateDatasource(su, jdbcId, "jdbc", "", "jdbc", `{"url": "jdbc:postgresql://db:5432/steam", "query": "SELECT * FROM flights", "username": "steam"}`); err != nil {
+		t.Fatal(err)
+	}
+	if stored, err = svc.ds.ReadDatasource(su, jdbcId); err != nil {
+		t.Fatal(err)
+	}
+	if c, err := svc.openDatasourceConfig(stored); err != nil || c.Password != "s3cret" || c.Query != "SELECT * FROM flights" {
+		t.Fatalf("unexpected configuration after update: %+v (%v)", c, err)
+	}
+}
+
+func TestDatasourceReachability(t *testing.T) {
+	svc, su, cleanup := newSandbox(t)
+	defer cleanup()
+
+	projectId, err := svc.CreateProject(su, "p1", "d1", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// A MinIO-style stub with one object, which requires signed requests
+	store := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.Contains(r.Header.Get("Authorization"), "Credential=minio/") {
+			http.Error(w, "AccessDenied", http.StatusForbidden)
+			return
+		}
+		fmt.Fprint(w, `<ListBucketResult><Contents><Key>airlines/2008.csv</Key><Size>1024</Size></Contents></ListBucketResult>`)
+	}))
+	defer store.Close()
+
+	s3Id, err := svc.CreateDatasource(su, projectId, "s3", "", "s3", `{"endpoint": "`+store.URL+`", "bucket": "data", "prefix": "airlines/", "accessKey": "minio", "secretKey": "minio123"}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if msg, err := svc.TestDatasource(su, s3Id, 0); err != nil || !strings.Contains(msg, "1 objects") {
+		t.Fatalf("unexpected result: %q (%v)", msg, err)
+	}
+	s3, err := svc.GetDatasource(su, s3Id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s3.Config.AccessKey != "minio" || s3.Config.Bucket != "data" {
+		t.Fatalf("unexpected configuration: %+v", s3.Config)
+	}
+
+	db, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	jdbcId, err := svc.CreateDatasource(su, projectId, "jdbc", "", "jdbc", `{"url": "jdbc:postgresql://`+db.Addr().String()+`/steam", "table": "flights"}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := svc.TestDatasource(su, jdbcId, 0); err != nil {
+		t.Fatal(err)
+	}
+	db.Close()
+	if _, err := svc.TestDatasource(su, jdbcId, 0); err == nil {
+		t.Fatal("expected an unreachable database to fail the test")
+	}
+}
+
+func TestDatasourceEditRights(t *testing.T) {
+	svc, su, cleanup := newSandbox(t)
+	defer cleanup()
+
+	h2o := newFakeH2O()
+	defer h2o.Close()
+	clusterId, err := svc.RegisterCluster(su, h2o.address(), "", "", "", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	projectId, err := svc.CreateProject(su, "p1", "d1", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	db, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	jdbcId, err := svc.CreateDatasource(su, projectId, "jdbc", "", "jdbc", `{"url": "jdbc:postgresql://`+db.Addr().String()+`/steam", "table": "flights", "username": "steam", "password": "secret"}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	roleId, err := svc.CreateRole(su, "analyst", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := svc.LinkRoleWithPermissions(su, roleId, []int64{
+		svc.ds.Permissions.ViewCluster,
+		svc.ds.Permissions.ViewDatasource,
+		svc.ds.Permissions.ManageDataset,
+	}); err != nil {
+		t.Fatal(err)
+	}
+	bobId, err := svc.CreateIdentity(su, "bob", "password")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := svc.LinkIdentityWithRole(su, bobId, roleId); err != nil {
+		t.Fatal(err)
+	}
+	bob, err := svc.ds.Lookup("bob")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := svc.ShareEntity(su, data.CanView, bob.WorkgroupId(), svc.ds.EntityTypes.Cluster, clusterId); err != nil {
+		t.Fatal(err)
+	}
+	if err := svc.ShareEntity(su, data.CanView, bob.WorkgroupId(), svc.ds.EntityTypes.Datasource, jdbcId); err != nil {
+		t.Fatal(err)
+	}
+
+	// Viewers can neither use the stored credentials nor make Steam dial out
+	if _, err := svc.TestDatasource(bob, jdbcId, 0); err == nil {
+		t.Fatal("expected a viewer not to test the datasource")
+	}
+	if _, err := svc.CreateDataset(bob, clusterId, jdbcId, "flights", "", "", false); err == nil {
+		t.Fatal("expected a viewer not to import the datasource")
+	}
+
+	if err := svc.ShareEntity(su, data.CanEdit, bob.WorkgroupId(), svc.ds.EntityTypes.Datasource, jdbcId); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := svc.TestDatasource(bob, jdbcId, 0); err != nil {
+		t.Fatal(err)
+	}
+}
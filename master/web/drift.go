@@ -0,0 +1,350 @@
+/*
+  Copyright (C) 2016 H2O.ai, Inc. <http://h2o.ai/>
+
+  This program is free software: you can redistribute it and/or modify
+  it under the terms of the GNU Affero General Public License as
+  published by the Free Software Foundation, either version 3 of the
+  License, or (at your option) any later version.
+
+  This program is distributed in the hope that it will be useful,
+  but WITHOUT ANY WARRANTY; without even the implied warranty of
+  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+  GNU Affero General Public License for more details.
+
+  You should have received a copy of the GNU Affero General Public License
+  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package web
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"math"
+	"sort"
+
+	"github.com/h2oai/steam/master/az"
+	"github.com/h2oai/steam/master/data"
+	"github.com/h2oai/steam/srv/web"
+	"github.com/pkg/errors"
+)
+
+// Datasets are compared through their column profiles. Distribution shift
+// is summarised per column as the change in mean and sigma, and as the
+// population stability index (PSI) of the other dataset's histogram against
+// the base dataset's. Histograms are only present on refreshed profiles, so
+// the automatic comparison of a new import refreshes both profiles first.
+// Columns the other dataset ignores for modelling are reported, but do not
+// count towards the maximum PSI.
+
+// psiFloor stands in for empty bins, whose PSI term is otherwise infinite.
+const psiFloor = 0.0001
+
+func (s *Service) CompareDatasets(pz az.Principal, baseDatasetId, otherDatasetId int64) (*web.DatasetComparison, error) {
+	if err := pz.CheckPermission(s.ds.Permissions.ViewDataset); err != nil {
+		return nil, err
+	}
+
+	return s.compareDatasets(pz, baseDatasetId, otherDatasetId)
+}
+
+func (s *Service) GetDatasetComparison(pz az.Principal, datasetId int64) (*web.DatasetComparison, error) {
+	if err := pz.CheckPermission(s.ds.Permissions.ViewDataset); err != nil {
+		return nil, err
+	}
+
+	comparison, ok, err := s.ds.ReadDatasetComparison(pz, datasetId)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, fmt.Errorf("No comparison was recorded for dataset %d", datasetId)
+	}
+
+	var report web.DatasetComparison
+	if err := json.Unmarshal([]byte(comparison.Report), &report); err != nil {
+		return nil, errors.Wrap(err, "decoding comparison")
+	}
+	report.CreatedAt = toTimestamp(comparison.Created)
+	return &report, nil
+}
+
+// compareWithPrevious records the comparison of a new dataset with the
+// previous import from its datasource, if any. The dataset stands on its
+// own, so a failure is logged rather than failing the dataset.
+func (s *Service) compareWithPrevious(pz az.Principal, dataset data.Dataset) {
+	if !dataset.PreviousId.Valid {
+		return
+	}
+	for _, datasetId := range []int64{dataset.PreviousId.Int64, dataset.Id} {
+		if err := s.profileHistograms(pz, datasetId); err != nil {
+			log.Printf("Failed profiling histograms of dataset %d: %v\n", datasetId, err)
+		}
+	}
+	if err := s.recordComparison(pz, dataset.PreviousId.Int64, dataset.Id); err != nil {
+		log.Printf("Failed comparing dataset %d with its previous import: %v\n", dataset.Id, err)
+	}
+}
+
+// profileHistograms refreshes the profile of a dataset that has no histograms
+// yet, such as a fresh import, so that it can be compared by PSI. Datasets
+// whose frame is out of reach keep their profile.
+func (s *Service) profileHistograms(pz az.Principal, datasetId int64) error {
+	dataset, err := s.ds.ReadDataset(pz, datasetId)
+	if err != nil {
+		return err
+	}
+	if dataset.ClusterId == 0 || dataset.Stale {
+		return nil
+	}
+	columns, err := s.readDatasetColumns(pz, dataset)
+	if err != nil {
+		return err
+	}
+	for _, c := range columns {
+		if c.HistogramBins != "" && c.HistogramBins != "[]" {
+			return nil
+		}
+	}
+	return s.refreshDatasetProfile(pz, dataset)
+}
+
+func (s *Service) recordComparison(pz az.Principal, baseDatasetId, datasetId int64) error {
+	comparison, err := s.compareDatasets(pz, baseDatasetId, datasetId)
+	if err != nil {
+		return err
+	}
+	report, err := json.Marshal(comparison)
+	if err != nil {
+		return err
+	}
+	return s.ds.CreateDatasetComparison(pz, datasetId, baseDatasetId, string(report))
+}
+
+func (s *Service) compareDatasets(pz az.Principal, baseDatasetId, otherDatasetId int64) (*web.DatasetComparison, error) {
+	base, err := s.ds.ReadDataset(pz, baseDatasetId)
+	if err != nil {
+		return nil, err
+	}
+	other, err := s.ds.ReadDataset(pz, otherDatasetId)
+	if err != nil {
+		return nil, err
+	}
+	baseColumns, err := s.readDatasetColumns(pz, base)
+	if err != nil {
+		return nil, err
+	}
+	otherColumns, err := s.readDatasetColumns(pz, other)
+	if err != nil {
+		return nil, err
+	}
+
+	roles, err := s.readColumnRoles(pz, other, otherColumns)
+	if err != nil {
+		return nil, err
+	}
+
+	comparison, err := compareProfiles(baseColumns, otherColumns, roles)
+	if err != nil {
+		return nil, err
+	}
+	comparison.BaseDatasetId = baseDatasetId
+	comparison.OtherDatasetId = otherDatasetId
+	return comparison, nil
+}
+
+func compareProfiles(base, other []data.DatasetColumn, roles map[string]string) (*web.DatasetComparison, error) {
+	comparison := &web.DatasetComparison{
+		AddedColumns:   []string{},
+		RemovedColumns: []string{},
+		Columns:        []*web.ColumnComparison{},
+	}
+
+	others := make(map[string]data.DatasetColumn, len(other))
+	for _, c := range other {
+		others[c.Name] = c
+	}
+	bases := make(map[string]bool, len(base))
+
+	for _, b := range base {
+		bases[b.Name] = true
+		o, ok := others[b.Name]
+		if !ok {
+			comparison.RemovedColumns = append(comparison.RemovedColumns, b.Name)
+			continue
+		}
+		c, err := compareColumns(b, o)
+		if err != nil {
+			return nil, err
+		}
+		if c.BaseType != c.OtherType || len(c.NewLevels) > 0 || len(c.MissingLevels) > 0 {
+			comparison.SchemaChanged = true
+		}
+		c.Role = roles[c.Name]
+		if c.HasPSI && c.PSI > comparison.MaxPSI && c.Role != data.IgnoredColumnRole {
+			comparison.MaxPSI = c.PSI
+		}
+		comparison.Columns = append(comparison.Columns, c)
+	}
+	for _, o := range other {
+		if !bases[o.Name] {
+			comparison.AddedColumns = append(comparison.AddedColumns, o.Name)
+		}
+	}
+	if len(comparison.AddedColumns) > 0 || len(comparison.RemovedColumns) > 0 {
+		comparison.SchemaChanged = true
+	}
+
+	return comparison, nil
+}
+
+func compareColumns(base, other data.DatasetColumn) (*web.ColumnComparison, error) {
+	b, err := toDatasetColumn(base)
+	if err != nil {
+		return nil, err
+	}
+	o, err := toDatasetColumn(other)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &web.ColumnComparison{
+		Name:          b.Name,
+		BaseType:      b.Type,
+		OtherType:     o.Type,
+		NewLevels:     []string{},
+		MissingLevels: []string{},
+	}
+
+	if b.Type == "enum" && o.Type == "enum" {
+		c.NewLevels = difference(o.Domain, b.Domain)
+		c.MissingLevels = difference(b.Domain, o.Domain)
+		if len(b.HistogramBins) == len(b.Domain) && len(o.HistogramBins) == len(o.Domain) && len(b.Domain) > 0 {
+			c.PSI, c.HasPSI = levelPSI(b.Domain, b.HistogramBins, o.Domain, o.HistogramBins)
+		}
+		return c, nil
+	}
+
+	if isNumeric(b.Type) && isNumeric(o.Type) {
+		if base.Mean.Valid && other.Mean.Valid {
+			c.MeanDelta = o.Mean - b.Mean
+		}
+		if base.Sigma.Valid && other.Sigma.Valid {
+			c.SigmaDelta = o.Sigma - b.Sigma
+		}
+		if b.HistogramStride > 0 && o.HistogramStride > 0 {
+			c.PSI, c.HasPSI = binPSI(b, o)
+		}
+	}
+	return c, nil
+}
+
+func isNumeric(columnType string) bool {
+	return columnType == "int" || columnType == "real" || columnType == "time"
+}
+
+// difference returns the values of a not in b, sorted.
+func difference(a, b []string) []string {
+	in := make(map[string]bool, len(b))
+	for _, v := range b {
+		in[v] = true
+	}
+	diff := []string{}
+	for _, v := range a {
+		if !in[v] {
+			diff = append(diff, v)
+		}
+	}
+	sort.Strings(diff)
+	return diff
+}
+
+// levelPSI compares categorical columns by the share of rows at each level.
+func levelPSI(baseDomain []string, baseBins []int64, otherDomain []string, otherBins []int64) (float64, bool) {
+	baseCounts := make(map[string]int64, len(baseDomain))
+	for i, level := range baseDomain {
+		baseCounts[level] = baseBins[i]
+	}
+	otherCounts := make(map[string]int64, len(otherDomain))
+	for i, level := range otherDomain {
+		otherCounts[level] = otherBins[i]
+	}
+
+	levels := append(append([]string{}, baseDomain...), difference(otherDomain, baseDomain)...)
+	expected := make([]float64, len(levels))
+	actual := make([]float64, len(levels))
+	for i, level := range levels {
+		expected[i] = float64(baseCounts[level])
+		actual[i] = float64(otherCounts[level])
+	}
+	return psi(expected, actual)
+}
+
+// binPSI compares numeric columns over the base histogram's bins. The other
+// histogram is rebinned assuming values are spread evenly within each bin;
+// its mass outside the base range falls in the outermost bins.
+func binPSI(base, other *web.DatasetColumn) (float64, bool) {
+	n := len(base.HistogramBins)
+	if n == 0 || len(other.HistogramBins) == 0 {
+		return 0, false
+	}
+
+	expected := make([]float64, n)
+	actual := make([]float64, n)
+	for i := range base.HistogramBins {
+		expected[i] = float64(base.HistogramBins[i])
+		lo := base.HistogramBase + float64(i)*base.HistogramStride
+		hi := lo + base.HistogramStride
+		actual[i] = histogramCDF(other, hi) - histogramCDF(other, lo)
+	}
+	actual[0] += histogramCDF(other, base.HistogramBase)
+	actual[n-1] += 1 - histogramCDF(other, base.HistogramBase+float64(n)*base.HistogramStride)
+	return psi(expected, actual)
+}
+
+// histogramCDF returns the share of a column's rows below x.
+func histogramCDF(c *web.DatasetColumn, x float64) float64 {
+	var total int64
+	for _, count := range c.HistogramBins {
+		total += count
+	}
+	if total == 0 {
+		return 0
+	}
+
+	pos := (x - c.HistogramBase) / c.HistogramStride
+	if pos <= 0 {
+		return 0
+	}
+	var below float64
+	for i, count := range c.HistogramBins {
+		if pos < float64(i+1) {
+			below += float64(count) * (pos - float64(i))
+			break
+		}
+		below += float64(count)
+	}
+	return below / float64(total)
+}
+
+// psi returns the population stability index of the actual distribution
+// against the expected one; both are normalised first.
+func psi(expected, actual []float64) (float64, bool) {
+	var expectedTotal, actualTotal float64
+	for i := range expected {
+		expectedTotal += expected[i]
+		actualTotal += actual[i]
+	}
+	if expectedTotal == 0 || actualTotal == 0 {
+		return 0, false
+	}
+
+	var index float64
+	for i := range expected {
+		e := math.Max(expected[i]/expectedTotal, psiFloor)
+		a := math.Max(actual[i]/actualTotal, psiFloor)
+		index += (a - e) * math.Log(a/e)
+	}
+	return index, true
+}
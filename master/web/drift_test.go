@@ -0,0 +1,173 @@
+/*
+  Copyright (C) 2016 H2O.ai, Inc. <http://h2o.ai/>
+
+  This program is free software: you can redistribute it and/or modify
+  it under the terms of the GNU Affero General Public License as
+  published by the Free Software Foundation, either version 3 of the
+  License, or (at your option) any later version.
+
+  This program is distributed in the hope that it will be useful,
+  but WITHOUT ANY WARRANTY; without even the implied warranty of
+  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+  GNU Affero General Public License for more details.
+
+  You should have received a copy of the GNU Affero General Public License
+  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package web
+
+import (
+	"database/sql"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/h2oai/steam/master/data"
+)
+
+func TestPSI(t *testing.T) {
+	if v, ok := psi([]float64{10, 20, 30}, []float64{1, 2, 3}); !ok || v != 0 {
+		t.Fatalf("expected identical distributions to have no shift, got %v", v)
+	}
+	// 50/50 against 90/10: 0.4*ln(1.8) + 0.4*ln(5)
+	v, ok := psi([]float64{50, 50}, []float64{90, 10})
+	if want := 0.4*math.Log(1.8) + 0.4*math.Log(5); !ok || math.Abs(v-want) > 1e-9 {
+		t.Fatalf("expected %v, got %v", want, v)
+	}
+	if _, ok := psi([]float64{0, 0}, []float64{1, 1}); ok {
+		t.Fatal("expected an empty histogram to have no PSI")
+	}
+}
+
+func TestCompareProfiles(t *testing.T) {
+	base := []data.DatasetColumn{
+		{0, 0, "Origin", "enum", 0, 0, sql.NullFloat64{}, sql.NullFloat64{}, sql.NullFloat64{}, sql.NullFloat64{}, `["ORD","SFO"]`, 2, sql.NullFloat64{0, true}, sql.NullFloat64{1, true}, `[50,50]`},
+		{0, 1, "Distance", "int", 0, 0, sql.NullFloat64{0, true}, sql.NullFloat64{200, true}, sql.NullFloat64{100, true}, sql.NullFloat64{50, true}, `[]`, 0, sql.NullFloat64{0, true}, sql.NullFloat64{100, true}, `[50,50]`},
+		{0, 2, "Year", "int", 0, 0, sql.NullFloat64{}, sql.NullFloat64{}, sql.NullFloat64{}, sql.NullFloat64{}, `[]`, 0, sql.NullFloat64{}, sql.NullFloat64{}, `[]`},
+		{0, 3, "Cancelled", "enum", 0, 0, sql.NullFloat64{}, sql.NullFloat64{}, sql.NullFloat64{}, sql.NullFloat64{}, `["NO","YES"]`, 2, sql.NullFloat64{}, sql.NullFloat64{}, `[]`},
+	}
+	other := []data.DatasetColumn{
+		{0, 0, "Origin", "enum", 0, 0, sql.NullFloat64{}, sql.NullFloat64{}, sql.NullFloat64{}, sql.NullFloat64{}, `["LAX","ORD"]`, 2, sql.NullFloat64{0, true}, sql.NullFloat64{1, true}, `[50,50]`},
+		// Same bins shifted by half a bin: 25, 50, 25 over the base bins
+		{0, 1, "Distance", "int", 0, 0, sql.NullFloat64{50, true}, sql.NullFloat64{250, true}, sql.NullFloat64{150, true}, sql.NullFloat64{50, true}, `[]`, 0, sql.NullFloat64{50, true}, sql.NullFloat64{100, true}, `[50,50]`},
+		{0, 2, "Cancelled", "string", 0, 0, sql.NullFloat64{}, sql.NullFloat64{}, sql.NullFloat64{}, sql.NullFloat64{}, `[]`, 0, sql.NullFloat64{}, sql.NullFloat64{}, `[]`},
+		{0, 3, "Month", "int", 0, 0, sql.NullFloat64{}, sql.NullFloat64{}, sql.NullFloat64{}, sql.NullFloat64{}, `[]`, 0, sql.NullFloat64{}, sql.NullFloat64{}, `[]`},
+	}
+
+	c, err := compareProfiles(base, other, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !c.SchemaChanged || len(c.AddedColumns) != 1 || c.AddedColumns[0] != "Month" || len(c.RemovedColumns) != 1 || c.RemovedColumns[0] != "Year" {
+		t.Fatalf("unexpected schema changes: %+v", c)
+	}
+	if len(c.Columns) != 3 {
+		t.Fatalf("expected 3 shared columns, got %d", len(c.Columns))
+	}
+
+	origin := c.Columns[0]
+	if len(origin.NewLevels) != 1 || origin.NewLevels[0] != "LAX" || len(origin.MissingLevels) != 1 || origin.MissingLevels[0] != "SFO" {
+		t.Fatalf("unexpected level changes: %+v", origin)
+	}
+	if !origin.HasPSI || origin.PSI < 1 {
+		t.Fatalf("expected a large categorical shift: %+v", origin)
+	}
+
+	distance := c.Columns[1]
+	if distance.MeanDelta != 50 || distance.SigmaDelta != 0 || !distance.HasPSI {
+		t.Fatalf("unexpected distribution shift: %+v", distance)
+	}
+	if want, _ := psi([]float64{50, 50}, []float64{25, 75}); math.Abs(distance.PSI-want) > 1e-9 {
+		t.Fatalf("expected PSI %v, got %v", want, distance.PSI)
+	}
+
+	if cancelled := c.Columns[2]; cancelled.BaseType != "enum" || cancelled.OtherType != "string" {
+		t.Fatalf("expected a type change: %+v", cancelled)
+	}
+	if c.MaxPSI != origin.PSI {
+		t.Fatalf("expected the largest PSI to be reported, got %v", c.MaxPSI)
+	}
+}
+
+func TestCompareWithPrevious(t *testing.T) {
+	svc, su, cleanup := newSandbox(t)
+	defer cleanup()
+
+	projectId, err := svc.CreateProject(su, "p1", "d1", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	datasourceId, err := svc.CreateDatasource(su, projectId, "airlines", "", "file", `{"path": "/data/airlines.csv"}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	frame := func(columns string) string {
+		return `{"frames": [{"rows": 2, "columns": [` + columns + `]}]}`
+	}
+	create := func(name, properties string) data.Dataset {
+		dataset := data.Dataset{0, datasourceId, 0, sql.NullInt64{}, 0, sql.NullInt64{}, 0, "", "", name, "", name + ".hex", "", properties, "1", time.Now(), false}
+		if dataset.Id, err = svc.ds.CreateDataset(su, dataset); err != nil {
+			t.Fatal(err)
+		}
+		svc.profileDataset(su, dataset.Id, properties)
+		if dataset, err = svc.ds.ReadDataset(su, dataset.Id); err != nil {
+			t.Fatal(err)
+		}
+		return dataset
+	}
+
+	first := create("jan", frame(`{"label": "Origin", "type": "enum", "domain": ["ORD", "SFO"], "mean": 0.5, "sigma": 0.5}`))
+	svc.compareWithPrevious(su, first)
+	if _, err := svc.GetDatasetComparison(su, first.Id); err == nil {
+		t.Fatal("expected no comparison for the first import")
+	}
+
+	second := create("feb", frame(`{"label": "Origin", "type": "enum", "domain": ["ORD"], "mean": 0, "sigma": 0}, {"label": "Delay", "type": "real", "mean": 10, "sigma": 2}`))
+	svc.compareWithPrevious(su, second)
+	c, err := svc.GetDatasetComparison(su, second.Id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.BaseDatasetId != first.Id || c.OtherDatasetId != second.Id || !c.SchemaChanged || len(c.AddedColumns) != 1 || c.CreatedAt == 0 {
+		t.Fatalf("unexpected comparison: %+v", c)
+	}
+	if c.Columns[0].MissingLevels[0] != "SFO" {
+		t.Fatalf("expected a missing level: %+v", c.Columns[0])
+	}
+
+	if c, err = svc.CompareDatasets(su, second.Id, first.Id); err != nil {
+		t.Fatal(err)
+	}
+	if len(c.RemovedColumns) != 1 || c.RemovedColumns[0] != "Delay" {
+		t.Fatalf("unexpected comparison: %+v", c)
+	}
+}
+
+func TestCompareWithPreviousImport(t *testing.T) {
+	svc, su, cleanup := newSandbox(t)
+	defer cleanup()
+
+	h2o, _ := newFakeImports("DONE")
+	defer h2o.Close()
+
+	first := waitForDatasetJob(t, svc, su, startDatasetJob(t, svc, su, h2o), finished)
+	jobId, err := svc.CreateDataset(su, first.ClusterId, first.DatasourceId, "airlines", "", "", true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	second := waitForDatasetJob(t, svc, su, jobId, finished)
+	if second.State != data.ImportDoneState {
+		t.Fatalf("unexpected job: %+v", second)
+	}
+
+	// Imports are profiled without histograms; the comparison adds them
+	comparison, err := svc.GetDatasetComparison(su, second.DatasetId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if comparison.BaseDatasetId != first.DatasetId || len(comparison.Columns) != 1 || !comparison.Columns[0].HasPSI {
+		t.Fatalf("expected the automatic comparison to include PSI: %+v", comparison)
+	}
+}
@@ -0,0 +1,76 @@
+/*
+  Copyright (C) 2016 H2O.ai, Inc. <http://h2o.ai/>
+
+  This program is free software: you can redistribute it and/or modify
+  it under the terms of the GNU Affero General Public License as
+  published by the Free Software Foundation, either version 3 of the
+  License, or (at your option) any later version.
+
+  This program is distributed in the hope that it will be useful,
+  but WITHOUT ANY WARRANTY; without even the implied warranty of
+  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+  GNU Affero General Public License for more details.
+
+  You should have received a copy of the GNU Affero General Public License
+  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package web
+
+import (
+	"fmt"
+
+	"github.com/h2oai/steam/lib/engine"
+	"github.com/h2oai/steam/master/az"
+	"github.com/h2oai/steam/master/data"
+	"github.com/h2oai/steam/srv/h2ov3"
+)
+
+func toEngineInfo(e data.Engine) engine.Info {
+	return engine.Info{
+		e.Version,
+		e.Distribution,
+		e.Checksum,
+		e.DeepWater,
+	}
+}
+
+// checkEngine rejects engines that cannot be started by the launcher of a
+// cluster type.
+func (s *Service) checkEngine(e data.Engine, clusterTypeId int64) error {
+	clusterType := data.ClusterYarn
+	if clusterTypeId == s.ds.ClusterTypes.Local {
+		clusterType = data.ClusterLocal
+	}
+	if err := engine.CheckCompatible(toEngineInfo(e), clusterType); err != nil {
+		return fmt.Errorf("Cannot start engine %s: %v", e.Name, err)
+	}
+	return nil
+}
+
+// clusterCapabilities determines what the H2O version running on a cluster
+// supports, from its engine if Steam started it or from the cluster itself.
+func (s *Service) clusterCapabilities(pz az.Principal, cluster data.Cluster, h2o *h2ov3.H2O) (string, engine.Capabilities, error) {
+	if cluster.TypeId != s.ds.ClusterTypes.External {
+		yarnCluster, err := s.ds.ReadYarnCluster(pz, cluster.Id)
+		if err != nil {
+			return "", engine.Capabilities{}, err
+		}
+		e, err := s.ds.ReadEngine(pz, yarnCluster.EngineId)
+		if err != nil {
+			return "", engine.Capabilities{}, err
+		}
+		if e.Version != "" {
+			info := toEngineInfo(e)
+			return info.Version, info.Capabilities(), nil
+		}
+	}
+
+	cloud, err := h2o.GetCloudStatus()
+	if err != nil {
+		return "", engine.Capabilities{}, err
+	}
+	// Whether an external build bundles Deep Water is unknown; a Deep Water
+	// model on the cluster implies that it does.
+	return cloud.Version, engine.CapabilitiesOf(cloud.Version, true), nil
+}
@@ -0,0 +1,194 @@
+/*
+  Copyright (C) 2016 H2O.ai, Inc. <http://h2o.ai/>
+
+  This program is free software: you can redistribute it and/or modify
+  it under the terms of the GNU Affero General Public License as
+  published by the Free Software Foundation, either version 3 of the
+  License, or (at your option) any later version.
+
+  This program is distributed in the hope that it will be useful,
+  but WITHOUT ANY WARRANTY; without even the implied warranty of
+  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+  GNU Affero General Public License for more details.
+
+  You should have received a copy of the GNU Affero General Public License
+  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package web
+
+import (
+	"log"
+	"sync"
+	"time"
+
+	"github.com/h2oai/steam/lib/yarn"
+	"github.com/h2oai/steam/master/az"
+	"github.com/h2oai/steam/master/data"
+	"github.com/pkg/errors"
+)
+
+// ClusterHealthReconciler periodically polls every registered cluster and moves
+// it between the started, disconnected and failed states.
+//
+// A cluster that can't be reached is polled with exponential back-off, from the
+// configured interval up to maxBackoff, until it answers again.
+type ClusterHealthReconciler struct {
+	s          *Service
+	interval   time.Duration
+	maxBackoff time.Duration
+	mu         sync.Mutex
+	checks     map[int64]*clusterCheck
+}
+
+type clusterCheck struct {
+	failures uint
+	next     time.Time
+	probing  bool
+}
+
+func NewClusterHealthReconciler(s *Service, interval, maxBackoff time.Duration) *ClusterHealthReconciler {
+	if maxBackoff < interval {
+		maxBackoff = interval
+	}
+	return &ClusterHealthReconciler{
+		s,
+		interval,
+		maxBackoff,
+		sync.Mutex{},
+		make(map[int64]*clusterCheck),
+	}
+}
+
+// Run reconciles cluster states every interval until stop is closed.
+func (r *ClusterHealthReconciler) Run(stop <-chan struct{}) {
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+	for {
+		if err := r.Reconcile(time.Now()); err != nil {
+			log.Println("Cluster health check failed:", err)
+		}
+		select {
+		case <-ticker.C:
+		case <-stop:
+			return
+		}
+	}
+}
+
+// Reconcile checks all clusters that are due at the given time. Clusters are
+// probed without holding the reconciler's lock; a cluster being probed is not
+// due again until its probe is done.
+func (r *ClusterHealthReconciler) Reconcile(now time.Time) error {
+	pz, err := r.s.ds.LookupSuperuser()
+	if err != nil {
+		return errors.Wrap(err, "failed reading superuser")
+	}
+
+	clusters, err := r.s.ds.ReadAllClusters(pz)
+	if err != nil {
+		return errors.Wrap(err, "failed reading clusters")
+	}
+
+	r.mu.Lock()
+	seen := make(map[int64]bool)
+	var due []data.Cluster
+	for _, cluster := range clusters {
+		if cluster.State != data.StartedState && cluster.State != data.DisconnectedState {
+			continue
+		}
+		seen[cluster.Id] = true
+
+		check, ok := r.checks[cluster.Id]
+		if !ok {
+			check = &clusterCheck{}
+			r.checks[cluster.Id] = check
+		}
+		if check.probing || now.Before(check.next) {
+			continue
+		}
+		check.probing = true
+		due = append(due, cluster)
+	}
+
+	// Forget clusters that were deleted or are no longer monitored
+	for id := range r.checks {
+		if !seen[id] {
+			delete(r.checks, id)
+		}
+	}
+	r.mu.Unlock()
+
+	for _, cluster := range due {
+		state := r.s.probeCluster(pz, cluster)
+
+		r.mu.Lock()
+		if check, ok := r.checks[cluster.Id]; ok {
+			check.probing = false
+			if state == data.StartedState {
+				check.failures = 0
+				check.next = now.Add(r.interval)
+			} else {
+				check.failures++
+				check.next = now.Add(r.backoff(check.failures))
+			}
+		}
+		r.mu.Unlock()
+
+		if state != cluster.State {
+			log.Printf("Cluster %s (%d) changed state: %s -> %s\n", cluster.Name, cluster.Id, cluster.State, state)
+			if err := r.s.ds.UpdateClusterState(pz, cluster.Id, state); err != nil {
+				log.Printf("Failed updating state of cluster %d: %v\n", cluster.Id, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (r *ClusterHealthReconciler) backoff(failures uint) time.Duration {
+	d := r.interval
+	for i := uint(0); i < failures && d < r.maxBackoff; i++ {
+		d *= 2
+	}
+	if d > r.maxBackoff {
+		d = r.maxBackoff
+	}
+	return d
+}
+
+// probeCluster determines the state of a cluster from H2O, falling back to the
+// YARN application state for clusters started through YARN.
+func (s *Service) probeCluster(pz az.Principal, cluster data.Cluster) string {
+	if h2o, err := s.h2oCheckClient(cluster); err != nil {
+		log.Printf("Failed connecting to cluster %d: %v\n", cluster.Id, err)
+	} else if cloud, err := h2o.GetCloudStatus(); err == nil && cloud.CloudHealthy {
+		return data.StartedState
+	}
+
+	if cluster.TypeId != s.ds.ClusterTypes.Yarn {
+		return data.DisconnectedState
+	}
+
+	yarnCluster, err := s.ds.ReadYarnCluster(pz, cluster.Id)
+	if err != nil {
+		log.Printf("Failed reading yarn cluster %d: %v\n", cluster.Id, err)
+		return data.DisconnectedState
+	}
+
+	username := yarnCluster.Username
+	if s.kerberosEnabled {
+		username = s.username
+	}
+	appState, err := yarn.ApplicationState(s.kerberosEnabled, yarnCluster.ApplicationId, username, s.keytab)
+	if err != nil {
+		log.Printf("Failed reading YARN application state of cluster %d: %v\n", cluster.Id, err)
+		return data.DisconnectedState
+	}
+
+	switch appState {
+	case "FINISHED", "FAILED", "KILLED":
+		return data.FailedState
+	}
+	return data.DisconnectedState
+}
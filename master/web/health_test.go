@@ -0,0 +1,158 @@
+/*
+  Copyright (C) 2016 H2O.ai, Inc. <http://h2o.ai/>
+
+  This program is free software: you can redistribute it and/or modify
+  it under the terms of the GNU Affero General Public License as
+  published by the Free Software Foundation, either version 3 of the
+  License, or (at your option) any later version.
+
+  This program is distributed in the hope that it will be useful,
+  but WITHOUT ANY WARRANTY; without even the implied warranty of
+  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+  GNU Affero General Public License for more details.
+
+  You should have received a copy of the GNU Affero General Public License
+  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package web
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/h2oai/steam/master/data"
+)
+
+func TestClusterHealthReconciler(t *testing.T) {
+	svc, su, cleanup := newSandbox(t)
+	defer cleanup()
+
+	h2o := newFakeH2O()
+	defer h2o.Close()
+
+	clusterId, err := svc.RegisterCluster(su, h2o.address(), "", "", "", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	state := func() string {
+		cluster, err := svc.ds.ReadCluster(su, clusterId)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return cluster.State
+	}
+
+	interval := 10 * time.Second
+	r := NewClusterHealthReconciler(svc, interval, time.Minute)
+	start := time.Now()
+
+	if err := r.Reconcile(start); err != nil {
+		t.Fatal(err)
+	}
+	if s := state(); s != data.StartedState {
+		t.Fatalf("expected %s, got %s", data.StartedState, s)
+	}
+
+	// Unreachable clusters are marked disconnected
+	h2o.setUp(false)
+	if err := r.Reconcile(start.Add(interval)); err != nil {
+		t.Fatal(err)
+	}
+	if s := state(); s != data.DisconnectedState {
+		t.Fatalf("expected %s, got %s", data.DisconnectedState, s)
+	}
+
+	// Disconnected clusters are polled with back-off
+	probes := h2o.probeCount()
+	if err := r.Reconcile(start.Add(2 * interval)); err != nil {
+		t.Fatal(err)
+	}
+	if n := h2o.probeCount(); n != probes {
+		t.Fatalf("expected no probe during back-off, got %d", n-probes)
+	}
+
+	// Reachable clusters return to started
+	h2o.setUp(true)
+	if err := r.Reconcile(start.Add(3 * interval)); err != nil {
+		t.Fatal(err)
+	}
+	if s := state(); s != data.StartedState {
+		t.Fatalf("expected %s, got %s", data.StartedState, s)
+	}
+
+	// Each transition is audited
+	history, err := svc.GetHistory(su, svc.ds.EntityTypes.Cluster, clusterId, 0, 100)
+	if err != nil {
+		t.Fatal(err)
+	}
+	updates := 0
+	for _, h := range history {
+		if h.Action == data.UpdateOp {
+			updates++
+		}
+	}
+	if updates != 2 {
+		t.Fatalf("expected 2 state updates in history, got %d", updates)
+	}
+}
+
+func TestClusterHealthBackoff(t *testing.T) {
+	r := &ClusterHealthReconciler{interval: time.Second, maxBackoff: 5 * time.Second}
+	for failures, expected := range []time.Duration{1, 2, 4, 5, 5} {
+		if d := r.backoff(uint(failures)); d != expected*time.Second {
+			t.Fatalf("backoff(%d): expected %v, got %v", failures, expected*time.Second, d)
+		}
+	}
+}
+
+func TestClusterHealthReconcilerHungCluster(t *testing.T) {
+	svc, su, cleanup := newSandbox(t)
+	defer cleanup()
+
+	defer func(d time.Duration) { clusterCheckTimeout = d }(clusterCheckTimeout)
+	clusterCheckTimeout = 100 * time.Millisecond
+
+	hung, healthy := newFakeH2O(), newFakeH2O()
+	defer hung.Close()
+	defer healthy.Close()
+	hungId, err := svc.RegisterCluster(su, hung.address(), "", "", "", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	healthyId, err := svc.RegisterCluster(su, healthy.address(), "", "", "", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// A cluster that accepts requests but never answers them
+	release := make(chan struct{})
+	defer close(release)
+	hung.handle("/3/Cloud", func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	})
+
+	r := NewClusterHealthReconciler(svc, time.Second, time.Minute)
+	done := make(chan error, 1)
+	go func() { done <- r.Reconcile(time.Now()) }()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatal(err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected a hung cluster not to hold up the reconciler")
+	}
+
+	for id, want := range map[int64]string{hungId: data.DisconnectedState, healthyId: data.StartedState} {
+		cluster, err := svc.ds.ReadCluster(su, id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if cluster.State != want {
+			t.Fatalf("expected cluster %d to be %s, got %s", id, want, cluster.State)
+		}
+	}
+}
@@ -0,0 +1,184 @@
+/*
+  Copyright (C) 2016 H2O.ai, Inc. <http://h2o.ai/>
+
+  This program is free software: you can redistribute it and/or modify
+  it under the terms of the GNU Affero General Public License as
+  published by the Free Software Foundation, either version 3 of the
+  License, or (at your option) any later version.
+
+  This program is distributed in the hope that it will be useful,
+  but WITHOUT ANY WARRANTY; without even the implied warranty of
+  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+  GNU Affero General Public License for more details.
+
+  You should have received a copy of the GNU Affero General Public License
+  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package web
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/h2oai/steam/master/data"
+	"github.com/pkg/errors"
+)
+
+// idleDeadline returns the time at which an idle cluster will be shut down, if
+// it has an idle timeout.
+func idleDeadline(c data.YarnCluster) (time.Time, bool) {
+	if c.IdleTimeout <= 0 || !c.LastActivity.Valid {
+		return time.Time{}, false
+	}
+	return c.LastActivity.Time.Add(time.Duration(c.IdleTimeout) * time.Minute), true
+}
+
+// IdleClusterMonitor stops YARN clusters that have been idle for longer than
+// their idle timeout. Activity is determined from the cluster's H2O jobs and
+// from requests made through the cluster proxy. A warning is recorded in the
+// cluster's history ahead of the shutdown.
+type IdleClusterMonitor struct {
+	s             *Service
+	interval      time.Duration
+	warning       time.Duration
+	proxyActivity func(clusterId int64) time.Time
+	warned        map[int64]time.Time
+}
+
+func NewIdleClusterMonitor(s *Service, interval, warning time.Duration, proxyActivity func(clusterId int64) time.Time) *IdleClusterMonitor {
+	return &IdleClusterMonitor{
+		s,
+		interval,
+		warning,
+		proxyActivity,
+		make(map[int64]time.Time),
+	}
+}
+
+// Run checks for idle clusters every interval until stop is closed.
+func (m *IdleClusterMonitor) Run(stop <-chan struct{}) {
+	ticker := time.NewTicker(m.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			if err := m.Check(time.Now()); err != nil {
+				log.Println("Idle cluster check failed:", err)
+			}
+		case <-stop:
+			return
+		}
+	}
+}
+
+// Check records the latest activity of every started YARN cluster, and warns
+// about or stops clusters that are (nearly) past their idle deadline.
+func (m *IdleClusterMonitor) Check(now time.Time) error {
+	pz, err := m.s.ds.LookupSuperuser()
+	if err != nil {
+		return errors.Wrap(err, "failed reading superuser")
+	}
+
+	clusters, err := m.s.ds.ReadAllClusters(pz)
+	if err != nil {
+		return errors.Wrap(err, "failed reading clusters")
+	}
+
+	for _, cluster := range clusters {
+		if _, ok := m.s.clusterLauncher(cluster.TypeId); !ok || cluster.State != data.StartedState {
+			continue
+		}
+
+		yarnCluster, err := m.s.ds.ReadYarnCluster(pz, cluster.Id)
+		if err != nil {
+			log.Printf("Failed reading details of cluster %d: %v\n", cluster.Id, err)
+			continue
+		}
+		if yarnCluster.IdleTimeout <= 0 {
+			continue
+		}
+
+		// Record the latest activity; a cluster whose jobs can't be read may
+		// well be busy, so it is left alone until it can be reached again
+		last, err := m.lastActivity(cluster, now)
+		if err != nil {
+			log.Printf("Failed reading activity of cluster %d: %v\n", cluster.Id, err)
+			continue
+		}
+		if last.IsZero() && !yarnCluster.LastActivity.Valid {
+			// Clusters without any recorded activity are idle from now on
+			last = now
+		}
+		if !last.IsZero() && (!yarnCluster.LastActivity.Valid || last.After(yarnCluster.LastActivity.Time)) {
+			if err := m.s.ds.UpdateYarnClusterActivity(pz, cluster.Id, last); err != nil {
+				log.Printf("Failed recording activity of cluster %d: %v\n", cluster.Id, err)
+				continue
+			}
+			yarnCluster.LastActivity.Time, yarnCluster.LastActivity.Valid = last, true
+		}
+
+		deadline, _ := idleDeadline(yarnCluster)
+		if now.Before(deadline.Add(-m.warning)) {
+			delete(m.warned, cluster.Id)
+			continue
+		}
+
+		if now.Before(deadline) {
+			if warned, ok := m.warned[cluster.Id]; ok && warned.Equal(deadline) {
+				continue
+			}
+			msg := fmt.Sprintf("Cluster is idle and will be stopped at %s", deadline.UTC().Format(time.RFC3339))
+			log.Printf("Cluster %s (%d): %s\n", cluster.Name, cluster.Id, msg)
+			if err := m.s.ds.WarnCluster(pz, cluster.Id, msg); err != nil {
+				log.Printf("Failed recording idle warning of cluster %d: %v\n", cluster.Id, err)
+			}
+			m.warned[cluster.Id] = deadline
+			continue
+		}
+
+		log.Printf("Cluster %s (%d) has been idle since %s; stopping\n", cluster.Name, cluster.Id, yarnCluster.LastActivity.Time)
+		if err := m.s.ds.WarnCluster(pz, cluster.Id, "Cluster stopped after idle timeout"); err != nil {
+			log.Printf("Failed recording idle shutdown of cluster %d: %v\n", cluster.Id, err)
+		}
+		if err := m.s.stopCluster(pz, cluster, yarnCluster, yarnCluster.Username, yarnCluster.Keytab); err != nil {
+			log.Printf("Failed stopping idle cluster %d: %v\n", cluster.Id, err)
+			continue
+		}
+		delete(m.warned, cluster.Id)
+	}
+
+	return nil
+}
+
+// lastActivity returns the latest of the cluster's H2O job activity and proxy
+// traffic, or the zero time if there was none. A running job counts as
+// activity now.
+func (m *IdleClusterMonitor) lastActivity(cluster data.Cluster, now time.Time) (time.Time, error) {
+	h2o, err := m.s.h2oCheckClient(cluster)
+	if err != nil {
+		return time.Time{}, err
+	}
+	jobs, err := h2o.GetJobsList()
+	if err != nil {
+		return time.Time{}, errors.Wrap(err, "failed reading jobs")
+	}
+
+	var last time.Time
+	if m.proxyActivity != nil {
+		last = m.proxyActivity(cluster.Id)
+	}
+	for _, job := range jobs.Jobs {
+		var t time.Time
+		if job.Status == "RUNNING" || job.Status == "CREATED" {
+			t = now
+		} else {
+			t = time.Unix(0, (job.StartTime+job.Msec)*int64(time.Millisecond))
+		}
+		if t.After(last) {
+			last = t
+		}
+	}
+	return last, nil
+}
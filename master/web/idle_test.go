@@ -0,0 +1,129 @@
+/*
+  Copyright (C) 2016 H2O.ai, Inc. <http://h2o.ai/>
+
+  This program is free software: you can redistribute it and/or modify
+  it under the terms of the GNU Affero General Public License as
+  published by the Free Software Foundation, either version 3 of the
+  License, or (at your option) any later version.
+
+  This program is distributed in the hope that it will be useful,
+  but WITHOUT ANY WARRANTY; without even the implied warranty of
+  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+  GNU Affero General Public License for more details.
+
+  You should have received a copy of the GNU Affero General Public License
+  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package web
+
+import (
+	"testing"
+	"time"
+
+	"github.com/h2oai/steam/master/az"
+
+	"github.com/h2oai/steam/master/data"
+	"github.com/lib/pq"
+)
+
+func TestIdleDeadline(t *testing.T) {
+	last := time.Date(2016, 10, 1, 12, 0, 0, 0, time.UTC)
+
+	c := data.YarnCluster{IdleTimeout: 30, LastActivity: pq.NullTime{Time: last, Valid: true}}
+	deadline, ok := idleDeadline(c)
+	if !ok {
+		t.Fatal("expected an idle deadline")
+	}
+	if expected := last.Add(30 * time.Minute); !deadline.Equal(expected) {
+		t.Fatalf("expected %s, got %s", expected, deadline)
+	}
+
+	c.IdleTimeout = 0
+	if _, ok := idleDeadline(c); ok {
+		t.Fatal("expected no idle deadline when idle timeout is disabled")
+	}
+
+	c.IdleTimeout, c.LastActivity.Valid = 30, false
+	if _, ok := idleDeadline(c); ok {
+		t.Fatal("expected no idle deadline without recorded activity")
+	}
+}
+
+// startIdleCluster starts a YARN cluster at the fake's address, with an idle
+// timeout of 30 minutes.
+func startIdleCluster(t *testing.T, svc *Service, su az.Principal, launcher *FakeLauncher) int64 {
+	svc.SetClusterLauncher(svc.ds.ClusterTypes.Yarn, launcher)
+	engineId, err := svc.ds.CreateEngine(su, "h2o", "/tmp/h2odriver.jar")
+	if err != nil {
+		t.Fatal(err)
+	}
+	clusterId, err := svc.StartClusterOnYarn(su, "c1", engineId, 1, "1g", 0, "", "", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 100; i++ {
+		cluster, err := svc.ds.ReadCluster(su, clusterId)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if cluster.State == data.StartedState {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if err := svc.ds.UpdateYarnClusterIdleTimeout(su, clusterId, 30); err != nil {
+		t.Fatal(err)
+	}
+	return clusterId
+}
+
+func TestIdleClusterMonitorCheck(t *testing.T) {
+	svc, su, cleanup := newSandbox(t)
+	defer cleanup()
+
+	h2o := newFakeH2O()
+	defer h2o.Close()
+	h2o.handleJSON("/3/Jobs", map[string]interface{}{"jobs": []interface{}{}})
+
+	launcher := NewFakeLauncher(h2o.address())
+	clusterId := startIdleCluster(t, svc, su, launcher)
+	m := NewIdleClusterMonitor(svc, time.Minute, 10*time.Minute, nil)
+	start := time.Now()
+
+	// A new cluster without jobs or proxy traffic is idle from its launch
+	if err := m.Check(start); err != nil {
+		t.Fatal(err)
+	}
+	yarnCluster, err := svc.ds.ReadYarnCluster(su, clusterId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !yarnCluster.LastActivity.Valid || start.Sub(yarnCluster.LastActivity.Time) > time.Minute {
+		t.Fatalf("expected activity to be seeded at launch, got %v", yarnCluster.LastActivity)
+	}
+	if stopped := launcher.Stopped(); len(stopped) != 0 {
+		t.Fatalf("expected a new cluster not to be stopped, got %v", stopped)
+	}
+
+	// A cluster that can't be reached is left alone
+	h2o.setUp(false)
+	if err := m.Check(start.Add(time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+	if stopped := launcher.Stopped(); len(stopped) != 0 {
+		t.Fatalf("expected an unreachable cluster not to be stopped, got %v", stopped)
+	}
+
+	// Clusters past their idle deadline are stopped
+	h2o.setUp(true)
+	if err := m.Check(start.Add(time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+	if stopped := launcher.Stopped(); len(stopped) != 1 {
+		t.Fatalf("expected the idle cluster to be stopped, got %v", stopped)
+	}
+	if _, err := svc.ds.ReadCluster(su, clusterId); err == nil {
+		t.Fatal("expected the stopped cluster to be deleted")
+	}
+}
@@ -0,0 +1,374 @@
+/*
+  Copyright (C) 2016 H2O.ai, Inc. <http://h2o.ai/>
+
+  This program is free software: you can redistribute it and/or modify
+  it under the terms of the GNU Affero General Public License as
+  published by the Free Software Foundation, either version 3 of the
+  License, or (at your option) any later version.
+
+  This program is distributed in the hope that it will be useful,
+  but WITHOUT ANY WARRANTY; without even the implied warranty of
+  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+  GNU Affero General Public License for more details.
+
+  You should have received a copy of the GNU Affero General Public License
+  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package web
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/h2oai/steam/bindings"
+	"github.com/h2oai/steam/master/az"
+	"github.com/h2oai/steam/master/data"
+	"github.com/h2oai/steam/srv/h2ov3"
+	"github.com/h2oai/steam/srv/web"
+	"github.com/lib/pq"
+	"github.com/pkg/errors"
+)
+
+// datasetJobPollInterval is how often the H2O jobs of a dataset import are
+// checked for progress.
+var datasetJobPollInterval = time.Second
+
+// CreateDataset queues the import of a datasource into a cluster, returning
+// the ID of the job running it. The dataset is created once its frame has been
+// parsed; see GetDatasetJob. Importing hands the datasource's credentials to
+// the cluster, so it takes edit rights on the datasource.
+func (s *Service) CreateDataset(pz az.Principal, clusterId int64, datasourceId int64, name, description string, responseColumnName string, compareWithPrevious bool) (int64, error) {
+	if err := pz.CheckPermission(s.ds.Permissions.ManageDataset); err != nil {
+		return 0, err
+	}
+	if err := pz.CheckEdit(s.ds.EntityTypes.Datasource, datasourceId); err != nil {
+		return 0, err
+	}
+
+	datasource, err := s.ds.ReadDatasource(pz, datasourceId)
+	if err != nil {
+		return 0, err
+	}
+	cluster, err := s.ds.ReadCluster(pz, clusterId)
+	if err != nil {
+		return 0, err
+	}
+
+	job := data.DatasetJob{
+		0,
+		datasourceId,
+		clusterId,
+		sql.NullInt64{},
+		name,
+		description,
+		responseColumnName,
+		compareWithPrevious,
+		data.ImportQueuedState,
+		"",
+		0,
+		"",
+		time.Now(),
+		pq.NullTime{},
+	}
+	if job.Id, err = s.ds.CreateDatasetJob(pz, job); err != nil {
+		return 0, errors.Wrap(err, "failed recording dataset job")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	s.datasetJobsMu.Lock()
+	s.datasetJobs[job.Id] = cancel
+	s.datasetJobsMu.Unlock()
+
+	go s.runDatasetJob(ctx, cancel, pz, job, datasource, cluster)
+
+	return job.Id, nil
+}
+
+// runDatasetJob imports a dataset, recording the outcome on its job. Failures,
+// including panics, end the job rather than the master.
+func (s *Service) runDatasetJob(ctx context.Context, cancel context.CancelFunc, pz az.Principal, job data.DatasetJob, datasource data.Datasource, cluster data.Cluster) {
+	defer func() {
+		s.datasetJobsMu.Lock()
+		delete(s.datasetJobs, job.Id)
+		s.datasetJobsMu.Unlock()
+		cancel()
+	}()
+
+	finish := func(datasetId int64, err error) {
+		switch {
+		case err == nil:
+			job.State = data.ImportDoneState
+			job.DatasetId = sql.NullInt64{datasetId, true}
+			job.Progress = 1
+		case ctx.Err() != nil:
+			job.State = data.ImportCancelledState
+		default:
+			job.State = data.ImportFailedState
+			job.Error = err.Error()
+			log.Printf("Dataset job %d failed: %v\n", job.Id, err)
+		}
+		if _, err := s.ds.UpdateDatasetJob(pz, job); err != nil {
+			log.Printf("Failed recording outcome of dataset job %d: %v\n", job.Id, err)
+		}
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			finish(0, fmt.Errorf("Import crashed: %v", r))
+		}
+	}()
+
+	datasetId, err := s.importDataset(ctx, pz, &job, datasource, cluster)
+	finish(datasetId, err)
+}
+
+// setDatasetJobState records the progress of a running dataset job.
+func (s *Service) setDatasetJobState(pz az.Principal, job *data.DatasetJob, state, jobName string, progress float64) {
+	job.State, job.JobName, job.Progress = state, jobName, progress
+	if _, err := s.ds.UpdateDatasetJob(pz, *job); err != nil {
+		log.Printf("Failed recording progress of dataset job %d: %v\n", job.Id, err)
+	}
+}
+
+func (s *Service) importDataset(ctx context.Context, pz az.Principal, job *data.DatasetJob, datasource data.Datasource, cluster data.Cluster) (int64, error) {
+	h2o, err := s.h2oClient(cluster)
+	if err != nil {
+		return 0, err
+	}
+
+	s.setDatasetJobState(pz, job, data.ImportImportingState, "", 0)
+	wait := func(jobName string) (*bindings.JobV3, error) {
+		return s.waitForDatasetJob(ctx, pz, h2o, job, jobName)
+	}
+
+	sourceFrames, frameName, err := s.importDatasource(h2o, datasource, wait)
+	if err != nil {
+		return 0, err
+	}
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+
+	// Tables arrive parsed
+	if frameName == "" {
+		parseSetupBody, err := h2o.PostParseSetupGuesssetup(sourceFrames)
+		if err != nil {
+			return 0, errors.Wrap(err, "failed guessing parse setup")
+		}
+
+		parseParms := bindings.NewParseV3()
+		parseParms.FromParseSetup(*parseSetupBody)
+		parseBody, err := h2o.PostParseParse(parseParms)
+		if err != nil {
+			return 0, errors.Wrap(err, "failed starting parse")
+		}
+
+		s.setDatasetJobState(pz, job, data.ImportParsingState, parseBody.Job.Key.Name, 0)
+		parsed, err := wait(parseBody.Job.Key.Name)
+		if err != nil {
+			return 0, err
+		}
+		frameName = parsed.Dest.Name
+	}
+
+	properties, _, err := h2o.GetFramesFetch(frameName, false)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed reading parsed frame")
+	}
+	rowCount, columnHash := datasetFingerprint(string(properties))
+	if job.ResponseColumnName != "" {
+		columns, err := toDatasetColumns(0, string(properties))
+		if err != nil {
+			return 0, err
+		}
+		if err := checkColumnRole(job.Name, columns, job.ResponseColumnName, data.ResponseColumnRole); err != nil {
+			return 0, err
+		}
+	}
+
+	dataset := data.Dataset{
+		0,
+		datasource.Id,
+		cluster.Id,
+		sql.NullInt64{},
+		0,
+		sql.NullInt64{},
+		rowCount,
+		columnHash,
+		s.datasourceChecksum(datasource, string(properties)),
+		job.Name,
+		job.Description,
+		frameName,
+		job.ResponseColumnName,
+		string(properties),
+		"1",
+		time.Now(),
+		false,
+	}
+
+	datasetId, err := s.ds.CreateDataset(pz, dataset)
+	if err != nil {
+		return 0, err
+	}
+	s.profileDataset(pz, datasetId, dataset.Properties)
+
+	if job.CompareWithPrevious {
+		if dataset, err = s.ds.ReadDataset(pz, datasetId); err != nil {
+			return 0, err
+		}
+		s.compareWithPrevious(pz, dataset)
+	}
+
+	return datasetId, nil
+}
+
+// waitForDatasetJob follows an H2O job of a dataset import until it ends,
+// recording its progress. The H2O job is cancelled if ctx is.
+func (s *Service) waitForDatasetJob(ctx context.Context, pz az.Principal, h2o *h2ov3.H2O, job *data.DatasetJob, jobName string) (*bindings.JobV3, error) {
+	s.setDatasetJobState(pz, job, job.State, jobName, 0)
+
+	ticker := time.NewTicker(datasetJobPollInterval)
+	defer ticker.Stop()
+
+	for {
+		j, err := h2o.GetJobsFetch(jobName)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed reading job")
+		}
+		if len(j.Jobs) == 0 {
+			return nil, fmt.Errorf("Job %s not found", jobName)
+		}
+
+		p := toJobProgress(j.Jobs[0])
+		if p.Done() {
+			switch {
+			case p.Exception != "":
+				return nil, fmt.Errorf("H2O job %s failed: %s", jobName, p.Exception)
+			case p.Status != "DONE":
+				return nil, fmt.Errorf("H2O job %s ended as %s", jobName, p.Status)
+			}
+			return j.Jobs[0], nil
+		}
+		if float64(p.Progress) != job.Progress {
+			s.setDatasetJobState(pz, job, job.State, jobName, float64(p.Progress))
+		}
+
+		select {
+		case <-ctx.Done():
+			if _, err := h2o.PostJobsCancel(jobName); err != nil {
+				log.Printf("Failed cancelling H2O job %s of dataset job %d: %v\n", jobName, job.Id, err)
+			}
+			return nil, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *Service) GetDatasetJob(pz az.Principal, jobId int64) (*web.DatasetJob, error) {
+	if err := pz.CheckPermission(s.ds.Permissions.ViewDataset); err != nil {
+		return nil, err
+	}
+
+	job, err := s.ds.ReadDatasetJob(pz, jobId)
+	if err != nil {
+		return nil, err
+	}
+
+	return toDatasetJob(job), nil
+}
+
+func (s *Service) GetDatasetJobs(pz az.Principal, datasourceId, offset, limit int64) ([]*web.DatasetJob, error) {
+	if err := pz.CheckPermission(s.ds.Permissions.ViewDataset); err != nil {
+		return nil, err
+	}
+
+	jobs, err := s.ds.ReadDatasetJobs(pz, datasourceId, offset, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	array := make([]*web.DatasetJob, len(jobs))
+	for i, job := range jobs {
+		array[i] = toDatasetJob(job)
+	}
+	return array, nil
+}
+
+// CancelDatasetJob stops a queued or running dataset import, along with the
+// H2O job it is waiting for.
+func (s *Service) CancelDatasetJob(pz az.Principal, jobId int64) error {
+	if err := pz.CheckPermission(s.ds.Permissions.ManageDataset); err != nil {
+		return err
+	}
+
+	job, err := s.ds.ReadDatasetJob(pz, jobId)
+	if err != nil {
+		return err
+	}
+	if job.Finished.Valid {
+		return fmt.Errorf("Dataset job %d has already ended as %s", jobId, job.State)
+	}
+
+	s.datasetJobsMu.Lock()
+	cancel, ok := s.datasetJobs[jobId]
+	s.datasetJobsMu.Unlock()
+
+	if ok {
+		cancel()
+	} else {
+		// Not running in this master; settle it here
+		job.State = data.ImportCancelledState
+		if _, err := s.ds.UpdateDatasetJob(pz, job); err != nil {
+			return err
+		}
+	}
+
+	return s.ds.AuditDatasetJobCancel(pz, job.DatasourceId, jobId)
+}
+
+// RecoverDatasetJobs fails dataset jobs left unfinished by a previous run of
+// the master.
+func (s *Service) RecoverDatasetJobs() error {
+	pz, err := s.ds.LookupSuperuser()
+	if err != nil {
+		return errors.Wrap(err, "failed reading superuser")
+	}
+
+	jobs, err := s.ds.ReadUnfinishedDatasetJobs(pz)
+	if err != nil {
+		return errors.Wrap(err, "failed reading dataset jobs")
+	}
+
+	for _, job := range jobs {
+		log.Printf("Dataset job %d was interrupted; marking %s\n", job.Id, data.ImportFailedState)
+		job.State = data.ImportFailedState
+		job.Error = "Interrupted by master restart"
+		if _, err := s.ds.UpdateDatasetJob(pz, job); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func toDatasetJob(job data.DatasetJob) *web.DatasetJob {
+	var finishedAt int64
+	if job.Finished.Valid {
+		finishedAt = toTimestamp(job.Finished.Time)
+	}
+	return &web.DatasetJob{
+		job.Id,
+		job.ClusterId,
+		job.DatasourceId,
+		job.DatasetId.Int64,
+		job.Name,
+		job.State,
+		job.JobName,
+		job.Progress,
+		job.Error,
+		toTimestamp(job.Created),
+		finishedAt,
+	}
+}
@@ -0,0 +1,267 @@
+/*
+  Copyright (C) 2016 H2O.ai, Inc. <http://h2o.ai/>
+
+  This program is free software: you can redistribute it and/or modify
+  it under the terms of the GNU Affero General Public License as
+  published by the Free Software Foundation, either version 3 of the
+  License, or (at your option) any later version.
+
+  This program is distributed in the hope that it will be useful,
+  but WITHOUT ANY WARRANTY; without even the implied warranty of
+  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+  GNU Affero General Public License for more details.
+
+  You should have received a copy of the GNU Affero General Public License
+  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package web
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"path"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/h2oai/steam/master/az"
+	"github.com/h2oai/steam/master/data"
+	"github.com/h2oai/steam/srv/web"
+	"github.com/lib/pq"
+)
+
+// newFakeImports serves a cloud that imports and parses files. The parse job
+// ends with outcome ("DONE" or "FAILED"), or keeps running until cancelled if
+// outcome is empty.
+func newFakeImports(outcome string) (*fakeH2O, func() bool) {
+	var cancelled bool
+	job := func() map[string]interface{} {
+		j := map[string]interface{}{
+			"key":      map[string]string{"name": "parse1"},
+			"status":   "RUNNING",
+			"progress": 0.5,
+			"dest":     map[string]string{"name": "airlines.hex"},
+		}
+		switch {
+		case cancelled:
+			j["status"] = "CANCELLED"
+		case outcome == "FAILED":
+			j["status"], j["exception"] = outcome, "bad CSV"
+		case outcome != "":
+			j["status"], j["progress"] = outcome, 1
+		}
+		return j
+	}
+	h2o := newFakeH2O()
+	h2o.handleJSON("/3/ImportFiles", map[string]interface{}{"destination_frames": []string{"nfs://data/airlines.csv"}})
+	h2o.handleJSON("/3/ParseSetup", map[string]interface{}{
+		"_exclude_fields": "",
+		"source_frames":   []interface{}{map[string]string{"name": "nfs://data/airlines.csv"}},
+	})
+	h2o.handleJSON("/3/Parse", map[string]interface{}{"job": map[string]interface{}{"key": map[string]string{"name": "parse1"}}})
+	h2o.handle("/3/Jobs/parse1", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]interface{}{"jobs": []interface{}{job()}})
+	})
+	h2o.handle("/3/Jobs/parse1/cancel", func(w http.ResponseWriter, r *http.Request) {
+		cancelled = true
+		json.NewEncoder(w).Encode(map[string]interface{}{"jobs": []interface{}{job()}})
+	})
+	h2o.handleJSON("/3/Frames", map[string]interface{}{"frames": []interface{}{}})
+	h2o.handleJSON("/3/Models", map[string]interface{}{"models": []interface{}{}})
+	h2o.handleJSON("/3/Frames/airlines.hex/summary", map[string]interface{}{"frames": []interface{}{map[string]interface{}{
+		"rows": 10,
+		"columns": []interface{}{map[string]interface{}{
+			"label": "Origin", "type": "enum", "domain": []string{"ORD", "SFO"}, "domain_cardinality": 2,
+			"histogram_bins": []int{4, 6}, "histogram_base": 0, "histogram_stride": 1,
+		}},
+	}}})
+	h2o.handleJSON("/3/Frames/airlines.hex", map[string]interface{}{"frames": []interface{}{map[string]interface{}{
+		"rows":    10,
+		"columns": []interface{}{map[string]string{"label": "Origin", "type": "enum"}},
+	}}})
+	return h2o, func() bool {
+		h2o.mu.Lock()
+		defer h2o.mu.Unlock()
+		return cancelled
+	}
+}
+
+func startDatasetJob(t *testing.T, svc *Service, su az.Principal, h2o *fakeH2O) int64 {
+	clusterId, err := svc.RegisterCluster(su, h2o.address(), "", "", "", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	projectId, err := svc.CreateProject(su, "p1", "d1", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	datasourceId, err := svc.CreateDatasource(su, projectId, "airlines", "", "file", `{"path": "/data/airlines.csv"}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	jobId, err := svc.CreateDataset(su, clusterId, datasourceId, "airlines", "", "Origin", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return jobId
+}
+
+func waitForDatasetJob(t *testing.T, svc *Service, su az.Principal, jobId int64, until func(*web.DatasetJob) bool) *web.DatasetJob {
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		job, err := svc.GetDatasetJob(su, jobId)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if until(job) {
+			return job
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for dataset job: %+v", job)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func finished(job *web.DatasetJob) bool { return job.FinishedAt != 0 }
+
+func init() {
+	datasetJobPollInterval = 5 * time.Millisecond
+}
+
+func TestDatasetJob(t *testing.T) {
+	svc, su, cleanup := newSandbox(t)
+	defer cleanup()
+
+	h2o, _ := newFakeImports("DONE")
+	defer h2o.Close()
+
+	job := waitForDatasetJob(t, svc, su, startDatasetJob(t, svc, su, h2o), finished)
+	if job.State != data.ImportDoneState || job.DatasetId == 0 || job.ClusterJobName != "parse1" || job.Progress != 1 {
+		t.Fatalf("unexpected job: %+v", job)
+	}
+
+	dataset, err := svc.GetDataset(su, job.DatasetId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if dataset.FrameName != "airlines.hex" || dataset.ResponseColumnName != "Origin" || dataset.RowCount != 10 {
+		t.Fatalf("unexpected dataset: %+v", dataset)
+	}
+	if !strings.HasPrefix(dataset.Checksum, "stats:") {
+		t.Fatalf("expected a file import to be fingerprinted by its statistics, got %q", dataset.Checksum)
+	}
+
+	jobs, err := svc.GetDatasetJobs(su, dataset.DatasourceId, 0, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(jobs) != 1 || jobs[0].Id != job.Id {
+		t.Fatalf("unexpected jobs: %+v", jobs)
+	}
+}
+
+func TestDatasetJobParseFailure(t *testing.T) {
+	svc, su, cleanup := newSandbox(t)
+	defer cleanup()
+
+	h2o, _ := newFakeImports("FAILED")
+	defer h2o.Close()
+
+	job := waitForDatasetJob(t, svc, su, startDatasetJob(t, svc, su, h2o), finished)
+	if job.State != data.ImportFailedState || job.DatasetId != 0 || !strings.Contains(job.Error, "bad CSV") {
+		t.Fatalf("expected the parse failure on the job: %+v", job)
+	}
+	if err := svc.CancelDatasetJob(su, job.Id); err == nil {
+		t.Fatal("expected a finished job not to be cancellable")
+	}
+}
+
+func TestCancelDatasetJob(t *testing.T) {
+	svc, su, cleanup := newSandbox(t)
+	defer cleanup()
+
+	h2o, cancelled := newFakeImports("")
+	defer h2o.Close()
+
+	jobId := startDatasetJob(t, svc, su, h2o)
+	waitForDatasetJob(t, svc, su, jobId, func(job *web.DatasetJob) bool {
+		return job.State == data.ImportParsingState && job.Progress > 0
+	})
+
+	if err := svc.CancelDatasetJob(su, jobId); err != nil {
+		t.Fatal(err)
+	}
+	job := waitForDatasetJob(t, svc, su, jobId, finished)
+	if job.State != data.ImportCancelledState || job.DatasetId != 0 {
+		t.Fatalf("expected the job to be cancelled: %+v", job)
+	}
+	if !cancelled() {
+		t.Fatal("expected the parse to be cancelled on the cluster")
+	}
+}
+
+func TestRecoverDatasetJobs(t *testing.T) {
+	svc, su, cleanup := newSandbox(t)
+	defer cleanup()
+
+	projectId, err := svc.CreateProject(su, "p1", "d1", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	datasourceId, err := svc.CreateDatasource(su, projectId, "airlines", "", "file", `{"path": "/data/airlines.csv"}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// A job queued by a master that has since stopped
+	jobId, err := svc.ds.CreateDatasetJob(su, data.DatasetJob{0, datasourceId, 0, sql.NullInt64{}, "airlines", "", "", false, data.ImportQueuedState, "", 0, "", time.Now(), pq.NullTime{}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := svc.RecoverDatasetJobs(); err != nil {
+		t.Fatal(err)
+	}
+	job, err := svc.GetDatasetJob(su, jobId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if job.State != data.ImportFailedState || job.FinishedAt == 0 || job.Error == "" {
+		t.Fatalf("expected the interrupted job to fail: %+v", job)
+	}
+}
+
+func TestDatasourceChecksumFile(t *testing.T) {
+	svc, su, cleanup := newSandbox(t)
+	defer cleanup()
+
+	// File paths are paths on the cluster; a file of the same name on the
+	// master must not be read
+	local := path.Join(svc.workingDir, "airlines.csv")
+	if err := ioutil.WriteFile(local, []byte("Origin\nSFO\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	projectId, err := svc.CreateProject(su, "p1", "d1", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	datasourceId, err := svc.CreateDatasource(su, projectId, "airlines", "", "file", `{"path": "`+local+`"}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	datasource, err := svc.ds.ReadDatasource(su, datasourceId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if checksum := svc.datasourceChecksum(datasource, ""); checksum != "" {
+		t.Fatalf("expected no checksum without frame statistics, got %q", checksum)
+	}
+	properties := fmt.Sprintf(profileFrame, "")
+	if checksum := svc.datasourceChecksum(datasource, properties); checksum != "stats:"+datasetStatistics(properties) {
+		t.Fatalf("unexpected checksum %q", checksum)
+	}
+}
@@ -92,7 +92,7 @@ func TestExternalClusterCRUD(tt *testing.T) {
 
 	// -- C --
 
-	id, err := t.svc.RegisterCluster(t.su, ClusterAddress)
+	id, err := t.svc.RegisterCluster(t.su, ClusterAddress, "", "", "", "")
 	t.nil(err)
 
 	// -- R --
@@ -123,17 +123,17 @@ func TestDatasourceCRUD(tt *testing.T) {
 	const (
 		name1 = "datasource1"
 		desc1 = "first description"
-		path1 = "dummy/path1"
+		path1 = "/dummy/path1"
 		name2 = "datasource2"
 		desc2 = "second description"
-		path2 = "dummy/path2"
+		path2 = "/dummy/path2"
 	)
 
 	// -- C --
 
 	projectId, err := t.svc.CreateProject(t.su, "p1", "d1", "") // This is not being tested here
 
-	id, err := t.svc.CreateDatasource(t.su, projectId, name1, desc1, path1)
+	id, err := t.svc.CreateDatasource(t.su, projectId, name1, desc1, "file", `{"path": "`+path1+`"}`)
 	t.nil(err)
 
 	// -- R --
@@ -143,7 +143,7 @@ func TestDatasourceCRUD(tt *testing.T) {
 
 	t.ok(name1 == datasource.Name, "name")
 	t.ok(desc1 == datasource.Description, "description")
-	// UNMARSHALL JSON t.ok(path1 == datasource.Configuration, "configuration")
+	t.ok(path1 == datasource.Config.Path, "configuration")
 
 	datasources, err := t.svc.GetDatasources(t.su, projectId, 0, 1000)
 	t.nil(err)
@@ -151,11 +151,11 @@ func TestDatasourceCRUD(tt *testing.T) {
 	t.ok(len(datasources) == 1, "datasource count")
 	t.ok(name1 == datasources[0].Name, "multi name")
 	t.ok(desc1 == datasources[0].Description, "multi description")
-	// UNMARSHAL JSON t.ok(path1 == datasources[0].Configuration, "configuration")
+	t.ok(path1 == datasources[0].Config.Path, "configuration")
 
 	// -- U --
 
-	err = t.svc.UpdateDatasource(t.su, id, name2, desc2, path2)
+	err = t.svc.UpdateDatasource(t.su, id, name2, desc2, "file", `{"path": "`+path2+`"}`)
 	t.nil(err)
 
 	datasource, err = t.svc.GetDatasource(t.su, id)
@@ -163,7 +163,7 @@ func TestDatasourceCRUD(tt *testing.T) {
 
 	t.ok(name2 == datasource.Name, "updated name")
 	t.ok(desc2 == datasource.Description, "updated description")
-	// UNMARSHAL JSON t.ok(path2 == datasource.Configuration, "updated configuration")
+	t.ok(path2 == datasource.Config.Path, "updated configuration")
 
 	// -- D --
 
@@ -0,0 +1,136 @@
+/*
+  Copyright (C) 2016 H2O.ai, Inc. <http://h2o.ai/>
+
+  This program is free software: you can redistribute it and/or modify
+  it under the terms of the GNU Affero General Public License as
+  published by the Free Software Foundation, either version 3 of the
+  License, or (at your option) any later version.
+
+  This program is distributed in the hope that it will be useful,
+  but WITHOUT ANY WARRANTY; without even the implied warranty of
+  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+  GNU Affero General Public License for more details.
+
+  You should have received a copy of the GNU Affero General Public License
+  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package web
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/h2oai/steam/bindings"
+	"github.com/h2oai/steam/master/az"
+	"github.com/pkg/errors"
+)
+
+// JobProgress is a snapshot of an H2O job, as streamed to job watchers.
+type JobProgress struct {
+	Name        string  `json:"name"`
+	Description string  `json:"description"`
+	Status      string  `json:"status"`
+	Progress    float32 `json:"progress"`
+	ProgressMsg string  `json:"progress_msg"`
+	Exception   string  `json:"exception,omitempty"`
+	StartedAt   int64   `json:"started_at"`
+	CompletedAt int64   `json:"completed_at"`
+}
+
+// Done reports whether the job has stopped running.
+func (p JobProgress) Done() bool {
+	return p.Status != "CREATED" && p.Status != "RUNNING"
+}
+
+func toJobProgress(j *bindings.JobV3) JobProgress {
+	var end int64
+	if j.Status == "DONE" {
+		end = j.StartTime + j.Msec
+	}
+	return JobProgress{
+		j.Key.Name,
+		j.Description,
+		j.Status,
+		j.Progress,
+		j.ProgressMsg,
+		j.Exception,
+		j.StartTime,
+		end,
+	}
+}
+
+func (s *Service) CancelJob(pz az.Principal, clusterId int64, jobName string) error {
+	if err := pz.CheckPermission(s.ds.Permissions.ViewCluster); err != nil {
+		return err
+	}
+
+	cluster, err := s.ds.ReadCluster(pz, clusterId)
+	if err != nil {
+		return err
+	}
+	if err := pz.CheckEdit(s.ds.EntityTypes.Cluster, clusterId); err != nil {
+		return err
+	}
+
+	h, err := s.h2oClient(cluster)
+	if err != nil {
+		return err
+	}
+	if _, err := h.PostJobsCancel(jobName); err != nil {
+		return errors.Wrap(err, "failed cancelling job")
+	}
+
+	return s.ds.AuditJobCancel(pz, clusterId, jobName)
+}
+
+// WatchJob polls an H2O job every interval, calling emit with the job's state
+// whenever its status or progress changes. It returns once the job is done,
+// ctx is cancelled, or emit fails.
+func (s *Service) WatchJob(ctx context.Context, pz az.Principal, clusterId int64, jobName string, interval time.Duration, emit func(JobProgress) error) error {
+	if err := pz.CheckPermission(s.ds.Permissions.ViewCluster); err != nil {
+		return err
+	}
+
+	cluster, err := s.ds.ReadCluster(pz, clusterId)
+	if err != nil {
+		return err
+	}
+
+	// Each poll times out, so a cluster that stops answering ends the watch
+	h, err := s.h2oCheckClient(cluster)
+	if err != nil {
+		return err
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	var last *JobProgress
+	for {
+		j, err := h.GetJobsFetch(jobName)
+		if err != nil {
+			return errors.Wrap(err, "failed reading job")
+		}
+		if len(j.Jobs) == 0 {
+			return fmt.Errorf("Job %s not found", jobName)
+		}
+
+		p := toJobProgress(j.Jobs[0])
+		if last == nil || p.Status != last.Status || p.Progress != last.Progress || p.ProgressMsg != last.ProgressMsg {
+			if err := emit(p); err != nil {
+				return err
+			}
+			last = &p
+		}
+		if p.Done() {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
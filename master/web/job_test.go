@@ -0,0 +1,133 @@
+/*
+  Copyright (C) 2016 H2O.ai, Inc. <http://h2o.ai/>
+
+  This program is free software: you can redistribute it and/or modify
+  it under the terms of the GNU Affero General Public License as
+  published by the Free Software Foundation, either version 3 of the
+  License, or (at your option) any later version.
+
+  This program is distributed in the hope that it will be useful,
+  but WITHOUT ANY WARRANTY; without even the implied warranty of
+  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+  GNU Affero General Public License for more details.
+
+  You should have received a copy of the GNU Affero General Public License
+  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package web
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/h2oai/steam/master/data"
+)
+
+// fakeJobs serves a cloud with a single job that advances on every poll until
+// it is done or cancelled.
+func newFakeJobs() (*fakeH2O, func() bool) {
+	var (
+		polls     int
+		cancelled bool
+	)
+	job := func() map[string]interface{} {
+		status, progress := "RUNNING", float32(polls)/4
+		if cancelled {
+			status = "CANCELLED"
+		} else if polls >= 4 {
+			status, progress = "DONE", 1
+		}
+		return map[string]interface{}{
+			"key":          map[string]string{"name": "job1"},
+			"description":  "GBM",
+			"status":       status,
+			"progress":     progress,
+			"progress_msg": "Building",
+		}
+	}
+	h2o := newFakeH2O()
+	h2o.handle("/3/Jobs/job1/cancel", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" {
+			http.NotFound(w, r)
+			return
+		}
+		cancelled = true
+		json.NewEncoder(w).Encode(map[string]interface{}{"jobs": []interface{}{job()}})
+	})
+	h2o.handle("/3/Jobs/job1", func(w http.ResponseWriter, r *http.Request) {
+		polls++
+		json.NewEncoder(w).Encode(map[string]interface{}{"jobs": []interface{}{job()}})
+	})
+	return h2o, func() bool {
+		h2o.mu.Lock()
+		defer h2o.mu.Unlock()
+		return cancelled
+	}
+}
+
+func TestWatchJob(t *testing.T) {
+	svc, su, cleanup := newSandbox(t)
+	defer cleanup()
+
+	h2o, _ := newFakeJobs()
+	defer h2o.Close()
+
+	clusterId, err := svc.RegisterCluster(su, h2o.address(), "", "", "", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var updates []JobProgress
+	err = svc.WatchJob(context.Background(), su, clusterId, "job1", time.Millisecond, func(p JobProgress) error {
+		updates = append(updates, p)
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(updates) != 4 {
+		t.Fatalf("expected 4 updates, got %d", len(updates))
+	}
+	if last := updates[len(updates)-1]; last.Status != "DONE" || !last.Done() {
+		t.Fatalf("expected job to end done, got %+v", last)
+	}
+}
+
+func TestCancelJob(t *testing.T) {
+	svc, su, cleanup := newSandbox(t)
+	defer cleanup()
+
+	h2o, cancelled := newFakeJobs()
+	defer h2o.Close()
+
+	clusterId, err := svc.RegisterCluster(su, h2o.address(), "", "", "", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := svc.CancelJob(su, clusterId, "job1"); err != nil {
+		t.Fatal(err)
+	}
+	if !cancelled() {
+		t.Fatal("expected the job to be cancelled on the cluster")
+	}
+
+	history, err := svc.GetHistory(su, svc.ds.EntityTypes.Cluster, clusterId, 0, 100)
+	if err != nil {
+		t.Fatal(err)
+	}
+	found := false
+	for _, h := range history {
+		if h.Action == data.CancelOp && strings.Contains(h.Description, "job1") {
+			found = true
+		}
+	}
+	if !found {
+		t.Fatal("expected the cancellation to be audited")
+	}
+}
@@ -0,0 +1,105 @@
+/*
+  Copyright (C) 2016 H2O.ai, Inc. <http://h2o.ai/>
+
+  This program is free software: you can redistribute it and/or modify
+  it under the terms of the GNU Affero General Public License as
+  published by the Free Software Foundation, either version 3 of the
+  License, or (at your option) any later version.
+
+  This program is distributed in the hope that it will be useful,
+  but WITHOUT ANY WARRANTY; without even the implied warranty of
+  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+  GNU Affero General Public License for more details.
+
+  You should have received a copy of the GNU Affero General Public License
+  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package web
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/h2oai/steam/master/az"
+	"github.com/h2oai/steam/master/data"
+	"github.com/h2oai/steam/srv/web"
+	"github.com/pkg/errors"
+)
+
+// Keytabs are uploaded through the /upload handler, which checks that they
+// hold a principal of the uploading identity. Each identity can use only the
+// keytabs it uploaded; keytabs are never shared.
+
+func (s *Service) GetKeytabs(pz az.Principal) ([]*web.Keytab, error) {
+	if err := pz.CheckPermission(s.ds.Permissions.ViewCluster); err != nil {
+		return nil, err
+	}
+
+	keytabs, err := s.ds.ReadKeytabs(pz)
+	if err != nil {
+		return nil, err
+	}
+
+	ks := make([]*web.Keytab, len(keytabs))
+	for i, k := range keytabs {
+		ks[i] = toKeytab(k)
+	}
+	return ks, nil
+}
+
+func (s *Service) DeleteKeytab(pz az.Principal, keytabId int64) error {
+	if err := pz.CheckPermission(s.ds.Permissions.ManageCluster); err != nil {
+		return err
+	}
+
+	keytab, err := s.ds.ReadKeytab(pz, keytabId)
+	if err != nil {
+		return err
+	}
+
+	// Running clusters still need the keytab to be stopped
+	n, err := s.ds.CountActiveKeytabClusters(pz, keytabId)
+	if err != nil {
+		return err
+	}
+	if n > 0 {
+		return fmt.Errorf("Keytab %s is in use by %d running clusters", keytab.Name, n)
+	}
+
+	if err := s.ds.DeleteKeytab(pz, keytabId); err != nil {
+		return err
+	}
+
+	if err := os.Remove(keytab.Location); err != nil && !os.IsNotExist(err) {
+		return errors.Wrap(err, "failed removing keytab file")
+	}
+	return nil
+}
+
+// resolveKeytab returns the location of a keytab owned by the caller, or an
+// empty path if no keytab is given.
+func (s *Service) resolveKeytab(pz az.Principal, keytabId int64) (string, error) {
+	if keytabId == 0 {
+		return "", nil
+	}
+
+	keytab, err := s.ds.ReadKeytab(pz, keytabId)
+	if err != nil {
+		return "", err
+	}
+
+	if _, err := os.Stat(keytab.Location); err != nil {
+		return "", fmt.Errorf("Keytab %s is missing on the server; upload it again", keytab.Name)
+	}
+	return keytab.Location, nil
+}
+
+func toKeytab(k data.Keytab) *web.Keytab {
+	return &web.Keytab{
+		k.Id,
+		k.Name,
+		k.Principal,
+		toTimestamp(k.Created),
+	}
+}
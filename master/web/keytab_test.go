@@ -0,0 +1,179 @@
+/*
+  Copyright (C) 2016 H2O.ai, Inc. <http://h2o.ai/>
+
+  This program is free software: you can redistribute it and/or modify
+  it under the terms of the GNU Affero General Public License as
+  published by the Free Software Foundation, either version 3 of the
+  License, or (at your option) any later version.
+
+  This program is distributed in the hope that it will be useful,
+  but WITHOUT ANY WARRANTY; without even the implied warranty of
+  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+  GNU Affero General Public License for more details.
+
+  You should have received a copy of the GNU Affero General Public License
+  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package web
+
+import (
+	"io/ioutil"
+	"os"
+	"path"
+	"testing"
+
+	"github.com/h2oai/steam/master/az"
+	"github.com/h2oai/steam/master/data"
+)
+
+func TestKeytabOwnership(t *testing.T) {
+	svc, su, cleanup := newSandbox(t)
+	defer cleanup()
+
+	roleId, err := svc.CreateRole(su, "launcher", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := svc.LinkRoleWithPermissions(su, roleId, []int64{
+		svc.ds.Permissions.ManageCluster,
+		svc.ds.Permissions.ViewCluster,
+	}); err != nil {
+		t.Fatal(err)
+	}
+	users := make(map[string]az.Principal)
+	for _, name := range []string{"alice", "bob"} {
+		id, err := svc.CreateIdentity(su, name, "password")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := svc.LinkIdentityWithRole(su, id, roleId); err != nil {
+			t.Fatal(err)
+		}
+		if users[name], err = svc.ds.Lookup(name); err != nil {
+			t.Fatal(err)
+		}
+	}
+	alice, bob := users["alice"], users["bob"]
+
+	location := path.Join(svc.workingDir, "alice.keytab")
+	if err := ioutil.WriteFile(location, []byte("keytab"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	keytabId, err := svc.ds.CreateKeytab(alice, "alice.keytab", "alice@EXAMPLE.COM", location)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if p, err := svc.resolveKeytab(alice, keytabId); err != nil || p != location {
+		t.Fatalf("expected %s, got %q (%v)", location, p, err)
+	}
+	if _, err := svc.resolveKeytab(bob, keytabId); err == nil {
+		t.Fatal("expected another identity's keytab to be rejected")
+	}
+	if keytabs, err := svc.GetKeytabs(bob); err != nil || len(keytabs) != 0 {
+		t.Fatalf("expected no keytabs for bob, got %v (%v)", keytabs, err)
+	}
+	if err := svc.DeleteKeytab(bob, keytabId); err == nil {
+		t.Fatal("expected another identity's keytab not to be deleted")
+	}
+	if err := svc.ShareEntity(alice, data.CanView, alice.WorkgroupId(), svc.ds.EntityTypes.Keytab, keytabId); err == nil {
+		t.Fatal("expected keytabs not to be shareable")
+	}
+
+	keytabs, err := svc.GetKeytabs(alice)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(keytabs) != 1 || keytabs[0].Principal != "alice@EXAMPLE.COM" {
+		t.Fatalf("unexpected keytabs: %+v", keytabs)
+	}
+	if err := svc.DeleteKeytab(alice, keytabId); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(location); !os.IsNotExist(err) {
+		t.Fatal("expected keytab file to be removed")
+	}
+}
+
+func TestStopClusterOnYarnKeytab(t *testing.T) {
+	svc, su, cleanup := newSandbox(t)
+	defer cleanup()
+
+	launcher := NewFakeLauncher("localhost:54321")
+	clusterId := startIdleCluster(t, svc, su, launcher)
+
+	roleId, err := svc.CreateRole(su, "operator", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := svc.LinkRoleWithPermissions(su, roleId, []int64{
+		svc.ds.Permissions.ManageCluster,
+		svc.ds.Permissions.ViewCluster,
+	}); err != nil {
+		t.Fatal(err)
+	}
+	bobId, err := svc.CreateIdentity(su, "bob", "password")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := svc.LinkIdentityWithRole(su, bobId, roleId); err != nil {
+		t.Fatal(err)
+	}
+	bob, err := svc.ds.Lookup("bob")
+	if err != nil {
+		t.Fatal(err)
+	}
+	location := path.Join(svc.workingDir, "bob.keytab")
+	if err := ioutil.WriteFile(location, []byte("keytab"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	keytabId, err := svc.ds.CreateKeytab(bob, "bob.keytab", "bob@EXAMPLE.COM", location)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Editing a cluster is not enough to stop it, even with a keytab
+	if err := svc.ShareEntity(su, data.CanEdit, bob.WorkgroupId(), svc.ds.EntityTypes.Cluster, clusterId); err != nil {
+		t.Fatal(err)
+	}
+	if err := svc.StopClusterOnYarn(bob, clusterId, keytabId); err == nil {
+		t.Fatal("expected stopping a cluster without owning it to be refused")
+	}
+	if stopped := launcher.Stopped(); len(stopped) != 0 {
+		t.Fatalf("expected the cluster to keep running, got %v", stopped)
+	}
+
+	if err := svc.ShareEntity(su, data.Owns, bob.WorkgroupId(), svc.ds.EntityTypes.Cluster, clusterId); err != nil {
+		t.Fatal(err)
+	}
+
+	// Another identity cannot borrow the launcher's credentials
+	if err := svc.StopClusterOnYarn(bob, clusterId, 0); err == nil {
+		t.Fatal("expected stopping another identity's cluster without a keytab to be refused")
+	}
+	if stopped := launcher.Stopped(); len(stopped) != 0 {
+		t.Fatalf("expected the cluster to keep running, got %v", stopped)
+	}
+
+	if err := svc.StopClusterOnYarn(bob, clusterId, keytabId); err != nil {
+		t.Fatal(err)
+	}
+	if stopped := launcher.Stopped(); len(stopped) != 1 {
+		t.Fatalf("expected the cluster to be stopped, got %v", stopped)
+	}
+}
+
+func TestStopClusterOnYarnAsLauncher(t *testing.T) {
+	svc, su, cleanup := newSandbox(t)
+	defer cleanup()
+
+	launcher := NewFakeLauncher("localhost:54321")
+	clusterId := startIdleCluster(t, svc, su, launcher)
+	if err := svc.StopClusterOnYarn(su, clusterId, 0); err != nil {
+		t.Fatal(err)
+	}
+	if stopped := launcher.Stopped(); len(stopped) != 1 {
+		t.Fatalf("expected the cluster to be stopped, got %v", stopped)
+	}
+}
@@ -0,0 +1,277 @@
+/*
+  Copyright (C) 2016 H2O.ai, Inc. <http://h2o.ai/>
+
+  This program is free software: you can redistribute it and/or modify
+  it under the terms of the GNU Affero General Public License as
+  published by the Free Software Foundation, either version 3 of the
+  License, or (at your option) any later version.
+
+  This program is distributed in the hope that it will be useful,
+  but WITHOUT ANY WARRANTY; without even the implied warranty of
+  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+  GNU Affero General Public License for more details.
+
+  You should have received a copy of the GNU Affero General Public License
+  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package web
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/h2oai/steam/lib/fs"
+	"github.com/h2oai/steam/lib/yarn"
+	"github.com/h2oai/steam/master/az"
+	"github.com/h2oai/steam/master/data"
+	"github.com/h2oai/steam/srv/web"
+	"github.com/pkg/errors"
+)
+
+func (s *Service) clusterLaunchLogPath(clusterId int64) string {
+	return fs.GetJobLogFilePath(s.workingDir, "cluster-"+strconv.FormatInt(clusterId, 10), "launch")
+}
+
+// launchCluster starts the launch of a cluster recorded in StartingState.
+// The launcher output is streamed to the cluster's launch log; the cluster
+// moves to started, failed or (if cancelled) stopped once the launch ends.
+func (s *Service) launchCluster(pz az.Principal, clusterId int64, launcher ClusterLauncher, spec LaunchSpec) error {
+	f, err := os.OpenFile(s.clusterLaunchLogPath(clusterId), os.O_CREATE|os.O_WRONLY|os.O_APPEND, fs.FilePerm)
+	if err != nil {
+		if err := s.ds.UpdateClusterState(pz, clusterId, data.FailedState); err != nil {
+			log.Printf("Failed updating state of cluster %d: %v\n", clusterId, err)
+		}
+		return errors.Wrap(err, "failed creating launch log")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	s.launchesMu.Lock()
+	s.launches[clusterId] = cancel
+	s.launchesMu.Unlock()
+
+	go func() {
+		defer f.Close()
+		defer func() {
+			s.launchesMu.Lock()
+			delete(s.launches, clusterId)
+			s.launchesMu.Unlock()
+			cancel()
+		}()
+
+		onAppId := func(appId string) {
+			if err := s.ds.UpdateYarnClusterApplication(pz, clusterId, appId); err != nil {
+				log.Printf("Failed recording application of cluster %d: %v\n", clusterId, err)
+			}
+		}
+
+		appId, address, out, err := launcher.Start(ctx, f, onAppId, spec)
+		if err == nil {
+			if err := s.ds.UpdateYarnClusterLaunch(pz, clusterId, address, data.StartedState, appId, out); err != nil {
+				log.Printf("Failed recording launch of cluster %d: %v\n", clusterId, err)
+			}
+			return
+		}
+
+		state := data.FailedState
+		if ctx.Err() != nil {
+			state = data.StoppedState
+			fmt.Fprintln(f, "Launch cancelled")
+		} else {
+			fmt.Fprintln(f, "Launch failed:", err)
+		}
+
+		// Don't leave a half-started application holding the queue
+		if appId != "" {
+			if err := launcher.Stop(spec, appId, out); err != nil {
+				log.Printf("Failed killing application of cluster %d: %v\n", clusterId, err)
+			}
+		}
+
+		if err := s.ds.UpdateClusterState(pz, clusterId, state); err != nil {
+			log.Printf("Failed updating state of cluster %d: %v\n", clusterId, err)
+		}
+	}()
+
+	return nil
+}
+
+func (s *Service) GetClusterLaunch(pz az.Principal, clusterId int64, logLines int) (*web.ClusterLaunch, error) {
+	if err := pz.CheckPermission(s.ds.Permissions.ViewCluster); err != nil {
+		return nil, err
+	}
+
+	cluster, err := s.ds.ReadCluster(pz, clusterId)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed reading cluster")
+	}
+	if _, ok := s.clusterLauncher(cluster.TypeId); !ok {
+		return nil, fmt.Errorf("Cluster %d was not started by Steam", clusterId)
+	}
+
+	yarnCluster, err := s.ds.ReadYarnCluster(pz, clusterId)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed reading yarn cluster")
+	}
+
+	launch := &web.ClusterLaunch{
+		cluster.Id,
+		cluster.State,
+		yarnCluster.ApplicationId,
+		int(yarnCluster.Size),
+		0,
+		"",
+		"",
+	}
+
+	// Clusters started before launches were logged have no launch log
+	logPath := s.clusterLaunchLogPath(clusterId)
+	f, err := os.Open(logPath)
+	if os.IsNotExist(err) {
+		return launch, nil
+	} else if err != nil {
+		return nil, errors.Wrap(err, "failed opening launch log")
+	}
+	defer f.Close()
+
+	report, err := yarn.ParseLaunchLog(f)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed reading launch log")
+	}
+	if launch.ApplicationId == "" {
+		launch.ApplicationId = report.ApplicationID
+	}
+	launch.NodesUp = len(report.Nodes)
+	launch.Errors = strings.Join(report.Errors, "\n")
+
+	launch.Log, err = fs.Tail(logPath, logLines)
+	if err != nil {
+		return nil, err
+	}
+
+	return launch, nil
+}
+
+// GetClusterLogs returns a page of the aggregated YARN logs of a cluster,
+// fetched as the identity that started it. Pages count back from the end of
+// the log: offset lines are skipped, and the tailLines before them returned.
+func (s *Service) GetClusterLogs(pz az.Principal, clusterId int64, containerId string, tailLines int, offset int64) (*web.ClusterLogs, error) {
+	if err := pz.CheckPermission(s.ds.Permissions.ViewCluster); err != nil {
+		return nil, err
+	}
+
+	cluster, err := s.ds.ReadCluster(pz, clusterId)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed reading cluster")
+	}
+	if cluster.TypeId != s.ds.ClusterTypes.Yarn {
+		return nil, fmt.Errorf("Cluster %d was not started through YARN", clusterId)
+	}
+
+	yarnCluster, err := s.ds.ReadYarnCluster(pz, clusterId)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed reading yarn cluster")
+	}
+	if yarnCluster.ApplicationId == "" {
+		return nil, fmt.Errorf("Cluster %d has no YARN application; see its launch log instead", clusterId)
+	}
+
+	if tailLines <= 0 {
+		tailLines = 100
+	} else if tailLines > yarn.MaxLogLines {
+		tailLines = yarn.MaxLogLines
+	}
+	if offset < 0 {
+		return nil, fmt.Errorf("Offset cannot be negative")
+	}
+	if offset > yarn.MaxLogOffset {
+		return nil, fmt.Errorf("Offset cannot exceed %d lines", yarn.MaxLogOffset)
+	}
+
+	page, err := yarn.ApplicationLogs(s.kerberosEnabled, yarnCluster.ApplicationId, strings.TrimSpace(containerId), yarnCluster.Username, s.launchedKeytabPath(yarnCluster.Keytab), int(offset), tailLines)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed fetching cluster logs")
+	}
+
+	return &web.ClusterLogs{
+		cluster.Id,
+		yarnCluster.ApplicationId,
+		strings.Join(page.Lines, "\n"),
+		int64(page.Total),
+		offset,
+	}, nil
+}
+
+func (s *Service) CancelClusterLaunch(pz az.Principal, clusterId int64) error {
+	if err := pz.CheckPermission(s.ds.Permissions.ManageCluster); err != nil {
+		return err
+	}
+
+	cluster, err := s.ds.ReadCluster(pz, clusterId)
+	if err != nil {
+		return errors.Wrap(err, "failed reading cluster")
+	}
+	if err := pz.CheckEdit(s.ds.EntityTypes.Cluster, clusterId); err != nil {
+		return err
+	}
+	if cluster.State != data.StartingState {
+		return fmt.Errorf("Cluster %d is not starting", clusterId)
+	}
+
+	s.launchesMu.Lock()
+	cancel, ok := s.launches[clusterId]
+	s.launchesMu.Unlock()
+	if !ok {
+		return fmt.Errorf("No launch in progress for cluster %d", clusterId)
+	}
+
+	cancel()
+	return nil
+}
+
+// RecoverClusterLaunches settles clusters left in StartingState by a previous
+// run of the master. Launches that never got an application ID are marked failed;
+// the rest are marked disconnected, so the health reconciler can determine
+// whether their application is still running.
+func (s *Service) RecoverClusterLaunches() error {
+	pz, err := s.ds.LookupSuperuser()
+	if err != nil {
+		return errors.Wrap(err, "failed reading superuser")
+	}
+
+	clusters, err := s.ds.ReadAllClusters(pz)
+	if err != nil {
+		return errors.Wrap(err, "failed reading clusters")
+	}
+
+	for _, cluster := range clusters {
+		if _, ok := s.clusterLauncher(cluster.TypeId); !ok || cluster.State != data.StartingState {
+			continue
+		}
+
+		yarnCluster, err := s.ds.ReadYarnCluster(pz, cluster.Id)
+		if err != nil {
+			return errors.Wrap(err, "failed reading yarn cluster")
+		}
+
+		state := data.DisconnectedState
+		if yarnCluster.ApplicationId == "" {
+			state = data.FailedState
+		}
+
+		if f, err := os.OpenFile(s.clusterLaunchLogPath(cluster.Id), os.O_CREATE|os.O_WRONLY|os.O_APPEND, fs.FilePerm); err == nil {
+			fmt.Fprintln(f, "Launch interrupted by master restart")
+			f.Close()
+		}
+
+		log.Printf("Cluster %s (%d) launch was interrupted; marking %s\n", cluster.Name, cluster.Id, state)
+		if err := s.ds.UpdateClusterState(pz, cluster.Id, state); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,204 @@
+/*
+  Copyright (C) 2016 H2O.ai, Inc. <http://h2o.ai/>
+
+  This program is free software: you can redistribute it and/or modify
+  it under the terms of the GNU Affero General Public License as
+  published by the Free Software Foundation, either version 3 of the
+  License, or (at your option) any later version.
+
+  This program is distributed in the hope that it will be useful,
+  but WITHOUT ANY WARRANTY; without even the implied warranty of
+  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+  GNU Affero General Public License for more details.
+
+  You should have received a copy of the GNU Affero General Public License
+  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package web
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"path"
+	"regexp"
+	"strconv"
+	"sync"
+
+	"github.com/h2oai/steam/lib/local"
+	"github.com/h2oai/steam/lib/yarn"
+)
+
+// Local clusters listen on DefaultLocalClusterHost, on ports from
+// DefaultLocalClusterBasePort up, unless the local launcher is set otherwise.
+const (
+	DefaultLocalClusterHost     = "127.0.0.1"
+	DefaultLocalClusterBasePort = 54321
+)
+
+// LaunchSpec describes a cluster to be started by a ClusterLauncher.
+type LaunchSpec struct {
+	Name       string
+	EnginePath string
+	Size       int
+	Memory     string
+	Username   string
+	KeytabPath string
+	Queue      string   // YARN queue
+	NodeLabel  string   // YARN node label expression
+	DriverArgs []string // Extra h2odriver arguments, vetted by the driver option policy
+}
+
+// ClusterLauncher starts and stops H2O clusters on a provisioning backend.
+type ClusterLauncher interface {
+	// Start launches a cluster, streaming launch output to w. onAppId is called
+	// as soon as the backend has assigned the cluster an application ID.
+	Start(ctx context.Context, w io.Writer, onAppId func(string), spec LaunchSpec) (appId, address, outputDir string, err error)
+	// Stop shuts down a cluster previously started by this launcher.
+	Stop(spec LaunchSpec, appId, outputDir string) error
+}
+
+// yarnLauncher starts clusters as YARN applications through h2odriver.
+type yarnLauncher struct {
+	kerberos bool
+}
+
+func (l *yarnLauncher) Start(ctx context.Context, w io.Writer, onAppId func(string), spec LaunchSpec) (string, string, string, error) {
+	return yarn.StartCloudContext(ctx, w, onAppId, spec.Size, l.kerberos, spec.Memory, spec.Name, spec.EnginePath, spec.Username, spec.KeytabPath, spec.Queue, spec.NodeLabel, spec.DriverArgs)
+}
+
+func (l *yarnLauncher) Stop(spec LaunchSpec, appId, outputDir string) error {
+	return yarn.StopCloud(l.kerberos, spec.Name, appId, outputDir, spec.Username, spec.KeytabPath)
+}
+
+// LocalLauncher starts clusters as H2O processes on the Steam host, for
+// installations without Hadoop. Nodes listen on host at free ports from
+// basePort upwards and cluster through a flatfile.
+type LocalLauncher struct {
+	workingDir string
+	java       string
+	host       string
+	basePort   int
+}
+
+func NewLocalLauncher(workingDir, java, host string, basePort int) *LocalLauncher {
+	return &LocalLauncher{
+		workingDir,
+		java,
+		host,
+		basePort,
+	}
+}
+
+func (l *LocalLauncher) Start(ctx context.Context, w io.Writer, onAppId func(string), spec LaunchSpec) (string, string, string, error) {
+	dir := path.Join(l.workingDir, "cluster", spec.Name)
+	pids, address, err := local.StartCloudContext(ctx, w, l.java, l.host, l.basePort, spec.Size, spec.Memory, spec.Name, spec.EnginePath, dir)
+	if err != nil {
+		return "", "", "", err
+	}
+	appId := local.FormatPids(pids)
+	onAppId(appId)
+	return appId, address, dir, nil
+}
+
+func (l *LocalLauncher) Stop(spec LaunchSpec, appId, outputDir string) error {
+	pids, err := local.ParsePids(appId)
+	if err != nil {
+		return err
+	}
+	return local.StopCloud(spec.Name, pids)
+}
+
+// FakeLauncher is a ClusterLauncher for tests. Its clusters "start" at a fixed
+// address without running anything; launches fail with Err, if set.
+type FakeLauncher struct {
+	Address string
+	Err     error
+
+	mu      sync.Mutex
+	started []string
+	stopped []string
+}
+
+func NewFakeLauncher(address string) *FakeLauncher {
+	return &FakeLauncher{Address: address}
+}
+
+func (l *FakeLauncher) Start(ctx context.Context, w io.Writer, onAppId func(string), spec LaunchSpec) (string, string, string, error) {
+	if l.Err != nil {
+		fmt.Fprintln(w, "Fake launch failed:", l.Err)
+		return "", "", "", l.Err
+	}
+
+	l.mu.Lock()
+	l.started = append(l.started, spec.Name)
+	appId := "fake_" + strconv.Itoa(len(l.started))
+	l.mu.Unlock()
+
+	onAppId(appId)
+	fmt.Fprintf(w, "Fake cluster %s of size %d started at %s\n", spec.Name, spec.Size, l.Address)
+	return appId, l.Address, "", nil
+}
+
+func (l *FakeLauncher) Stop(spec LaunchSpec, appId, outputDir string) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.stopped = append(l.stopped, appId)
+	return nil
+}
+
+// Started returns the names of the clusters started so far.
+func (l *FakeLauncher) Started() []string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return append([]string(nil), l.started...)
+}
+
+// Stopped returns the application IDs of the clusters stopped so far.
+func (l *FakeLauncher) Stopped() []string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return append([]string(nil), l.stopped...)
+}
+
+// SetClusterLauncher sets the launcher used to start clusters of a type.
+func (s *Service) SetClusterLauncher(clusterTypeId int64, launcher ClusterLauncher) {
+	s.launchers[clusterTypeId] = launcher
+}
+
+var (
+	defaultDriverOptionPolicy, _ = yarn.ParseDriverOptionPolicy(yarn.DefaultDriverOptions)
+	reYarnQueue                  = regexp.MustCompile(`^[A-Za-z0-9_.-]+$`)
+)
+
+// SetDriverOptionPolicy sets the extra h2odriver options users may pass when
+// starting clusters on YARN.
+func (s *Service) SetDriverOptionPolicy(p *yarn.DriverOptionPolicy) {
+	s.driverOptions = p
+}
+
+// checkYarnOptions validates the YARN-specific launch options of a cluster,
+// returning the driver arguments in canonical form.
+func (s *Service) checkYarnOptions(clusterTypeId int64, queue, nodeLabel string, driverArgs []string) ([]string, error) {
+	if clusterTypeId != s.ds.ClusterTypes.Yarn {
+		if queue != "" || nodeLabel != "" || len(driverArgs) > 0 {
+			return nil, fmt.Errorf("Queues, node labels and driver options only apply to clusters on Yarn")
+		}
+		return nil, nil
+	}
+	if queue != "" && !reYarnQueue.MatchString(queue) {
+		return nil, fmt.Errorf("Invalid Yarn queue %q", queue)
+	}
+	if err := yarn.ValidateNodeLabel(nodeLabel); err != nil {
+		return nil, err
+	}
+	return s.driverOptions.Validate(driverArgs)
+}
+
+// clusterLauncher returns the launcher for a cluster type; clusters without one
+// (e.g. external clusters) are registered rather than started by Steam.
+func (s *Service) clusterLauncher(clusterTypeId int64) (ClusterLauncher, bool) {
+	launcher, ok := s.launchers[clusterTypeId]
+	return launcher, ok
+}
@@ -0,0 +1,180 @@
+/*
+  Copyright (C) 2016 H2O.ai, Inc. <http://h2o.ai/>
+
+  This program is free software: you can redistribute it and/or modify
+  it under the terms of the GNU Affero General Public License as
+  published by the Free Software Foundation, either version 3 of the
+  License, or (at your option) any later version.
+
+  This program is distributed in the hope that it will be useful,
+  but WITHOUT ANY WARRANTY; without even the implied warranty of
+  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+  GNU Affero General Public License for more details.
+
+  You should have received a copy of the GNU Affero General Public License
+  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package web
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/h2oai/steam/master/data"
+)
+
+func TestStartClusterWithLauncher(t *testing.T) {
+	svc, su, cleanup := newSandbox(t)
+	defer cleanup()
+
+	launcher := NewFakeLauncher("localhost:54321")
+	svc.SetClusterLauncher(svc.ds.ClusterTypes.Local, launcher)
+
+	engineId, err := svc.ds.CreateEngine(su, "h2o", "/tmp/h2o.jar")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := svc.StartCluster(su, "c1", "external", engineId, 1, "1g", 0); err == nil {
+		t.Fatal("expected external clusters not to be started")
+	}
+
+	if _, err := svc.StartCluster(su, "../../www", "local", engineId, 1, "1g", 0); err == nil {
+		t.Fatal("expected a cluster name with a path to be rejected")
+	}
+	if started := launcher.Started(); len(started) != 0 {
+		t.Fatalf("expected no launch for an invalid name, got %v", started)
+	}
+
+	clusterId, err := svc.StartCluster(su, "c1", "local", engineId, 2, "1g", 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var cluster data.Cluster
+	for i := 0; i < 100; i++ {
+		if cluster, err = svc.ds.ReadCluster(su, clusterId); err != nil {
+			t.Fatal(err)
+		}
+		if cluster.State != data.StartingState {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if cluster.State != data.StartedState {
+		t.Fatalf("expected %s, got %s", data.StartedState, cluster.State)
+	}
+	if cluster.TypeId != svc.ds.ClusterTypes.Local {
+		t.Fatalf("expected local cluster type, got %d", cluster.TypeId)
+	}
+	if cluster.Address != launcher.Address {
+		t.Fatalf("expected address %s, got %s", launcher.Address, cluster.Address)
+	}
+
+	if err := svc.StopCluster(su, clusterId); err != nil {
+		t.Fatal(err)
+	}
+	if stopped := launcher.Stopped(); len(stopped) != 1 || stopped[0] != "fake_1" {
+		t.Fatalf("expected fake_1 to be stopped, got %v", stopped)
+	}
+	if _, err := svc.ds.ReadCluster(su, clusterId); err == nil {
+		t.Fatal("expected stopped cluster to be deleted")
+	}
+}
+
+func TestStartClusterOnYarnOptions(t *testing.T) {
+	svc, su, cleanup := newSandbox(t)
+	defer cleanup()
+
+	svc.SetClusterLauncher(svc.ds.ClusterTypes.Yarn, NewFakeLauncher("localhost:54321"))
+
+	engineId, err := svc.ds.CreateEngine(su, "h2o", "/tmp/h2odriver.jar")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := svc.StartClusterOnYarn(su, "c1", engineId, 1, "1g", 0, "", "", "-libjars evil.jar"); err == nil {
+		t.Fatal("expected a driver option outside the allowlist to be rejected")
+	}
+	clusterId, err := svc.StartClusterOnYarn(su, "c2", engineId, 1, "1g", 0, "analytics", "gpu", "-nthreads 8 -extramempercent 20")
+	if err != nil {
+		t.Fatal(err)
+	}
+	yarnCluster, err := svc.ds.ReadYarnCluster(su, clusterId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if yarnCluster.Queue != "analytics" || yarnCluster.NodeLabel != "gpu" || yarnCluster.DriverArgs != "-nthreads 8 -extramempercent 20" {
+		t.Fatalf("launch options not stored: %+v", yarnCluster)
+	}
+}
+
+func TestStopClusterOwnership(t *testing.T) {
+	svc, su, cleanup := newSandbox(t)
+	defer cleanup()
+
+	launcher := NewFakeLauncher("localhost:54321")
+	clusterId := startIdleCluster(t, svc, su, launcher)
+
+	roleId, err := svc.CreateRole(su, "operator", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := svc.LinkRoleWithPermissions(su, roleId, []int64{
+		svc.ds.Permissions.ManageCluster,
+		svc.ds.Permissions.ViewCluster,
+	}); err != nil {
+		t.Fatal(err)
+	}
+	bobId, err := svc.CreateIdentity(su, "bob", "password")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := svc.LinkIdentityWithRole(su, bobId, roleId); err != nil {
+		t.Fatal(err)
+	}
+	bob, err := svc.ds.Lookup("bob")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Viewers cannot stop a cluster
+	if err := svc.ShareEntity(su, data.CanView, bob.WorkgroupId(), svc.ds.EntityTypes.Cluster, clusterId); err != nil {
+		t.Fatal(err)
+	}
+	if err := svc.StopCluster(bob, clusterId); err == nil {
+		t.Fatal("expected a viewer not to stop the cluster")
+	}
+
+	// Owners who did not launch a YARN cluster need a keytab of their own
+	if err := svc.ShareEntity(su, data.Owns, bob.WorkgroupId(), svc.ds.EntityTypes.Cluster, clusterId); err != nil {
+		t.Fatal(err)
+	}
+	if err := svc.StopCluster(bob, clusterId); err == nil {
+		t.Fatal("expected stopping with the launcher's credentials to be refused")
+	}
+	if stopped := launcher.Stopped(); len(stopped) != 0 {
+		t.Fatalf("expected the cluster to keep running, got %v", stopped)
+	}
+
+	if err := svc.StopCluster(su, clusterId); err != nil {
+		t.Fatal(err)
+	}
+	if stopped := launcher.Stopped(); len(stopped) != 1 {
+		t.Fatalf("expected the cluster to be stopped, got %v", stopped)
+	}
+}
+
+func TestGetClusterLogsOffset(t *testing.T) {
+	svc, su, cleanup := newSandbox(t)
+	defer cleanup()
+
+	clusterId := startIdleCluster(t, svc, su, NewFakeLauncher("localhost:54321"))
+	for _, offset := range []int64{-1, 1e12} {
+		if _, err := svc.GetClusterLogs(su, clusterId, "", 100, offset); err == nil || !strings.Contains(err.Error(), "Offset") {
+			t.Fatalf("expected offset %d to be rejected, got %v", offset, err)
+		}
+	}
+}
@@ -0,0 +1,234 @@
+/*
+  Copyright (C) 2016 H2O.ai, Inc. <http://h2o.ai/>
+
+  This program is free software: you can redistribute it and/or modify
+  it under the terms of the GNU Affero General Public License as
+  published by the Free Software Foundation, either version 3 of the
+  License, or (at your option) any later version.
+
+  This program is distributed in the hope that it will be useful,
+  but WITHOUT ANY WARRANTY; without even the implied warranty of
+  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+  GNU Affero General Public License for more details.
+
+  You should have received a copy of the GNU Affero General Public License
+  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package web
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/h2oai/steam/master/az"
+	"github.com/h2oai/steam/master/data"
+	"github.com/h2oai/steam/srv/web"
+	"github.com/pkg/errors"
+)
+
+// defaultMetricsWindow is the period reported when no start time is given.
+const defaultMetricsWindow = time.Hour
+
+// ClusterMetricsSampler periodically records the resource usage of each node
+// of every started cluster, and drops samples older than the retention period.
+//
+// H2O's /3/Cloud doesn't report JVM GC time, so GC pressure shows up only
+// indirectly, as CPU load with little free memory.
+type ClusterMetricsSampler struct {
+	s         *Service
+	interval  time.Duration
+	retention time.Duration
+}
+
+func NewClusterMetricsSampler(s *Service, interval, retention time.Duration) *ClusterMetricsSampler {
+	return &ClusterMetricsSampler{
+		s,
+		interval,
+		retention,
+	}
+}
+
+// Run samples every interval until stop is closed.
+func (m *ClusterMetricsSampler) Run(stop <-chan struct{}) {
+	ticker := time.NewTicker(m.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			if err := m.Sample(time.Now()); err != nil {
+				log.Println("Cluster metrics sampling failed:", err)
+			}
+		case <-stop:
+			return
+		}
+	}
+}
+
+// Sample records the current node stats of every started cluster.
+func (m *ClusterMetricsSampler) Sample(now time.Time) error {
+	pz, err := m.s.ds.LookupSuperuser()
+	if err != nil {
+		return errors.Wrap(err, "failed reading superuser")
+	}
+
+	clusters, err := m.s.ds.ReadAllClusters(pz)
+	if err != nil {
+		return errors.Wrap(err, "failed reading clusters")
+	}
+
+	for _, cluster := range clusters {
+		if cluster.State != data.StartedState {
+			continue
+		}
+
+		h2o, err := m.s.h2oCheckClient(cluster)
+		if err != nil {
+			log.Printf("Failed connecting to cluster %d: %v\n", cluster.Id, err)
+			continue
+		}
+		cloud, err := h2o.GetCloudStatus()
+		if err != nil {
+			// Unreachable clusters are the health reconciler's concern
+			continue
+		}
+
+		metrics := make([]data.ClusterMetric, 0, len(cloud.Nodes))
+		for _, n := range cloud.Nodes {
+			if n == nil {
+				continue
+			}
+			metrics = append(metrics, data.ClusterMetric{
+				0,
+				cluster.Id,
+				n.IpPort,
+				n.Healthy,
+				float64(n.SysLoad),
+				int64(n.MyCpuPct),
+				int64(n.SysCpuPct),
+				n.FreeMem,
+				n.MaxMem,
+				n.PojoMem,
+				n.MemValueSize,
+				now,
+			})
+		}
+
+		if err := m.s.ds.CreateClusterMetrics(pz, cluster.Id, now, metrics); err != nil {
+			log.Printf("Failed recording metrics of cluster %d: %v\n", cluster.Id, err)
+		}
+	}
+
+	if m.retention > 0 {
+		if err := m.s.ds.DeleteClusterMetricsBefore(now.Add(-m.retention)); err != nil {
+			return errors.Wrap(err, "failed dropping expired cluster metrics")
+		}
+	}
+	return nil
+}
+
+func (s *Service) GetClusterMetrics(pz az.Principal, clusterId, from, to, step int64) ([]*web.ClusterMetric, error) {
+	if err := pz.CheckPermission(s.ds.Permissions.ViewCluster); err != nil {
+		return nil, err
+	}
+
+	if step < 0 {
+		return nil, fmt.Errorf("Step cannot be negative")
+	}
+
+	end := time.Now()
+	if to > 0 {
+		end = time.Unix(to, 0)
+	}
+	start := end.Add(-defaultMetricsWindow)
+	if from > 0 {
+		start = time.Unix(from, 0)
+	}
+	if !start.Before(end) {
+		return nil, fmt.Errorf("Start of the period must be before its end")
+	}
+
+	metrics, err := s.ds.ReadClusterMetrics(pz, clusterId, start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	return downsampleClusterMetrics(metrics, start, step), nil
+}
+
+// downsampleClusterMetrics averages the samples of each node over buckets of
+// step seconds from start. Buckets are reported at their start time, and a
+// node is healthy in a bucket only if it was healthy in every sample. With a
+// step of 0, samples are reported as is.
+func downsampleClusterMetrics(metrics []data.ClusterMetric, start time.Time, step int64) []*web.ClusterMetric {
+	if step == 0 {
+		array := make([]*web.ClusterMetric, len(metrics))
+		for i, m := range metrics {
+			array[i] = toClusterMetric(m)
+		}
+		return array
+	}
+
+	type bucket struct {
+		time  int64
+		node  string
+		count int64
+		sum   data.ClusterMetric
+	}
+	var buckets []*bucket
+	index := make(map[string]*bucket)
+	for _, m := range metrics {
+		t := toTimestamp(start) + (toTimestamp(m.Sampled)-toTimestamp(start))/step*step
+		key := fmt.Sprintf("%d/%s", t, m.Node)
+		b, ok := index[key]
+		if !ok {
+			b = &bucket{time: t, node: m.Node}
+			b.sum.Healthy = true
+			index[key] = b
+			buckets = append(buckets, b)
+		}
+		b.count++
+		b.sum.Healthy = b.sum.Healthy && m.Healthy
+		b.sum.SysLoad += m.SysLoad
+		b.sum.MyCpuPct += m.MyCpuPct
+		b.sum.SysCpuPct += m.SysCpuPct
+		b.sum.FreeMem += m.FreeMem
+		b.sum.MaxMem += m.MaxMem
+		b.sum.PojoMem += m.PojoMem
+		b.sum.MemValueSize += m.MemValueSize
+	}
+
+	array := make([]*web.ClusterMetric, len(buckets))
+	for i, b := range buckets {
+		n := b.count
+		array[i] = &web.ClusterMetric{
+			b.time,
+			b.node,
+			b.sum.Healthy,
+			b.sum.SysLoad / float64(n),
+			int(b.sum.MyCpuPct / n),
+			int(b.sum.SysCpuPct / n),
+			b.sum.FreeMem / n,
+			b.sum.MaxMem / n,
+			b.sum.PojoMem / n,
+			b.sum.MemValueSize / n,
+		}
+	}
+	return array
+}
+
+func toClusterMetric(m data.ClusterMetric) *web.ClusterMetric {
+	return &web.ClusterMetric{
+		toTimestamp(m.Sampled),
+		m.Node,
+		m.Healthy,
+		m.SysLoad,
+		int(m.MyCpuPct),
+		int(m.SysCpuPct),
+		m.FreeMem,
+		m.MaxMem,
+		m.PojoMem,
+		m.MemValueSize,
+	}
+}
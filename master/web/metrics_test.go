@@ -0,0 +1,80 @@
+/*
+  Copyright (C) 2016 H2O.ai, Inc. <http://h2o.ai/>
+
+  This program is free software: you can redistribute it and/or modify
+  it under the terms of the GNU Affero General Public License as
+  published by the Free Software Foundation, either version 3 of the
+  License, or (at your option) any later version.
+
+  This program is distributed in the hope that it will be useful,
+  but WITHOUT ANY WARRANTY; without even the implied warranty of
+  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+  GNU Affero General Public License for more details.
+
+  You should have received a copy of the GNU Affero General Public License
+  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package web
+
+import (
+	"testing"
+	"time"
+)
+
+func TestClusterMetricsSampler(t *testing.T) {
+	svc, su, cleanup := newSandbox(t)
+	defer cleanup()
+
+	h2o := newFakeH2O()
+	defer h2o.Close()
+
+	clusterId, err := svc.RegisterCluster(su, h2o.address(), "", "", "", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	m := NewClusterMetricsSampler(svc, time.Minute, time.Hour)
+	start := time.Now().Add(-30 * time.Minute).Truncate(time.Minute)
+	for i := 0; i < 4; i++ {
+		if err := m.Sample(start.Add(time.Duration(i) * time.Minute)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	from, to := toTimestamp(start), toTimestamp(start.Add(10*time.Minute))
+
+	raw, err := svc.GetClusterMetrics(su, clusterId, from, to, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(raw) != 4 {
+		t.Fatalf("expected 4 samples, got %d", len(raw))
+	}
+	if raw[0].Node != "127.0.0.1:54321" || raw[0].FreeMem != 1000 || !raw[0].Healthy {
+		t.Fatalf("unexpected sample %+v", raw[0])
+	}
+
+	averaged, err := svc.GetClusterMetrics(su, clusterId, from, to, 120)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(averaged) != 2 {
+		t.Fatalf("expected 2 buckets, got %d", len(averaged))
+	}
+	if averaged[1].Time != from+120 {
+		t.Fatalf("expected second bucket at %d, got %d", from+120, averaged[1].Time)
+	}
+
+	// Samples past the retention period are dropped
+	if err := m.Sample(start.Add(2 * time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+	raw, err = svc.GetClusterMetrics(su, clusterId, from, to, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(raw) != 0 {
+		t.Fatalf("expected expired samples to be dropped, got %d", len(raw))
+	}
+}
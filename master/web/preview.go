@@ -0,0 +1,295 @@
+/*
+  Copyright (C) 2016 H2O.ai, Inc. <http://h2o.ai/>
+
+  This program is free software: you can redistribute it and/or modify
+  it under the terms of the GNU Affero General Public License as
+  published by the Free Software Foundation, either version 3 of the
+  License, or (at your option) any later version.
+
+  This program is distributed in the hope that it will be useful,
+  but WITHOUT ANY WARRANTY; without even the implied warranty of
+  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+  GNU Affero General Public License for more details.
+
+  You should have received a copy of the GNU Affero General Public License
+  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package web
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/h2oai/steam/master/az"
+	"github.com/h2oai/steam/master/data"
+	"github.com/h2oai/steam/srv/web"
+	"github.com/pkg/errors"
+)
+
+const (
+	defaultPreviewRows = 10
+	maxPreviewRows     = 1000
+)
+
+// Dataset previews are read from the dataset's frame on its cluster. If the
+// frame cannot be read and the dataset was imported from a file uploaded to
+// Steam, the rows are read from that file instead. Other file datasources
+// name paths on the cluster, so Steam never reads them itself.
+
+// frameRows is the part of an H2O frame fetch that previews use. Values are
+// decoded loosely, since H2O sends missing numbers as null or "NaN" and
+// missing strings as null.
+type frameRows struct {
+	Frames []struct {
+		Rows    int64 `json:"rows"`
+		Columns []struct {
+			Label      string        `json:"label"`
+			Type       string        `json:"type"`
+			Domain     []string      `json:"domain"`
+			Data       []interface{} `json:"data"`
+			StringData []*string     `json:"string_data"`
+		} `json:"columns"`
+	} `json:"frames"`
+}
+
+func (s *Service) PreviewDataset(pz az.Principal, datasetId, offset, rowCount int64, columns []string) (*web.DatasetPreview, error) {
+	if err := pz.CheckPermission(s.ds.Permissions.ViewDataset); err != nil {
+		return nil, err
+	}
+	if offset < 0 {
+		return nil, fmt.Errorf("Invalid offset %d", offset)
+	}
+	if rowCount == 0 {
+		rowCount = defaultPreviewRows
+	}
+	if rowCount < 0 || rowCount > maxPreviewRows {
+		return nil, fmt.Errorf("Invalid row count %d: at most %d rows can be previewed", rowCount, maxPreviewRows)
+	}
+
+	dataset, err := s.ds.ReadDataset(pz, datasetId)
+	if err != nil {
+		return nil, err
+	}
+
+	preview, err := s.previewFrame(pz, dataset, offset, rowCount)
+	if err != nil {
+		var ferr error
+		if preview, ferr = s.previewFile(pz, dataset, offset, rowCount); ferr != nil {
+			return nil, err
+		}
+		log.Printf("Previewed dataset %d from its file: %v\n", datasetId, err)
+	}
+
+	return selectPreviewColumns(preview, dataset.Name, columns)
+}
+
+func (s *Service) previewFrame(pz az.Principal, dataset data.Dataset, offset, rowCount int64) (*web.DatasetPreview, error) {
+	if dataset.ClusterId == 0 {
+		return nil, fmt.Errorf("Dataset %s is not bound to a cluster", dataset.Name)
+	}
+	if dataset.Stale {
+		return nil, errStaleDataset(dataset)
+	}
+	cluster, err := s.ds.ReadCluster(pz, dataset.ClusterId)
+	if err != nil {
+		return nil, err
+	}
+	h2o, err := s.h2oClient(cluster)
+	if err != nil {
+		return nil, err
+	}
+	rawFrame, err := h2o.GetFramesRows(dataset.FrameName, offset, rowCount)
+	if err != nil {
+		return nil, errors.Wrapf(err, "fetching rows of frame %s", dataset.FrameName)
+	}
+
+	var frames frameRows
+	if err := json.Unmarshal(rawFrame, &frames); err != nil {
+		return nil, errors.Wrap(err, "decoding frame rows")
+	}
+	if len(frames.Frames) == 0 {
+		return nil, fmt.Errorf("Frame %s not found", dataset.FrameName)
+	}
+	frame := frames.Frames[0]
+
+	// H2O returns no more rows than the frame holds past the offset
+	n := frame.Rows - offset
+	if n > rowCount {
+		n = rowCount
+	}
+	if n < 0 {
+		n = 0
+	}
+
+	preview := newDatasetPreview(dataset.Id, "cluster", offset, frame.Rows, len(frame.Columns), int(n))
+	for j, col := range frame.Columns {
+		preview.Columns[j] = &web.DatasetPreviewColumn{col.Label, col.Type}
+		for i := range preview.Rows {
+			var value string
+			var ok bool
+			switch col.Type {
+			case "string", "uuid":
+				if i < len(col.StringData) && col.StringData[i] != nil {
+					value, ok = *col.StringData[i], true
+				}
+			default:
+				if i < len(col.Data) {
+					var v float64
+					if v, ok = col.Data[i].(float64); ok {
+						value, ok = formatFrameValue(col.Type, col.Domain, v)
+					}
+				}
+			}
+			preview.Rows[i].Values[j] = value
+			preview.Rows[i].Missing[j] = !ok
+		}
+	}
+	return preview, nil
+}
+
+// formatFrameValue renders a value of an H2O numeric, categorical or time
+// column; time values are milliseconds since the epoch.
+func formatFrameValue(colType string, domain []string, v float64) (string, bool) {
+	switch colType {
+	case "enum":
+		if i := int(v); i >= 0 && i < len(domain) {
+			return domain[i], true
+		}
+		return "", false
+	case "time":
+		return time.Unix(0, int64(v)*int64(time.Millisecond)).UTC().Format(time.RFC3339), true
+	case "int":
+		return strconv.FormatFloat(v, 'f', -1, 64), true
+	}
+	return strconv.FormatFloat(v, 'g', -1, 64), true
+}
+
+func (s *Service) previewFile(pz az.Principal, dataset data.Dataset, offset, rowCount int64) (*web.DatasetPreview, error) {
+	if dataset.DatasourceId == 0 {
+		return nil, fmt.Errorf("Dataset %s has no datasource", dataset.Name)
+	}
+	// Splits share their parent's datasource, but hold only some of its rows
+	if dataset.ParentId.Valid {
+		return nil, fmt.Errorf("Dataset %s is a split and cannot be read from its datasource", dataset.Name)
+	}
+	datasource, err := s.ds.ReadDatasource(pz, dataset.DatasourceId)
+	if err != nil {
+		return nil, err
+	}
+	if datasource.Kind != data.DatasourceUpload {
+		return nil, fmt.Errorf("%s datasources cannot be read by Steam", datasource.Kind)
+	}
+	c, err := s.openDatasourceConfig(datasource)
+	if err != nil {
+		return nil, err
+	}
+
+	f, err := os.Open(c.Path)
+	if err != nil {
+		return nil, errors.Wrap(err, "opening uploaded file")
+	}
+	defer f.Close()
+
+	r := csv.NewReader(f)
+	r.FieldsPerRecord = -1
+	r.LazyQuotes = true
+	header, err := r.Read()
+	if err != nil {
+		return nil, errors.Wrap(err, "reading header of uploaded file")
+	}
+
+	var records [][]string
+	for i := int64(0); i < offset+rowCount; i++ {
+		record, err := r.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, errors.Wrap(err, "reading uploaded file")
+		}
+		if i >= offset {
+			records = append(records, record)
+		}
+	}
+
+	// Column types come from the dataset's profile; unprofiled columns are
+	// returned as they appear in the file
+	types := make(map[string]string)
+	if profile, err := s.readDatasetColumns(pz, dataset); err == nil {
+		for _, col := range profile {
+			types[col.Name] = col.Type
+		}
+	}
+
+	preview := newDatasetPreview(dataset.Id, "file", offset, dataset.RowCount, len(header), len(records))
+	for j, name := range header {
+		colType, ok := types[name]
+		if !ok {
+			colType = "string"
+		}
+		preview.Columns[j] = &web.DatasetPreviewColumn{name, colType}
+	}
+	for i, record := range records {
+		for j := range header {
+			if j < len(record) && record[j] != "" && record[j] != "NA" {
+				preview.Rows[i].Values[j] = record[j]
+			} else {
+				preview.Rows[i].Missing[j] = true
+			}
+		}
+	}
+	return preview, nil
+}
+
+func newDatasetPreview(datasetId int64, source string, offset, totalRows int64, columnCount, rowCount int) *web.DatasetPreview {
+	rows := make([]*web.DatasetPreviewRow, rowCount)
+	for i := range rows {
+		rows[i] = &web.DatasetPreviewRow{make([]string, columnCount), make([]bool, columnCount)}
+	}
+	return &web.DatasetPreview{
+		datasetId,
+		source,
+		offset,
+		totalRows,
+		make([]*web.DatasetPreviewColumn, columnCount),
+		rows,
+	}
+}
+
+// selectPreviewColumns narrows a preview to the named columns, in the order
+// given.
+func selectPreviewColumns(preview *web.DatasetPreview, datasetName string, columns []string) (*web.DatasetPreview, error) {
+	if len(columns) == 0 {
+		return preview, nil
+	}
+
+	positions := make(map[string]int, len(preview.Columns))
+	for j, col := range preview.Columns {
+		positions[col.Name] = j
+	}
+	indexes := make([]int, len(columns))
+	for k, name := range columns {
+		j, ok := positions[name]
+		if !ok {
+			return nil, fmt.Errorf("Dataset %s has no column %s", datasetName, name)
+		}
+		indexes[k] = j
+	}
+
+	selected := newDatasetPreview(preview.DatasetId, preview.Source, preview.Offset, preview.TotalRows, len(columns), len(preview.Rows))
+	for k, j := range indexes {
+		selected.Columns[k] = preview.Columns[j]
+		for i, row := range preview.Rows {
+			selected.Rows[i].Values[k] = row.Values[j]
+			selected.Rows[i].Missing[k] = row.Missing[j]
+		}
+	}
+	return selected, nil
+}
@@ -0,0 +1,161 @@
+/*
+  Copyright (C) 2016 H2O.ai, Inc. <http://h2o.ai/>
+
+  This program is free software: you can redistribute it and/or modify
+  it under the terms of the GNU Affero General Public License as
+  published by the Free Software Foundation, either version 3 of the
+  License, or (at your option) any later version.
+
+  This program is distributed in the hope that it will be useful,
+  but WITHOUT ANY WARRANTY; without even the implied warranty of
+  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+  GNU Affero General Public License for more details.
+
+  You should have received a copy of the GNU Affero General Public License
+  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package web
+
+import (
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/h2oai/steam/master/data"
+)
+
+func TestPreviewDataset(t *testing.T) {
+	svc, su, cleanup := newSandbox(t)
+	defer cleanup()
+
+	content := "Origin,Distance,Name\nSFO,100,a\nJFK,,b\nSFO,300,\n"
+	name := func(s string) *string { return &s }
+	columns := []map[string]interface{}{
+		{"label": "Origin", "type": "enum", "domain": []string{"JFK", "SFO"}, "data": []interface{}{1, 0, 1}},
+		{"label": "Distance", "type": "int", "data": []interface{}{100, "NaN", 300}},
+		{"label": "Name", "type": "string", "string_data": []*string{name("a"), name("b"), nil}},
+	}
+
+	h2o, _ := newFakeImports("DONE")
+	defer h2o.Close()
+	h2o.handle("/3/PostFile", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"destination_frame": "` + r.URL.Query().Get("destination_frame") + `"}`))
+	})
+	h2o.handle("/3/Frames/airlines.hex", func(w http.ResponseWriter, r *http.Request) {
+		offset, _ := strconv.Atoi(r.URL.Query().Get("row_offset"))
+		count, err := strconv.Atoi(r.URL.Query().Get("row_count"))
+		if err != nil {
+			count = 3
+		}
+		end := offset + count
+		if end > 3 {
+			end = 3
+		}
+		page := make([]map[string]interface{}, len(columns))
+		for j, col := range columns {
+			page[j] = make(map[string]interface{})
+			for k, v := range col {
+				page[j][k] = v
+			}
+			if d, ok := col["data"].([]interface{}); ok {
+				page[j]["data"] = d[offset:end]
+			}
+			if sd, ok := col["string_data"].([]*string); ok {
+				page[j]["string_data"] = sd[offset:end]
+			}
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{"frames": []interface{}{map[string]interface{}{
+			"rows":    3,
+			"columns": page,
+		}}})
+	})
+
+	projectId, err := svc.CreateProject(su, "p1", "d1", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, datasourceId, err := svc.UploadDatasetChunk(su, projectId, "", "airlines.csv", int64(len(content)), 0, strings.NewReader(content))
+	if err != nil {
+		t.Fatal(err)
+	}
+	clusterId, err := svc.RegisterCluster(su, h2o.address(), "", "", "", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	jobId, err := svc.CreateDataset(su, clusterId, datasourceId, "airlines", "", "", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	job := waitForDatasetJob(t, svc, su, jobId, finished)
+	if job.State != data.ImportDoneState {
+		t.Fatalf("unexpected job: %+v", job)
+	}
+
+	preview, err := svc.PreviewDataset(su, job.DatasetId, 1, 5, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if preview.Source != "cluster" || preview.Offset != 1 || preview.TotalRows != 3 || len(preview.Rows) != 2 {
+		t.Fatalf("unexpected preview: %+v", preview)
+	}
+	if c := preview.Columns[0]; c.Name != "Origin" || c.Type != "enum" {
+		t.Fatalf("unexpected column: %+v", c)
+	}
+	expected := [][]string{{"JFK", "", "b"}, {"SFO", "300", ""}}
+	missing := [][]bool{{false, true, false}, {false, false, true}}
+	for i, row := range preview.Rows {
+		if !reflect.DeepEqual(row.Values, expected[i]) || !reflect.DeepEqual(row.Missing, missing[i]) {
+			t.Fatalf("unexpected row %d: %+v", i, row)
+		}
+	}
+
+	selected, err := svc.PreviewDataset(su, job.DatasetId, 0, 0, []string{"Name", "Origin"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(selected.Columns) != 2 || selected.Columns[0].Name != "Name" || len(selected.Rows) != 3 {
+		t.Fatalf("unexpected selection: %+v", selected)
+	}
+	if row := selected.Rows[0]; !reflect.DeepEqual(row.Values, []string{"a", "SFO"}) {
+		t.Fatalf("unexpected selected row: %+v", row)
+	}
+
+	if _, err := svc.PreviewDataset(su, job.DatasetId, 0, 0, []string{"Dest"}); err == nil {
+		t.Fatal("expected an unknown column to be refused")
+	}
+	if _, err := svc.PreviewDataset(su, job.DatasetId, 0, maxPreviewRows+1, nil); err == nil {
+		t.Fatal("expected too many rows to be refused")
+	}
+
+	// Without the cluster, the same rows are read from the uploaded file
+	h2o.Close()
+	fromFile, err := svc.PreviewDataset(su, job.DatasetId, 1, 5, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fromFile.Source != "file" || fromFile.TotalRows != 3 {
+		t.Fatalf("unexpected file preview: %+v", fromFile)
+	}
+	if !reflect.DeepEqual(fromFile.Columns, preview.Columns) || !reflect.DeepEqual(fromFile.Rows, preview.Rows) {
+		t.Fatalf("expected the file preview to match the cluster's: %+v", fromFile)
+	}
+
+	// Splits hold only some of the file's rows, so they are not read from it
+	dataset, err := svc.ds.ReadDataset(su, job.DatasetId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	splitId, err := svc.ds.CreateDataset(su, data.Dataset{0, dataset.DatasourceId, dataset.ClusterId, sql.NullInt64{dataset.Id, true}, 0, sql.NullInt64{}, 0, "", "", "airlines (training)", "", "airlines_train.hex", "", "{}", "1", time.Now(), false})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := svc.PreviewDataset(su, splitId, 0, 5, nil); err == nil {
+		t.Fatal("expected a split not to be previewed from its parent's file")
+	}
+}
@@ -0,0 +1,266 @@
+/*
+  Copyright (C) 2016 H2O.ai, Inc. <http://h2o.ai/>
+
+  This program is free software: you can redistribute it and/or modify
+  it under the terms of the GNU Affero General Public License as
+  published by the Free Software Foundation, either version 3 of the
+  License, or (at your option) any later version.
+
+  This program is distributed in the hope that it will be useful,
+  but WITHOUT ANY WARRANTY; without even the implied warranty of
+  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+  GNU Affero General Public License for more details.
+
+  You should have received a copy of the GNU Affero General Public License
+  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package web
+
+import (
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log"
+	"math"
+
+	"github.com/h2oai/steam/bindings"
+	"github.com/h2oai/steam/master/az"
+	"github.com/h2oai/steam/master/data"
+	"github.com/h2oai/steam/srv/web"
+	"github.com/pkg/errors"
+)
+
+// A dataset's column profile is decoded from the H2O frame JSON stored in
+// its properties. Frames fetched on import carry only rollup statistics;
+// refreshing a profile fetches the frame summary, which adds histograms.
+
+// frameProperties is the part of an H2O frame fetch that profiles use.
+type frameProperties struct {
+	Frames []struct {
+		Rows    int64             `json:"rows"`
+		Columns []*bindings.ColV3 `json:"columns"`
+	} `json:"frames"`
+}
+
+func toDatasetColumns(datasetId int64, properties string) ([]data.DatasetColumn, error) {
+	var frames frameProperties
+	if err := json.Unmarshal([]byte(properties), &frames); err != nil {
+		return nil, errors.Wrap(err, "decoding frame properties")
+	}
+	if len(frames.Frames) == 0 {
+		return nil, errors.New("frame properties hold no frame")
+	}
+
+	cols := frames.Frames[0].Columns
+	columns := make([]data.DatasetColumn, len(cols))
+	for i, col := range cols {
+		domain := col.Domain
+		if domain == nil {
+			domain = []string{}
+		}
+		bins := col.HistogramBins
+		if bins == nil {
+			bins = []int64{}
+		}
+		domainJSON, err := json.Marshal(domain)
+		if err != nil {
+			return nil, err
+		}
+		binsJSON, err := json.Marshal(bins)
+		if err != nil {
+			return nil, err
+		}
+
+		columns[i] = data.DatasetColumn{
+			datasetId,
+			int64(i),
+			col.Label,
+			col.Type,
+			col.MissingCount,
+			col.ZeroCount,
+			toNullFloat(col.Mins),
+			toNullFloat(col.Maxs),
+			toNullFloat([]float64{col.Mean}),
+			toNullFloat([]float64{col.Sigma}),
+			string(domainJSON),
+			int64(col.DomainCardinality),
+			toNullFloat([]float64{col.HistogramBase}),
+			toNullFloat([]float64{col.HistogramStride}),
+			string(binsJSON),
+		}
+	}
+	return columns, nil
+}
+
+// datasetFingerprint identifies a frame's content by its row count and a
+// hash of its column names and types.
+func datasetFingerprint(properties string) (int64, string) {
+	var frames frameProperties
+	if err := json.Unmarshal([]byte(properties), &frames); err != nil || len(frames.Frames) == 0 {
+		return 0, ""
+	}
+
+	h := sha256.New()
+	for _, col := range frames.Frames[0].Columns {
+		fmt.Fprintf(h, "%s\t%s\n", col.Label, col.Type)
+	}
+	return frames.Frames[0].Rows, hex.EncodeToString(h.Sum(nil))
+}
+
+// datasetStatistics hashes the rollup statistics of a frame's columns, which
+// tell apart frames of the same row count and columns.
+func datasetStatistics(properties string) string {
+	var frames frameProperties
+	if err := json.Unmarshal([]byte(properties), &frames); err != nil || len(frames.Frames) == 0 {
+		return ""
+	}
+
+	h := sha256.New()
+	for _, col := range frames.Frames[0].Columns {
+		fmt.Fprintf(h, "%s\t%d\t%d\t%v\t%v\t%v\t%v\t%d\n", col.Label, col.MissingCount, col.ZeroCount, col.Mins, col.Maxs, col.Mean, col.Sigma, col.DomainCardinality)
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// toNullFloat returns the first value, treating an empty list, NaN and
+// infinities as missing.
+func toNullFloat(values []float64) sql.NullFloat64 {
+	if len(values) == 0 || math.IsNaN(values[0]) || math.IsInf(values[0], 0) {
+		return sql.NullFloat64{}
+	}
+	return sql.NullFloat64{values[0], true}
+}
+
+// profileDataset records the column profile of a newly created dataset.
+// Profiles can be refreshed later, so a failure does not fail the dataset.
+func (s *Service) profileDataset(pz az.Principal, datasetId int64, properties string) {
+	columns, err := toDatasetColumns(datasetId, properties)
+	if err == nil {
+		err = s.ds.UpdateDatasetProfile(pz, datasetId, properties, columns)
+	}
+	if err != nil {
+		log.Printf("Failed profiling dataset %d: %v\n", datasetId, err)
+	}
+}
+
+func (s *Service) GetDatasetColumns(pz az.Principal, datasetId int64, refresh bool) ([]*web.DatasetColumn, error) {
+	if err := pz.CheckPermission(s.ds.Permissions.ViewDataset); err != nil {
+		return nil, err
+	}
+
+	dataset, err := s.ds.ReadDataset(pz, datasetId)
+	if err != nil {
+		return nil, err
+	}
+
+	if refresh {
+		if err := pz.CheckPermission(s.ds.Permissions.ManageDataset); err != nil {
+			return nil, err
+		}
+		if err := s.refreshDatasetProfile(pz, dataset); err != nil {
+			return nil, err
+		}
+	}
+
+	columns, err := s.readDatasetColumns(pz, dataset)
+	if err != nil {
+		return nil, err
+	}
+	roles, err := s.readColumnRoles(pz, dataset, columns)
+	if err != nil {
+		return nil, err
+	}
+
+	array, err := toDatasetColumnList(columns)
+	if err != nil {
+		return nil, err
+	}
+	for _, c := range array {
+		c.Role = roles[c.Name]
+	}
+	return array, nil
+}
+
+// refreshDatasetProfile profiles a dataset from the summary of its frame.
+func (s *Service) refreshDatasetProfile(pz az.Principal, dataset data.Dataset) error {
+	if dataset.ClusterId == 0 {
+		return fmt.Errorf("Dataset %s is not bound to a cluster; re-create it from its datasource to profile it", dataset.Name)
+	}
+	if dataset.Stale {
+		return errStaleDataset(dataset)
+	}
+	cluster, err := s.ds.ReadCluster(pz, dataset.ClusterId)
+	if err != nil {
+		return err
+	}
+	h2o, err := s.h2oClient(cluster)
+	if err != nil {
+		return err
+	}
+	rawFrame, _, err := h2o.GetFramesSummary(dataset.FrameName)
+	if err != nil {
+		return errors.Wrapf(err, "summarizing frame %s", dataset.FrameName)
+	}
+	columns, err := toDatasetColumns(dataset.Id, string(rawFrame))
+	if err != nil {
+		return err
+	}
+	return s.ds.UpdateDatasetProfile(pz, dataset.Id, string(rawFrame), columns)
+}
+
+func (s *Service) readDatasetColumns(pz az.Principal, dataset data.Dataset) ([]data.DatasetColumn, error) {
+	columns, err := s.ds.ReadDatasetColumns(pz, dataset.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	// Datasets created before profiling was introduced are decoded on the fly
+	if len(columns) == 0 && dataset.Properties != "" {
+		return toDatasetColumns(dataset.Id, dataset.Properties)
+	}
+
+	return columns, nil
+}
+
+func toDatasetColumnList(columns []data.DatasetColumn) ([]*web.DatasetColumn, error) {
+	array := make([]*web.DatasetColumn, len(columns))
+	for i, c := range columns {
+		column, err := toDatasetColumn(c)
+		if err != nil {
+			return nil, err
+		}
+		array[i] = column
+	}
+	return array, nil
+}
+
+func toDatasetColumn(c data.DatasetColumn) (*web.DatasetColumn, error) {
+	var domain []string
+	if err := json.Unmarshal([]byte(c.Domain), &domain); err != nil {
+		return nil, errors.Wrapf(err, "decoding domain of column %s", c.Name)
+	}
+	var bins []int64
+	if err := json.Unmarshal([]byte(c.HistogramBins), &bins); err != nil {
+		return nil, errors.Wrapf(err, "decoding histogram of column %s", c.Name)
+	}
+
+	return &web.DatasetColumn{
+		c.Name,
+		c.Type,
+		c.MissingCount,
+		c.ZeroCount,
+		c.Min.Float64,
+		c.Max.Float64,
+		c.Mean.Float64,
+		c.Sigma.Float64,
+		domain,
+		c.DomainCardinality,
+		c.HistogramBase.Float64,
+		c.HistogramStride.Float64,
+		bins,
+		"",
+	}, nil
+}
@@ -0,0 +1,134 @@
+/*
+  Copyright (C) 2016 H2O.ai, Inc. <http://h2o.ai/>
+
+  This program is free software: you can redistribute it and/or modify
+  it under the terms of the GNU Affero General Public License as
+  published by the Free Software Foundation, either version 3 of the
+  License, or (at your option) any later version.
+
+  This program is distributed in the hope that it will be useful,
+  but WITHOUT ANY WARRANTY; without even the implied warranty of
+  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+  GNU Affero General Public License for more details.
+
+  You should have received a copy of the GNU Affero General Public License
+  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package web
+
+import (
+	"database/sql"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/h2oai/steam/master/data"
+)
+
+const profileFrame = `{"frames": [{"rows": 3, "columns": [
+	{"label": "Origin", "type": "enum", "missing_count": 1, "zero_count": 0, "mins": [0], "maxs": [1], "mean": 0.5, "sigma": 0.7, "domain": ["ORD", "SFO"], "domain_cardinality": 2, "data": [0, 1, "NaN"]},
+	{"label": "Distance", "type": "int", "missing_count": 0, "zero_count": 1, "mins": [0], "maxs": [2000], "mean": 900, "sigma": "NaN", "domain": null, "data": [0, 700, 2000]%s},
+	{"label": "Comment", "type": "string", "missing_count": 3, "mins": [], "maxs": [], "mean": "NaN", "sigma": "NaN", "data": null, "string_data": [null, null, null]}
+]}]}`
+
+func TestDatasetColumns(t *testing.T) {
+	svc, su, cleanup := newSandbox(t)
+	defer cleanup()
+
+	h2o := newFakeH2O()
+	h2o.handle("/3/Frames/airlines.hex/summary", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, profileFrame, `, "histogram_bins": [1, 0, 2], "histogram_base": 0, "histogram_stride": 700`)
+	})
+	defer h2o.Close()
+
+	clusterId, err := svc.RegisterCluster(su, h2o.address(), "", "", "", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	projectId, err := svc.CreateProject(su, "p1", "d1", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	datasourceId, err := svc.CreateDatasource(su, projectId, "airlines", "", "file", `{"path": "/data/airlines.csv"}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	properties := fmt.Sprintf(profileFrame, "")
+	datasetId, err := svc.ds.CreateDataset(su, data.Dataset{0, datasourceId, clusterId, sql.NullInt64{}, 0, sql.NullInt64{}, 0, "", "", "airlines", "", "airlines.hex", "", properties, "1", time.Now(), false})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Not yet profiled; decoded from the stored properties
+	columns, err := svc.GetDatasetColumns(su, datasetId, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(columns) != 3 {
+		t.Fatalf("expected 3 columns, got %d", len(columns))
+	}
+	if c := columns[0]; c.Name != "Origin" || c.MissingCount != 1 || len(c.Domain) != 2 || c.Domain[1] != "SFO" {
+		t.Fatalf("unexpected column: %+v", c)
+	}
+	if c := columns[2]; c.Type != "string" || c.Mean != 0 || len(c.Domain) != 0 {
+		t.Fatalf("unexpected column: %+v", c)
+	}
+
+	svc.profileDataset(su, datasetId, properties)
+	if columns, err = svc.GetDatasetColumns(su, datasetId, false); err != nil {
+		t.Fatal(err)
+	}
+	if c := columns[1]; c.Name != "Distance" || c.Max != 2000 || c.Sigma != 0 || len(c.HistogramBins) != 0 {
+		t.Fatalf("unexpected column: %+v", c)
+	}
+
+	if columns, err = svc.GetDatasetColumns(su, datasetId, true); err != nil {
+		t.Fatal(err)
+	}
+	if c := columns[1]; len(c.HistogramBins) != 3 || c.HistogramStride != 700 {
+		t.Fatalf("expected a refreshed histogram: %+v", c)
+	}
+	dataset, err := svc.GetDataset(su, datasetId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(dataset.JSONProperties, "histogram_bins") {
+		t.Fatal("expected refreshed frame properties to be stored")
+	}
+}
+
+func TestDatasetColumnsNullStatistics(t *testing.T) {
+	svc, su, cleanup := newSandbox(t)
+	defer cleanup()
+
+	projectId, err := svc.CreateProject(su, "p1", "d1", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	datasourceId, err := svc.CreateDatasource(su, projectId, "airlines", "", "file", `{"path": "/data/airlines.csv"}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// H2O reports statistics of an all-missing column as nulls
+	properties := `{"frames": [{"rows": 2, "columns": [
+		{"label": "Delay", "type": "real", "missing_count": 2, "mins": [null], "maxs": [null], "mean": null, "sigma": null, "data": [null, null], "histogram_base": null, "histogram_stride": null, "percentiles": [null]}
+	]}]}`
+	datasetId, err := svc.ds.CreateDataset(su, data.Dataset{0, datasourceId, 0, sql.NullInt64{}, 0, sql.NullInt64{}, 0, "", "", "airlines", "", "airlines.hex", "", properties, "1", time.Now(), false})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	columns, err := svc.GetDatasetColumns(su, datasetId, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(columns) != 1 {
+		t.Fatalf("expected 1 column, got %d", len(columns))
+	}
+	if c := columns[0]; c.Name != "Delay" || c.MissingCount != 2 || c.Mean != 0 || c.Sigma != 0 {
+		t.Fatalf("unexpected column: %+v", c)
+	}
+}
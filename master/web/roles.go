@@ -0,0 +1,157 @@
+/*
+  Copyright (C) 2016 H2O.ai, Inc. <http://h2o.ai/>
+
+  This program is free software: you can redistribute it and/or modify
+  it under the terms of the GNU Affero General Public License as
+  published by the Free Software Foundation, either version 3 of the
+  License, or (at your option) any later version.
+
+  This program is distributed in the hope that it will be useful,
+  but WITHOUT ANY WARRANTY; without even the implied warranty of
+  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+  GNU Affero General Public License for more details.
+
+  You should have received a copy of the GNU Affero General Public License
+  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package web
+
+import (
+	"fmt"
+
+	"github.com/h2oai/steam/master/az"
+	"github.com/h2oai/steam/master/data"
+	"github.com/h2oai/steam/srv/web"
+)
+
+// Column roles tell model builds how to use a dataset's columns. They map
+// onto H2O's response_column, ignored_columns, weights_column, fold_column
+// and offset_column model parameters; unassigned columns are features.
+
+var columnRoles = []string{
+	data.ResponseColumnRole,
+	data.IgnoredColumnRole,
+	data.WeightColumnRole,
+	data.FoldColumnRole,
+	data.OffsetColumnRole,
+}
+
+// checkColumnRole verifies that a column exists in a dataset's profile and
+// that its type suits the role.
+func checkColumnRole(datasetName string, columns []data.DatasetColumn, name, role string) error {
+	if role != "" && !containsString(columnRoles, role) {
+		return fmt.Errorf("Invalid column role %s: expected one of %v", role, columnRoles)
+	}
+
+	for _, c := range columns {
+		if c.Name != name {
+			continue
+		}
+		switch role {
+		case data.ResponseColumnRole:
+			if c.Type == "string" || c.Type == "uuid" {
+				return fmt.Errorf("Column %s holds %s values and cannot be a response", name, c.Type)
+			}
+		case data.WeightColumnRole, data.OffsetColumnRole:
+			if c.Type != "int" && c.Type != "real" {
+				return fmt.Errorf("Column %s holds %s values; a %s column must be numeric", name, c.Type, role)
+			}
+		case data.FoldColumnRole:
+			if c.Type != "int" && c.Type != "enum" {
+				return fmt.Errorf("Column %s holds %s values; a fold column must be an integer or categorical", name, c.Type)
+			}
+		}
+		return nil
+	}
+
+	return fmt.Errorf("Dataset %s has no column %s", datasetName, name)
+}
+
+// readColumnRoles returns the roles of a dataset's columns by name,
+// including its response column. Roles of columns a refreshed profile no
+// longer has are left out.
+func (s *Service) readColumnRoles(pz az.Principal, dataset data.Dataset, columns []data.DatasetColumn) (map[string]string, error) {
+	assigned, err := s.ds.ReadDatasetColumnRoles(pz, dataset.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	present := make(map[string]bool, len(columns))
+	for _, c := range columns {
+		present[c.Name] = true
+	}
+	roles := make(map[string]string, len(assigned)+1)
+	for _, r := range assigned {
+		if present[r.Name] {
+			roles[r.Name] = r.Role
+		}
+	}
+	if present[dataset.ResponseColumnName] {
+		roles[dataset.ResponseColumnName] = data.ResponseColumnRole
+	}
+	return roles, nil
+}
+
+func (s *Service) GetDatasetColumnRoles(pz az.Principal, datasetId int64) (*web.DatasetColumnRoles, error) {
+	if err := pz.CheckPermission(s.ds.Permissions.ViewDataset); err != nil {
+		return nil, err
+	}
+
+	dataset, err := s.ds.ReadDataset(pz, datasetId)
+	if err != nil {
+		return nil, err
+	}
+	columns, err := s.readDatasetColumns(pz, dataset)
+	if err != nil {
+		return nil, err
+	}
+	roles, err := s.readColumnRoles(pz, dataset, columns)
+	if err != nil {
+		return nil, err
+	}
+
+	// Follow frame order, so ignored columns are listed as H2O lists them
+	r := &web.DatasetColumnRoles{
+		DatasetId:      datasetId,
+		IgnoredColumns: []string{},
+	}
+	for _, c := range columns {
+		switch roles[c.Name] {
+		case data.ResponseColumnRole:
+			r.ResponseColumnName = c.Name
+		case data.IgnoredColumnRole:
+			r.IgnoredColumns = append(r.IgnoredColumns, c.Name)
+		case data.WeightColumnRole:
+			r.WeightsColumn = c.Name
+		case data.FoldColumnRole:
+			r.FoldColumn = c.Name
+		case data.OffsetColumnRole:
+			r.OffsetColumn = c.Name
+		}
+	}
+	return r, nil
+}
+
+// SetDatasetColumnRole assigns a role to a column, replacing the role it had.
+// Only one column can be the response, weight, fold or offset column, so
+// assigning one of those takes it from the column that held it.
+func (s *Service) SetDatasetColumnRole(pz az.Principal, datasetId int64, columnName, role string) error {
+	if err := pz.CheckPermission(s.ds.Permissions.ManageDataset); err != nil {
+		return err
+	}
+
+	dataset, err := s.ds.ReadDataset(pz, datasetId)
+	if err != nil {
+		return err
+	}
+	columns, err := s.readDatasetColumns(pz, dataset)
+	if err != nil {
+		return err
+	}
+	if err := checkColumnRole(dataset.Name, columns, columnName, role); err != nil {
+		return err
+	}
+
+	return s.ds.UpdateDatasetColumnRole(pz, datasetId, columnName, role)
+}
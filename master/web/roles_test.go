@@ -0,0 +1,191 @@
+/*
+  Copyright (C) 2016 H2O.ai, Inc. <http://h2o.ai/>
+
+  This program is free software: you can redistribute it and/or modify
+  it under the terms of the GNU Affero General Public License as
+  published by the Free Software Foundation, either version 3 of the
+  License, or (at your option) any later version.
+
+  This program is distributed in the hope that it will be useful,
+  but WITHOUT ANY WARRANTY; without even the implied warranty of
+  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+  GNU Affero General Public License for more details.
+
+  You should have received a copy of the GNU Affero General Public License
+  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package web
+
+import (
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/h2oai/steam/master/az"
+	"github.com/h2oai/steam/master/data"
+)
+
+func createProfiledDataset(t *testing.T, svc *Service, su az.Principal, datasourceId int64, histogram string) int64 {
+	properties := fmt.Sprintf(profileFrame, histogram)
+	datasetId, err := svc.ds.CreateDataset(su, data.Dataset{0, datasourceId, 0, sql.NullInt64{}, 0, sql.NullInt64{}, 0, "", "", "airlines", "", "airlines.hex", "", properties, "1", time.Now(), false})
+	if err != nil {
+		t.Fatal(err)
+	}
+	svc.profileDataset(su, datasetId, properties)
+	return datasetId
+}
+
+func TestDatasetColumnRoles(t *testing.T) {
+	svc, su, cleanup := newSandbox(t)
+	defer cleanup()
+
+	projectId, err := svc.CreateProject(su, "p1", "d1", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	datasourceId, err := svc.CreateDatasource(su, projectId, "airlines", "", "file", `{"path": "/data/airlines.csv"}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	datasetId := createProfiledDataset(t, svc, su, datasourceId, "")
+
+	if err := svc.UpdateDataset(su, datasetId, "airlines", "", "Dest"); err == nil {
+		t.Fatal("expected an unknown response column to be refused")
+	}
+	if err := svc.UpdateDataset(su, datasetId, "airlines", "", "Comment"); err == nil {
+		t.Fatal("expected a string response column to be refused")
+	}
+	if err := svc.UpdateDataset(su, datasetId, "flights", "", "Origin"); err != nil {
+		t.Fatal(err)
+	}
+	dataset, err := svc.GetDataset(su, datasetId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if dataset.Name != "flights" || dataset.ResponseColumnName != "Origin" {
+		t.Fatalf("unexpected dataset: %+v", dataset)
+	}
+
+	if err := svc.SetDatasetColumnRole(su, datasetId, "Comment", "label"); err == nil {
+		t.Fatal("expected an invalid role to be refused")
+	}
+	if err := svc.SetDatasetColumnRole(su, datasetId, "Comment", data.WeightColumnRole); err == nil {
+		t.Fatal("expected a string weight column to be refused")
+	}
+	if err := svc.SetDatasetColumnRole(su, datasetId, "Dest", data.IgnoredColumnRole); err == nil {
+		t.Fatal("expected an unknown column to be refused")
+	}
+	if err := svc.SetDatasetColumnRole(su, datasetId, "Comment", data.IgnoredColumnRole); err != nil {
+		t.Fatal(err)
+	}
+	if err := svc.SetDatasetColumnRole(su, datasetId, "Distance", data.WeightColumnRole); err != nil {
+		t.Fatal(err)
+	}
+	roles, err := svc.GetDatasetColumnRoles(su, datasetId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if roles.ResponseColumnName != "Origin" || roles.WeightsColumn != "Distance" || !reflect.DeepEqual(roles.IgnoredColumns, []string{"Comment"}) {
+		t.Fatalf("unexpected roles: %+v", roles)
+	}
+
+	// Moving the response takes it from its column and replaces the new
+	// column's role
+	if err := svc.SetDatasetColumnRole(su, datasetId, "Distance", data.ResponseColumnRole); err != nil {
+		t.Fatal(err)
+	}
+	if err := svc.SetDatasetColumnRole(su, datasetId, "Origin", data.FoldColumnRole); err != nil {
+		t.Fatal(err)
+	}
+	columns, err := svc.GetDatasetColumns(su, datasetId, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []string{data.FoldColumnRole, data.ResponseColumnRole, data.IgnoredColumnRole}
+	for i, c := range columns {
+		if c.Role != expected[i] {
+			t.Fatalf("expected column %s to be %q, got %q", c.Name, expected[i], c.Role)
+		}
+	}
+
+	if err := svc.SetDatasetColumnRole(su, datasetId, "Distance", ""); err != nil {
+		t.Fatal(err)
+	}
+	if roles, err = svc.GetDatasetColumnRoles(su, datasetId); err != nil {
+		t.Fatal(err)
+	}
+	if roles.ResponseColumnName != "" || roles.WeightsColumn != "" || roles.FoldColumn != "Origin" {
+		t.Fatalf("unexpected roles: %+v", roles)
+	}
+}
+
+func TestCompareDatasetsIgnoredColumns(t *testing.T) {
+	svc, su, cleanup := newSandbox(t)
+	defer cleanup()
+
+	projectId, err := svc.CreateProject(su, "p1", "d1", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	datasourceId, err := svc.CreateDatasource(su, projectId, "airlines", "", "file", `{"path": "/data/airlines.csv"}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	baseId := createProfiledDataset(t, svc, su, datasourceId, `, "histogram_bins": [3, 0, 0], "histogram_base": 0, "histogram_stride": 700`)
+	otherId := createProfiledDataset(t, svc, su, datasourceId, `, "histogram_bins": [0, 0, 3], "histogram_base": 0, "histogram_stride": 700`)
+
+	comparison, err := svc.CompareDatasets(su, baseId, otherId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if comparison.MaxPSI == 0 {
+		t.Fatal("expected the shifted column to count towards the maximum PSI")
+	}
+
+	if err := svc.SetDatasetColumnRole(su, otherId, "Distance", data.IgnoredColumnRole); err != nil {
+		t.Fatal(err)
+	}
+	if comparison, err = svc.CompareDatasets(su, baseId, otherId); err != nil {
+		t.Fatal(err)
+	}
+	if comparison.MaxPSI != 0 {
+		t.Fatalf("expected an ignored column not to count towards the maximum PSI, got %v", comparison.MaxPSI)
+	}
+	if c := comparison.Columns[1]; c.Name != "Distance" || !c.HasPSI || c.Role != data.IgnoredColumnRole {
+		t.Fatalf("unexpected column comparison: %+v", c)
+	}
+}
+
+func TestCreateDatasetUnknownResponse(t *testing.T) {
+	svc, su, cleanup := newSandbox(t)
+	defer cleanup()
+
+	h2o, _ := newFakeImports("DONE")
+	defer h2o.Close()
+
+	clusterId, err := svc.RegisterCluster(su, h2o.address(), "", "", "", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	projectId, err := svc.CreateProject(su, "p1", "d1", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	datasourceId, err := svc.CreateDatasource(su, projectId, "airlines", "", "file", `{"path": "/data/airlines.csv"}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	jobId, err := svc.CreateDataset(su, clusterId, datasourceId, "airlines", "", "Dest", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	job := waitForDatasetJob(t, svc, su, jobId, finished)
+	if job.State != data.ImportFailedState || !strings.Contains(job.Error, "no column Dest") || job.DatasetId != 0 {
+		t.Fatalf("expected the import to fail on the unknown response column: %+v", job)
+	}
+}
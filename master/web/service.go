@@ -18,28 +18,33 @@
 package web
 
 import (
+	"context"
 	"database/sql"
-	"encoding/json"
 	"fmt"
 	"log"
 	"math/rand"
 	"net"
+	"os"
 	"path"
 	"sort"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/h2oai/steam/bindings"
+	"github.com/h2oai/steam/lib/engine"
 	"github.com/h2oai/steam/lib/fs"
 	"github.com/h2oai/steam/lib/svc"
 	"github.com/h2oai/steam/lib/yarn"
 	"github.com/h2oai/steam/master/auth"
 	"github.com/h2oai/steam/master/az"
 	"github.com/h2oai/steam/master/data"
+	"github.com/h2oai/steam/master/proxy"
 	"github.com/h2oai/steam/srv/compiler"
 	"github.com/h2oai/steam/srv/h2ov3"
 	"github.com/h2oai/steam/srv/web"
+	"github.com/lib/pq"
 	"github.com/pkg/errors"
 )
 
@@ -54,6 +59,16 @@ type Service struct {
 	kerberosEnabled           bool
 	username                  string
 	keytab                    string
+	launchesMu                sync.Mutex
+	launches                  map[int64]context.CancelFunc
+	datasetJobsMu             sync.Mutex
+	datasetJobs               map[int64]context.CancelFunc
+	datasetUploadLimit        int64
+	uploadsMu                 sync.Mutex
+	uploads                   map[string]*uploadLock
+	launchers                 map[int64]ClusterLauncher
+	clusterDeleted            []func(int64)
+	driverOptions             *yarn.DriverOptionPolicy
 }
 
 func NewService(
@@ -71,6 +86,19 @@ func NewService(
 		scoringServicePortsRange[0], scoringServicePortsRange[1],
 		kerberos,
 		username, keytab,
+		sync.Mutex{},
+		make(map[int64]context.CancelFunc),
+		sync.Mutex{},
+		make(map[int64]context.CancelFunc),
+		DefaultDatasetUploadLimit,
+		sync.Mutex{},
+		make(map[string]*uploadLock),
+		map[int64]ClusterLauncher{
+			ds.ClusterTypes.Yarn:  &yarnLauncher{kerberos},
+			ds.ClusterTypes.Local: NewLocalLauncher(workingDir, "java", DefaultLocalClusterHost, DefaultLocalClusterBasePort),
+		},
+		nil,
+		defaultDriverOptionPolicy,
 	}
 }
 
@@ -93,13 +121,19 @@ func (s *Service) GetConfig(pz az.Principal) (*web.Config, error) {
 	}, nil
 }
 
-func (s *Service) RegisterCluster(pz az.Principal, address string) (int64, error) {
+func (s *Service) RegisterCluster(pz az.Principal, address, scheme, caCert, username, password string) (int64, error) {
 
 	if err := pz.CheckPermission(s.ds.Permissions.ManageCluster); err != nil {
 		return 0, err
 	}
 
-	h := h2ov3.NewClient(address)
+	if scheme == "" {
+		scheme = "http"
+	}
+	h, err := h2ov3.NewSecureClient(address, h2ov3.Connection{scheme, caCert, username, password})
+	if err != nil {
+		return 0, err
+	}
 	cloud, err := h.GetCloudStatus()
 	if err != nil {
 		return 0, fmt.Errorf("Could not communicate with an h2o cluster at %s", address)
@@ -114,7 +148,7 @@ func (s *Service) RegisterCluster(pz az.Principal, address string) (int64, error
 		return 0, fmt.Errorf("A cluster with the address %s is already registered", address)
 	}
 
-	clusterId, err := s.ds.CreateExternalCluster(pz, cloud.CloudName, address, data.StartedState)
+	clusterId, err := s.ds.CreateSecureExternalCluster(pz, cloud.CloudName, address, data.StartedState, scheme, caCert, username, password)
 	if err != nil {
 		return 0, err
 	}
@@ -122,6 +156,29 @@ func (s *Service) RegisterCluster(pz az.Principal, address string) (int64, error
 	return clusterId, nil
 }
 
+// h2oClient returns a client for the cluster that uses its connection settings.
+func (s *Service) h2oClient(cluster data.Cluster) (*h2ov3.H2O, error) {
+	password, err := s.ds.DecryptSecret(cluster.Password)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed reading credentials of cluster %d", cluster.Id)
+	}
+	return h2ov3.NewSecureClient(cluster.Address, h2ov3.Connection{cluster.Scheme, cluster.CaCert, cluster.Username, password})
+}
+
+// clusterCheckTimeout bounds each request background checks make to a
+// cluster, so that a cluster that stops answering cannot hold them up.
+var clusterCheckTimeout = 30 * time.Second
+
+// h2oCheckClient returns a client for short requests to a cluster, which
+// time out after clusterCheckTimeout.
+func (s *Service) h2oCheckClient(cluster data.Cluster) (*h2ov3.H2O, error) {
+	h2o, err := s.h2oClient(cluster)
+	if err != nil {
+		return nil, err
+	}
+	return h2o.WithTimeout(clusterCheckTimeout), nil
+}
+
 func (s *Service) UnregisterCluster(pz az.Principal, clusterId int64) error {
 	if err := pz.CheckPermission(s.ds.Permissions.ManageCluster); err != nil {
 		return err
@@ -136,20 +193,61 @@ func (s *Service) UnregisterCluster(pz az.Principal, clusterId int64) error {
 		return fmt.Errorf("Cannot unregister internal clusters.")
 	}
 
-	if err := s.ds.DeleteCluster(pz, clusterId); err != nil {
+	if err := s.deleteCluster(pz, clusterId); err != nil {
 		return err
 	}
 
 	return nil
 }
 
-func (s *Service) StartClusterOnYarn(pz az.Principal, clusterName string, engineId int64, size int, memory, keytab string) (int64, error) {
+func (s *Service) StartClusterOnYarn(pz az.Principal, clusterName string, engineId int64, size int, memory string, keytabId int64, queue, nodeLabel, driverArgs string) (int64, error) {
+	return s.startCluster(pz, s.ds.ClusterTypes.Yarn, clusterName, clusterShape{engineId, size, memory, keytabId, queue, nodeLabel, strings.Fields(driverArgs), 0, 0})
+}
+
+func (s *Service) StartCluster(pz az.Principal, clusterName, clusterType string, engineId int64, size int, memory string, keytabId int64) (int64, error) {
+	for _, ct := range s.ds.ReadClusterTypes(pz) {
+		if ct.Name == clusterType {
+			return s.startCluster(pz, ct.Id, clusterName, clusterShape{engineId, size, memory, keytabId, "", "", nil, 0, 0})
+		}
+	}
+	return 0, fmt.Errorf("Invalid cluster type %s", clusterType)
+}
+
+// clusterShape describes what a new cluster is launched with.
+type clusterShape struct {
+	EngineId    int64
+	Size        int
+	Memory      string
+	KeytabId    int64 // 0 launches without a keytab
+	Queue       string
+	NodeLabel   string
+	DriverArgs  []string
+	IdleTimeout int64 // Minutes; 0 inherits the launching identity's workgroup default
+	TemplateId  int64
+}
+
+func (s *Service) startCluster(pz az.Principal, clusterTypeId int64, clusterName string, shape clusterShape) (int64, error) {
 	if err := pz.CheckPermission(s.ds.Permissions.ManageCluster); err != nil {
 		return 0, err
 	}
 
+	// The name is used in paths on the Steam and Hadoop hosts
+	if err := fs.ValidateName(clusterName); err != nil {
+		return 0, fmt.Errorf("Invalid cluster name: %s", err)
+	}
+
+	launcher, ok := s.clusterLauncher(clusterTypeId)
+	if !ok {
+		return 0, fmt.Errorf("Clusters of this type cannot be started by Steam; register them instead")
+	}
+
+	driverArgs, err := s.checkYarnOptions(clusterTypeId, shape.Queue, shape.NodeLabel, shape.DriverArgs)
+	if err != nil {
+		return 0, err
+	}
+
 	// Cluster should have a unique name
-	_, ok, err := s.ds.ReadClusterByName(pz, clusterName)
+	_, ok, err = s.ds.ReadClusterByName(pz, clusterName)
 	if err != nil {
 		return 0, err
 	}
@@ -162,38 +260,78 @@ func (s *Service) StartClusterOnYarn(pz az.Principal, clusterName string, engine
 		return 0, err
 	}
 
-	engine, err := s.ds.ReadEngine(pz, engineId)
+	engine, err := s.ds.ReadEngine(pz, shape.EngineId)
 	if err != nil {
 		return 0, err
 	}
+	if err := s.checkEngine(engine, clusterTypeId); err != nil {
+		return 0, err
+	}
 
-	// FIXME check if file exists
-	keytabPath := path.Join(s.workingDir, fs.KTDir, keytab)
-
-	appId, address, out, err := yarn.StartCloud(size, s.kerberosEnabled, memory, clusterName, engine.Location, identity.Name, keytabPath)
+	keytabPath, err := s.resolveKeytab(pz, shape.KeytabId)
 	if err != nil {
 		return 0, err
 	}
+	if keytabPath == "" && s.kerberosEnabled && clusterTypeId == s.ds.ClusterTypes.Yarn {
+		return 0, fmt.Errorf("A keytab is required to start clusters on a Kerberos-enabled Hadoop cluster")
+	}
+
+	// Unless set, clusters inherit the idle timeout of the launching identity's workgroups
+	idleTimeout := shape.IdleTimeout
+	if idleTimeout == 0 {
+		idleTimeout, err = s.ds.ReadDefaultIdleTimeout(pz, identity.Id)
+		if err != nil {
+			return 0, err
+		}
+	}
 
+	// Record the cluster up front; the launch itself runs in the background
 	yarnCluster := data.YarnCluster{
 		0,
-		engineId,
-		int64(size),
-		appId,
-		memory,
+		shape.EngineId,
+		int64(shape.Size),
+		"",
+		shape.Memory,
 		identity.Name,
-		out,
+		"",
+		keytabPath,
+		idleTimeout,
+		pq.NullTime{time.Now(), true}, // Idle time counts from the launch
+		shape.TemplateId,
+		shape.Queue,
+		shape.NodeLabel,
+		strings.Join(driverArgs, " "),
+	}
+
+	var clusterId int64
+	if clusterTypeId == s.ds.ClusterTypes.Local {
+		clusterId, err = s.ds.CreateLocalCluster(pz, clusterName, "", data.StartingState, yarnCluster)
+	} else {
+		clusterId, err = s.ds.CreateYarnCluster(pz, clusterName, "", data.StartingState, yarnCluster)
 	}
-
-	clusterId, err := s.ds.CreateYarnCluster(pz, clusterName, address, data.StartedState, yarnCluster)
 	if err != nil {
 		return 0, err
 	}
 
+	spec := LaunchSpec{
+		clusterName,
+		engine.Location,
+		shape.Size,
+		shape.Memory,
+		identity.Name,
+		keytabPath,
+		shape.Queue,
+		shape.NodeLabel,
+		driverArgs,
+	}
+	if err := s.launchCluster(pz, clusterId, launcher, spec); err != nil {
+		return 0, err
+	}
+
 	return clusterId, nil
 }
 
-func (s *Service) StopClusterOnYarn(pz az.Principal, clusterId int64, keytab string) error {
+func (s *Service) StopClusterOnYarn(pz az.Principal, clusterId, keytabId int64) error {
 	if err := pz.CheckPermission(s.ds.Permissions.ManageCluster); err != nil {
 		return err
 	}
@@ -212,25 +350,173 @@ func (s *Service) StopClusterOnYarn(pz az.Principal, clusterId int64, keytab str
 	if cluster.State == data.StoppedState {
 		return fmt.Errorf("Cluster %d is already stopped", clusterId)
 	}
+	if cluster.State == data.StartingState {
+		return fmt.Errorf("Cluster %d is still starting; cancel the launch instead", clusterId)
+	}
 	// Get cluster information
 	yarnCluster, err := s.ds.ReadYarnCluster(pz, clusterId)
 	if err != nil {
 		return errors.Wrap(err, "failed reading yarn cluster")
 	}
+
 	//	Get username
 	identity, err := s.ds.ReadIdentity(pz, pz.Id())
 	if err != nil {
 		return errors.Wrap(err, "failed reading identity")
 	}
 
-	// FIXME check if file exists
-	keytabPath := path.Join(s.workingDir, fs.KTDir, keytab)
-	if err := yarn.StopCloud(s.kerberosEnabled, cluster.Name, yarnCluster.ApplicationId,
-		yarnCluster.OutputDir, identity.Name, keytabPath); err != nil {
+	// Without a keytab, only the identity that started the cluster can stop
+	// it, with the keytab it started with
+	if keytabId == 0 {
+		if identity.Name != yarnCluster.Username {
+			return fmt.Errorf("Cluster %d was started by another identity; stop it with a keytab of your own", clusterId)
+		}
+		return s.stopCluster(pz, cluster, yarnCluster, yarnCluster.Username, yarnCluster.Keytab)
+	}
+
+	keytabPath, err := s.resolveKeytab(pz, keytabId)
+	if err != nil {
+		return err
+	}
+
+	return s.stopCluster(pz, cluster, yarnCluster, identity.Name, keytabPath)
+}
+
+func (s *Service) StopCluster(pz az.Principal, clusterId int64) error {
+	if err := pz.CheckPermission(s.ds.Permissions.ManageCluster); err != nil {
+		return err
+	}
+
+	cluster, err := s.ds.ReadCluster(pz, clusterId)
+	if err != nil {
+		return errors.Wrap(err, "failed reading cluster")
+	}
+	if _, ok := s.clusterLauncher(cluster.TypeId); !ok {
+		return fmt.Errorf("Cluster %d was not started by Steam; unregister it instead", clusterId)
+	}
+	if err := pz.CheckOwns(s.ds.EntityTypes.Cluster, clusterId); err != nil {
+		return err
+	}
+
+	// Clusters on YARN are stopped with Kerberos credentials, which only
+	// their launcher may borrow from the cluster
+	if cluster.TypeId == s.ds.ClusterTypes.Yarn {
+		return s.StopClusterOnYarn(pz, clusterId, 0)
+	}
+
+	if cluster.State == data.StoppedState {
+		return fmt.Errorf("Cluster %d is already stopped", clusterId)
+	}
+	if cluster.State == data.StartingState {
+		return fmt.Errorf("Cluster %d is still starting; cancel the launch instead", clusterId)
+	}
+
+	yarnCluster, err := s.ds.ReadYarnCluster(pz, clusterId)
+	if err != nil {
+		return errors.Wrap(err, "failed reading cluster details")
+	}
+
+	return s.stopCluster(pz, cluster, yarnCluster, yarnCluster.Username, yarnCluster.Keytab)
+}
+
+// stopCluster shuts down a cluster through its launcher and deletes its record.
+// The keytab is a path as recorded at launch.
+func (s *Service) stopCluster(pz az.Principal, cluster data.Cluster, yarnCluster data.YarnCluster, username, keytab string) error {
+	// Check before stopping, so the record is not left behind a stopped cluster
+	if err := pz.CheckOwns(s.ds.EntityTypes.Cluster, cluster.Id); err != nil {
+		return err
+	}
+
+	launcher, ok := s.clusterLauncher(cluster.TypeId)
+	if !ok {
+		return fmt.Errorf("Cluster %d was not started by Steam", cluster.Id)
+	}
+
+	// A failed cluster's application is already gone; only the record remains
+	if cluster.State != data.FailedState {
+		spec := LaunchSpec{
+			cluster.Name,
+			"",
+			int(yarnCluster.Size),
+			yarnCluster.Memory,
+			username,
+			s.launchedKeytabPath(keytab),
+			"",
+			"",
+			nil,
+		}
+		if err := launcher.Stop(spec, yarnCluster.ApplicationId, yarnCluster.OutputDir); err != nil {
+			return err
+		}
+	}
+
+	return s.deleteCluster(pz, cluster.Id)
+}
+
+// launchedKeytabPath returns the location of a keytab recorded with a cluster.
+// Clusters launched before keytab uploads recorded a file name under the
+// keytab directory.
+func (s *Service) launchedKeytabPath(keytab string) string {
+	if keytab != "" && !path.IsAbs(keytab) {
+		return path.Join(s.workingDir, fs.KTDir, keytab)
+	}
+	return keytab
+}
+
+func (s *Service) SetClusterIdleTimeout(pz az.Principal, clusterId, minutes int64) error {
+	if err := pz.CheckPermission(s.ds.Permissions.ManageCluster); err != nil {
+		return err
+	}
+
+	if minutes < 0 {
+		return fmt.Errorf("Idle timeout cannot be negative")
+	}
+
+	cluster, err := s.ds.ReadCluster(pz, clusterId)
+	if err != nil {
+		return errors.Wrap(err, "failed reading cluster")
+	}
+	if _, ok := s.clusterLauncher(cluster.TypeId); !ok {
+		return fmt.Errorf("Cluster %d was not started by Steam", clusterId)
+	}
+
+	return s.ds.UpdateYarnClusterIdleTimeout(pz, clusterId, minutes)
+}
+
+func (s *Service) KeepAlive(pz az.Principal, clusterId int64) error {
+	if err := pz.CheckPermission(s.ds.Permissions.ViewCluster); err != nil {
 		return err
 	}
 
-	return s.ds.DeleteCluster(pz, clusterId)
+	cluster, err := s.ds.ReadCluster(pz, clusterId)
+	if err != nil {
+		return errors.Wrap(err, "failed reading cluster")
+	}
+	if _, ok := s.clusterLauncher(cluster.TypeId); !ok {
+		return fmt.Errorf("Cluster %d was not started by Steam", clusterId)
+	}
+
+	return s.ds.KeepAliveYarnCluster(pz, clusterId)
+}
+
+func (s *Service) GetClusterProxyPolicy(pz az.Principal, clusterId int64) (string, error) {
+	if err := pz.CheckPermission(s.ds.Permissions.ViewCluster); err != nil {
+		return "", err
+	}
+
+	return s.ds.ReadClusterProxyPolicy(pz, clusterId)
+}
+
+func (s *Service) SetClusterProxyPolicy(pz az.Principal, clusterId int64, policy string) error {
+	if err := pz.CheckPermission(s.ds.Permissions.ManageCluster); err != nil {
+		return err
+	}
+
+	if _, err := proxy.ParsePolicy(policy); err != nil {
+		return err
+	}
+
+	return s.ds.UpdateClusterProxyPolicy(pz, clusterId, policy)
 }
 
 func (s *Service) GetCluster(pz az.Principal, clusterId int64) (*web.Cluster, error) {
@@ -285,8 +571,8 @@ func (s *Service) GetClusters(pz az.Principal, offset, limit int64) ([]*web.Clus
 
 // Returns the Cloud status from H2O
 // This method should only be called if the cluster reports a non-Stopped status
-// If the cloud was shut down from the outside of steam, will report Unknown
-// / status for cloud
+// If the cloud was shut down from the outside of steam, will report the state
+// recorded by the cluster health reconciler (disconnected or failed)
 //
 // TODO: Maybe this should only report if non-Stopped,non-Unknown status
 //       In the case of Unknown, should only check if forced?
@@ -300,11 +586,19 @@ func (s *Service) GetClusterStatus(pz az.Principal, cloudId int64) (*web.Cluster
 		return nil, err
 	}
 
-	h2o := h2ov3.NewClient(cluster.Address)
+	h2o, err := s.h2oClient(cluster)
+	if err != nil {
+		return nil, err
+	}
 
 	stat, err := h2o.GetCloudStatus()
 	if err != nil {
-		return &web.ClusterStatus{Status: "Unknown"}, nil
+		// Report the reconciled state; a cluster recorded as started is unreachable now
+		status := cluster.State
+		if status == data.StartedState {
+			status = data.DisconnectedState
+		}
+		return &web.ClusterStatus{Status: status}, nil
 	}
 
 	var (
@@ -344,11 +638,27 @@ func (s *Service) DeleteCluster(pz az.Principal, clusterId int64) error {
 		return err
 	}
 
-	if cluster.State != data.StoppedState {
+	if cluster.State != data.StoppedState && cluster.State != data.FailedState {
 		return fmt.Errorf("Cannot delete a running cluster")
 	}
 
-	return s.ds.DeleteCluster(pz, clusterId)
+	return s.deleteCluster(pz, clusterId)
+}
+
+// OnClusterDeleted registers a function to be called whenever a cluster is
+// deleted, e.g. to drop state kept for the cluster elsewhere.
+func (s *Service) OnClusterDeleted(f func(clusterId int64)) {
+	s.clusterDeleted = append(s.clusterDeleted, f)
+}
+
+func (s *Service) deleteCluster(pz az.Principal, clusterId int64) error {
+	if err := s.ds.DeleteCluster(pz, clusterId); err != nil {
+		return err
+	}
+	for _, f := range s.clusterDeleted {
+		f(clusterId)
+	}
+	return nil
 }
 
 type Jobs []*web.Job
@@ -385,7 +695,10 @@ func (s *Service) GetJob(pz az.Principal, clusterId int64, jobName string) (*web
 		return nil, err
 	}
 
-	h := h2ov3.NewClient(cluster.Address)
+	h, err := s.h2oClient(cluster)
+	if err != nil {
+		return nil, err
+	}
 
 	j, err := h.GetJobsFetch(jobName)
 	if err != nil {
@@ -406,7 +719,10 @@ func (s *Service) GetJobs(pz az.Principal, clusterId int64) ([]*web.Job, error)
 		return nil, err
 	}
 
-	h := h2ov3.NewClient(cluster.Address)
+	h, err := s.h2oClient(cluster)
+	if err != nil {
+		return nil, err
+	}
 
 	j, err := h.GetJobsList()
 	if err != nil {
@@ -466,7 +782,7 @@ func (s *Service) GetProject(pz az.Principal, projectId int64) (*web.Project, er
 
 func (s *Service) deleteImplicitDatasouce(pz az.Principal, d data.Datasource) error {
 	// Verify Datasource is Implicit
-	if d.Kind != "Implicit" {
+	if d.Kind != data.DatasourceImplicit {
 		return fmt.Errorf("non-implicit datasource: %s", d.Name)
 	}
 
@@ -507,7 +823,7 @@ func (s *Service) DeleteProject(pz az.Principal, projectId int64) error {
 			return errors.Wrap(err, "failed to read datasources for project")
 		}
 		for _, d := range ds {
-			if d.Kind != "Implicit" {
+			if d.Kind != data.DatasourceImplicit {
 				return fmt.Errorf("project contains at least one datasource")
 			}
 			if err := s.deleteImplicitDatasouce(pz, d); err != nil {
@@ -521,13 +837,13 @@ func (s *Service) DeleteProject(pz az.Principal, projectId int64) error {
 
 // --- Datasource ---
 
-func (s *Service) CreateDatasource(pz az.Principal, projectId int64, name, description, filePath string) (int64, error) {
+func (s *Service) CreateDatasource(pz az.Principal, projectId int64, name, description, kind, configuration string) (int64, error) {
 	if err := pz.CheckPermission(s.ds.Permissions.ManageDatasource); err != nil {
 		return 0, err
 	}
 
-	mapPath := map[string]string{"path": filePath}
-	jsonPath, err := json.Marshal(mapPath)
+	kind = normalizeDatasourceKind(kind)
+	config, err := s.newDatasourceConfig(kind, configuration, nil)
 	if err != nil {
 		return 0, err
 	}
@@ -537,8 +853,8 @@ func (s *Service) CreateDatasource(pz az.Principal, projectId int64, name, descr
 		projectId,
 		name,
 		description,
-		"CSV", // FIXME: this is hardcoded
-		string(jsonPath),
+		kind,
+		config,
 		time.Now(),
 	}
 
@@ -576,24 +892,37 @@ func (s *Service) GetDatasource(pz az.Principal, datasourceId int64) (*web.Datas
 	return toDatasource(datasource), nil
 }
 
-func (s *Service) UpdateDatasource(pz az.Principal, datasourceId int64, name, description, filePath string) error {
+func (s *Service) UpdateDatasource(pz az.Principal, datasourceId int64, name, description, kind, configuration string) error {
 	if err := pz.CheckPermission(s.ds.Permissions.ManageDatasource); err != nil {
 		return err
 	}
 
-	mapPath := map[string]string{"path": filePath}
-	jsonPath, err := json.Marshal(mapPath)
+	current, err := s.ds.ReadDatasource(pz, datasourceId)
 	if err != nil {
 		return err
 	}
+	if current.Kind == data.DatasourceImplicit {
+		return fmt.Errorf("Datasources of imported models cannot be changed")
+	}
+
+	kind = normalizeDatasourceKind(kind)
+	config := current.Configuration
+	if current.Kind == data.DatasourceUpload {
+		// The uploaded file stays; only the name and description can change
+		if kind != current.Kind {
+			return fmt.Errorf("Uploaded datasources cannot change kind")
+		}
+	} else if config, err = s.newDatasourceConfig(kind, configuration, &current); err != nil {
+		return err
+	}
 
 	datasource := data.Datasource{
 		0,
 		0,
 		name,
 		description,
-		"CSV", // FIXME this is hardcoded
-		string(jsonPath),
+		kind,
+		config,
 		time.Now(),
 	}
 
@@ -618,103 +947,47 @@ func (s *Service) DeleteDatasource(pz az.Principal, datasourceId int64) error {
 		return fmt.Errorf("A dataset is still using this datasource.")
 	}
 
-	if err := s.ds.DeleteDatasource(pz, datasourceId); err != nil {
-		return err
-	}
-
-	return nil
-}
-
-// --- Dataset ---
-
-func (s *Service) importDataset(name, configuration, address string) ([]byte, string, error) {
-	h2o := h2ov3.NewClient(address)
-
-	// Translate json to string path
-	rawJson := make(map[string]string)
-	if err := json.Unmarshal([]byte(configuration), &rawJson); err != nil {
-		return nil, "", err
-	}
-	filePath, ok := rawJson["path"]
-	if !ok {
-		return nil, "", fmt.Errorf("Cannot locate path: Empty datasource configuration")
-	}
-
-	importBody, err := h2o.PostImportFilesImportfiles(filePath)
-	if err != nil {
-		return nil, "", err
-	}
-
-	parseSetupBody, err := h2o.PostParseSetupGuesssetup(importBody.DestinationFrames)
+	datasource, err := s.ds.ReadDatasource(pz, datasourceId)
 	if err != nil {
-		return nil, "", err
+		return err
 	}
 
-	parseParms := bindings.NewParseV3()
-	parseParms.FromParseSetup(*parseSetupBody)
-	parseParms.Blocking = true
-	parseBody, err := h2o.PostParseParse(parseParms)
-	if err != nil {
-		log.Fatalln(err)
+	if err := s.ds.DeleteDatasource(pz, datasourceId); err != nil {
+		return err
 	}
 
-	job, err := h2o.JobPoll(parseBody.Job.Key.Name)
-	if err != nil {
-		return nil, "", err
-	}
-	rawFrame, _, err := h2o.GetFramesFetch(job.Dest.Name, false)
-	if err != nil {
-		return nil, "", err
+	if datasource.Kind == data.DatasourceUpload {
+		if c, err := s.openDatasourceConfig(datasource); err == nil {
+			if err := os.Remove(c.Path); err != nil {
+				log.Printf("Failed removing uploaded file of datasource %d: %v\n", datasourceId, err)
+			}
+		}
 	}
 
-	return rawFrame, parseParms.DestinationFrame.Name, err
+	return nil
 }
 
-func (s *Service) CreateDataset(pz az.Principal, clusterId int64, datasourceId int64, name, description string, responseColumnName string) (int64, error) {
-	if err := pz.CheckPermission(s.ds.Permissions.ManageDataset); err != nil {
-		return 0, err
-	}
-
-	datasource, err := s.ds.ReadDatasource(pz, datasourceId)
-	if err != nil {
-		return 0, err
-	}
-	cluster, err := s.ds.ReadCluster(pz, clusterId)
-	if err != nil {
-		return 0, err
-	}
-
-	properties, frameName, err := s.importDataset(name, datasource.Configuration, cluster.Address)
-	if err != nil {
-		return 0, err
-	}
+// --- Dataset ---
 
-	dataset := data.Dataset{
-		0,
-		datasourceId,
-		name,
-		description,
-		frameName,
-		responseColumnName,
-		string(properties),
-		"1",
-		time.Now(),
+func (s *Service) GetDatasets(pz az.Principal, datasourceId int64, offset, limit int64) ([]*web.Dataset, error) {
+	if err := pz.CheckPermission(s.ds.Permissions.ViewDataset); err != nil {
+		return nil, err
 	}
 
-	datasetId, err := s.ds.CreateDataset(pz, dataset)
+	datasets, err := s.ds.ReadDatasets(pz, datasourceId, offset, limit)
 	if err != nil {
-		return 0, err
+		return nil, err
 	}
 
-	return datasetId, nil
+	return toDatasets(datasets), nil
 }
 
-func (s *Service) GetDatasets(pz az.Principal, datasourceId int64, offset, limit int64) ([]*web.Dataset, error) {
+func (s *Service) GetDatasetVersions(pz az.Principal, datasourceId int64) ([]*web.Dataset, error) {
 	if err := pz.CheckPermission(s.ds.Permissions.ViewDataset); err != nil {
 		return nil, err
 	}
 
-	datasets, err := s.ds.ReadDatasets(pz, datasourceId, offset, limit)
+	datasets, err := s.ds.ReadDatasetVersions(pz, datasourceId)
 	if err != nil {
 		return nil, err
 	}
@@ -749,6 +1022,13 @@ func frameToDataset(frame *bindings.FrameBase) data.Dataset {
 	return data.Dataset{
 		0,
 		0,
+		0,
+		sql.NullInt64{},
+		0,
+		sql.NullInt64{},
+		0,
+		"",
+		"",
 		frame.FrameId.Name,
 		"",
 		frame.FrameId.Name,
@@ -756,6 +1036,7 @@ func frameToDataset(frame *bindings.FrameBase) data.Dataset {
 		"",
 		"",
 		time.Now(),
+		false,
 	}
 }
 
@@ -771,7 +1052,10 @@ func (s *Service) GetDatasetsFromCluster(pz az.Principal, clusterId int64) ([]*w
 	}
 
 	// Start h2o communication
-	h2o := h2ov3.NewClient(cluster.Address)
+	h2o, err := s.h2oClient(cluster)
+	if err != nil {
+		return nil, err
+	}
 	frames, err := h2o.GetFramesList()
 	if err != nil {
 		return nil, err
@@ -785,9 +1069,30 @@ func (s *Service) UpdateDataset(pz az.Principal, datasetId int64, name, descript
 		return err
 	}
 
+	if responseColumnName != "" {
+		current, err := s.ds.ReadDataset(pz, datasetId)
+		if err != nil {
+			return err
+		}
+		columns, err := s.readDatasetColumns(pz, current)
+		if err != nil {
+			return err
+		}
+		if err := checkColumnRole(current.Name, columns, responseColumnName, data.ResponseColumnRole); err != nil {
+			return err
+		}
+	}
+
 	dataset := data.Dataset{
 		0,
 		0,
+		0,
+		sql.NullInt64{},
+		0,
+		sql.NullInt64{},
+		0,
+		"",
+		"",
 		name,
 		description,
 		"",
@@ -795,6 +1100,7 @@ func (s *Service) UpdateDataset(pz az.Principal, datasetId int64, name, descript
 		"",
 		"1",
 		time.Now(),
+		false,
 	}
 
 	if err := s.ds.UpdateDataset(pz, datasetId, dataset); err != nil {
@@ -804,8 +1110,186 @@ func (s *Service) UpdateDataset(pz az.Principal, datasetId int64, name, descript
 	return nil
 }
 
-func (s *Service) SplitDataset(pz az.Principal, datasetId int64, ratio1 int, ratio2 int) ([]int64, error) {
-	return nil, nil // XXX
+// SplitDataset splits a dataset's frame on its cluster into training and
+// validation frames, and a test frame for any remaining rows. Each split is
+// recorded as a dataset derived from the original.
+func (s *Service) SplitDataset(pz az.Principal, datasetId int64, ratio1 int, ratio2 int, seed int64) ([]int64, error) {
+	if err := pz.CheckPermission(s.ds.Permissions.ManageDataset); err != nil {
+		return nil, err
+	}
+
+	parts, err := toSplitParts(ratio1, ratio2)
+	if err != nil {
+		return nil, err
+	}
+
+	dataset, err := s.ds.ReadDataset(pz, datasetId)
+	if err != nil {
+		return nil, err
+	}
+	if dataset.ClusterId == 0 {
+		return nil, fmt.Errorf("Dataset %s is not bound to a cluster; re-create it from its datasource to split it", dataset.Name)
+	}
+	if dataset.Stale {
+		return nil, errStaleDataset(dataset)
+	}
+	roles, err := s.ds.ReadDatasetColumnRoles(pz, dataset.Id)
+	if err != nil {
+		return nil, err
+	}
+	cluster, err := s.ds.ReadCluster(pz, dataset.ClusterId)
+	if err != nil {
+		return nil, err
+	}
+	h2o, err := s.h2oClient(cluster)
+	if err != nil {
+		return nil, err
+	}
+
+	// Pick the seed here rather than in H2O so the split can be reproduced;
+	// H2O picks a random seed of its own for negative seeds
+	if seed < 0 {
+		return nil, fmt.Errorf("Split seeds cannot be negative; use 0 for a random seed")
+	}
+	if seed == 0 {
+		seed = rand.Int63()
+	}
+
+	session, err := h2o.GetInitIDIssue()
+	if err != nil {
+		return nil, errors.Wrap(err, "starting H2O session")
+	}
+	rapids := func(ast string) error {
+		in := bindings.NewRapidsSchemaV3()
+		in.Ast = ast
+		in.SessionId = session.SessionKey
+		_, err := h2o.PostRapidsExec(in)
+		return err
+	}
+
+	// Every split filters the same uniform draw, so together they partition
+	// the rows. The draw is dropped once the splits are made, and so are the
+	// splits made so far if a later one fails.
+	suffix := strconv.FormatInt(time.Now().UnixNano(), 36)
+	drawName := fmt.Sprintf("%s_draw_%s", dataset.FrameName, suffix)
+	if err := rapids(fmt.Sprintf("(assign %s (h2o.runif %s %d))", drawName, dataset.FrameName, seed)); err != nil {
+		return nil, errors.Wrapf(err, "splitting frame %s", dataset.FrameName)
+	}
+	drop := []string{drawName}
+	defer func() {
+		for _, frameName := range drop {
+			if err := rapids(fmt.Sprintf("(rm %s)", frameName)); err != nil {
+				log.Printf("Failed removing frame %s: %v\n", frameName, err)
+			}
+		}
+	}()
+
+	rawFrames := make([][]byte, len(parts))
+	for i, part := range parts {
+		part.frameName = fmt.Sprintf("%s_%s_%s", dataset.FrameName, part.label, suffix)
+		parts[i] = part
+
+		if err := rapids(part.ast(dataset.FrameName, drawName)); err != nil {
+			return nil, errors.Wrapf(err, "splitting frame %s", dataset.FrameName)
+		}
+		drop = append(drop, part.frameName)
+		if rawFrames[i], _, err = h2o.GetFramesFetch(part.frameName, false); err != nil {
+			return nil, err
+		}
+	}
+
+	// The splits' frames are kept only once every split is recorded; a
+	// failure removes the datasets recorded so far along with the frames
+	var recorded bool
+	datasetIds := make([]int64, 0, len(parts))
+	defer func() {
+		if recorded {
+			return
+		}
+		for _, id := range datasetIds {
+			if err := s.ds.DeleteDataset(pz, id); err != nil {
+				log.Printf("Failed removing dataset %d: %v\n", id, err)
+			}
+		}
+	}()
+	for i, part := range parts {
+		rowCount, columnHash := datasetFingerprint(string(rawFrames[i]))
+		datasetId, err := s.ds.CreateDataset(pz, data.Dataset{
+			0,
+			dataset.DatasourceId,
+			dataset.ClusterId,
+			sql.NullInt64{dataset.Id, true},
+			0,
+			sql.NullInt64{},
+			rowCount,
+			columnHash,
+			"",
+			fmt.Sprintf("%s (%s)", dataset.Name, part.name),
+			fmt.Sprintf("%d%% %s split of %s (seed %d)", part.upper-part.lower, part.name, dataset.Name, seed),
+			part.frameName,
+			dataset.ResponseColumnName,
+			string(rawFrames[i]),
+			"1",
+			time.Now(),
+			false,
+		})
+		if err != nil {
+			return nil, err
+		}
+		datasetIds = append(datasetIds, datasetId)
+		s.profileDataset(pz, datasetId, string(rawFrames[i]))
+
+		// Splits have their parent's columns, so they are modelled the same way
+		for _, r := range roles {
+			if err := s.ds.UpdateDatasetColumnRole(pz, datasetId, r.Name, r.Role); err != nil {
+				return nil, err
+			}
+		}
+	}
+	recorded = true
+	drop = drop[:1]
+
+	return datasetIds, nil
+}
+
+// splitPart is one split of a frame: the rows whose uniform random draw
+// falls in (lower, upper] percent.
+type splitPart struct {
+	name         string
+	label        string
+	lower, upper int
+	frameName    string
+}
+
+func toSplitParts(ratio1, ratio2 int) ([]splitPart, error) {
+	if ratio1 <= 0 || ratio2 <= 0 || ratio1+ratio2 > 100 {
+		return nil, fmt.Errorf("Split ratios must be positive percentages adding up to at most 100")
+	}
+
+	parts := []splitPart{
+		{"training", "train", 0, ratio1, ""},
+		{"validation", "valid", ratio1, ratio1 + ratio2, ""},
+	}
+	if ratio1+ratio2 < 100 {
+		parts = append(parts, splitPart{"test", "test", ratio1 + ratio2, 100, ""})
+	}
+	return parts, nil
+}
+
+// ast returns the Rapids expression assigning this split of a frame, given the
+// uniform draw that the splits share.
+func (p splitPart) ast(frameName, drawName string) string {
+	var cond string
+	switch {
+	case p.lower == 0:
+		cond = fmt.Sprintf("(<= %s %g)", drawName, float64(p.upper)/100)
+	case p.upper == 100:
+		cond = fmt.Sprintf("(> %s %g)", drawName, float64(p.lower)/100)
+	default:
+		cond = fmt.Sprintf("(& (> %s %g) (<= %s %g))", drawName, float64(p.lower)/100, drawName, float64(p.upper)/100)
+	}
+
+	return fmt.Sprintf("(assign %s (rows %s %s))", p.frameName, frameName, cond)
 }
 
 func (s *Service) DeleteDataset(pz az.Principal, datasetId int64) error {
@@ -822,6 +1306,15 @@ func (s *Service) DeleteDataset(pz az.Principal, datasetId int64) error {
 		return fmt.Errorf("A model is still using this dataset.")
 	}
 
+	derived, err := s.ds.CountDerivedDatasets(pz, datasetId)
+	if err != nil {
+		return err
+	}
+
+	if derived > 0 {
+		return fmt.Errorf("Datasets split from this dataset still exist.")
+	}
+
 	if err := s.ds.DeleteDataset(pz, datasetId); err != nil {
 		return err
 	}
@@ -865,7 +1358,10 @@ func (s *Service) BuildModelAuto(pz az.Principal, clusterId int64, dataset, targ
 		return nil, fmt.Errorf("Cluster is not running")
 	}
 
-	h2o := h2ov3.NewClient(cluster.Address)
+	h2o, err := s.h2oClient(cluster)
+	if err != nil {
+		return nil, err
+	}
 
 	modelKey, err := h2o.AutoML(dataset, targetName, maxRunTime) // TODO: can be a goroutine
 	if err != nil {
@@ -892,6 +1388,8 @@ func (s *Service) BuildModelAuto(pz az.Principal, clusterId int64, dataset, targ
 		"",  // TODO Sebastian: put raw metrics json here (do not unmarshal/marshal json from h2o)
 		"1", // MUST be "1"; will change when H2O's API version is bumped.
 		time.Now(),
+		0,
+		false,
 		sql.NullInt64{0, false},
 		sql.NullString{"", false},
 	})
@@ -959,6 +1457,14 @@ func dataFrameName(m *bindings.ModelSchema) string {
 	return ""
 }
 
+func validationFrameName(m *bindings.ModelSchema) string {
+	if m.Output != nil && m.Output.ValidationMetrics != nil && m.Output.ValidationMetrics.Frame != nil {
+		return m.Output.ValidationMetrics.Frame.Name
+	}
+
+	return ""
+}
+
 func h2oToModel(model *bindings.ModelSchema) data.Model {
 	return data.Model{
 		Name:               model.ModelId.Name,
@@ -991,7 +1497,10 @@ func (s *Service) GetModelsFromCluster(pz az.Principal, clusterId int64, frameKe
 	}
 
 	// Connect to h2o
-	h2o := h2ov3.NewClient(cluster.Address)
+	h2o, err := s.h2oClient(cluster)
+	if err != nil {
+		return nil, err
+	}
 	_, frame, err := h2o.GetFramesFetch(frameKey, true)
 	if err != nil {
 		return nil, err
@@ -1143,7 +1652,10 @@ func (s *Service) ImportModelFromCluster(pz az.Principal, clusterId, projectId i
 	}
 
 	// get model from the cloud
-	h2o := h2ov3.NewClient(cluster.Address)
+	h2o, err := s.h2oClient(cluster)
+	if err != nil {
+		return 0, err
+	}
 	rawModel, r, err := h2o.GetModelsFetch(modelKey)
 	if err != nil {
 		return 0, err
@@ -1151,27 +1663,119 @@ func (s *Service) ImportModelFromCluster(pz az.Principal, clusterId, projectId i
 
 	m := r.Models[0]
 
+	trainingDatasetId, err := s.trainingDataset(pz, h2o, cluster, projectId, modelName, m)
+	if err != nil {
+		return 0, err
+	}
+
+	// Bind the validation frame if it is a known dataset, e.g. a split
+	var validationDatasetId sql.NullInt64
+	if frameName := validationFrameName(m); frameName != "" {
+		validation, ok, err := s.ds.ReadDatasetByFrame(pz, cluster.Id, frameName)
+		if err != nil {
+			return 0, err
+		}
+		if ok {
+			validationDatasetId = sql.NullInt64{validation.Id, true}
+		}
+	}
+
+	// TODO: create a function to make this statically typed
+	model := data.Model{
+		ProjectId:           projectId,
+		TrainingDatasetId:   trainingDatasetId,
+		ValidationDatasetId: validationDatasetId,
+		Name:                modelName,
+		ClusterName:         cluster.Name,
+		ClusterId:           cluster.Id,
+		ModelKey:            modelKey,
+		Algorithm:           m.AlgoFullName,
+		ModelCategory:       string(m.Output.ModelCategory),
+		DatasetName:         dataFrameName(m),
+		ResponseColumnName:  m.ResponseColumnName,
+		Metrics:             string(rawModel),
+		MetricsVersion:      "1",
+		Created:             time.Now(),
+	}
+
+	modelId, err := s.ds.CreateModel(pz, model)
+	if err != nil {
+		return 0, err
+	}
+
+	if err := s.createMetricsTable(pz, modelId, m.Output.TrainingMetrics, string(m.Output.ModelCategory)); err != nil {
+		return 0, err
+	}
+
+	return modelId, nil
+}
+
+// trainingDataset returns the dataset version a model was trained on: the
+// dataset recorded for its training frame, or else the latest dataset in the
+// project with the same content. Failing both, the frame is recorded as a
+// dataset of its own implicit datasource.
+func (s *Service) trainingDataset(pz az.Principal, h2o *h2ov3.H2O, cluster data.Cluster, projectId int64, modelName string, m *bindings.ModelSchema) (int64, error) {
+	dataset, ok, err := s.ds.ReadDatasetByFrame(pz, cluster.Id, dataFrameName(m))
+	if err != nil {
+		return 0, err
+	}
+	if ok {
+		return dataset.Id, nil
+	}
+
 	// fetch raw frame json from H2O
 	rawFrame, _, err := h2o.GetFramesFetch(dataFrameName(m), false)
 	if err != nil {
 		return 0, err
 	}
+	rowCount, columnHash := datasetFingerprint(string(rawFrame))
+
+	// Frames of the same shape are told apart by their column statistics.
+	// Versions of one datasource with the same content are the same data, so
+	// the latest is used; a match across datasources is ambiguous.
+	candidates, err := s.ds.ReadDatasetsByFingerprint(pz, projectId, rowCount, columnHash)
+	if err != nil {
+		return 0, err
+	}
+	statistics := datasetStatistics(string(rawFrame))
+	var matches []data.Dataset
+	for _, d := range candidates {
+		if statistics != "" && datasetStatistics(d.Properties) == statistics {
+			matches = append(matches, d)
+		}
+	}
+	if len(matches) > 0 {
+		unique := true
+		for _, d := range matches {
+			unique = unique && d.DatasourceId == matches[0].DatasourceId
+		}
+		if unique {
+			return matches[0].Id, nil
+		}
+	}
 
 	datasourceId, err := s.ds.CreateDatasource(pz, data.Datasource{
 		0,
 		projectId,
 		modelName + " Datasource",
 		"Datasource for model " + modelName,
-		"Implicit",
+		data.DatasourceImplicit,
 		"",
 		time.Now(),
 	})
 	if err != nil {
 		return 0, err
 	}
-	trainingDatasetId, err := s.ds.CreateDataset(pz, data.Dataset{
+	datasetId, err := s.ds.CreateDataset(pz, data.Dataset{
 		0,
 		datasourceId,
+		cluster.Id,
+		sql.NullInt64{},
+		0,
+		sql.NullInt64{},
+		rowCount,
+		columnHash,
+		"",
 		modelName + " Dataset",
 		"Dataset for model " + modelName,
 		m.DataFrame.Name,
@@ -1179,38 +1783,14 @@ func (s *Service) ImportModelFromCluster(pz az.Principal, clusterId, projectId i
 		string(rawFrame),
 		"1", // MUST be "1"; will change when H2O's API version is bumped.
 		time.Now(),
+		false,
 	})
 	if err != nil {
 		return 0, err
 	}
+	s.profileDataset(pz, datasetId, string(rawFrame))
 
-	// TODO: create a function to make this statically typed
-	model := data.Model{
-		ProjectId:          projectId,
-		TrainingDatasetId:  trainingDatasetId,
-		Name:               modelName,
-		ClusterName:        cluster.Name,
-		ClusterId:          cluster.Id,
-		ModelKey:           modelKey,
-		Algorithm:          m.AlgoFullName,
-		ModelCategory:      string(m.Output.ModelCategory),
-		DatasetName:        dataFrameName(m),
-		ResponseColumnName: m.ResponseColumnName,
-		Metrics:            string(rawModel),
-		MetricsVersion:     "1",
-		Created:            time.Now(),
-	}
-
-	modelId, err := s.ds.CreateModel(pz, model)
-	if err != nil {
-		return 0, err
-	}
-
-	if err := s.createMetricsTable(pz, modelId, m.Output.TrainingMetrics, string(m.Output.ModelCategory)); err != nil {
-		return 0, err
-	}
-
-	return modelId, nil
+	return datasetId, nil
 }
 
 func (s *Service) createMetricsTable(pz az.Principal, modelId int64, metrics *bindings.ModelMetrics, category string) error {
@@ -1253,12 +1833,10 @@ func (s *Service) createMetricsTable(pz az.Principal, modelId int64, metrics *bi
 	return nil
 }
 
+// CheckMojo reports whether models of an algorithm can be exported as MOJOs by
+// the latest known H2O release; ImportModelMojo checks the model's cluster.
 func (s *Service) CheckMojo(pz az.Principal, algo string) (bool, error) {
-	switch algo {
-	case "Gradient Boosting Method", "Distributed Random Forest", "Deep Water":
-		return true, nil
-	}
-	return false, nil
+	return engine.CapabilitiesOf("", true).CanMojo(algo), nil
 }
 
 func (s *Service) ImportModelPojo(pz az.Principal, modelId int64) error {
@@ -1270,12 +1848,18 @@ func (s *Service) ImportModelPojo(pz az.Principal, modelId int64) error {
 	if err != nil {
 		return errors.Wrap(err, "failed reading model from database")
 	}
+	if m.Stale {
+		return fmt.Errorf("Model %s is gone from its cluster and can no longer be exported", m.Name)
+	}
 
 	c, err := s.ds.ReadCluster(pz, m.ClusterId)
 	if err != nil {
 		return errors.Wrap(err, "failed reading cluster from database")
 	}
-	h2o := h2ov3.NewClient(c.Address)
+	h2o, err := s.h2oClient(c)
+	if err != nil {
+		return err
+	}
 
 	modelPath := fs.GetModelPath(s.workingDir, modelId)
 	javaModelPath, err := h2o.ExportJavaModel(m.ModelKey, modelPath)
@@ -1302,6 +1886,9 @@ func (s *Service) ImportModelMojo(pz az.Principal, modelId int64) error {
 	if err != nil {
 		return errors.Wrap(err, "failed reading model from database")
 	}
+	if m.Stale {
+		return fmt.Errorf("Model %s is gone from its cluster and can no longer be exported", m.Name)
+	}
 
 	// Doesn't return errors
 	if ok, _ := s.CheckMojo(pz, m.Algorithm); !ok {
@@ -1312,7 +1899,18 @@ func (s *Service) ImportModelMojo(pz az.Principal, modelId int64) error {
 	if err != nil {
 		return errors.Wrap(err, "failed reading cluster from database")
 	}
-	h2o := h2ov3.NewClient(c.Address)
+	h2o, err := s.h2oClient(c)
+	if err != nil {
+		return err
+	}
+
+	version, caps, err := s.clusterCapabilities(pz, c, h2o)
+	if err != nil {
+		return errors.Wrap(err, "failed determining cluster capabilities")
+	}
+	if !caps.CanMojo(m.Algorithm) {
+		return fmt.Errorf("model of type %s does not have MOJO support in H2O %s", m.Algorithm, version)
+	}
 
 	modelPath := fs.GetModelPath(s.workingDir, modelId)
 	mojoPath, err := h2o.ExportMOJO(m.ModelKey, modelPath)
@@ -1324,7 +1922,7 @@ func (s *Service) ImportModelMojo(pz az.Principal, modelId int64) error {
 	}
 
 	// MOJO only check
-	if m.Algorithm == "Deep Water" {
+	if m.Algorithm == engine.DeepWater {
 		if _, err := h2o.ExportDeepWaterAll(modelPath); err != nil {
 			return errors.Wrap(err, "exporting Deep Water dependency")
 		}
@@ -1682,7 +2280,15 @@ func (s *Service) AddEngine(pz az.Principal, engineName, enginePath string) (int
 		return 0, err
 	}
 
-	return s.ds.CreateEngine(pz, engineName, enginePath)
+	info, err := engine.Inspect(enginePath, engineName)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed inspecting engine")
+	}
+	if err := engine.CheckCompatible(info, ""); err != nil {
+		return 0, err
+	}
+
+	return s.ds.CreateCataloguedEngine(pz, engineName, enginePath, info.Version, info.Distribution, info.Checksum, info.DeepWater)
 }
 
 func (s *Service) GetEngine(pz az.Principal, engineId int64) (*web.Engine, error) {
@@ -1943,6 +2549,18 @@ func (s *Service) UpdateWorkgroup(pz az.Principal, workgroupId int64, name strin
 	return s.ds.UpdateWorkgroup(pz, workgroupId, name, description)
 }
 
+func (s *Service) SetWorkgroupIdleTimeout(pz az.Principal, workgroupId, minutes int64) error {
+	if err := pz.CheckPermission(s.ds.Permissions.ManageWorkgroup); err != nil {
+		return err
+	}
+
+	if minutes < 0 {
+		return fmt.Errorf("Idle timeout cannot be negative")
+	}
+
+	return s.ds.UpdateWorkgroupIdleTimeout(pz, workgroupId, minutes)
+}
+
 func (s *Service) DeleteWorkgroup(pz az.Principal, workgroupId int64) error {
 	if err := pz.CheckPermission(s.ds.Permissions.ManageWorkgroup); err != nil {
 		return err
@@ -2457,7 +3075,19 @@ func toYarnCluster(c data.YarnCluster) *web.YarnCluster {
 		c.ApplicationId,
 		c.Memory,
 		c.Username,
+		c.IdleTimeout,
+		toIdleDeadline(c),
+		c.Queue,
+		c.NodeLabel,
+		c.DriverArgs,
+	}
+}
+
+func toIdleDeadline(c data.YarnCluster) int64 {
+	if deadline, ok := idleDeadline(c); ok {
+		return toTimestamp(deadline)
 	}
+	return 0
 }
 
 func fromNullInt64(maybeId sql.NullInt64) int64 {
@@ -2480,6 +3110,8 @@ func toModel(m data.Model) *web.Model {
 		m.Id,
 		m.TrainingDatasetId,
 		m.ValidationDatasetId.Int64,
+		m.TrainingDatasetVersion,
+		m.Stale,
 		m.Name,
 		m.ClusterName,
 		m.ModelKey,
@@ -2504,6 +3136,8 @@ func toBinomialModel(model data.BinomialModel) *web.BinomialModel {
 		model.Id,
 		model.TrainingDatasetId,
 		model.ValidationDatasetId.Int64,
+		model.TrainingDatasetVersion,
+		model.Stale,
 		model.Name,
 		model.ClusterName,
 		model.ModelKey,
@@ -2541,6 +3175,8 @@ func toMultinomialModel(model data.MultinomialModel) *web.MultinomialModel {
 		model.Id,
 		model.TrainingDatasetId,
 		model.ValidationDatasetId.Int64,
+		model.TrainingDatasetVersion,
+		model.Stale,
 		model.Name,
 		model.ClusterName,
 		model.ModelKey,
@@ -2575,6 +3211,8 @@ func toRegressionModel(model data.RegressionModel) *web.RegressionModel {
 		model.Id,
 		model.TrainingDatasetId,
 		model.ValidationDatasetId.Int64,
+		model.TrainingDatasetVersion,
+		model.Stale,
 		model.Name,
 		model.ClusterName,
 		model.ModelKey,
@@ -2618,11 +3256,21 @@ func toScoringService(s data.Service) *web.ScoringService {
 }
 
 func toEngine(e data.Engine) *web.Engine {
+	var caps engine.Capabilities
+	if e.Version != "" {
+		caps = toEngineInfo(e).Capabilities()
+	}
 	return &web.Engine{
 		e.Id,
 		e.Name,
 		e.Location,
 		toTimestamp(e.Created),
+		e.Version,
+		e.Distribution,
+		e.Checksum,
+		caps.APILevel,
+		caps.MojoAlgorithms,
+		caps.DeepWater,
 	}
 }
 
@@ -2683,6 +3331,7 @@ func toWorkgroup(w data.Workgroup) *web.Workgroup {
 		w.Name,
 		w.Description,
 		toTimestamp(w.Created),
+		w.IdleTimeout,
 	}
 }
 
@@ -2804,7 +3453,7 @@ func toDatasource(datasource data.Datasource) *web.Datasource {
 		datasource.Name,
 		datasource.Description,
 		datasource.Kind,
-		datasource.Configuration,
+		toDatasourceConfig(datasource.Configuration),
 		toTimestamp(datasource.Created),
 	}
 }
@@ -2821,12 +3470,20 @@ func toDataset(dataset data.Dataset) *web.Dataset {
 	return &web.Dataset{
 		dataset.Id,
 		dataset.DatasourceId,
+		dataset.ClusterId,
+		dataset.ParentId.Int64,
+		dataset.Version,
+		dataset.PreviousId.Int64,
+		dataset.RowCount,
+		dataset.ColumnHash,
+		dataset.Checksum,
 		dataset.Name,
 		dataset.Description,
 		dataset.FrameName,
 		dataset.ResponseColumnName,
 		dataset.Properties,
 		toTimestamp(dataset.Created),
+		dataset.Stale,
 	}
 }
 
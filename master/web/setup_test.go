@@ -18,11 +18,18 @@
 package web
 
 import (
+	"database/sql"
+	"encoding/json"
 	"flag"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"path"
 	"path/filepath"
 	"runtime/debug"
+	"strings"
+	"sync"
 	"testing"
 
 	"github.com/h2oai/steam/lib/fs"
@@ -173,3 +180,151 @@ func buildEntityTypeMap(t *test) map[string]int64 {
 	}
 	return entityTypeMap
 }
+
+// newSandbox creates a service backed by a fresh database in a temporary
+// directory, for tests that don't need a live H2O cluster.
+func newSandbox(t *testing.T) (*Service, az.Principal, func()) {
+	wd, err := ioutil.TempDir("", "steam-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	cleanup := func() { os.RemoveAll(wd) }
+
+	if _, err := fs.MkWorkingDirectory(wd); err != nil {
+		cleanup()
+		t.Fatal(err)
+	}
+
+	schema, err := ioutil.ReadFile(path.Join("..", "..", "scripts", "database", "create-schema.sql"))
+	if err != nil {
+		cleanup()
+		t.Fatalf("Failed reading schema: %v", err)
+	}
+
+	dbPath := path.Join(wd, "steam.db")
+	db, err := sql.Open("sqlite3", dbPath)
+	if err != nil {
+		cleanup()
+		t.Fatal(err)
+	}
+	if _, err := db.Exec(string(schema)); err != nil {
+		cleanup()
+		t.Fatalf("Failed creating schema: %v", err)
+	}
+	db.Close()
+
+	ds, err := data.Create(dbPath, superuser, superuser)
+	if err != nil {
+		cleanup()
+		t.Fatal(err)
+	}
+
+	key, err := data.LoadOrCreateSecretKey(path.Join(wd, "secret.key"))
+	if err != nil {
+		cleanup()
+		t.Fatal(err)
+	}
+	ds.SetSecretKey(key)
+
+	su, err := ds.Lookup(superuser)
+	if err != nil {
+		cleanup()
+		t.Fatal(err)
+	}
+
+	svc := NewService(wd, ds, "", "", ":9001", [2]int{1025, 65535}, false, "", "")
+	return svc, su, cleanup
+}
+
+// fakeH2O serves a minimal /3/Cloud endpoint whose availability can be
+// toggled, plus any other endpoints registered with handle.
+type fakeH2O struct {
+	*httptest.Server
+	mu     sync.Mutex
+	up     bool
+	probes int
+	routes map[string]http.HandlerFunc
+}
+
+func newFakeH2O() *fakeH2O {
+	h := &fakeH2O{up: true, routes: make(map[string]http.HandlerFunc)}
+	h.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		h.mu.Lock()
+		defer h.mu.Unlock()
+		h.probes++
+		if !h.up {
+			http.Error(w, "unavailable", http.StatusServiceUnavailable)
+			return
+		}
+		if route := h.route(r.URL.Path); route != nil {
+			route(w, r)
+			return
+		}
+		if r.URL.Path != "/3/Cloud" {
+			http.NotFound(w, r)
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"cloud_name":    "fake",
+			"cloud_healthy": true,
+			"version":       "3.10.0.7",
+			"nodes": []map[string]interface{}{{
+				"ip_port":  "127.0.0.1:54321",
+				"healthy":  true,
+				"free_mem": 1000,
+				"max_mem":  4000,
+				"sys_load": 0.5,
+				"gflops":   1.0,
+				"mem_bw":   1.0,
+			}},
+		})
+	}))
+	return h
+}
+
+// route returns the route for a path: the route of the path itself, or else
+// of its longest prefix registered with a trailing slash.
+func (h *fakeH2O) route(p string) http.HandlerFunc {
+	if route, ok := h.routes[p]; ok {
+		return route
+	}
+	var match string
+	for prefix := range h.routes {
+		if strings.HasSuffix(prefix, "/") && strings.HasPrefix(p, prefix) && len(prefix) > len(match) {
+			match = prefix
+		}
+	}
+	return h.routes[match]
+}
+
+// handle serves path with route, replacing any earlier route. A path ending
+// in a slash also serves the paths below it. Routes run with the fake's lock
+// held.
+func (h *fakeH2O) handle(path string, route http.HandlerFunc) {
+	h.mu.Lock()
+	h.routes[path] = route
+	h.mu.Unlock()
+}
+
+// handleJSON serves path with a fixed JSON response.
+func (h *fakeH2O) handleJSON(path string, v interface{}) {
+	h.handle(path, func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(v)
+	})
+}
+
+func (h *fakeH2O) address() string {
+	return strings.TrimPrefix(h.URL, "http://")
+}
+
+func (h *fakeH2O) setUp(up bool) {
+	h.mu.Lock()
+	h.up = up
+	h.mu.Unlock()
+}
+
+func (h *fakeH2O) probeCount() int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.probes
+}
@@ -0,0 +1,270 @@
+/*
+  Copyright (C) 2016 H2O.ai, Inc. <http://h2o.ai/>
+
+  This program is free software: you can redistribute it and/or modify
+  it under the terms of the GNU Affero General Public License as
+  published by the Free Software Foundation, either version 3 of the
+  License, or (at your option) any later version.
+
+  This program is distributed in the hope that it will be useful,
+  but WITHOUT ANY WARRANTY; without even the implied warranty of
+  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+  GNU Affero General Public License for more details.
+
+  You should have received a copy of the GNU Affero General Public License
+  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package web
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"path"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/h2oai/steam/master/data"
+)
+
+func TestSplitPartsPartitionRows(t *testing.T) {
+	if _, err := toSplitParts(80, 30); err == nil {
+		t.Fatal("expected ratios over 100% to be rejected")
+	}
+	if _, err := toSplitParts(100, 0); err == nil {
+		t.Fatal("expected an empty validation split to be rejected")
+	}
+
+	parts, err := toSplitParts(70, 20)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(parts) != 3 || parts[2].name != "test" || parts[2].lower != 90 {
+		t.Fatalf("unexpected splits: %+v", parts)
+	}
+	parts[1].frameName = "f_valid"
+	if ast := parts[1].ast("f", "f_draw"); ast != "(assign f_valid (rows f (& (> f_draw 0.7) (<= f_draw 0.9))))" {
+		t.Fatalf("unexpected ast: %s", ast)
+	}
+
+	if parts, _ = toSplitParts(75, 25); len(parts) != 2 {
+		t.Fatalf("expected no test split: %+v", parts)
+	}
+}
+
+// newFakeSplits serves the endpoints used to split frames, recording the
+// Rapids expressions it receives. Expressions containing fail are refused.
+func newFakeSplits(fail string) (*fakeH2O, func() []string) {
+	var asts []string
+	h2o := newFakeH2O()
+	h2o.handleJSON("/3/InitID", map[string]interface{}{"session_key": "_sid1"})
+	h2o.handle("/99/Rapids", func(w http.ResponseWriter, r *http.Request) {
+		if r.FormValue("session_id") != "_sid1" {
+			http.Error(w, "missing session", http.StatusBadRequest)
+			return
+		}
+		ast := r.FormValue("ast")
+		asts = append(asts, ast)
+		if fail != "" && strings.Contains(ast, fail) {
+			http.Error(w, "rapids failed", http.StatusInternalServerError)
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{"key": map[string]string{"name": "x"}})
+	})
+	h2o.handle("/3/Frames/", func(w http.ResponseWriter, r *http.Request) {
+		name := strings.TrimPrefix(r.URL.Path, "/3/Frames/")
+		json.NewEncoder(w).Encode(map[string]interface{}{"frames": []interface{}{map[string]interface{}{"frame_id": map[string]string{"name": name}}}})
+	})
+	return h2o, func() []string {
+		h2o.mu.Lock()
+		defer h2o.mu.Unlock()
+		return append([]string(nil), asts...)
+	}
+}
+
+func TestSplitDataset(t *testing.T) {
+	svc, su, cleanup := newSandbox(t)
+	defer cleanup()
+
+	h2o, received := newFakeSplits("")
+	defer h2o.Close()
+
+	clusterId, err := svc.RegisterCluster(su, h2o.address(), "", "", "", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	projectId, err := svc.CreateProject(su, "p1", "d1", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	datasourceId, err := svc.CreateDatasource(su, projectId, "airlines", "", "file", `{"path": "/data/airlines.csv"}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	legacyId, err := svc.ds.CreateDataset(su, data.Dataset{0, datasourceId, 0, sql.NullInt64{}, 0, sql.NullInt64{}, 0, "", "", "legacy", "", "airlines.hex", "", "{}", "1", time.Now(), false})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := svc.SplitDataset(su, legacyId, 70, 30, 0); err == nil {
+		t.Fatal("expected splitting a dataset without a cluster to fail")
+	}
+	datasetId, err := svc.ds.CreateDataset(su, data.Dataset{0, datasourceId, clusterId, sql.NullInt64{}, 0, sql.NullInt64{}, 0, "", "", "airlines", "", "airlines.hex", "IsDepDelayed", "{}", "1", time.Now(), false})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := svc.SplitDataset(su, datasetId, 60, 20, -1); err == nil {
+		t.Fatal("expected a negative seed to be rejected")
+	}
+
+	ids, err := svc.SplitDataset(su, datasetId, 60, 20, 1234)
+	if err != nil {
+		t.Fatal(err)
+	}
+	asts := received()
+	if len(ids) != 3 || len(asts) != 5 {
+		t.Fatalf("expected three splits, got %v from %v", ids, asts)
+	}
+
+	// The splits share a single draw, which is dropped afterwards
+	var draw string
+	if _, err := fmt.Sscanf(asts[0], "(assign %s (h2o.runif airlines.hex 1234))", &draw); err != nil {
+		t.Fatalf("expected the seed to be drawn once: %s", asts[0])
+	}
+	if asts[4] != "(rm "+draw+")" {
+		t.Fatalf("expected the draw to be removed: %s", asts[4])
+	}
+	for i, label := range []string{"train", "valid", "test"} {
+		ast := asts[i+1]
+		if strings.Contains(ast, "runif") || !strings.Contains(ast, draw) {
+			t.Errorf("expected the shared draw to be used: %s", ast)
+		}
+		split, err := svc.GetDataset(su, ids[i])
+		if err != nil {
+			t.Fatal(err)
+		}
+		if split.ParentId != datasetId || split.ClusterId != clusterId || split.ResponseColumnName != "IsDepDelayed" {
+			t.Errorf("unexpected split: %+v", split)
+		}
+		if !strings.HasPrefix(split.FrameName, "airlines.hex_"+label+"_") || !strings.HasPrefix(ast, "(assign "+split.FrameName+" ") {
+			t.Errorf("unexpected frame %s for %s", split.FrameName, ast)
+		}
+	}
+
+	if err := svc.DeleteDataset(su, datasetId); err == nil {
+		t.Fatal("expected deleting a split dataset to fail while its splits exist")
+	}
+	for _, id := range ids {
+		if err := svc.DeleteDataset(su, id); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := svc.DeleteDataset(su, datasetId); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestSplitDatasetFailure(t *testing.T) {
+	svc, su, cleanup := newSandbox(t)
+	defer cleanup()
+
+	h2o, received := newFakeSplits("_valid_")
+	defer h2o.Close()
+
+	clusterId, err := svc.RegisterCluster(su, h2o.address(), "", "", "", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	projectId, err := svc.CreateProject(su, "p1", "d1", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	datasourceId, err := svc.CreateDatasource(su, projectId, "airlines", "", "file", `{"path": "/data/airlines.csv"}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	datasetId, err := svc.ds.CreateDataset(su, data.Dataset{0, datasourceId, clusterId, sql.NullInt64{}, 0, sql.NullInt64{}, 0, "", "", "airlines", "", "airlines.hex", "", "{}", "1", time.Now(), false})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := svc.SplitDataset(su, datasetId, 70, 30, 1234); err == nil {
+		t.Fatal("expected a failed split to fail")
+	}
+
+	// The draw and the training split made before the failure are removed
+	asts := received()
+	if len(asts) != 5 {
+		t.Fatalf("unexpected expressions: %v", asts)
+	}
+	var draw, train string
+	fmt.Sscanf(asts[0], "(assign %s ", &draw)
+	fmt.Sscanf(asts[1], "(assign %s ", &train)
+	if asts[3] != "(rm "+draw+")" || asts[4] != "(rm "+train+")" {
+		t.Fatalf("expected the partial frames to be removed: %v", asts)
+	}
+	if datasets, err := svc.GetDatasets(su, datasourceId, 0, 100); err != nil || len(datasets) != 1 {
+		t.Fatalf("expected no split datasets, got %d (%v)", len(datasets), err)
+	}
+}
+
+func TestSplitDatasetRecordFailure(t *testing.T) {
+	svc, su, cleanup := newSandbox(t)
+	defer cleanup()
+
+	h2o, received := newFakeSplits("")
+	defer h2o.Close()
+
+	clusterId, err := svc.RegisterCluster(su, h2o.address(), "", "", "", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	projectId, err := svc.CreateProject(su, "p1", "d1", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	datasourceId, err := svc.CreateDatasource(su, projectId, "airlines", "", "file", `{"path": "/data/airlines.csv"}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	datasetId, err := svc.ds.CreateDataset(su, data.Dataset{0, datasourceId, clusterId, sql.NullInt64{}, 0, sql.NullInt64{}, 0, "", "", "airlines", "", "airlines.hex", "", "{}", "1", time.Now(), false})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Fail recording the second split, after the first is recorded
+	db, err := sql.Open("sqlite3", path.Join(svc.workingDir, "steam.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	if _, err := db.Exec(`
+		CREATE TRIGGER fail_validation BEFORE INSERT ON dataset WHEN NEW.name LIKE '%(validation)'
+		BEGIN
+			SELECT RAISE(ABORT, 'disk full');
+		END`); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := svc.SplitDataset(su, datasetId, 70, 30, 1234); err == nil {
+		t.Fatal("expected a split that cannot be recorded to fail")
+	}
+
+	// Both split frames and the recorded split are removed
+	asts := received()
+	removed := 0
+	for _, ast := range asts {
+		if strings.HasPrefix(ast, "(rm ") {
+			removed++
+		}
+	}
+	if removed != 3 {
+		t.Fatalf("expected the draw and both splits to be removed: %v", asts)
+	}
+	if datasets, err := svc.GetDatasets(su, datasourceId, 0, 100); err != nil || len(datasets) != 1 {
+		t.Fatalf("expected no split datasets, got %d (%v)", len(datasets), err)
+	}
+}
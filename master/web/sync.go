@@ -0,0 +1,244 @@
+/*
+  Copyright (C) 2016 H2O.ai, Inc. <http://h2o.ai/>
+
+  This program is free software: you can redistribute it and/or modify
+  it under the terms of the GNU Affero General Public License as
+  published by the Free Software Foundation, either version 3 of the
+  License, or (at your option) any later version.
+
+  This program is distributed in the hope that it will be useful,
+  but WITHOUT ANY WARRANTY; without even the implied warranty of
+  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+  GNU Affero General Public License for more details.
+
+  You should have received a copy of the GNU Affero General Public License
+  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package web
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"github.com/h2oai/steam/master/az"
+	"github.com/h2oai/steam/master/data"
+	"github.com/h2oai/steam/srv/web"
+	"github.com/pkg/errors"
+)
+
+// Datasets and models point at frames and models on their cluster, which are
+// lost when the cluster is wiped or H2O restarts. Syncing a cluster compares
+// its frames and models with Steam's records and marks the datasets and
+// models whose cluster objects are gone as stale; objects that reappear, e.g.
+// after a re-import under the same name, are no longer stale.
+
+// ClusterSyncer periodically syncs every started cluster.
+type ClusterSyncer struct {
+	s        *Service
+	interval time.Duration
+}
+
+func NewClusterSyncer(s *Service, interval time.Duration) *ClusterSyncer {
+	return &ClusterSyncer{
+		s,
+		interval,
+	}
+}
+
+// Run syncs clusters every interval until stop is closed.
+func (y *ClusterSyncer) Run(stop <-chan struct{}) {
+	ticker := time.NewTicker(y.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			if err := y.Sync(); err != nil {
+				log.Println("Cluster sync failed:", err)
+			}
+		case <-stop:
+			return
+		}
+	}
+}
+
+// Sync syncs every started cluster. Clusters that cannot be listed are left
+// alone, so an unreachable cluster does not mark everything on it stale.
+func (y *ClusterSyncer) Sync() error {
+	pz, err := y.s.ds.LookupSuperuser()
+	if err != nil {
+		return errors.Wrap(err, "failed reading superuser")
+	}
+
+	clusters, err := y.s.ds.ReadAllClusters(pz)
+	if err != nil {
+		return errors.Wrap(err, "failed reading clusters")
+	}
+
+	for _, cluster := range clusters {
+		if cluster.State != data.StartedState {
+			continue
+		}
+		if _, err := y.s.syncCluster(pz, cluster); err != nil {
+			log.Printf("Failed syncing cluster %d: %v\n", cluster.Id, err)
+		}
+	}
+	return nil
+}
+
+func (s *Service) SyncCluster(pz az.Principal, clusterId int64) (*web.ClusterSync, error) {
+	if err := pz.CheckPermission(s.ds.Permissions.ManageCluster); err != nil {
+		return nil, err
+	}
+
+	cluster, err := s.ds.ReadCluster(pz, clusterId)
+	if err != nil {
+		return nil, err
+	}
+	if cluster.State != data.StartedState {
+		return nil, fmt.Errorf("Cluster %s is %s; only started clusters can be synced", cluster.Name, cluster.State)
+	}
+
+	return s.syncCluster(pz, cluster)
+}
+
+func (s *Service) syncCluster(pz az.Principal, cluster data.Cluster) (*web.ClusterSync, error) {
+	h2o, err := s.h2oClient(cluster)
+	if err != nil {
+		return nil, err
+	}
+	frameList, err := h2o.GetFramesList()
+	if err != nil {
+		return nil, errors.Wrap(err, "listing frames")
+	}
+	modelList, err := h2o.GetModelsList()
+	if err != nil {
+		return nil, errors.Wrap(err, "listing models")
+	}
+
+	frames := make(map[string]bool, len(frameList.Frames))
+	for _, f := range frameList.Frames {
+		if f != nil && f.FrameId != nil && f.FrameId.KeyV3 != nil {
+			frames[f.FrameId.Name] = true
+		}
+	}
+	models := make(map[string]bool, len(modelList.Models))
+	for _, m := range modelList.Models {
+		if m != nil && m.ModelId != nil && m.ModelId.KeyV3 != nil {
+			models[m.ModelId.Name] = true
+		}
+	}
+
+	datasets, err := s.ds.ReadDatasetsForCluster(pz, cluster.Id)
+	if err != nil {
+		return nil, err
+	}
+	clusterModels, err := s.ds.ReadModelsForCluster(pz, cluster.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	sync := &web.ClusterSync{
+		ClusterId:     cluster.Id,
+		FrameCount:    int64(len(frames)),
+		ModelCount:    int64(len(models)),
+		StaleDatasets: []*web.StaleDataset{},
+		StaleModels:   []*web.StaleModel{},
+		SyncedAt:      time.Now().Unix(),
+	}
+
+	var changed bool
+	staleDatasets := make(map[int64]bool, len(datasets))
+	for _, d := range datasets {
+		stale := !frames[d.FrameName]
+		changed = changed || stale != d.Stale
+		staleDatasets[d.Id] = stale
+		if stale {
+			sync.StaleDatasets = append(sync.StaleDatasets, &web.StaleDataset{
+				d.Id,
+				d.Name,
+				d.FrameName,
+				s.checkReimportable(pz, d) == nil,
+			})
+		}
+	}
+	staleModels := make(map[int64]bool, len(clusterModels))
+	for _, m := range clusterModels {
+		stale := !models[m.ModelKey]
+		changed = changed || stale != m.Stale
+		staleModels[m.Id] = stale
+		if stale {
+			sync.StaleModels = append(sync.StaleModels, &web.StaleModel{
+				m.Id,
+				m.Name,
+				m.ModelKey,
+				m.ModelObjectType.Valid,
+			})
+		}
+	}
+
+	// Only the datasets and models the principal can view are reported and
+	// updated; the others are left to the cluster syncer.
+	if changed {
+		log.Printf("Cluster %s (%d) has %d stale datasets and %d stale models\n", cluster.Name, cluster.Id, len(sync.StaleDatasets), len(sync.StaleModels))
+		if err := s.ds.UpdateClusterStaleness(pz, cluster.Id, staleDatasets, staleModels); err != nil {
+			return nil, err
+		}
+	}
+
+	return sync, nil
+}
+
+// checkReimportable verifies that a dataset can be imported again from its
+// datasource. Splits are re-created by splitting their re-imported parent.
+func (s *Service) checkReimportable(pz az.Principal, dataset data.Dataset) error {
+	if dataset.ParentId.Valid {
+		return fmt.Errorf("Dataset %s was split from dataset %d; re-import that dataset and split it again", dataset.Name, dataset.ParentId.Int64)
+	}
+	if dataset.DatasourceId == 0 {
+		return fmt.Errorf("Dataset %s has no datasource", dataset.Name)
+	}
+	datasource, err := s.ds.ReadDatasource(pz, dataset.DatasourceId)
+	if err != nil {
+		return err
+	}
+	if datasource.Kind == data.DatasourceUpload {
+		c, err := s.openDatasourceConfig(datasource)
+		if err != nil {
+			return err
+		}
+		if _, err := os.Stat(c.Path); err != nil {
+			return fmt.Errorf("The file uploaded for dataset %s is missing", dataset.Name)
+		}
+	}
+	return nil
+}
+
+// ReimportDataset starts importing a stale dataset's datasource into its
+// cluster again. The import is a new version of the datasource; the stale
+// dataset is kept for its history.
+func (s *Service) ReimportDataset(pz az.Principal, datasetId int64) (int64, error) {
+	if err := pz.CheckPermission(s.ds.Permissions.ManageDataset); err != nil {
+		return 0, err
+	}
+
+	dataset, err := s.ds.ReadDataset(pz, datasetId)
+	if err != nil {
+		return 0, err
+	}
+	if !dataset.Stale {
+		return 0, fmt.Errorf("Dataset %s is not stale", dataset.Name)
+	}
+	if err := s.checkReimportable(pz, dataset); err != nil {
+		return 0, err
+	}
+
+	return s.CreateDataset(pz, dataset.ClusterId, dataset.DatasourceId, dataset.Name, dataset.Description, dataset.ResponseColumnName, false)
+}
+
+// errStaleDataset explains that a stale dataset's frame cannot be used.
+func errStaleDataset(dataset data.Dataset) error {
+	return fmt.Errorf("The frame of dataset %s is gone from its cluster; re-import the dataset", dataset.Name)
+}
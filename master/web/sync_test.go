@@ -0,0 +1,306 @@
+/*
+  Copyright (C) 2016 H2O.ai, Inc. <http://h2o.ai/>
+
+  This program is free software: you can redistribute it and/or modify
+  it under the terms of the GNU Affero General Public License as
+  published by the Free Software Foundation, either version 3 of the
+  License, or (at your option) any later version.
+
+  This program is distributed in the hope that it will be useful,
+  but WITHOUT ANY WARRANTY; without even the implied warranty of
+  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+  GNU Affero General Public License for more details.
+
+  You should have received a copy of the GNU Affero General Public License
+  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package web
+
+import (
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/h2oai/steam/master/data"
+)
+
+func TestSyncCluster(t *testing.T) {
+	svc, su, cleanup := newSandbox(t)
+	defer cleanup()
+
+	var (
+		frames = []string{"airlines.hex"}
+		models = []string{"gbm1"}
+	)
+	keys := func(names []string, key string) []interface{} {
+		list := make([]interface{}, len(names))
+		for i, name := range names {
+			list[i] = map[string]interface{}{key: map[string]string{"name": name}}
+		}
+		return list
+	}
+	h2o := newFakeH2O()
+	defer h2o.Close()
+	h2o.handle("/3/Frames", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]interface{}{"frames": keys(frames, "frame_id")})
+	})
+	h2o.handle("/3/Models", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]interface{}{"models": keys(models, "model_id")})
+	})
+
+	clusterId, err := svc.RegisterCluster(su, h2o.address(), "", "", "", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	projectId, err := svc.CreateProject(su, "p1", "d1", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	datasourceId, err := svc.CreateDatasource(su, projectId, "airlines", "", "file", `{"path": "/data/airlines.csv"}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	createDataset := func(name, frameName string, parentId sql.NullInt64) int64 {
+		id, err := svc.ds.CreateDataset(su, data.Dataset{0, datasourceId, clusterId, parentId, 0, sql.NullInt64{}, 0, "", "", name, "", frameName, "", "{}", "1", time.Now(), false})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return id
+	}
+	keptId := createDataset("airlines", "airlines.hex", sql.NullInt64{})
+	lostId := createDataset("flights", "flights.hex", sql.NullInt64{})
+	splitId := createDataset("flights (training)", "flights_train.hex", sql.NullInt64{lostId, true})
+	createModel := func(key string) int64 {
+		id, err := svc.ds.CreateModel(su, data.Model{
+			ProjectId:         projectId,
+			TrainingDatasetId: keptId,
+			Name:              key,
+			ClusterId:         clusterId,
+			ClusterName:       "c1",
+			ModelKey:          key,
+			Algorithm:         "gbm",
+			Metrics:           "{}",
+			MetricsVersion:    "1",
+			Created:           time.Now(),
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return id
+	}
+	createModel("gbm1")
+	lostModelId := createModel("gbm2")
+
+	sync, err := svc.SyncCluster(su, clusterId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sync.FrameCount != 1 || sync.ModelCount != 1 || len(sync.StaleDatasets) != 2 || len(sync.StaleModels) != 1 {
+		t.Fatalf("unexpected sync: %+v", sync)
+	}
+	if d := sync.StaleDatasets[0]; d.DatasetId != lostId || !d.Reimportable {
+		t.Fatalf("expected an imported dataset to be reimportable: %+v", d)
+	}
+	if d := sync.StaleDatasets[1]; d.DatasetId != splitId || d.Reimportable {
+		t.Fatalf("expected a split not to be reimportable: %+v", d)
+	}
+	if m := sync.StaleModels[0]; m.ModelId != lostModelId || m.Exported {
+		t.Fatalf("unexpected stale model: %+v", m)
+	}
+
+	datasets, err := svc.GetDatasets(su, datasourceId, 0, 100)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, d := range datasets {
+		if d.Stale != (d.Id != keptId) {
+			t.Fatalf("unexpected staleness of dataset %s: %v", d.Name, d.Stale)
+		}
+	}
+	model, err := svc.GetModel(su, lostModelId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !model.Stale {
+		t.Fatal("expected the lost model to be stale")
+	}
+
+	// Stale objects fail with an explanation rather than an H2O error
+	if err := svc.ImportModelPojo(su, lostModelId); err == nil || !strings.Contains(err.Error(), "gone from its cluster") {
+		t.Fatalf("expected exporting a stale model to be refused, got %v", err)
+	}
+	if _, err := svc.SplitDataset(su, lostId, 60, 40, 1); err == nil || !strings.Contains(err.Error(), "re-import") {
+		t.Fatalf("expected splitting a stale dataset to be refused, got %v", err)
+	}
+	if _, err := svc.ReimportDataset(su, keptId); err == nil {
+		t.Fatal("expected a dataset on its cluster not to be reimported")
+	}
+	if _, err := svc.ReimportDataset(su, splitId); err == nil {
+		t.Fatal("expected a split not to be reimported")
+	}
+
+	// Objects that reappear are no longer stale
+	h2o.mu.Lock()
+	frames = append(frames, "flights.hex")
+	models = append(models, "gbm2")
+	h2o.mu.Unlock()
+	if err := NewClusterSyncer(svc, time.Hour).Sync(); err != nil {
+		t.Fatal(err)
+	}
+	if model, err = svc.GetModel(su, lostModelId); err != nil {
+		t.Fatal(err)
+	}
+	dataset, err := svc.GetDataset(su, lostId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if model.Stale || dataset.Stale {
+		t.Fatalf("expected restored objects not to be stale: %v %v", model.Stale, dataset.Stale)
+	}
+
+	// An unreachable cluster leaves staleness alone
+	h2o.Close()
+	if err := NewClusterSyncer(svc, time.Hour).Sync(); err != nil {
+		t.Fatal(err)
+	}
+	if dataset, err = svc.GetDataset(su, keptId); err != nil {
+		t.Fatal(err)
+	}
+	if dataset.Stale {
+		t.Fatal("expected an unreachable cluster not to mark its datasets stale")
+	}
+}
+
+func TestReimportDataset(t *testing.T) {
+	svc, su, cleanup := newSandbox(t)
+	defer cleanup()
+
+	h2o, _ := newFakeImports("DONE")
+	defer h2o.Close()
+
+	jobId := startDatasetJob(t, svc, su, h2o)
+	job := waitForDatasetJob(t, svc, su, jobId, finished)
+	if job.State != data.ImportDoneState {
+		t.Fatalf("unexpected job: %+v", job)
+	}
+
+	// The fake cluster lists no frames, so the imported frame is lost
+	if _, err := svc.SyncCluster(su, job.ClusterId); err != nil {
+		t.Fatal(err)
+	}
+	dataset, err := svc.GetDataset(su, job.DatasetId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !dataset.Stale {
+		t.Fatal("expected the lost dataset to be stale")
+	}
+
+	jobId, err = svc.ReimportDataset(su, job.DatasetId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	reimport := waitForDatasetJob(t, svc, su, jobId, finished)
+	if reimport.State != data.ImportDoneState || reimport.DatasetId == job.DatasetId {
+		t.Fatalf("unexpected re-import: %+v", reimport)
+	}
+	reimported, err := svc.GetDataset(su, reimport.DatasetId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if reimported.Stale || reimported.PreviousId != job.DatasetId || reimported.ResponseColumnName != "Origin" {
+		t.Fatalf("unexpected re-imported dataset: %+v", reimported)
+	}
+}
+
+func TestSyncClusterVisibility(t *testing.T) {
+	svc, su, cleanup := newSandbox(t)
+	defer cleanup()
+
+	// The fake cluster lists no frames, so every dataset on it is lost
+	h2o := newFakeH2O()
+	defer h2o.Close()
+	h2o.handleJSON("/3/Frames", map[string]interface{}{"frames": []interface{}{}})
+	h2o.handleJSON("/3/Models", map[string]interface{}{"models": []interface{}{}})
+
+	clusterId, err := svc.RegisterCluster(su, h2o.address(), "", "", "", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	projectId, err := svc.CreateProject(su, "p1", "d1", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	datasourceId, err := svc.CreateDatasource(su, projectId, "airlines", "", "file", `{"path": "/data/airlines.csv"}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	createDataset := func(name string) int64 {
+		id, err := svc.ds.CreateDataset(su, data.Dataset{0, datasourceId, clusterId, sql.NullInt64{}, 0, sql.NullInt64{}, 0, "", "", name, "", name + ".hex", "", "{}", "1", time.Now(), false})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return id
+	}
+	sharedId := createDataset("shared")
+	privateId := createDataset("private")
+
+	roleId, err := svc.CreateRole(su, "syncer", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := svc.LinkRoleWithPermissions(su, roleId, []int64{
+		svc.ds.Permissions.ManageCluster,
+		svc.ds.Permissions.ViewCluster,
+		svc.ds.Permissions.ViewDataset,
+	}); err != nil {
+		t.Fatal(err)
+	}
+	bobId, err := svc.CreateIdentity(su, "bob", "password")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := svc.LinkIdentityWithRole(su, bobId, roleId); err != nil {
+		t.Fatal(err)
+	}
+	bob, err := svc.ds.Lookup("bob")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := svc.ShareEntity(su, data.CanEdit, bob.WorkgroupId(), svc.ds.EntityTypes.Cluster, clusterId); err != nil {
+		t.Fatal(err)
+	}
+	if err := svc.ShareEntity(su, data.CanView, bob.WorkgroupId(), svc.ds.EntityTypes.Dataset, sharedId); err != nil {
+		t.Fatal(err)
+	}
+
+	sync, err := svc.SyncCluster(bob, clusterId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(sync.StaleDatasets) != 1 || sync.StaleDatasets[0].DatasetId != sharedId {
+		t.Fatalf("expected only the shared dataset to be reported, got %+v", sync.StaleDatasets)
+	}
+	stale := func(id int64) bool {
+		dataset, err := svc.GetDataset(su, id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return dataset.Stale
+	}
+	if !stale(sharedId) || stale(privateId) {
+		t.Fatal("expected only the shared dataset to be marked stale")
+	}
+
+	// The cluster syncer updates every dataset
+	if err := NewClusterSyncer(svc, time.Hour).Sync(); err != nil {
+		t.Fatal(err)
+	}
+	if !stale(privateId) {
+		t.Fatal("expected the cluster syncer to mark the private dataset stale")
+	}
+}
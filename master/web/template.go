@@ -0,0 +1,203 @@
+/*
+  Copyright (C) 2016 H2O.ai, Inc. <http://h2o.ai/>
+
+  This program is free software: you can redistribute it and/or modify
+  it under the terms of the GNU Affero General Public License as
+  published by the Free Software Foundation, either version 3 of the
+  License, or (at your option) any later version.
+
+  This program is distributed in the hope that it will be useful,
+  but WITHOUT ANY WARRANTY; without even the implied warranty of
+  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+  GNU Affero General Public License for more details.
+
+  You should have received a copy of the GNU Affero General Public License
+  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package web
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/h2oai/steam/master/az"
+	"github.com/h2oai/steam/master/data"
+	"github.com/h2oai/steam/srv/web"
+)
+
+// newClusterTemplate validates and normalizes template settings.
+func (s *Service) newClusterTemplate(pz az.Principal, templateId int64, name string, engineId int64, size int, memory, driverArgs, queue string, idleTimeout int64, tags string, maxClusters int) (data.ClusterTemplate, error) {
+	name = strings.TrimSpace(name)
+	if len(name) == 0 {
+		return data.ClusterTemplate{}, fmt.Errorf("Template name cannot be empty")
+	}
+	if size < 1 {
+		return data.ClusterTemplate{}, fmt.Errorf("Template size must be at least 1")
+	}
+	if len(strings.TrimSpace(memory)) == 0 {
+		return data.ClusterTemplate{}, fmt.Errorf("Template memory cannot be empty")
+	}
+	if idleTimeout < 0 {
+		return data.ClusterTemplate{}, fmt.Errorf("Idle timeout cannot be negative")
+	}
+	if maxClusters < 0 {
+		return data.ClusterTemplate{}, fmt.Errorf("Maximum number of clusters cannot be negative")
+	}
+
+	if _, err := s.checkYarnOptions(s.ds.ClusterTypes.Yarn, queue, "", strings.Fields(driverArgs)); err != nil {
+		return data.ClusterTemplate{}, err
+	}
+
+	// Templates may only refer to engines their author can use
+	if _, err := s.ds.ReadEngine(pz, engineId); err != nil {
+		return data.ClusterTemplate{}, err
+	}
+
+	return data.ClusterTemplate{
+		templateId,
+		name,
+		engineId,
+		int64(size),
+		strings.TrimSpace(memory),
+		strings.Join(strings.Fields(driverArgs), " "),
+		strings.TrimSpace(queue),
+		idleTimeout,
+		strings.Join(splitTags(tags), ","),
+		int64(maxClusters),
+		time.Time{},
+	}, nil
+}
+
+func splitTags(tags string) []string {
+	var ts []string
+	for _, t := range strings.Split(tags, ",") {
+		if t = strings.TrimSpace(t); t != "" {
+			ts = append(ts, t)
+		}
+	}
+	return ts
+}
+
+func (s *Service) CreateClusterTemplate(pz az.Principal, name string, engineId int64, size int, memory, driverArgs, queue string, idleTimeout int64, tags string, maxClusters int) (int64, error) {
+	if err := pz.CheckPermission(s.ds.Permissions.ManageCluster); err != nil {
+		return 0, err
+	}
+
+	t, err := s.newClusterTemplate(pz, 0, name, engineId, size, memory, driverArgs, queue, idleTimeout, tags, maxClusters)
+	if err != nil {
+		return 0, err
+	}
+
+	return s.ds.CreateClusterTemplate(pz, t)
+}
+
+func (s *Service) GetClusterTemplate(pz az.Principal, templateId int64) (*web.ClusterTemplate, error) {
+	if err := pz.CheckPermission(s.ds.Permissions.ViewCluster); err != nil {
+		return nil, err
+	}
+
+	t, err := s.ds.ReadClusterTemplate(pz, templateId)
+	if err != nil {
+		return nil, err
+	}
+	return toClusterTemplate(t), nil
+}
+
+func (s *Service) GetClusterTemplates(pz az.Principal, offset, limit int64) ([]*web.ClusterTemplate, error) {
+	if err := pz.CheckPermission(s.ds.Permissions.ViewCluster); err != nil {
+		return nil, err
+	}
+
+	ts, err := s.ds.ReadClusterTemplates(pz, offset, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	templates := make([]*web.ClusterTemplate, len(ts))
+	for i, t := range ts {
+		templates[i] = toClusterTemplate(t)
+	}
+	return templates, nil
+}
+
+func (s *Service) UpdateClusterTemplate(pz az.Principal, templateId int64, name string, engineId int64, size int, memory, driverArgs, queue string, idleTimeout int64, tags string, maxClusters int) error {
+	if err := pz.CheckPermission(s.ds.Permissions.ManageCluster); err != nil {
+		return err
+	}
+
+	t, err := s.newClusterTemplate(pz, templateId, name, engineId, size, memory, driverArgs, queue, idleTimeout, tags, maxClusters)
+	if err != nil {
+		return err
+	}
+
+	return s.ds.UpdateClusterTemplate(pz, t)
+}
+
+func (s *Service) DeleteClusterTemplate(pz az.Principal, templateId int64) error {
+	if err := pz.CheckPermission(s.ds.Permissions.ManageCluster); err != nil {
+		return err
+	}
+
+	return s.ds.DeleteClusterTemplate(pz, templateId)
+}
+
+// StartClusterFromTemplate starts a cluster on YARN with a template's
+// settings. Anyone the template is shared with can use it, up to the
+// template's limit on running clusters. Templates carry no keytab; the
+// caller supplies one of their own.
+func (s *Service) StartClusterFromTemplate(pz az.Principal, templateId int64, clusterName string, keytabId int64) (int64, error) {
+	if err := pz.CheckPermission(s.ds.Permissions.ManageCluster); err != nil {
+		return 0, err
+	}
+
+	t, err := s.ds.ReadClusterTemplate(pz, templateId)
+	if err != nil {
+		return 0, err
+	}
+
+	// The template's driver options are checked again, against the options
+	// allowed now rather than when the template was saved
+	if _, err := s.checkYarnOptions(s.ds.ClusterTypes.Yarn, t.Queue, "", strings.Fields(t.DriverArgs)); err != nil {
+		return 0, fmt.Errorf("Template %s cannot be used: %s", t.Name, err)
+	}
+
+	if t.MaxClusters > 0 {
+		n, err := s.ds.CountActiveTemplateClusters(pz, templateId)
+		if err != nil {
+			return 0, err
+		}
+		if n >= t.MaxClusters {
+			return 0, fmt.Errorf("Template %s allows at most %d running clusters", t.Name, t.MaxClusters)
+		}
+	}
+
+	return s.startCluster(pz, s.ds.ClusterTypes.Yarn, clusterName, clusterShape{
+		t.EngineId,
+		int(t.Size),
+		t.Memory,
+		keytabId,
+		t.Queue,
+		"",
+		strings.Fields(t.DriverArgs),
+		t.IdleTimeout,
+		t.Id,
+	})
+}
+
+func toClusterTemplate(t data.ClusterTemplate) *web.ClusterTemplate {
+	return &web.ClusterTemplate{
+		t.Id,
+		t.Name,
+		t.EngineId,
+		int(t.Size),
+		t.Memory,
+		t.DriverArgs,
+		t.Queue,
+		t.IdleTimeout,
+		t.Tags,
+		int(t.MaxClusters),
+		toTimestamp(t.Created),
+	}
+}
@@ -0,0 +1,103 @@
+/*
+  Copyright (C) 2016 H2O.ai, Inc. <http://h2o.ai/>
+
+  This program is free software: you can redistribute it and/or modify
+  it under the terms of the GNU Affero General Public License as
+  published by the Free Software Foundation, either version 3 of the
+  License, or (at your option) any later version.
+
+  This program is distributed in the hope that it will be useful,
+  but WITHOUT ANY WARRANTY; without even the implied warranty of
+  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+  GNU Affero General Public License for more details.
+
+  You should have received a copy of the GNU Affero General Public License
+  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package web
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/h2oai/steam/lib/yarn"
+)
+
+func TestStartClusterFromTemplate(t *testing.T) {
+	svc, su, cleanup := newSandbox(t)
+	defer cleanup()
+
+	launcher := NewFakeLauncher("localhost:54321")
+	svc.SetClusterLauncher(svc.ds.ClusterTypes.Yarn, launcher)
+
+	engineId, err := svc.ds.CreateEngine(su, "h2o", "/tmp/h2odriver.jar")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := svc.CreateClusterTemplate(su, "bad", engineId, 0, "8g", "", "", 0, "", 0); err == nil {
+		t.Fatal("expected a template without nodes to be rejected")
+	}
+
+	if _, err := svc.CreateClusterTemplate(su, "evil", engineId, 1, "8g", "-libjars evil.jar", "", 0, "", 0); err == nil {
+		t.Fatal("expected a template with disallowed driver options to be rejected")
+	}
+
+	templateId, err := svc.CreateClusterTemplate(su, " small-4x8g ", engineId, 4, "8g", " -nthreads  4 ", "analytics", 45, "small, shared ,", 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	template, err := svc.GetClusterTemplate(su, templateId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if template.Name != "small-4x8g" || template.DriverArgs != "-nthreads 4" || template.Tags != "small,shared" {
+		t.Fatalf("template not normalized: %+v", template)
+	}
+
+	clusterId, err := svc.StartClusterFromTemplate(su, templateId, "c1", 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	yarnCluster, err := svc.ds.ReadYarnCluster(su, clusterId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if yarnCluster.Size != 4 || yarnCluster.Memory != "8g" || yarnCluster.IdleTimeout != 45 || yarnCluster.TemplateId != templateId {
+		t.Fatalf("cluster does not match template: %+v", yarnCluster)
+	}
+
+	if _, err := svc.StartClusterFromTemplate(su, templateId, "c2", 0); err == nil {
+		t.Fatal("expected the template's cluster limit to be enforced")
+	}
+
+	if err := svc.UpdateClusterTemplate(su, templateId, "small-4x8g", engineId, 4, "8g", "", "", 0, "", 2); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := svc.StartClusterFromTemplate(su, templateId, "c2", 0); err != nil {
+		t.Fatal(err)
+	}
+
+	// Options allowed when the template was saved may be disallowed since
+	if err := svc.UpdateClusterTemplate(su, templateId, "small-4x8g", engineId, 4, "8g", "-nthreads 4", "", 0, "", 0); err != nil {
+		t.Fatal(err)
+	}
+	policy, err := yarn.ParseDriverOptionPolicy("-extramempercent")
+	if err != nil {
+		t.Fatal(err)
+	}
+	svc.SetDriverOptionPolicy(policy)
+	if _, err := svc.StartClusterFromTemplate(su, templateId, "c3", 0); err == nil || !strings.Contains(err.Error(), "-nthreads") {
+		t.Fatalf("expected a template with disallowed driver options not to be usable, got %v", err)
+	}
+	svc.SetDriverOptionPolicy(defaultDriverOptionPolicy)
+
+	if err := svc.DeleteClusterTemplate(su, templateId); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := svc.StartClusterFromTemplate(su, templateId, "c3", 0); err == nil {
+		t.Fatal("expected a deleted template not to be usable")
+	}
+}
@@ -0,0 +1,244 @@
+/*
+  Copyright (C) 2016 H2O.ai, Inc. <http://h2o.ai/>
+
+  This program is free software: you can redistribute it and/or modify
+  it under the terms of the GNU Affero General Public License as
+  published by the Free Software Foundation, either version 3 of the
+  License, or (at your option) any later version.
+
+  This program is distributed in the hope that it will be useful,
+  but WITHOUT ANY WARRANTY; without even the implied warranty of
+  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+  GNU Affero General Public License for more details.
+
+  You should have received a copy of the GNU Affero General Public License
+  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package web
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sync"
+	"time"
+
+	"github.com/h2oai/steam/lib/fs"
+	"github.com/h2oai/steam/master/az"
+	"github.com/h2oai/steam/master/data"
+	"github.com/pkg/errors"
+)
+
+// DefaultDatasetUploadLimit is the largest file accepted as a dataset upload,
+// unless set otherwise with SetDatasetUploadLimit.
+const DefaultDatasetUploadLimit = 10 << 30
+
+// DefaultDatasetUploadExpiry is how long an unfinished upload is kept after its
+// last chunk.
+const DefaultDatasetUploadExpiry = 24 * time.Hour
+
+// UploadOffsetError rejects a chunk that does not continue an upload where it
+// left off. Offset is where the next chunk must start.
+type UploadOffsetError struct {
+	Offset int64
+}
+
+func (e *UploadOffsetError) Error() string {
+	return fmt.Sprintf("Upload continues at byte %d", e.Offset)
+}
+
+// UploadTooLargeError rejects an upload larger than the upload limit.
+type UploadTooLargeError struct {
+	Limit int64
+}
+
+func (e *UploadTooLargeError) Error() string {
+	return fmt.Sprintf("Uploads are limited to %d bytes", e.Limit)
+}
+
+// SetDatasetUploadLimit sets the largest file, in bytes, accepted as a dataset
+// upload; 0 removes the limit.
+func (s *Service) SetDatasetUploadLimit(limit int64) {
+	s.datasetUploadLimit = limit
+}
+
+// uploadPartPath returns where an identity's upload of a file of a given size
+// collects until it is complete. The size is part of the name, so that a
+// different file of the same name starts over instead of resuming.
+func uploadPartPath(dir, fileName string, identityId, size int64) string {
+	return path.Join(dir, fmt.Sprintf(".%s.%d.%d.part", fileName, identityId, size))
+}
+
+var uploadPartRegexp = regexp.MustCompile(`^\.(.+)\.\d+\.\d+\.part$`)
+
+type uploadLock struct {
+	sync.Mutex
+	refs int
+}
+
+// lockUpload serializes the chunks of all uploads to the same file, so that
+// a retried chunk waits for the one it replaces. It returns the unlock.
+func (s *Service) lockUpload(dstPath string) func() {
+	s.uploadsMu.Lock()
+	l, ok := s.uploads[dstPath]
+	if !ok {
+		l = &uploadLock{}
+		s.uploads[dstPath] = l
+	}
+	l.refs++
+	s.uploadsMu.Unlock()
+
+	l.Lock()
+	return func() {
+		l.Unlock()
+		s.uploadsMu.Lock()
+		if l.refs--; l.refs == 0 {
+			delete(s.uploads, dstPath)
+		}
+		s.uploadsMu.Unlock()
+	}
+}
+
+// UploadDatasetChunk appends a chunk, starting at offset, to an upload of a
+// file of size bytes into a project's data area. Chunks are accepted only in
+// order; if offset is not where the upload left off, an *UploadOffsetError
+// tells the client where to resume. It returns the bytes received so far and,
+// once the file is complete, the ID of the upload datasource created for it.
+func (s *Service) UploadDatasetChunk(pz az.Principal, projectId int64, name, fileName string, size, offset int64, chunk io.Reader) (int64, int64, error) {
+	if err := pz.CheckPermission(s.ds.Permissions.ManageDatasource); err != nil {
+		return 0, 0, err
+	}
+	if err := pz.CheckEdit(s.ds.EntityTypes.Project, projectId); err != nil {
+		return 0, 0, err
+	}
+	if err := fs.ValidateName(fileName); err != nil {
+		return 0, 0, fmt.Errorf("Invalid file name: %v", err)
+	}
+	if size <= 0 {
+		return 0, 0, fmt.Errorf("Invalid file size: %d", size)
+	}
+	if s.datasetUploadLimit > 0 && size > s.datasetUploadLimit {
+		return 0, 0, &UploadTooLargeError{s.datasetUploadLimit}
+	}
+
+	dir := fs.GetProjectDataPath(s.workingDir, projectId)
+	dstPath := path.Join(dir, fileName)
+	unlock := s.lockUpload(dstPath)
+	defer unlock()
+
+	if fs.FileExists(dstPath) {
+		return 0, 0, fmt.Errorf("A file named %s has already been uploaded to this project", fileName)
+	}
+	if err := os.MkdirAll(dir, fs.DirPerm); err != nil {
+		return 0, 0, errors.Wrap(err, "failed creating data directory")
+	}
+
+	partPath := uploadPartPath(dir, fileName, pz.Id(), size)
+	f, err := os.OpenFile(partPath, os.O_CREATE|os.O_WRONLY|os.O_APPEND, fs.FilePerm)
+	if err != nil {
+		return 0, 0, errors.Wrap(err, "failed opening upload")
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return 0, 0, errors.Wrap(err, "failed reading upload")
+	}
+	received := info.Size()
+	if offset != received {
+		return received, 0, &UploadOffsetError{received}
+	}
+
+	// Whatever arrives is kept, so that a broken chunk can be resumed
+	n, err := io.Copy(f, io.LimitReader(chunk, size-received+1))
+	received += n
+	if received > size {
+		if err := f.Truncate(size); err != nil {
+			log.Printf("Failed truncating upload %s: %v\n", partPath, err)
+		}
+		return size, 0, fmt.Errorf("Upload is longer than its size of %d bytes", size)
+	}
+	if err != nil {
+		return received, 0, errors.Wrap(err, "failed receiving upload")
+	}
+	if received < size {
+		return received, 0, nil
+	}
+
+	if err := f.Close(); err != nil {
+		return received, 0, errors.Wrap(err, "failed saving upload")
+	}
+	if err := os.Rename(partPath, dstPath); err != nil {
+		return received, 0, errors.Wrap(err, "failed saving upload")
+	}
+
+	if name == "" {
+		name = fileName
+	}
+	config, err := json.Marshal(datasourceConfig{Path: dstPath, Size: size})
+	if err != nil {
+		return received, 0, err
+	}
+	datasourceId, err := s.ds.CreateDatasource(pz, data.Datasource{
+		0,
+		projectId,
+		name,
+		fmt.Sprintf("Uploaded %s (%d bytes)", fileName, size),
+		data.DatasourceUpload,
+		string(config),
+		time.Now(),
+	})
+	if err != nil {
+		os.Remove(dstPath)
+		return received, 0, err
+	}
+
+	return received, datasourceId, nil
+}
+
+// ExpireDatasetUploads removes unfinished uploads that have not received a
+// chunk for longer than expiry.
+func (s *Service) ExpireDatasetUploads(now time.Time, expiry time.Duration) error {
+	parts, err := filepath.Glob(path.Join(s.workingDir, fs.DataDir, "*", ".*.part"))
+	if err != nil {
+		return errors.Wrap(err, "failed listing uploads")
+	}
+	for _, partPath := range parts {
+		m := uploadPartRegexp.FindStringSubmatch(path.Base(partPath))
+		if m == nil {
+			continue
+		}
+		unlock := s.lockUpload(path.Join(path.Dir(partPath), m[1]))
+		if info, err := os.Stat(partPath); err == nil && now.Sub(info.ModTime()) > expiry {
+			log.Printf("Removing upload %s, unfinished since %s\n", partPath, info.ModTime())
+			if err := os.Remove(partPath); err != nil {
+				log.Printf("Failed removing upload %s: %v\n", partPath, err)
+			}
+		}
+		unlock()
+	}
+	return nil
+}
+
+// RunDatasetUploadExpiry expires unfinished uploads every interval until stop
+// is closed.
+func (s *Service) RunDatasetUploadExpiry(interval, expiry time.Duration, stop <-chan struct{}) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			if err := s.ExpireDatasetUploads(time.Now(), expiry); err != nil {
+				log.Println("Upload expiry failed:", err)
+			}
+		case <-stop:
+			return
+		}
+	}
+}
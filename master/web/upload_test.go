@@ -0,0 +1,258 @@
+/*
+  Copyright (C) 2016 H2O.ai, Inc. <http://h2o.ai/>
+
+  This program is free software: you can redistribute it and/or modify
+  it under the terms of the GNU Affero General Public License as
+  published by the Free Software Foundation, either version 3 of the
+  License, or (at your option) any later version.
+
+  This program is distributed in the hope that it will be useful,
+  but WITHOUT ANY WARRANTY; without even the implied warranty of
+  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+  GNU Affero General Public License for more details.
+
+  You should have received a copy of the GNU Affero General Public License
+  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package web
+
+import (
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/h2oai/steam/lib/fs"
+	"github.com/h2oai/steam/master/data"
+)
+
+func TestUploadDatasetChunks(t *testing.T) {
+	svc, su, cleanup := newSandbox(t)
+	defer cleanup()
+
+	projectId, err := svc.CreateProject(su, "p1", "d1", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	content := "Origin,Distance\nSFO,100\nJFK,200\n"
+	size := int64(len(content))
+
+	received, datasourceId, err := svc.UploadDatasetChunk(su, projectId, "", "airlines.csv", size, 0, strings.NewReader(content[:10]))
+	if err != nil || received != 10 || datasourceId != 0 {
+		t.Fatalf("unexpected first chunk: %d %d %v", received, datasourceId, err)
+	}
+
+	// Starting over resumes where the upload left off
+	_, _, err = svc.UploadDatasetChunk(su, projectId, "", "airlines.csv", size, 0, strings.NewReader(content))
+	if e, ok := err.(*UploadOffsetError); !ok || e.Offset != 10 {
+		t.Fatalf("expected an offset error at byte 10, got %v", err)
+	}
+
+	received, datasourceId, err = svc.UploadDatasetChunk(su, projectId, "airlines", "airlines.csv", size, 10, strings.NewReader(content[10:]))
+	if err != nil || received != size || datasourceId == 0 {
+		t.Fatalf("unexpected last chunk: %d %d %v", received, datasourceId, err)
+	}
+
+	datasource, err := svc.ds.ReadDatasource(su, datasourceId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	filePath := path.Join(fs.GetProjectDataPath(svc.workingDir, projectId), "airlines.csv")
+	if datasource.Kind != data.DatasourceUpload || datasource.Name != "airlines" || !strings.Contains(datasource.Configuration, filePath) {
+		t.Fatalf("unexpected datasource: %+v", datasource)
+	}
+	if b, err := ioutil.ReadFile(filePath); err != nil || string(b) != content {
+		t.Fatalf("unexpected upload: %q %v", b, err)
+	}
+	if _, err := svc.TestDatasource(su, datasourceId, 0); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, _, err := svc.UploadDatasetChunk(su, projectId, "", "airlines.csv", size, 0, strings.NewReader(content)); err == nil {
+		t.Fatal("expected an uploaded file not to be replaced")
+	}
+	if err := svc.UpdateDatasource(su, datasourceId, "flights", "", "file", `{"path": "/etc/passwd"}`); err == nil {
+		t.Fatal("expected an upload datasource to keep its kind")
+	}
+	if err := svc.UpdateDatasource(su, datasourceId, "flights", "", data.DatasourceUpload, ""); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := svc.DeleteDatasource(su, datasourceId); err != nil {
+		t.Fatal(err)
+	}
+	if fs.FileExists(filePath) {
+		t.Fatal("expected the uploaded file to be removed with its datasource")
+	}
+}
+
+func TestUploadDatasetLimits(t *testing.T) {
+	svc, su, cleanup := newSandbox(t)
+	defer cleanup()
+
+	projectId, err := svc.CreateProject(su, "p1", "d1", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	svc.SetDatasetUploadLimit(8)
+	if _, _, err := svc.UploadDatasetChunk(su, projectId, "", "big.csv", 9, 0, strings.NewReader("123456789")); err == nil {
+		t.Fatal("expected an upload over the limit to be refused")
+	} else if _, ok := err.(*UploadTooLargeError); !ok {
+		t.Fatalf("expected a too large error, got %v", err)
+	}
+
+	// A chunk cannot run past the declared size
+	if _, _, err := svc.UploadDatasetChunk(su, projectId, "", "small.csv", 4, 0, strings.NewReader("123456")); err == nil {
+		t.Fatal("expected an overlong upload to be refused")
+	}
+	received, datasourceId, err := svc.UploadDatasetChunk(su, projectId, "", "small.csv", 4, 0, strings.NewReader("1234"))
+	if _, ok := err.(*UploadOffsetError); !ok || received != 4 || datasourceId != 0 {
+		t.Fatalf("expected the overlong chunk to be cut at the declared size: %d %d %v", received, datasourceId, err)
+	}
+
+	if _, _, err := svc.UploadDatasetChunk(su, projectId, "", "../escape.csv", 4, 0, strings.NewReader("1234")); err == nil {
+		t.Fatal("expected an invalid file name to be refused")
+	}
+}
+
+func TestUploadDatasetChunkRetry(t *testing.T) {
+	svc, su, cleanup := newSandbox(t)
+	defer cleanup()
+
+	projectId, err := svc.CreateProject(su, "p1", "d1", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	content := "Origin,Distance\nSFO,100\nJFK,200\n"
+	size := int64(len(content))
+
+	// A retried chunk waits for the one still in flight
+	r, w := io.Pipe()
+	first := make(chan error)
+	go func() {
+		_, _, err := svc.UploadDatasetChunk(su, projectId, "", "airlines.csv", size, 0, r)
+		first <- err
+	}()
+	w.Write([]byte(content[:5]))
+	retry := make(chan error)
+	go func() {
+		_, _, err := svc.UploadDatasetChunk(su, projectId, "", "airlines.csv", size, 0, strings.NewReader(content[:10]))
+		retry <- err
+	}()
+	time.Sleep(50 * time.Millisecond)
+	w.Write([]byte(content[5:10]))
+	w.Close()
+	if err := <-first; err != nil {
+		t.Fatal(err)
+	}
+	if e, ok := (<-retry).(*UploadOffsetError); !ok || e.Offset != 10 {
+		t.Fatalf("expected the retry to resume at byte 10, got %v", e)
+	}
+
+	// Uploads of other identities are kept apart
+	roleId, err := svc.CreateRole(su, "uploader", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := svc.LinkRoleWithPermissions(su, roleId, []int64{svc.ds.Permissions.ManageDatasource, svc.ds.Permissions.ViewProject}); err != nil {
+		t.Fatal(err)
+	}
+	bobId, err := svc.CreateIdentity(su, "bob", "password")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := svc.LinkIdentityWithRole(su, bobId, roleId); err != nil {
+		t.Fatal(err)
+	}
+	bob, err := svc.ds.Lookup("bob")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := svc.ShareEntity(su, data.CanEdit, bob.WorkgroupId(), svc.ds.EntityTypes.Project, projectId); err != nil {
+		t.Fatal(err)
+	}
+	_, _, err = svc.UploadDatasetChunk(bob, projectId, "", "airlines.csv", size, 10, strings.NewReader(content[10:]))
+	if e, ok := err.(*UploadOffsetError); !ok || e.Offset != 0 {
+		t.Fatalf("expected bob's upload to start at byte 0, got %v", err)
+	}
+
+	// Abandoned uploads expire
+	if err := svc.ExpireDatasetUploads(time.Now(), time.Hour); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := svc.UploadDatasetChunk(su, projectId, "", "airlines.csv", size, 0, strings.NewReader(content)); err == nil {
+		t.Fatal("expected a recent upload to be kept")
+	}
+	if err := svc.ExpireDatasetUploads(time.Now().Add(2*time.Hour), time.Hour); err != nil {
+		t.Fatal(err)
+	}
+	received, datasourceId, err := svc.UploadDatasetChunk(su, projectId, "", "airlines.csv", size, 0, strings.NewReader(content))
+	if err != nil || received != size || datasourceId == 0 {
+		t.Fatalf("expected an expired upload to start over: %d %d %v", received, datasourceId, err)
+	}
+}
+
+func TestUploadDatasetImport(t *testing.T) {
+	svc, su, cleanup := newSandbox(t)
+	defer cleanup()
+
+	var posted string
+	h2o, _ := newFakeImports("DONE")
+	defer h2o.Close()
+	h2o.handle("/3/PostFile", func(w http.ResponseWriter, r *http.Request) {
+		f, _, err := r.FormFile("file")
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		b, _ := ioutil.ReadAll(f)
+		posted = string(b)
+		w.Write([]byte(`{"destination_frame": "` + r.URL.Query().Get("destination_frame") + `", "total_bytes": 10}`))
+	})
+
+	projectId, err := svc.CreateProject(su, "p1", "d1", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, datasourceId, err := svc.UploadDatasetChunk(su, projectId, "", "airlines.csv", 10, 0, strings.NewReader("Origin\nSFO\n"[:10]))
+	if err != nil {
+		t.Fatal(err)
+	}
+	clusterId, err := svc.RegisterCluster(su, h2o.address(), "", "", "", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	jobId, err := svc.CreateDataset(su, clusterId, datasourceId, "airlines", "", "", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	job := waitForDatasetJob(t, svc, su, jobId, finished)
+	if job.State != data.ImportDoneState {
+		t.Fatalf("unexpected job: %+v", job)
+	}
+	if posted != "Origin\nSFO" {
+		t.Fatalf("expected the uploaded file to be posted to the cluster, got %q", posted)
+	}
+	dataset, err := svc.ds.ReadDataset(su, job.DatasetId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(dataset.Checksum, "sha256:") {
+		t.Fatalf("expected the uploaded file to be checksummed: %+v", dataset)
+	}
+
+	if err := os.Remove(path.Join(fs.GetProjectDataPath(svc.workingDir, projectId), "airlines.csv")); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := svc.TestDatasource(su, datasourceId, 0); err == nil {
+		t.Fatal("expected a missing upload to fail its test")
+	}
+}
@@ -0,0 +1,216 @@
+/*
+  Copyright (C) 2016 H2O.ai, Inc. <http://h2o.ai/>
+
+  This program is free software: you can redistribute it and/or modify
+  it under the terms of the GNU Affero General Public License as
+  published by the Free Software Foundation, either version 3 of the
+  License, or (at your option) any later version.
+
+  This program is distributed in the hope that it will be useful,
+  but WITHOUT ANY WARRANTY; without even the implied warranty of
+  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+  GNU Affero General Public License for more details.
+
+  You should have received a copy of the GNU Affero General Public License
+  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package web
+
+import (
+	"database/sql"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/h2oai/steam/master/data"
+)
+
+const versionFrame = `{"frames": [{"rows": %d, "columns": [{"label": "Origin", "type": "enum"}, {"label": "%s", "type": "int", "mean": %g}]}]}`
+
+func TestDatasetVersions(t *testing.T) {
+	svc, su, cleanup := newSandbox(t)
+	defer cleanup()
+
+	projectId, err := svc.CreateProject(su, "p1", "d1", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	datasourceId, err := svc.CreateDatasource(su, projectId, "airlines", "", "file", `{"path": "/data/airlines.csv"}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	create := func(name string, parentId sql.NullInt64) data.Dataset {
+		properties := fmt.Sprintf(versionFrame, 10, "Distance", 812.5)
+		rowCount, columnHash := datasetFingerprint(properties)
+		id, err := svc.ds.CreateDataset(su, data.Dataset{0, datasourceId, 0, parentId, 0, sql.NullInt64{}, rowCount, columnHash, "", name, "", name + ".hex", "", properties, "1", time.Now(), false})
+		if err != nil {
+			t.Fatal(err)
+		}
+		dataset, err := svc.ds.ReadDataset(su, id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return dataset
+	}
+
+	v1 := create("jan", sql.NullInt64{})
+	v2 := create("feb", sql.NullInt64{})
+	split := create("feb (training)", sql.NullInt64{v2.Id, true})
+	v3 := create("mar", sql.NullInt64{})
+
+	if v1.Version != 1 || v1.PreviousId.Valid || v2.Version != 2 || v2.PreviousId.Int64 != v1.Id || v3.Version != 3 || v3.PreviousId.Int64 != v2.Id {
+		t.Fatalf("unexpected versions: %+v %+v %+v", v1, v2, v3)
+	}
+	if split.Version != 2 || split.PreviousId.Valid {
+		t.Fatalf("expected a split to share its dataset's version: %+v", split)
+	}
+	if v1.RowCount != 10 || v1.ColumnHash == "" || v1.ColumnHash != v3.ColumnHash {
+		t.Fatalf("unexpected fingerprints: %+v %+v", v1, v3)
+	}
+
+	versions, err := svc.GetDatasetVersions(su, datasourceId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(versions) != 3 || versions[0].Id != v3.Id || versions[2].Id != v1.Id {
+		t.Fatalf("expected the imports newest first: %+v", versions)
+	}
+
+	// Removing a version links its successor to its predecessor
+	if err := svc.DeleteDataset(su, split.Id); err != nil {
+		t.Fatal(err)
+	}
+	if err := svc.DeleteDataset(su, v2.Id); err != nil {
+		t.Fatal(err)
+	}
+	if v3, err = svc.ds.ReadDataset(su, v3.Id); err != nil {
+		t.Fatal(err)
+	}
+	if v3.PreviousId.Int64 != v1.Id {
+		t.Fatalf("expected version 3 to follow version 1: %+v", v3)
+	}
+	if v4 := create("apr", sql.NullInt64{}); v4.Version != 4 {
+		t.Fatalf("expected version numbers not to be reused: %+v", v4)
+	}
+}
+
+func TestImportModelTrainingDataset(t *testing.T) {
+	svc, su, cleanup := newSandbox(t)
+	defer cleanup()
+
+	metrics := `{"MSE": 0.2, "r2": 0.1, "logloss": 0.5, "AUC": 0.7, "Gini": 0.4}`
+	h2o := newFakeH2O()
+	defer h2o.Close()
+	h2o.handle("/3/Models/", func(w http.ResponseWriter, r *http.Request) {
+		key := strings.TrimPrefix(r.URL.Path, "/3/Models/")
+		frame := map[string]string{"m1": "airlines_train", "m2": "airlines_copy", "m3": "weather", "m4": "airlines_imputed", "m5": "airlines_copy"}[key]
+		fmt.Fprintf(w, `{"models": [{"model_id": {"name": %q}, "algo_full_name": "GBM", "response_column_name": "Origin", "data_frame": {"name": %q},
+			"output": {"model_category": "Binomial", "training_metrics": %s, "validation_metrics": {"frame": {"name": "airlines_valid"}}}}]}`, key, frame, metrics)
+	})
+	serveFrame := func(rows int, label string, mean float64) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprintf(w, versionFrame, rows, label, mean)
+		}
+	}
+	h2o.handle("/3/Frames/airlines_copy", serveFrame(10, "Distance", 812.5))
+	h2o.handle("/3/Frames/airlines_imputed", serveFrame(10, "Distance", 790.0))
+	h2o.handle("/3/Frames/weather", serveFrame(10, "Temperature", 12.5))
+
+	clusterId, err := svc.RegisterCluster(su, h2o.address(), "", "", "", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	projectId, err := svc.CreateProject(su, "p1", "d1", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	datasourceId, err := svc.CreateDatasource(su, projectId, "airlines", "", "file", `{"path": "/data/airlines.csv"}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	properties := fmt.Sprintf(versionFrame, 10, "Distance", 812.5)
+	rowCount, columnHash := datasetFingerprint(properties)
+	trainId, err := svc.ds.CreateDataset(su, data.Dataset{0, datasourceId, clusterId, sql.NullInt64{}, 0, sql.NullInt64{}, rowCount, columnHash, "", "airlines", "", "airlines_train", "Origin", properties, "1", time.Now(), false})
+	if err != nil {
+		t.Fatal(err)
+	}
+	validId, err := svc.ds.CreateDataset(su, data.Dataset{0, datasourceId, clusterId, sql.NullInt64{trainId, true}, 0, sql.NullInt64{}, 0, "", "", "airlines (validation)", "", "airlines_valid", "Origin", "{}", "1", time.Now(), false})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The training frame is a known dataset
+	modelId, err := svc.ImportModelFromCluster(su, clusterId, projectId, "m1", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	model, err := svc.GetModel(su, modelId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if model.TrainingDatasetId != trainId || model.TrainingDatasetVersion != 1 || model.ValidationDatasetId != validId {
+		t.Fatalf("unexpected datasets: %+v", model)
+	}
+
+	// The training frame has the content of a known dataset
+	if modelId, err = svc.ImportModelFromCluster(su, clusterId, projectId, "m2", ""); err != nil {
+		t.Fatal(err)
+	}
+	if model, err = svc.GetModel(su, modelId); err != nil {
+		t.Fatal(err)
+	}
+	if model.TrainingDatasetId != trainId {
+		t.Fatalf("expected the training dataset to be matched by content: %+v", model)
+	}
+
+	// The training frame has the shape but not the content of a known dataset
+	if modelId, err = svc.ImportModelFromCluster(su, clusterId, projectId, "m4", ""); err != nil {
+		t.Fatal(err)
+	}
+	if model, err = svc.GetModel(su, modelId); err != nil {
+		t.Fatal(err)
+	}
+	if model.TrainingDatasetId == trainId {
+		t.Fatalf("expected a frame with other statistics not to match: %+v", model)
+	}
+
+	// The same content in two datasources is ambiguous
+	otherId, err := svc.CreateDatasource(su, projectId, "flights", "", "file", `{"path": "/data/flights.csv"}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := svc.ds.CreateDataset(su, data.Dataset{0, otherId, clusterId, sql.NullInt64{}, 0, sql.NullInt64{}, rowCount, columnHash, "", "flights", "", "flights", "Origin", properties, "1", time.Now(), false}); err != nil {
+		t.Fatal(err)
+	}
+	if modelId, err = svc.ImportModelFromCluster(su, clusterId, projectId, "m5", ""); err != nil {
+		t.Fatal(err)
+	}
+	if model, err = svc.GetModel(su, modelId); err != nil {
+		t.Fatal(err)
+	}
+	if model.TrainingDatasetId == trainId {
+		t.Fatalf("expected an ambiguous match not to be used: %+v", model)
+	}
+
+	// The training frame is unknown
+	if modelId, err = svc.ImportModelFromCluster(su, clusterId, projectId, "m3", ""); err != nil {
+		t.Fatal(err)
+	}
+	if model, err = svc.GetModel(su, modelId); err != nil {
+		t.Fatal(err)
+	}
+	if model.TrainingDatasetId == trainId || model.TrainingDatasetVersion != 1 {
+		t.Fatalf("expected a new implicit dataset: %+v", model)
+	}
+	dataset, err := svc.GetDataset(su, model.TrainingDatasetId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if dataset.FrameName != "weather" || dataset.RowCount != 10 {
+		t.Fatalf("unexpected implicit dataset: %+v", dataset)
+	}
+}
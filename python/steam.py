@@ -114,18 +114,26 @@ class RPCClient:
 		response = self.connection.call("GetConfig", request)
 		return response['config']
 	
-	def register_cluster(self, address):
+	def register_cluster(self, address, scheme, ca_cert, username, password):
 		"""
 		Connect to a cluster
 
 		Parameters:
 		address: No description available (string)
+		scheme: Connection scheme: http (default) or https (string)
+		ca_cert: PEM-encoded CA bundle used to verify the cluster's certificate (string)
+		username: Username for basic authentication (string)
+		password: Password for basic authentication (string)
 
 		Returns:
 		cluster_id: No description available (int64)
 		"""
 		request = {
-			'address': address
+			'address': address,
+			'scheme': scheme,
+			'ca_cert': ca_cert,
+			'username': username,
+			'password': password
 		}
 		response = self.connection.call("RegisterCluster", request)
 		return response['cluster_id']
@@ -145,7 +153,48 @@ class RPCClient:
 		response = self.connection.call("UnregisterCluster", request)
 		return 
 	
-	def start_cluster_on_yarn(self, cluster_name, engine_id, size, memory, keytab):
+	def start_cluster(self, cluster_name, cluster_type, engine_id, size, memory, keytab_id):
+		"""
+		Start a cluster using the launcher for its cluster type
+
+		Parameters:
+		cluster_name: No description available (string)
+		cluster_type: Cluster type: yarn or local (string)
+		engine_id: No description available (int64)
+		size: No description available (int)
+		memory: No description available (string)
+		keytab_id: Uploaded keytab to authenticate with (0 for none) (int64)
+
+		Returns:
+		cluster_id: No description available (int64)
+		"""
+		request = {
+			'cluster_name': cluster_name,
+			'cluster_type': cluster_type,
+			'engine_id': engine_id,
+			'size': size,
+			'memory': memory,
+			'keytab_id': keytab_id
+		}
+		response = self.connection.call("StartCluster", request)
+		return response['cluster_id']
+	
+	def stop_cluster(self, cluster_id):
+		"""
+		Stop a cluster started by Steam
+
+		Parameters:
+		cluster_id: No description available (int64)
+
+		Returns:None
+		"""
+		request = {
+			'cluster_id': cluster_id
+		}
+		response = self.connection.call("StopCluster", request)
+		return 
+	
+	def start_cluster_on_yarn(self, cluster_name, engine_id, size, memory, keytab_id, queue, node_label, driver_args):
 		"""
 		Start a cluster using Yarn
 
@@ -154,7 +203,10 @@ class RPCClient:
 		engine_id: No description available (int64)
 		size: No description available (int)
 		memory: No description available (string)
-		keytab: No description available (string)
+		keytab_id: Uploaded keytab to authenticate with (0 for none) (int64)
+		queue: Yarn queue to submit the cluster to (optional) (string)
+		node_label: Yarn node label expression to place the cluster with (optional) (string)
+		driver_args: Extra h2odriver arguments, separated by spaces; must be allowed by the administrator (string)
 
 		Returns:
 		cluster_id: No description available (int64)
@@ -164,28 +216,352 @@ class RPCClient:
 			'engine_id': engine_id,
 			'size': size,
 			'memory': memory,
-			'keytab': keytab
+			'keytab_id': keytab_id,
+			'queue': queue,
+			'node_label': node_label,
+			'driver_args': driver_args
 		}
 		response = self.connection.call("StartClusterOnYarn", request)
 		return response['cluster_id']
 	
-	def stop_cluster_on_yarn(self, cluster_id, keytab):
+	def stop_cluster_on_yarn(self, cluster_id, keytab_id):
 		"""
 		Stop a cluster using Yarn
 
 		Parameters:
 		cluster_id: No description available (int64)
-		keytab: No description available (string)
+		keytab_id: Uploaded keytab to authenticate with (0 to stop a cluster you started with the keytab it was started with) (int64)
 
 		Returns:None
 		"""
 		request = {
 			'cluster_id': cluster_id,
-			'keytab': keytab
+			'keytab_id': keytab_id
 		}
 		response = self.connection.call("StopClusterOnYarn", request)
 		return 
 	
+	def get_cluster_launch(self, cluster_id, log_lines):
+		"""
+		Get the launch progress and log of a cluster started using Yarn
+
+		Parameters:
+		cluster_id: No description available (int64)
+		log_lines: Number of log lines to return (int)
+
+		Returns:
+		launch: No description available (ClusterLaunch)
+		"""
+		request = {
+			'cluster_id': cluster_id,
+			'log_lines': log_lines
+		}
+		response = self.connection.call("GetClusterLaunch", request)
+		return response['launch']
+	
+	def get_cluster_logs(self, cluster_id, container_id, tail_lines, offset):
+		"""
+		Get the aggregated Yarn logs of a cluster started using Yarn
+
+		Parameters:
+		cluster_id: No description available (int64)
+		container_id: Container to get the logs of (optional; all containers by default) (string)
+		tail_lines: Number of log lines to return (at most 1000) (int)
+		offset: Number of lines at the end of the log to skip, for paging back (int64)
+
+		Returns:
+		logs: No description available (ClusterLogs)
+		"""
+		request = {
+			'cluster_id': cluster_id,
+			'container_id': container_id,
+			'tail_lines': tail_lines,
+			'offset': offset
+		}
+		response = self.connection.call("GetClusterLogs", request)
+		return response['logs']
+	
+	def cancel_cluster_launch(self, cluster_id):
+		"""
+		Cancel a cluster launch on Yarn
+
+		Parameters:
+		cluster_id: No description available (int64)
+
+		Returns:None
+		"""
+		request = {
+			'cluster_id': cluster_id
+		}
+		response = self.connection.call("CancelClusterLaunch", request)
+		return 
+	
+	def set_cluster_idle_timeout(self, cluster_id, minutes):
+		"""
+		Set the idle timeout of a cluster started using Yarn
+
+		Parameters:
+		cluster_id: No description available (int64)
+		minutes: Idle timeout in minutes (0 disables idle shutdown) (int64)
+
+		Returns:None
+		"""
+		request = {
+			'cluster_id': cluster_id,
+			'minutes': minutes
+		}
+		response = self.connection.call("SetClusterIdleTimeout", request)
+		return 
+	
+	def keep_alive(self, cluster_id):
+		"""
+		Postpone the idle shutdown of a cluster
+
+		Parameters:
+		cluster_id: No description available (int64)
+
+		Returns:None
+		"""
+		request = {
+			'cluster_id': cluster_id
+		}
+		response = self.connection.call("KeepAlive", request)
+		return 
+	
+	def get_cluster_metrics(self, cluster_id, from, to, step):
+		"""
+		Get resource usage samples of a cluster's nodes
+
+		Parameters:
+		cluster_id: No description available (int64)
+		from: Start of the period (Unix time; defaults to an hour before its end) (int64)
+		to: End of the period (Unix time; defaults to now) (int64)
+		step: Seconds to average samples over (0 for raw samples) (int64)
+
+		Returns:
+		metrics: No description available (ClusterMetric)
+		"""
+		request = {
+			'cluster_id': cluster_id,
+			'from': from,
+			'to': to,
+			'step': step
+		}
+		response = self.connection.call("GetClusterMetrics", request)
+		return response['metrics']
+	
+	def sync_cluster(self, cluster_id):
+		"""
+		Mark datasets and models whose frames or models are gone from a cluster as stale
+
+		Parameters:
+		cluster_id: No description available (int64)
+
+		Returns:
+		sync: No description available (ClusterSync)
+		"""
+		request = {
+			'cluster_id': cluster_id
+		}
+		response = self.connection.call("SyncCluster", request)
+		return response['sync']
+	
+	def create_cluster_template(self, name, engine_id, size, memory, driver_args, queue, idle_timeout, tags, max_clusters):
+		"""
+		Create a cluster launch template
+
+		Parameters:
+		name: No description available (string)
+		engine_id: No description available (int64)
+		size: No description available (int)
+		memory: No description available (string)
+		driver_args: Extra h2odriver arguments, separated by spaces (string)
+		queue: Yarn queue to submit clusters to (optional) (string)
+		idle_timeout: Idle timeout in minutes (0 to use the workgroup default) (int64)
+		tags: Comma-separated tags to find the template by (string)
+		max_clusters: Maximum number of running clusters started from the template (0 for no limit) (int)
+
+		Returns:
+		template_id: No description available (int64)
+		"""
+		request = {
+			'name': name,
+			'engine_id': engine_id,
+			'size': size,
+			'memory': memory,
+			'driver_args': driver_args,
+			'queue': queue,
+			'idle_timeout': idle_timeout,
+			'tags': tags,
+			'max_clusters': max_clusters
+		}
+		response = self.connection.call("CreateClusterTemplate", request)
+		return response['template_id']
+	
+	def get_cluster_template(self, template_id):
+		"""
+		Get cluster launch template details
+
+		Parameters:
+		template_id: No description available (int64)
+
+		Returns:
+		template: No description available (ClusterTemplate)
+		"""
+		request = {
+			'template_id': template_id
+		}
+		response = self.connection.call("GetClusterTemplate", request)
+		return response['template']
+	
+	def get_cluster_templates(self, offset, limit):
+		"""
+		List cluster launch templates
+
+		Parameters:
+		offset: No description available (int64)
+		limit: No description available (int64)
+
+		Returns:
+		templates: No description available (ClusterTemplate)
+		"""
+		request = {
+			'offset': offset,
+			'limit': limit
+		}
+		response = self.connection.call("GetClusterTemplates", request)
+		return response['templates']
+	
+	def update_cluster_template(self, template_id, name, engine_id, size, memory, driver_args, queue, idle_timeout, tags, max_clusters):
+		"""
+		Update a cluster launch template
+
+		Parameters:
+		template_id: No description available (int64)
+		name: No description available (string)
+		engine_id: No description available (int64)
+		size: No description available (int)
+		memory: No description available (string)
+		driver_args: Extra h2odriver arguments, separated by spaces (string)
+		queue: Yarn queue to submit clusters to (optional) (string)
+		idle_timeout: Idle timeout in minutes (0 to use the workgroup default) (int64)
+		tags: Comma-separated tags to find the template by (string)
+		max_clusters: Maximum number of running clusters started from the template (0 for no limit) (int)
+
+		Returns:None
+		"""
+		request = {
+			'template_id': template_id,
+			'name': name,
+			'engine_id': engine_id,
+			'size': size,
+			'memory': memory,
+			'driver_args': driver_args,
+			'queue': queue,
+			'idle_timeout': idle_timeout,
+			'tags': tags,
+			'max_clusters': max_clusters
+		}
+		response = self.connection.call("UpdateClusterTemplate", request)
+		return 
+	
+	def delete_cluster_template(self, template_id):
+		"""
+		Delete a cluster launch template
+
+		Parameters:
+		template_id: No description available (int64)
+
+		Returns:None
+		"""
+		request = {
+			'template_id': template_id
+		}
+		response = self.connection.call("DeleteClusterTemplate", request)
+		return 
+	
+	def start_cluster_from_template(self, template_id, cluster_name, keytab_id):
+		"""
+		Start a cluster on Yarn from a launch template
+
+		Parameters:
+		template_id: No description available (int64)
+		cluster_name: No description available (string)
+		keytab_id: Uploaded keytab to authenticate with (0 for none) (int64)
+
+		Returns:
+		cluster_id: No description available (int64)
+		"""
+		request = {
+			'template_id': template_id,
+			'cluster_name': cluster_name,
+			'keytab_id': keytab_id
+		}
+		response = self.connection.call("StartClusterFromTemplate", request)
+		return response['cluster_id']
+	
+	def get_keytabs(self):
+		"""
+		List the Kerberos keytabs uploaded by the current identity
+
+		Parameters:
+
+		Returns:
+		keytabs: No description available (Keytab)
+		"""
+		request = {
+		}
+		response = self.connection.call("GetKeytabs", request)
+		return response['keytabs']
+	
+	def delete_keytab(self, keytab_id):
+		"""
+		Delete an uploaded Kerberos keytab
+
+		Parameters:
+		keytab_id: No description available (int64)
+
+		Returns:None
+		"""
+		request = {
+			'keytab_id': keytab_id
+		}
+		response = self.connection.call("DeleteKeytab", request)
+		return 
+	
+	def get_cluster_proxy_policy(self, cluster_id):
+		"""
+		Get the proxy rules of a cluster
+
+		Parameters:
+		cluster_id: No description available (int64)
+
+		Returns:
+		policy: Proxy rules as a JSON array of {method, path, privilege}; empty for the default policy (string)
+		"""
+		request = {
+			'cluster_id': cluster_id
+		}
+		response = self.connection.call("GetClusterProxyPolicy", request)
+		return response['policy']
+	
+	def set_cluster_proxy_policy(self, cluster_id, policy):
+		"""
+		Set the proxy rules of a cluster
+
+		Parameters:
+		cluster_id: No description available (int64)
+		policy: Proxy rules as a JSON array of {method, path, privilege}; empty for the default policy (string)
+
+		Returns:None
+		"""
+		request = {
+			'cluster_id': cluster_id,
+			'policy': policy
+		}
+		response = self.connection.call("SetClusterProxyPolicy", request)
+		return 
+	
 	def get_cluster(self, cluster_id):
 		"""
 		Get cluster details
@@ -285,6 +661,23 @@ class RPCClient:
 		response = self.connection.call("GetJob", request)
 		return response['job']
 	
+	def cancel_job(self, cluster_id, job_name):
+		"""
+		Cancel a running job
+
+		Parameters:
+		cluster_id: No description available (int64)
+		job_name: No description available (string)
+
+		Returns:None
+		"""
+		request = {
+			'cluster_id': cluster_id,
+			'job_name': job_name
+		}
+		response = self.connection.call("CancelJob", request)
+		return 
+	
 	def get_jobs(self, cluster_id):
 		"""
 		List jobs
@@ -370,7 +763,7 @@ class RPCClient:
 		response = self.connection.call("DeleteProject", request)
 		return 
 	
-	def create_datasource(self, project_id, name, description, path):
+	def create_datasource(self, project_id, name, description, kind, configuration):
 		"""
 		Create a datasource
 
@@ -378,7 +771,8 @@ class RPCClient:
 		project_id: No description available (int64)
 		name: No description available (string)
 		description: No description available (string)
-		path: No description available (string)
+		kind: Datasource kind: file, hdfs, s3 or jdbc (string)
+		configuration: Settings of the kind as a JSON object; file: path; hdfs: uri; s3: endpoint, region, bucket, prefix, accessKey, secretKey; jdbc: url, table or query, username, password (string)
 
 		Returns:
 		datasource_id: No description available (int64)
@@ -387,7 +781,8 @@ class RPCClient:
 			'project_id': project_id,
 			'name': name,
 			'description': description,
-			'path': path
+			'kind': kind,
+			'configuration': configuration
 		}
 		response = self.connection.call("CreateDatasource", request)
 		return response['datasource_id']
@@ -428,7 +823,7 @@ class RPCClient:
 		response = self.connection.call("GetDatasource", request)
 		return response['datasource']
 	
-	def update_datasource(self, datasource_id, name, description, path):
+	def update_datasource(self, datasource_id, name, description, kind, configuration):
 		"""
 		Update a datasource
 
@@ -436,7 +831,8 @@ class RPCClient:
 		datasource_id: No description available (int64)
 		name: No description available (string)
 		description: No description available (string)
-		path: No description available (string)
+		kind: Datasource kind: file, hdfs, s3 or jdbc (string)
+		configuration: Settings of the kind as a JSON object; file: path; hdfs: uri; s3: endpoint, region, bucket, prefix, accessKey, secretKey; jdbc: url, table or query, username, password. Secrets left out are kept (string)
 
 		Returns:None
 		"""
@@ -444,7 +840,8 @@ class RPCClient:
 			'datasource_id': datasource_id,
 			'name': name,
 			'description': description,
-			'path': path
+			'kind': kind,
+			'configuration': configuration
 		}
 		response = self.connection.call("UpdateDatasource", request)
 		return 
@@ -464,9 +861,27 @@ class RPCClient:
 		response = self.connection.call("DeleteDatasource", request)
 		return 
 	
-	def create_dataset(self, cluster_id, datasource_id, name, description, response_column_name):
+	def test_datasource(self, datasource_id, cluster_id):
 		"""
-		Create a dataset
+		Check that a datasource can be reached
+
+		Parameters:
+		datasource_id: No description available (int64)
+		cluster_id: Cluster to look for file and HDFS datasources from (int64)
+
+		Returns:
+		message: No description available (string)
+		"""
+		request = {
+			'datasource_id': datasource_id,
+			'cluster_id': cluster_id
+		}
+		response = self.connection.call("TestDatasource", request)
+		return response['message']
+	
+	def create_dataset(self, cluster_id, datasource_id, name, description, response_column_name, compare_with_previous):
+		"""
+		Start importing a dataset
 
 		Parameters:
 		cluster_id: No description available (int64)
@@ -474,19 +889,88 @@ class RPCClient:
 		name: No description available (string)
 		description: No description available (string)
 		response_column_name: No description available (string)
+		compare_with_previous: Compare the new dataset with the previous dataset from the same datasource (bool)
 
 		Returns:
-		dataset_id: No description available (int64)
+		job_id: The job importing the dataset; see GetDatasetJob (int64)
 		"""
 		request = {
 			'cluster_id': cluster_id,
 			'datasource_id': datasource_id,
 			'name': name,
 			'description': description,
-			'response_column_name': response_column_name
+			'response_column_name': response_column_name,
+			'compare_with_previous': compare_with_previous
 		}
 		response = self.connection.call("CreateDataset", request)
-		return response['dataset_id']
+		return response['job_id']
+	
+	def get_dataset_job(self, job_id):
+		"""
+		Get the state of a dataset import job
+
+		Parameters:
+		job_id: No description available (int64)
+
+		Returns:
+		dataset_job: No description available (DatasetJob)
+		"""
+		request = {
+			'job_id': job_id
+		}
+		response = self.connection.call("GetDatasetJob", request)
+		return response['dataset_job']
+	
+	def get_dataset_jobs(self, datasource_id, offset, limit):
+		"""
+		List the dataset import jobs of a datasource, newest first
+
+		Parameters:
+		datasource_id: No description available (int64)
+		offset: No description available (int64)
+		limit: No description available (int64)
+
+		Returns:
+		dataset_jobs: No description available (DatasetJob)
+		"""
+		request = {
+			'datasource_id': datasource_id,
+			'offset': offset,
+			'limit': limit
+		}
+		response = self.connection.call("GetDatasetJobs", request)
+		return response['dataset_jobs']
+	
+	def cancel_dataset_job(self, job_id):
+		"""
+		Cancel a dataset import job
+
+		Parameters:
+		job_id: No description available (int64)
+
+		Returns:None
+		"""
+		request = {
+			'job_id': job_id
+		}
+		response = self.connection.call("CancelDatasetJob", request)
+		return 
+	
+	def reimport_dataset(self, dataset_id):
+		"""
+		Re-import a stale dataset from its datasource into its cluster
+
+		Parameters:
+		dataset_id: No description available (int64)
+
+		Returns:
+		job_id: No description available (int64)
+		"""
+		request = {
+			'dataset_id': dataset_id
+		}
+		response = self.connection.call("ReimportDataset", request)
+		return response['job_id']
 	
 	def get_datasets(self, datasource_id, offset, limit):
 		"""
@@ -524,6 +1008,97 @@ class RPCClient:
 		response = self.connection.call("GetDataset", request)
 		return response['dataset']
 	
+	def get_dataset_columns(self, dataset_id, refresh):
+		"""
+		Get the column profile of a dataset
+
+		Parameters:
+		dataset_id: No description available (int64)
+		refresh: Recompute the profile from the dataset's frame on its cluster (bool)
+
+		Returns:
+		columns: No description available (DatasetColumn)
+		"""
+		request = {
+			'dataset_id': dataset_id,
+			'refresh': refresh
+		}
+		response = self.connection.call("GetDatasetColumns", request)
+		return response['columns']
+	
+	def get_dataset_column_roles(self, dataset_id):
+		"""
+		Get the columns a dataset assigns to model parameters
+
+		Parameters:
+		dataset_id: No description available (int64)
+
+		Returns:
+		roles: No description available (DatasetColumnRoles)
+		"""
+		request = {
+			'dataset_id': dataset_id
+		}
+		response = self.connection.call("GetDatasetColumnRoles", request)
+		return response['roles']
+	
+	def set_dataset_column_role(self, dataset_id, column_name, role):
+		"""
+		Assign a role to a dataset column
+
+		Parameters:
+		dataset_id: No description available (int64)
+		column_name: No description available (string)
+		role: response, ignored, weight, fold or offset; empty to clear (string)
+
+		Returns:None
+		"""
+		request = {
+			'dataset_id': dataset_id,
+			'column_name': column_name,
+			'role': role
+		}
+		response = self.connection.call("SetDatasetColumnRole", request)
+		return 
+	
+	def preview_dataset(self, dataset_id, offset, row_count, columns):
+		"""
+		Get a page of a dataset's rows
+
+		Parameters:
+		dataset_id: No description available (int64)
+		offset: No description available (int64)
+		row_count: Number of rows to return; 0 returns 10, and at most 1000 can be returned (int64)
+		columns: Names of the columns to return, in order; all columns if empty (string)
+
+		Returns:
+		preview: No description available (DatasetPreview)
+		"""
+		request = {
+			'dataset_id': dataset_id,
+			'offset': offset,
+			'row_count': row_count,
+			'columns': columns
+		}
+		response = self.connection.call("PreviewDataset", request)
+		return response['preview']
+	
+	def get_dataset_versions(self, datasource_id):
+		"""
+		List the versions of a datasource's data, newest first
+
+		Parameters:
+		datasource_id: No description available (int64)
+
+		Returns:
+		datasets: No description available (Dataset)
+		"""
+		request = {
+			'datasource_id': datasource_id
+		}
+		response = self.connection.call("GetDatasetVersions", request)
+		return response['datasets']
+	
 	def get_datasets_from_cluster(self, cluster_id):
 		"""
 		Get a list of datasets on a cluster
@@ -540,6 +1115,40 @@ class RPCClient:
 		response = self.connection.call("GetDatasetsFromCluster", request)
 		return response['dataset']
 	
+	def compare_datasets(self, base_dataset_id, other_dataset_id):
+		"""
+		Compare the schema and distributions of two datasets
+
+		Parameters:
+		base_dataset_id: No description available (int64)
+		other_dataset_id: No description available (int64)
+
+		Returns:
+		comparison: No description available (DatasetComparison)
+		"""
+		request = {
+			'base_dataset_id': base_dataset_id,
+			'other_dataset_id': other_dataset_id
+		}
+		response = self.connection.call("CompareDatasets", request)
+		return response['comparison']
+	
+	def get_dataset_comparison(self, dataset_id):
+		"""
+		Get the comparison recorded when a dataset was created
+
+		Parameters:
+		dataset_id: No description available (int64)
+
+		Returns:
+		comparison: No description available (DatasetComparison)
+		"""
+		request = {
+			'dataset_id': dataset_id
+		}
+		response = self.connection.call("GetDatasetComparison", request)
+		return response['comparison']
+	
 	def update_dataset(self, dataset_id, name, description, response_column_name):
 		"""
 		Update a dataset
@@ -561,14 +1170,15 @@ class RPCClient:
 		response = self.connection.call("UpdateDataset", request)
 		return 
 	
-	def split_dataset(self, dataset_id, ratio1, ratio2):
+	def split_dataset(self, dataset_id, ratio1, ratio2, seed):
 		"""
 		Split a dataset
 
 		Parameters:
 		dataset_id: No description available (int64)
-		ratio1: No description available (int)
-		ratio2: No description available (int)
+		ratio1: Percentage of rows in the training split (int)
+		ratio2: Percentage of rows in the validation split; any remainder becomes a test split (int)
+		seed: Random seed; 0 picks one, which is recorded on the splits (int64)
 
 		Returns:
 		dataset_ids: No description available (int64)
@@ -576,7 +1186,8 @@ class RPCClient:
 		request = {
 			'dataset_id': dataset_id,
 			'ratio1': ratio1,
-			'ratio2': ratio2
+			'ratio2': ratio2,
+			'seed': seed
 		}
 		response = self.connection.call("SplitDataset", request)
 		return response['dataset_ids']
@@ -1596,6 +2207,23 @@ class RPCClient:
 		response = self.connection.call("UpdateWorkgroup", request)
 		return 
 	
+	def set_workgroup_idle_timeout(self, workgroup_id, minutes):
+		"""
+		Set the default idle timeout for clusters started by workgroup members
+
+		Parameters:
+		workgroup_id: Integer ID of a workgroup in Steam. (int64)
+		minutes: Idle timeout in minutes (0 disables idle shutdown) (int64)
+
+		Returns:None
+		"""
+		request = {
+			'workgroup_id': workgroup_id,
+			'minutes': minutes
+		}
+		response = self.connection.call("SetWorkgroupIdleTimeout", request)
+		return 
+	
 	def delete_workgroup(self, workgroup_id):
 		"""
 		Delete a workgroup
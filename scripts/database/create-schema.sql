@@ -143,6 +143,11 @@ CREATE TABLE cluster (
     address text NOT NULL,
     state job_state NOT NULL,
     created datetime NOT NULL,
+    proxy_policy text NOT NULL DEFAULT '',
+    scheme text NOT NULL DEFAULT 'http',
+    ca_cert text NOT NULL DEFAULT '',
+    username text NOT NULL DEFAULT '',
+    password text NOT NULL DEFAULT '',
 
     FOREIGN KEY (type_id) REFERENCES cluster_type(id)
 );
@@ -171,6 +176,39 @@ CREATE TABLE cluster (
 -- ALTER SEQUENCE cluster_id_seq OWNED BY cluster.id;
 
 
+--
+-- Name: cluster_metric; Type: TABLE; Schema: public; Owner: steam
+--
+
+CREATE TABLE cluster_metric (
+    id integer PRIMARY KEY AUTOINCREMENT,
+    cluster_id integer NOT NULL,
+    node text NOT NULL,
+    healthy boolean NOT NULL,
+    sys_load double precision NOT NULL,
+    my_cpu_pct integer NOT NULL,
+    sys_cpu_pct integer NOT NULL,
+    free_mem bigint NOT NULL,
+    max_mem bigint NOT NULL,
+    pojo_mem bigint NOT NULL,
+    mem_value_size bigint NOT NULL,
+    sampled datetime NOT NULL,
+
+    FOREIGN KEY (cluster_id) REFERENCES cluster(id) ON DELETE CASCADE
+);
+
+CREATE INDEX cluster_metric_cluster_sampled ON cluster_metric (cluster_id, sampled);
+
+
+-- ALTER TABLE cluster_metric OWNER TO steam;
+
+--
+-- Name: TABLE cluster_metric; Type: COMMENT; Schema: public; Owner: steam
+--
+
+-- COMMENT ON TABLE cluster_metric IS 'Resource usage samples of cluster nodes.';
+
+
 --
 -- Name: cluster_type; Type: TABLE; Schema: public; Owner: steam
 --
@@ -216,6 +254,13 @@ CREATE TABLE cluster_yarn (
     memory text NOT NULL,
     username text NOT NULL,
     output_dir text NOT NULL,
+    keytab text NOT NULL DEFAULT '',
+    idle_timeout integer NOT NULL DEFAULT 0,
+    last_activity datetime,
+    template_id integer NOT NULL DEFAULT 0,
+    queue text NOT NULL DEFAULT '',
+    node_label text NOT NULL DEFAULT '',
+    driver_args text NOT NULL DEFAULT '',
 
     FOREIGN KEY (engine_id) REFERENCES engine(id)
 );
@@ -223,6 +268,29 @@ CREATE TABLE cluster_yarn (
 
 -- ALTER TABLE cluster_yarn OWNER TO steam;
 
+--
+-- Name: cluster_template; Type: TABLE; Schema: public; Owner: steam
+--
+
+CREATE TABLE cluster_template (
+    id integer PRIMARY KEY AUTOINCREMENT,
+    name text NOT NULL,
+    engine_id integer NOT NULL,
+    size integer NOT NULL,
+    memory text NOT NULL,
+    driver_args text NOT NULL,
+    queue text NOT NULL,
+    idle_timeout integer NOT NULL,
+    tags text NOT NULL,
+    max_clusters integer NOT NULL,
+    created datetime NOT NULL,
+
+    FOREIGN KEY (engine_id) REFERENCES engine(id)
+);
+
+
+-- ALTER TABLE cluster_template OWNER TO steam;
+
 --
 -- Name: TABLE cluster_yarn; Type: COMMENT; Schema: public; Owner: steam
 --
@@ -258,6 +326,13 @@ CREATE TABLE cluster_yarn (
 CREATE TABLE dataset (
     id integer PRIMARY KEY AUTOINCREMENT,
     datasource_id integer NOT NULL,
+    cluster_id integer NOT NULL DEFAULT 0,
+    parent_id integer,
+    version integer NOT NULL DEFAULT 0,
+    previous_id integer,
+    row_count integer NOT NULL DEFAULT 0,
+    column_hash text NOT NULL DEFAULT '',
+    checksum text NOT NULL DEFAULT '',
     name text NOT NULL,
     description text NOT NULL,
     frame_name text NOT NULL,
@@ -265,13 +340,106 @@ CREATE TABLE dataset (
     properties text NOT NULL,
     properties_version text NOT NULL,
     created datetime NOT NULL,
+    stale boolean NOT NULL DEFAULT 0,
 
-    FOREIGN KEY (datasource_id) REFERENCES datasource(id) ON DELETE CASCADE
+    FOREIGN KEY (datasource_id) REFERENCES datasource(id) ON DELETE CASCADE,
+    FOREIGN KEY (parent_id) REFERENCES dataset(id),
+    FOREIGN KEY (previous_id) REFERENCES dataset(id)
 );
 
 
 -- ALTER TABLE dataset OWNER TO steam;
 
+--
+-- Name: dataset_column; Type: TABLE; Schema: public; Owner: steam
+--
+
+CREATE TABLE dataset_column (
+    dataset_id integer NOT NULL,
+    position integer NOT NULL,
+    name text NOT NULL,
+    type text NOT NULL,
+    missing_count integer NOT NULL,
+    zero_count integer NOT NULL,
+    min double precision,
+    max double precision,
+    mean double precision,
+    sigma double precision,
+    domain text NOT NULL,
+    domain_cardinality integer NOT NULL,
+    histogram_base double precision,
+    histogram_stride double precision,
+    histogram_bins text NOT NULL,
+
+    PRIMARY KEY (dataset_id, position),
+    FOREIGN KEY (dataset_id) REFERENCES dataset(id) ON DELETE CASCADE
+);
+
+
+-- ALTER TABLE dataset_column OWNER TO steam;
+
+--
+-- Name: dataset_column_role; Type: TABLE; Schema: public; Owner: steam
+--
+
+CREATE TABLE dataset_column_role (
+    dataset_id integer NOT NULL,
+    name text NOT NULL,
+    role text NOT NULL,
+
+    PRIMARY KEY (dataset_id, name),
+    FOREIGN KEY (dataset_id) REFERENCES dataset(id) ON DELETE CASCADE
+);
+
+
+-- ALTER TABLE dataset_column_role OWNER TO steam;
+
+--
+-- Name: dataset_comparison; Type: TABLE; Schema: public; Owner: steam
+--
+
+CREATE TABLE dataset_comparison (
+    dataset_id integer PRIMARY KEY,
+    base_dataset_id integer NOT NULL,
+    report text NOT NULL,
+    created datetime NOT NULL,
+
+    FOREIGN KEY (dataset_id) REFERENCES dataset(id) ON DELETE CASCADE,
+    FOREIGN KEY (base_dataset_id) REFERENCES dataset(id) ON DELETE CASCADE
+);
+
+
+-- ALTER TABLE dataset_comparison OWNER TO steam;
+
+--
+-- Name: dataset_job; Type: TABLE; Schema: public; Owner: steam
+--
+
+CREATE TABLE dataset_job (
+    id integer PRIMARY KEY AUTOINCREMENT,
+    datasource_id integer NOT NULL,
+    cluster_id integer NOT NULL,
+    dataset_id integer,
+    name text NOT NULL,
+    description text NOT NULL,
+    response_column_name text NOT NULL,
+    compare_with_previous boolean NOT NULL,
+    state text NOT NULL,
+    job_name text NOT NULL,
+    progress double precision NOT NULL,
+    error text NOT NULL,
+    created datetime NOT NULL,
+    finished datetime,
+
+    FOREIGN KEY (datasource_id) REFERENCES datasource(id) ON DELETE CASCADE,
+    FOREIGN KEY (dataset_id) REFERENCES dataset(id) ON DELETE SET NULL
+);
+
+CREATE INDEX dataset_job_datasource ON dataset_job (datasource_id);
+
+
+-- ALTER TABLE dataset_job OWNER TO steam;
+
 --
 -- Name: dataset_id_seq; Type: SEQUENCE; Schema: public; Owner: steam
 --
@@ -341,7 +509,11 @@ CREATE TABLE engine (
     id integer PRIMARY KEY AUTOINCREMENT,
     name text NOT NULL,
     location text NOT NULL,
-    created datetime NOT NULL
+    created datetime NOT NULL,
+    version text NOT NULL DEFAULT '',
+    distribution text NOT NULL DEFAULT '',
+    checksum text NOT NULL DEFAULT '',
+    deep_water boolean NOT NULL DEFAULT 0
 );
 
 
@@ -510,6 +682,25 @@ CREATE TABLE identity_workgroup (
 
 -- ALTER TABLE identity_workgroup OWNER TO steam;
 
+--
+-- Name: keytab; Type: TABLE; Schema: public; Owner: steam
+--
+
+CREATE TABLE keytab (
+    id integer PRIMARY KEY AUTOINCREMENT,
+    identity_id integer NOT NULL,
+    name text NOT NULL,
+    principal text NOT NULL,
+    location text NOT NULL,
+    created datetime NOT NULL,
+
+    UNIQUE (identity_id, name),
+    FOREIGN KEY (identity_id) REFERENCES identity(id) ON DELETE CASCADE
+);
+
+
+-- ALTER TABLE keytab OWNER TO steam;
+
 --
 -- Name: label; Type: TABLE; Schema: public; Owner: steam
 --
@@ -610,6 +801,8 @@ CREATE TABLE model (
     metrics text NOT NULL,
     metrics_version text NOT NULL,
     created datetime NOT NULL,
+    training_dataset_version integer NOT NULL DEFAULT 0,
+    stale boolean NOT NULL DEFAULT 0,
 
     FOREIGN KEY (project_id) REFERENCES project(id),
     FOREIGN KEY (training_dataset_id) REFERENCES dataset(id),
@@ -950,7 +1143,8 @@ CREATE TABLE workgroup (
     type workgroup_type NOT NULL,
     name text NOT NULL UNIQUE,
     description text NOT NULL,
-    created datetime NOT NULL 
+    created datetime NOT NULL,
+    idle_timeout integer NOT NULL DEFAULT 0
 );
 
 
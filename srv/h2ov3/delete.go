@@ -41,7 +41,7 @@ func (h *H2O) DeleteInitIDEndsession() (*bindings.InitIDV3, error) {
 		return nil, fmt.Errorf("error making delete request: %s: %s", u, err)
 	}
 
-	res, err := http.DefaultClient.Do(req)
+	res, err := h.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("H2O delete request failed: %s: %s", u, err)
 	}
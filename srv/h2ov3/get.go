@@ -20,7 +20,7 @@ package h2ov3
 import (
 	"encoding/json"
 	"fmt"
-	"net/http"
+	"net/url"
 	"strconv"
 
 	"github.com/h2oai/steam/bindings"
@@ -37,7 +37,7 @@ func (h *H2O) GetCloudStatus() (*bindings.CloudV3, error) {
 	//@GET
 	u := h.url("/3/Cloud")
 
-	res, err := http.Get(u)
+	res, err := h.get(u)
 	if err != nil {
 		return nil, fmt.Errorf("H2O get request failed: %s: %s", u, err)
 	}
@@ -66,7 +66,7 @@ func (h *H2O) GetFramesFetch(frame_id string, find_compatible_models bool) ([]by
 	u := h.url("/3/Frames/?{frame_id}", frame_id)
 	u = u + "?find_compatible_models=" + strconv.FormatBool(find_compatible_models)
 
-	res, err := http.Get(u)
+	res, err := h.get(u)
 	if err != nil {
 		return nil, nil, fmt.Errorf("H2O get request failed: %s: %s", u, err)
 	}
@@ -84,12 +84,49 @@ func (h *H2O) GetFramesFetch(frame_id string, find_compatible_models bool) ([]by
 	return data, &out, nil
 }
 
+// GetFramesSummary Return a frame's summary, with per-column statistics and histograms. */
+func (h *H2O) GetFramesSummary(frame_id string) ([]byte, *bindings.FramesV3, error) {
+	//@GET
+	u := h.url("/3/Frames/?{frame_id}/summary", frame_id)
+
+	res, err := h.get(u)
+	if err != nil {
+		return nil, nil, fmt.Errorf("H2O get request failed: %s: %s", u, err)
+	}
+
+	data, err := h.handleResponse(res, u)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var out bindings.FramesV3
+	if err := json.Unmarshal(data, &out); err != nil {
+		return nil, nil, fmt.Errorf("H2O response unmarshal failed: %v", err)
+	}
+
+	return data, &out, nil
+}
+
+// GetFramesRows Return a frame's columns with the data of the given rows. */
+func (h *H2O) GetFramesRows(frame_id string, row_offset, row_count int64) ([]byte, error) {
+	//@GET
+	u := h.url("/3/Frames/?{frame_id}", frame_id)
+	u = u + "?row_offset=" + strconv.FormatInt(row_offset, 10) + "&row_count=" + strconv.FormatInt(row_count, 10)
+
+	res, err := h.get(u)
+	if err != nil {
+		return nil, fmt.Errorf("H2O get request failed: %s: %s", u, err)
+	}
+
+	return h.handleResponse(res, u)
+}
+
 // GetFramesList Return all Frames in the H2O distributed K/V store. */
 func (h *H2O) GetFramesList() (*bindings.FramesV3, error) {
 	//@GET
 	u := h.url("/3/Frames")
 
-	res, err := http.Get(u)
+	res, err := h.get(u)
 	if err != nil {
 		return nil, fmt.Errorf("H2O get request failed: %s: %s", u, err)
 	}
@@ -118,7 +155,7 @@ func (h *H2O) GetInitIDIssue() (*bindings.InitIDV3, error) {
 	//@GET
 	u := h.url("/3/InitID")
 
-	res, err := http.Get(u)
+	res, err := h.get(u)
 	if err != nil {
 		return nil, fmt.Errorf("H2O get request failed: %s: %s", u, err)
 	}
@@ -147,7 +184,7 @@ func (h *H2O) GetJobsList() (*bindings.JobsV3, error) {
 	//@GET
 	u := h.url("/3/Jobs")
 
-	res, err := http.Get(u)
+	res, err := h.get(u)
 	if err != nil {
 		return nil, fmt.Errorf("H2O get request failed: %s: %s", u, err)
 	}
@@ -169,7 +206,7 @@ func (h *H2O) GetJobsFetch(job_id string) (*bindings.JobsV3, error) {
 	//@GET
 	u := h.url("/3/Jobs/?{job_id}", job_id)
 
-	res, err := http.Get(u)
+	res, err := h.get(u)
 	if err != nil {
 		return nil, fmt.Errorf("H2O get request failed: %s: %s", u, err)
 	}
@@ -197,7 +234,7 @@ func (h *H2O) GetModelsFetch(model_id string) ([]byte, *bindings.ModelsV3, error
 	//@GET
 	u := h.url("/3/Models/?{model_id}", model_id)
 
-	res, err := http.Get(u)
+	res, err := h.get(u)
 	if err != nil {
 		return nil, nil, fmt.Errorf("H2O get request failed: %s: %s", u, err)
 	}
@@ -219,7 +256,7 @@ func (h *H2O) GetModelsList() (*bindings.ModelsV3, error) {
 	//@GET
 	u := h.url("/3/Models")
 
-	res, err := http.Get(u)
+	res, err := h.get(u)
 	if err != nil {
 		return nil, fmt.Errorf("H2O get request failed: %s: %s", u, err)
 	}
@@ -247,7 +284,7 @@ func (h *H2O) GetModelMetricsListSchemaFetchByModel(model string) ([]byte, *bind
 	//@GET
 	u := h.url("/3/ModelMetrics/models/?{model}", model)
 
-	res, err := http.Get(u)
+	res, err := h.get(u)
 	if err != nil {
 		return nil, nil, fmt.Errorf("H2O get request failed: %s: %s", u, err)
 	}
@@ -263,3 +300,32 @@ func (h *H2O) GetModelMetricsListSchemaFetchByModel(model string) ([]byte, *bind
 	}
 	return data, &out, nil
 }
+
+///////////////////////
+///////////////////////
+////// Typeahead //////
+///////////////////////
+///////////////////////
+
+// GetTypeaheadFiles Typeahead handler for filename completion. */
+func (h *H2O) GetTypeaheadFiles(src string, limit int) (*bindings.TypeaheadV3, error) {
+	//@GET
+	u := h.url("/3/Typeahead/files")
+	u = u + "?src=" + url.QueryEscape(src) + "&limit=" + strconv.Itoa(limit)
+
+	res, err := h.get(u)
+	if err != nil {
+		return nil, fmt.Errorf("H2O get request failed: %s: %s", u, err)
+	}
+
+	data, err := h.handleResponse(res, u)
+	if err != nil {
+		return nil, err
+	}
+
+	var out bindings.TypeaheadV3
+	if err := json.Unmarshal(data, &out); err != nil {
+		return nil, fmt.Errorf("H2O response unmarshal failed: %v", err)
+	}
+	return &out, nil
+}
@@ -20,6 +20,8 @@ package h2ov3
 import (
 	"encoding/json"
 	"fmt"
+	"io"
+	"mime/multipart"
 	"net/http"
 	"net/url"
 	"strconv"
@@ -43,7 +45,7 @@ func (h *H2O) PostImportFilesImportfiles(path string) (*bindings.ImportFilesV3,
 		"path": {path},
 	}
 
-	res, err := http.PostForm(u, v)
+	res, err := h.postForm(u, v)
 	if err != nil {
 		return nil, fmt.Errorf("H2O post request failed: %s: %s", u, err)
 	}
@@ -60,6 +62,76 @@ func (h *H2O) PostImportFilesImportfiles(path string) (*bindings.ImportFilesV3,
 	return &out, nil
 }
 
+////////////////////////////
+////////////////////////////
+////// ImportSQLTable //////
+////////////////////////////
+////////////////////////////
+
+// PostImportSQLTableImportsqltable Import SQL table into an H2O Frame. */
+func (h *H2O) PostImportSQLTableImportsqltable(in *bindings.ImportSQLTableV99) (*bindings.JobV3, error) {
+	//@POST
+	u := h.url("/99/ImportSQLTable")
+
+	v := url.Values{
+		"connection_url": {in.ConnectionUrl},
+		"username":       {in.Username},
+		"password":       {in.Password},
+		"columns":        {in.Columns},
+		"optimize":       {strconv.FormatBool(in.Optimize)},
+	}
+	if in.Table != "" {
+		v.Set("table", in.Table)
+	}
+	if in.SelectQuery != "" {
+		v.Set("select_query", in.SelectQuery)
+	}
+
+	res, err := h.postForm(u, v)
+	if err != nil {
+		return nil, fmt.Errorf("H2O post request failed: %s: %s", u, err)
+	}
+
+	data, err := h.handleResponse(res, u)
+	if err != nil {
+		return nil, err
+	}
+
+	var out bindings.JobV3
+	if err := json.Unmarshal(data, &out); err != nil {
+		return nil, fmt.Errorf("H2O response unmarshal failed: %v", err)
+	}
+	return &out, nil
+}
+
+//////////////////
+//////////////////
+////// Jobs //////
+//////////////////
+//////////////////
+
+// PostJobsCancel Cancel a running job. */
+func (h *H2O) PostJobsCancel(job_id string) (*bindings.JobsV3, error) {
+	//@POST
+	u := h.url("/3/Jobs/?{job_id}/cancel", job_id)
+
+	res, err := h.postForm(u, url.Values{})
+	if err != nil {
+		return nil, fmt.Errorf("H2O post request failed: %s: %s", u, err)
+	}
+
+	data, err := h.handleResponse(res, u)
+	if err != nil {
+		return nil, err
+	}
+
+	var out bindings.JobsV3
+	if err := json.Unmarshal(data, &out); err != nil {
+		return nil, fmt.Errorf("H2O response unmarshal failed: %v", err)
+	}
+	return &out, nil
+}
+
 ///////////////////
 ///////////////////
 ////// Parse //////
@@ -119,7 +191,7 @@ func (h *H2O) PostParseParse(in *bindings.ParseV3) (*bindings.ParseV3, error) {
 		v["_exclude_fields"] = []string{}
 	}
 
-	res, err := http.PostForm(u, v)
+	res, err := h.postForm(u, v)
 	if err != nil {
 		return nil, err
 	}
@@ -152,7 +224,7 @@ func (h *H2O) PostParseSetupGuesssetup(sourceFrames []string) (*bindings.ParseSe
 		"source_frames": sourceFrames,
 	}
 
-	res, err := http.PostForm(u, v)
+	res, err := h.postForm(u, v)
 	if err != nil {
 		return nil, err
 	}
@@ -168,3 +240,85 @@ func (h *H2O) PostParseSetupGuesssetup(sourceFrames []string) (*bindings.ParseSe
 	}
 	return &out, nil
 }
+
+////////////////////
+////////////////////
+////// Rapids //////
+////////////////////
+////////////////////
+
+// PostRapidsExec Execute a Rapids AST expression that evaluates to a Frame. */
+func (h *H2O) PostRapidsExec(in *bindings.RapidsSchemaV3) (*bindings.RapidsFrameV3, error) {
+	//@POST
+	u := h.url("/99/Rapids")
+
+	v := url.Values{
+		"ast":        {in.Ast},
+		"session_id": {in.SessionId},
+	}
+
+	res, err := h.postForm(u, v)
+	if err != nil {
+		return nil, fmt.Errorf("H2O post request failed: %s: %s", u, err)
+	}
+
+	data, err := h.handleResponse(res, u)
+	if err != nil {
+		return nil, err
+	}
+
+	var out bindings.RapidsFrameV3
+	if err := json.Unmarshal(data, &out); err != nil {
+		return nil, fmt.Errorf("H2O response unmarshal failed: %v", err)
+	}
+	return &out, nil
+}
+
+//////////////////////
+//////////////////////
+////// PostFile //////
+//////////////////////
+//////////////////////
+
+// PostFile Upload a file into a raw frame, to be parsed like an imported file.
+// The file is streamed from r. */
+func (h *H2O) PostFile(destinationFrame, fileName string, r io.Reader) (*PostFileV3, error) {
+	//@POST
+	u := h.url("/3/PostFile") + "?" + url.Values{"destination_frame": {destinationFrame}}.Encode()
+
+	pr, pw := io.Pipe()
+	defer pr.Close()
+	mw := multipart.NewWriter(pw)
+	go func() {
+		part, err := mw.CreateFormFile("file", fileName)
+		if err == nil {
+			_, err = io.Copy(part, r)
+		}
+		if err == nil {
+			err = mw.Close()
+		}
+		pw.CloseWithError(err)
+	}()
+
+	req, err := http.NewRequest("POST", u, pr)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", mw.FormDataContentType())
+
+	res, err := h.do(req)
+	if err != nil {
+		return nil, fmt.Errorf("H2O post request failed: %s: %s", u, err)
+	}
+
+	data, err := h.handleResponse(res, u)
+	if err != nil {
+		return nil, err
+	}
+
+	var out PostFileV3
+	if err := json.Unmarshal(data, &out); err != nil {
+		return nil, fmt.Errorf("H2O response unmarshal failed: %v", err)
+	}
+	return &out, nil
+}
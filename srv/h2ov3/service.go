@@ -18,6 +18,8 @@
 package h2ov3
 
 import (
+	"crypto/tls"
+	"crypto/x509"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
@@ -27,6 +29,7 @@ import (
 	"regexp"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/h2oai/steam/bindings"
 	"github.com/h2oai/steam/lib/fs"
@@ -35,14 +38,104 @@ import (
 
 type H2O struct {
 	Address string
+	conn    Connection
+	client  *http.Client
+}
+
+// Connection describes how to reach an H2O cluster that requires HTTPS or
+// authentication.
+type Connection struct {
+	Scheme   string // "http" (default) or "https"
+	CACert   string // PEM bundle to verify the cluster's certificate with (optional)
+	Username string // Basic authentication credentials (optional)
+	Password string
+}
+
+// Transport returns an HTTP transport that trusts the connection's CA bundle.
+func (c Connection) Transport() (*http.Transport, error) {
+	transport := &http.Transport{Proxy: http.ProxyFromEnvironment}
+	if c.CACert != "" {
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM([]byte(c.CACert)) {
+			return nil, fmt.Errorf("No certificates found in CA bundle")
+		}
+		transport.TLSClientConfig = &tls.Config{RootCAs: pool}
+	}
+	return transport, nil
+}
+
+// Authorize adds the connection's credentials, if any, to a request.
+func (c Connection) Authorize(req *http.Request) {
+	if c.Username != "" {
+		req.SetBasicAuth(c.Username, c.Password)
+	}
 }
 
 func NewClient(address string) *H2O {
 	return &H2O{
 		address,
+		Connection{Scheme: "http"},
+		http.DefaultClient,
+	}
+}
+
+// NewSecureClient creates a client for a cluster using the given connection
+// settings.
+func NewSecureClient(address string, conn Connection) (*H2O, error) {
+	switch conn.Scheme {
+	case "":
+		conn.Scheme = "http"
+	case "http", "https":
+	default:
+		return nil, fmt.Errorf("Invalid scheme %s: expected http or https", conn.Scheme)
+	}
+
+	transport, err := conn.Transport()
+	if err != nil {
+		return nil, err
+	}
+
+	return &H2O{
+		address,
+		conn,
+		&http.Client{Transport: transport},
+	}, nil
+}
+
+// WithTimeout returns a client for the same cluster whose requests fail if
+// they take longer than d.
+func (h *H2O) WithTimeout(d time.Duration) *H2O {
+	client := *h.client
+	client.Timeout = d
+	return &H2O{
+		h.Address,
+		h.conn,
+		&client,
 	}
 }
 
+func (h *H2O) do(req *http.Request) (*http.Response, error) {
+	h.conn.Authorize(req)
+	return h.client.Do(req)
+}
+
+func (h *H2O) get(u string) (*http.Response, error) {
+	req, err := http.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, err
+	}
+	return h.do(req)
+}
+
+func (h *H2O) postForm(u string, v url.Values) (*http.Response, error) {
+	req, err := http.NewRequest("POST", u, strings.NewReader(v.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	return h.do(req)
+}
+
 func (h *H2O) url(path string, parms ...interface{}) string {
 	parm := regexp.MustCompile(`/(\?{.+?})`)
 
@@ -67,7 +160,7 @@ func (h *H2O) url(path string, parms ...interface{}) string {
 		}
 	}
 
-	return (&url.URL{Scheme: "http", Host: h.Address, Path: path}).String()
+	return (&url.URL{Scheme: h.conn.Scheme, Host: h.Address, Path: path}).String()
 }
 
 type H2OException struct {
@@ -102,7 +195,7 @@ func (h *H2O) handleResponse(res *http.Response, u string) ([]byte, error) {
 func (h *H2O) download(s, p string, preserveFilename bool) (string, error) {
 	u := h.url(s)
 
-	_, filepath, err := fs.Download(p, u, preserveFilename)
+	_, filepath, err := fs.DownloadWith(h.get, p, u, preserveFilename)
 	if err != nil {
 		return "", err
 	}
@@ -114,6 +207,11 @@ type ModelKeyV3 struct {
 	Name string `json:"name"`
 }
 
+type PostFileV3 struct {
+	DestinationFrame string `json:"destination_frame"`
+	TotalBytes       int64  `json:"total_bytes"`
+}
+
 type AutoMLBuilderV3 struct {
 	Job bindings.JobV3 `json:"job"`
 }
@@ -197,7 +295,8 @@ func (h *H2O) ExportGenModel(p string) (string, error) {
 	return f, nil
 }
 
-// FIXME: MUST BE H2O VERSION 3.10.0.7
+// ExportMOJO downloads a model's MOJO. MOJO support depends on the H2O version
+// and algorithm; see lib/engine for the capability table.
 func (h *H2O) ExportMOJO(modelID, p string) (string, error) {
 	f, err := h.download("/3/Models/"+modelID+"/mojo", p, true)
 	if err != nil {
@@ -34,6 +34,42 @@ type Cluster struct {
 	CreatedAt int64
 }
 
+type StaleDataset struct {
+	DatasetId    int64
+	Name         string
+	FrameName    string
+	Reimportable bool `help:"Whether the dataset's datasource can still be imported"`
+}
+
+type StaleModel struct {
+	ModelId  int64
+	Name     string
+	ModelKey string
+	Exported bool `help:"Whether the model's POJO or MOJO was exported from the cluster"`
+}
+
+type ClusterSync struct {
+	ClusterId     int64
+	FrameCount    int64
+	ModelCount    int64
+	StaleDatasets []StaleDataset
+	StaleModels   []StaleModel
+	SyncedAt      int64
+}
+
+type ClusterMetric struct {
+	Time         int64
+	Node         string
+	Healthy      bool
+	SysLoad      float64
+	MyCpuPct     int
+	SysCpuPct    int
+	FreeMem      int64
+	MaxMem       int64
+	PojoMem      int64
+	MemValueSize int64
+}
+
 type YarnCluster struct {
 	Id            int64
 	EngineId      int64
@@ -41,6 +77,50 @@ type YarnCluster struct {
 	ApplicationId string
 	Memory        string
 	Username      string
+	IdleTimeout   int64
+	IdleDeadline  int64
+	Queue         string
+	NodeLabel     string
+	DriverArgs    string
+}
+
+type ClusterTemplate struct {
+	Id          int64
+	Name        string
+	EngineId    int64
+	Size        int
+	Memory      string
+	DriverArgs  string
+	Queue       string
+	IdleTimeout int64
+	Tags        string
+	MaxClusters int
+	CreatedAt   int64
+}
+
+type Keytab struct {
+	Id        int64
+	Name      string
+	Principal string
+	CreatedAt int64
+}
+
+type ClusterLaunch struct {
+	ClusterId     int64
+	State         string
+	ApplicationId string
+	Size          int
+	NodesUp       int
+	Errors        string
+	Log           string
+}
+
+type ClusterLogs struct {
+	ClusterId     int64
+	ApplicationId string
+	Log           string
+	TotalLines    int64
+	Offset        int64
 }
 
 type ClusterStatus struct {
@@ -69,119 +149,232 @@ type Project struct {
 }
 
 type Datasource struct {
-	Id            int64
-	ProjectId     int64
-	Name          string
-	Description   string
-	Kind          string
-	Configuration string
-	CreatedAt     int64
+	Id          int64
+	ProjectId   int64
+	Name        string
+	Description string
+	Kind        string
+	Config      DatasourceConfig
+	CreatedAt   int64
+}
+
+type DatasourceConfig struct {
+	Path      string
+	Uri       string
+	Endpoint  string
+	Region    string
+	Bucket    string
+	Prefix    string
+	AccessKey string
+	Url       string
+	Table     string
+	Query     string
+	Username  string
 }
 
 type Dataset struct {
 	Id                 int64
 	DatasourceId       int64
+	ClusterId          int64
+	ParentId           int64
+	Version            int64
+	PreviousId         int64
+	RowCount           int64
+	ColumnHash         string
+	Checksum           string
 	Name               string
 	Description        string
 	FrameName          string
 	ResponseColumnName string
 	JSONProperties     string
 	CreatedAt          int64
+	Stale              bool `help:"Whether the dataset's frame is gone from its cluster"`
+}
+
+type DatasetJob struct {
+	Id             int64
+	ClusterId      int64
+	DatasourceId   int64
+	DatasetId      int64
+	Name           string
+	State          string `help:"queued, importing, parsing, done, failed or cancelled"`
+	ClusterJobName string `help:"The H2O job currently running the import"`
+	Progress       float64
+	Error          string
+	CreatedAt      int64
+	FinishedAt     int64
+}
+
+type DatasetColumn struct {
+	Name              string
+	Type              string
+	MissingCount      int64
+	ZeroCount         int64
+	Min               float64
+	Max               float64
+	Mean              float64
+	Sigma             float64
+	Domain            []string
+	DomainCardinality int64
+	HistogramBase     float64
+	HistogramStride   float64
+	HistogramBins     []int64
+	Role              string `help:"response, ignored, weight, fold or offset; empty for other model features"`
+}
+
+type DatasetColumnRoles struct {
+	DatasetId          int64
+	ResponseColumnName string
+	IgnoredColumns     []string
+	WeightsColumn      string
+	FoldColumn         string
+	OffsetColumn       string
+}
+
+type DatasetPreviewColumn struct {
+	Name string
+	Type string
+}
+
+type DatasetPreviewRow struct {
+	Values  []string
+	Missing []bool
+}
+
+type DatasetPreview struct {
+	DatasetId int64
+	Source    string `help:"Where the rows were read from: cluster or file"`
+	Offset    int64
+	TotalRows int64
+	Columns   []DatasetPreviewColumn
+	Rows      []DatasetPreviewRow
+}
+
+type ColumnComparison struct {
+	Name          string
+	BaseType      string
+	OtherType     string
+	NewLevels     []string
+	MissingLevels []string
+	MeanDelta     float64
+	SigmaDelta    float64
+	PSI           float64
+	HasPSI        bool
+	Role          string `help:"The column's role in the other dataset"`
+}
+
+type DatasetComparison struct {
+	BaseDatasetId  int64
+	OtherDatasetId int64
+	AddedColumns   []string
+	RemovedColumns []string
+	Columns        []ColumnComparison
+	SchemaChanged  bool
+	MaxPSI         float64
+	CreatedAt      int64
 }
 
 type Model struct {
-	Id                  int64
-	TrainingDatasetId   int64
-	ValidationDatasetId int64
-	Name                string
-	ClusterName         string
-	ModelKey            string
-	Algorithm           string
-	ModelCategory       string
-	DatasetName         string
-	ResponseColumnName  string
-	LogicalName         string
-	Location            string
-	ModelObjectType     string
-	MaxRuntime          int
-	JSONMetrics         string
-	CreatedAt           int64
-	LabelId             int64
-	LabelName           string
+	Id                     int64
+	TrainingDatasetId      int64
+	ValidationDatasetId    int64
+	TrainingDatasetVersion int64
+	Stale                  bool `help:"Whether the model is gone from its cluster"`
+	Name                   string
+	ClusterName            string
+	ModelKey               string
+	Algorithm              string
+	ModelCategory          string
+	DatasetName            string
+	ResponseColumnName     string
+	LogicalName            string
+	Location               string
+	ModelObjectType        string
+	MaxRuntime             int
+	JSONMetrics            string
+	CreatedAt              int64
+	LabelId                int64
+	LabelName              string
 }
 
 type BinomialModel struct {
-	Id                  int64
-	TrainingDatasetId   int64
-	ValidationDatasetId int64
-	Name                string
-	ClusterName         string
-	ModelKey            string
-	Algorithm           string
-	ModelCategory       string
-	DatasetName         string
-	ResponseColumnName  string
-	LogicalName         string
-	Location            string
-	ModelObjectType     string
-	MaxRuntime          int
-	JSONMetrics         string
-	CreatedAt           int64
-	LabelId             int64
-	LabelName           string
-	Mse                 float64
-	RSquared            float64
-	Logloss             float64
-	Auc                 float64
-	Gini                float64
+	Id                     int64
+	TrainingDatasetId      int64
+	ValidationDatasetId    int64
+	TrainingDatasetVersion int64
+	Stale                  bool `help:"Whether the model is gone from its cluster"`
+	Name                   string
+	ClusterName            string
+	ModelKey               string
+	Algorithm              string
+	ModelCategory          string
+	DatasetName            string
+	ResponseColumnName     string
+	LogicalName            string
+	Location               string
+	ModelObjectType        string
+	MaxRuntime             int
+	JSONMetrics            string
+	CreatedAt              int64
+	LabelId                int64
+	LabelName              string
+	Mse                    float64
+	RSquared               float64
+	Logloss                float64
+	Auc                    float64
+	Gini                   float64
 }
 
 type MultinomialModel struct {
-	Id                  int64
-	TrainingDatasetId   int64
-	ValidationDatasetId int64
-	Name                string
-	ClusterName         string
-	ModelKey            string
-	Algorithm           string
-	ModelCategory       string
-	DatasetName         string
-	ResponseColumnName  string
-	LogicalName         string
-	Location            string
-	ModelObjectType     string
-	MaxRuntime          int
-	JSONMetrics         string
-	CreatedAt           int64
-	LabelId             int64
-	LabelName           string
-	Mse                 float64
-	RSquared            float64
-	Logloss             float64
+	Id                     int64
+	TrainingDatasetId      int64
+	ValidationDatasetId    int64
+	TrainingDatasetVersion int64
+	Stale                  bool `help:"Whether the model is gone from its cluster"`
+	Name                   string
+	ClusterName            string
+	ModelKey               string
+	Algorithm              string
+	ModelCategory          string
+	DatasetName            string
+	ResponseColumnName     string
+	LogicalName            string
+	Location               string
+	ModelObjectType        string
+	MaxRuntime             int
+	JSONMetrics            string
+	CreatedAt              int64
+	LabelId                int64
+	LabelName              string
+	Mse                    float64
+	RSquared               float64
+	Logloss                float64
 }
 
 type RegressionModel struct {
-	Id                   int64
-	TrainingDatasetId    int64
-	ValidationDatasetId  int64
-	Name                 string
-	ClusterName          string
-	ModelKey             string
-	Algorithm            string
-	ModelCategory        string
-	DatasetName          string
-	ResponseColumnName   string
-	LogicalName          string
-	Location             string
-	ModelObjectType      string
-	MaxRuntime           int
-	JSONMetrics          string
-	CreatedAt            int64
-	LabelId              int64
-	LabelName            string
-	Mse                  float64
-	RSquared             float64
-	MeanResidualDeviance float64
+	Id                     int64
+	TrainingDatasetId      int64
+	ValidationDatasetId    int64
+	TrainingDatasetVersion int64
+	Stale                  bool `help:"Whether the model is gone from its cluster"`
+	Name                   string
+	ClusterName            string
+	ModelKey               string
+	Algorithm              string
+	ModelCategory          string
+	DatasetName            string
+	ResponseColumnName     string
+	LogicalName            string
+	Location               string
+	ModelObjectType        string
+	MaxRuntime             int
+	JSONMetrics            string
+	CreatedAt              int64
+	LabelId                int64
+	LabelName              string
+	Mse                    float64
+	RSquared               float64
+	MeanResidualDeviance   float64
 }
 
 type Label struct {
@@ -205,10 +398,16 @@ type ScoringService struct {
 }
 
 type Engine struct {
-	Id        int64
-	Name      string
-	Location  string
-	CreatedAt int64
+	Id             int64
+	Name           string
+	Location       string
+	CreatedAt      int64
+	Version        string
+	Distribution   string
+	Checksum       string
+	ApiLevel       int
+	MojoAlgorithms []string
+	DeepWater      bool
 }
 
 type EntityType struct {
@@ -274,6 +473,7 @@ type Workgroup struct {
 	Name        string
 	Description string
 	Created     int64
+	IdleTimeout int64
 }
 
 // --- API Facade ---
@@ -283,14 +483,34 @@ type Service struct {
 	GetConfig                     GetConfig                     `help:Get Steam start up configurations`
 	RegisterCluster               RegisterCluster               `help:"Connect to a cluster"`
 	UnregisterCluster             UnregisterCluster             `help:"Disconnect from a cluster"`
+	StartCluster                  StartCluster                  `help:"Start a cluster using the launcher for its cluster type"`
+	StopCluster                   StopCluster                   `help:"Stop a cluster started by Steam"`
 	StartClusterOnYarn            StartClusterOnYarn            `help:"Start a cluster using Yarn"`
 	StopClusterOnYarn             StopClusterOnYarn             `help:"Stop a cluster using Yarn"`
+	GetClusterLaunch              GetClusterLaunch              `help:"Get the launch progress and log of a cluster started using Yarn"`
+	GetClusterLogs                GetClusterLogs                `help:"Get the aggregated Yarn logs of a cluster started using Yarn"`
+	CancelClusterLaunch           CancelClusterLaunch           `help:"Cancel a cluster launch on Yarn"`
+	SetClusterIdleTimeout         SetClusterIdleTimeout         `help:"Set the idle timeout of a cluster started using Yarn"`
+	KeepAlive                     KeepAlive                     `help:"Postpone the idle shutdown of a cluster"`
+	GetClusterMetrics             GetClusterMetrics             `help:"Get resource usage samples of a cluster's nodes"`
+	SyncCluster                   SyncCluster                   `help:"Mark datasets and models whose frames or models are gone from a cluster as stale"`
+	CreateClusterTemplate         CreateClusterTemplate         `help:"Create a cluster launch template"`
+	GetClusterTemplate            GetClusterTemplate            `help:"Get cluster launch template details"`
+	GetClusterTemplates           GetClusterTemplates           `help:"List cluster launch templates"`
+	UpdateClusterTemplate         UpdateClusterTemplate         `help:"Update a cluster launch template"`
+	DeleteClusterTemplate         DeleteClusterTemplate         `help:"Delete a cluster launch template"`
+	StartClusterFromTemplate      StartClusterFromTemplate      `help:"Start a cluster on Yarn from a launch template"`
+	GetKeytabs                    GetKeytabs                    `help:"List the Kerberos keytabs uploaded by the current identity"`
+	DeleteKeytab                  DeleteKeytab                  `help:"Delete an uploaded Kerberos keytab"`
+	GetClusterProxyPolicy         GetClusterProxyPolicy         `help:"Get the proxy rules of a cluster"`
+	SetClusterProxyPolicy         SetClusterProxyPolicy         `help:"Set the proxy rules of a cluster"`
 	GetCluster                    GetCluster                    `help:"Get cluster details"`
 	GetClusterOnYarn              GetClusterOnYarn              `help:"Get cluster details (Yarn only)"`
 	GetClusters                   GetClusters                   `help:"List clusters"`
 	GetClusterStatus              GetClusterStatus              `help:"Get cluster status"`
 	DeleteCluster                 DeleteCluster                 `help:"Delete a cluster"`
 	GetJob                        GetJob                        `help:"Get job details"`
+	CancelJob                     CancelJob                     `help:"Cancel a running job"`
 	GetJobs                       GetJobs                       `help:"List jobs"`
 	CreateProject                 CreateProject                 `help:"Create a project"`
 	GetProjects                   GetProjects                   `help:"List projects"`
@@ -301,10 +521,22 @@ type Service struct {
 	GetDatasource                 GetDatasource                 `help:"Get datasource details"`
 	UpdateDatasource              UpdateDatasource              `help:"Update a datasource"`
 	DeleteDatasource              DeleteDatasource              `help:"Delete a datasource"`
-	CreateDataset                 CreateDataset                 `help:"Create a dataset"`
+	TestDatasource                TestDatasource                `help:"Check that a datasource can be reached"`
+	CreateDataset                 CreateDataset                 `help:"Start importing a dataset"`
+	GetDatasetJob                 GetDatasetJob                 `help:"Get the state of a dataset import job"`
+	GetDatasetJobs                GetDatasetJobs                `help:"List the dataset import jobs of a datasource, newest first"`
+	CancelDatasetJob              CancelDatasetJob              `help:"Cancel a dataset import job"`
+	ReimportDataset               ReimportDataset               `help:"Re-import a stale dataset from its datasource into its cluster"`
 	GetDatasets                   GetDatasets                   `help:"List datasets"`
 	GetDataset                    GetDataset                    `help:"Get dataset details"`
+	GetDatasetColumns             GetDatasetColumns             `help:"Get the column profile of a dataset"`
+	GetDatasetColumnRoles         GetDatasetColumnRoles         `help:"Get the columns a dataset assigns to model parameters"`
+	SetDatasetColumnRole          SetDatasetColumnRole          `help:"Assign a role to a dataset column"`
+	PreviewDataset                PreviewDataset                `help:"Get a page of a dataset's rows"`
+	GetDatasetVersions            GetDatasetVersions            `help:"List the versions of a datasource's data, newest first"`
 	GetDatasetsFromCluster        GetDatasetsFromCluster        `help:"Get a list of datasets on a cluster"`
+	CompareDatasets               CompareDatasets               `help:"Compare the schema and distributions of two datasets"`
+	GetDatasetComparison          GetDatasetComparison          `help:"Get the comparison recorded when a dataset was created"`
 	UpdateDataset                 UpdateDataset                 `help:"Update a dataset"`
 	SplitDataset                  SplitDataset                  `help:"Split a dataset"`
 	DeleteDataset                 DeleteDataset                 `help:"Delete a dataset"`
@@ -366,6 +598,7 @@ type Service struct {
 	GetWorkgroup                  GetWorkgroup                  `help:"Get workgroup details"`
 	GetWorkgroupByName            GetWorkgroupByName            `help:"Get workgroup details by name"`
 	UpdateWorkgroup               UpdateWorkgroup               `help:"Update a workgroup"`
+	SetWorkgroupIdleTimeout       SetWorkgroupIdleTimeout       `help:"Set the default idle timeout for clusters started by workgroup members"`
 	DeleteWorkgroup               DeleteWorkgroup               `help:"Delete a workgroup"`
 	CreateIdentity                CreateIdentity                `help:"Create an identity"`
 	GetIdentities                 GetIdentities                 `help:"List identities"`
@@ -412,24 +645,143 @@ type GetConfig struct {
 }
 type RegisterCluster struct {
 	Address   string
+	Scheme    string `help:"Connection scheme: http (default) or https"`
+	CaCert    string `help:"PEM-encoded CA bundle used to verify the cluster's certificate"`
+	Username  string `help:"Username for basic authentication"`
+	Password  string `help:"Password for basic authentication"`
 	_         int
 	ClusterId int64
 }
 type UnregisterCluster struct {
 	ClusterId int64
 }
+type StartCluster struct {
+	ClusterName string
+	ClusterType string `help:"Cluster type: yarn or local"`
+	EngineId    int64
+	Size        int
+	Memory      string
+	KeytabId    int64 `help:"Uploaded keytab to authenticate with (0 for none)"`
+	_           int
+	ClusterId   int64
+}
+type StopCluster struct {
+	ClusterId int64
+}
 type StartClusterOnYarn struct {
 	ClusterName string
 	EngineId    int64
 	Size        int
 	Memory      string
-	Keytab      string
+	KeytabId    int64  `help:"Uploaded keytab to authenticate with (0 for none)"`
+	Queue       string `help:"Yarn queue to submit the cluster to (optional)"`
+	NodeLabel   string `help:"Yarn node label expression to place the cluster with (optional)"`
+	DriverArgs  string `help:"Extra h2odriver arguments, separated by spaces; must be allowed by the administrator"`
 	_           int
 	ClusterId   int64
 }
 type StopClusterOnYarn struct {
 	ClusterId int64
-	Keytab    string
+	KeytabId  int64 `help:"Uploaded keytab to authenticate with (0 to stop a cluster you started with the keytab it was started with)"`
+}
+type GetClusterLaunch struct {
+	ClusterId int64
+	LogLines  int `help:"Number of log lines to return"`
+	_         int
+	Launch    ClusterLaunch
+}
+type GetClusterLogs struct {
+	ClusterId   int64
+	ContainerId string `help:"Container to get the logs of (optional; all containers by default)"`
+	TailLines   int    `help:"Number of log lines to return (at most 1000)"`
+	Offset      int64  `help:"Number of lines at the end of the log to skip, for paging back"`
+	_           int
+	Logs        ClusterLogs
+}
+type CancelClusterLaunch struct {
+	ClusterId int64
+}
+type SetClusterIdleTimeout struct {
+	ClusterId int64
+	Minutes   int64 `help:"Idle timeout in minutes (0 disables idle shutdown)"`
+}
+type KeepAlive struct {
+	ClusterId int64
+}
+type GetClusterMetrics struct {
+	ClusterId int64
+	From      int64 `help:"Start of the period (Unix time; defaults to an hour before its end)"`
+	To        int64 `help:"End of the period (Unix time; defaults to now)"`
+	Step      int64 `help:"Seconds to average samples over (0 for raw samples)"`
+	_         int
+	Metrics   []ClusterMetric
+}
+type SyncCluster struct {
+	ClusterId int64
+	_         int
+	Sync      ClusterSync
+}
+type CreateClusterTemplate struct {
+	Name        string
+	EngineId    int64
+	Size        int
+	Memory      string
+	DriverArgs  string `help:"Extra h2odriver arguments, separated by spaces"`
+	Queue       string `help:"Yarn queue to submit clusters to (optional)"`
+	IdleTimeout int64  `help:"Idle timeout in minutes (0 to use the workgroup default)"`
+	Tags        string `help:"Comma-separated tags to find the template by"`
+	MaxClusters int    `help:"Maximum number of running clusters started from the template (0 for no limit)"`
+	_           int
+	TemplateId  int64
+}
+type GetClusterTemplate struct {
+	TemplateId int64
+	_          int
+	Template   ClusterTemplate
+}
+type GetClusterTemplates struct {
+	Offset    int64
+	Limit     int64
+	_         int
+	Templates []ClusterTemplate
+}
+type UpdateClusterTemplate struct {
+	TemplateId  int64
+	Name        string
+	EngineId    int64
+	Size        int
+	Memory      string
+	DriverArgs  string `help:"Extra h2odriver arguments, separated by spaces"`
+	Queue       string `help:"Yarn queue to submit clusters to (optional)"`
+	IdleTimeout int64  `help:"Idle timeout in minutes (0 to use the workgroup default)"`
+	Tags        string `help:"Comma-separated tags to find the template by"`
+	MaxClusters int    `help:"Maximum number of running clusters started from the template (0 for no limit)"`
+}
+type DeleteClusterTemplate struct {
+	TemplateId int64
+}
+type StartClusterFromTemplate struct {
+	TemplateId  int64
+	ClusterName string
+	KeytabId    int64 `help:"Uploaded keytab to authenticate with (0 for none)"`
+	_           int
+	ClusterId   int64
+}
+type GetKeytabs struct {
+	_       int
+	Keytabs []Keytab
+}
+type DeleteKeytab struct {
+	KeytabId int64
+}
+type GetClusterProxyPolicy struct {
+	ClusterId int64
+	_         int
+	Policy    string `help:"Proxy rules as a JSON array of {method, path, privilege}; empty for the default policy"`
+}
+type SetClusterProxyPolicy struct {
+	ClusterId int64
+	Policy    string `help:"Proxy rules as a JSON array of {method, path, privilege}; empty for the default policy"`
 }
 type GetCluster struct {
 	ClusterId int64
@@ -461,6 +813,10 @@ type GetJob struct {
 	_         int
 	Job       Job
 }
+type CancelJob struct {
+	ClusterId int64
+	JobName   string
+}
 type GetJobs struct {
 	ClusterId int64
 	_         int
@@ -488,12 +844,13 @@ type DeleteProject struct {
 	ProjectId int64
 }
 type CreateDatasource struct {
-	ProjectId    int64
-	Name         string
-	Description  string
-	Path         string
-	_            int
-	DatasourceId int64
+	ProjectId     int64
+	Name          string
+	Description   string
+	Kind          string `help:"Datasource kind: file, hdfs, s3 or jdbc"`
+	Configuration string `help:"Settings of the kind as a JSON object; file: path; hdfs: uri; s3: endpoint, region, bucket, prefix, accessKey, secretKey; jdbc: url, table or query, username, password"`
+	_             int
+	DatasourceId  int64
 }
 type GetDatasources struct {
 	ProjectId   int64
@@ -508,22 +865,50 @@ type GetDatasource struct {
 	Datasource   Datasource
 }
 type UpdateDatasource struct {
-	DatasourceId int64
-	Name         string
-	Description  string
-	Path         string
+	DatasourceId  int64
+	Name          string
+	Description   string
+	Kind          string `help:"Datasource kind: file, hdfs, s3 or jdbc"`
+	Configuration string `help:"Settings of the kind as a JSON object; file: path; hdfs: uri; s3: endpoint, region, bucket, prefix, accessKey, secretKey; jdbc: url, table or query, username, password. Secrets left out are kept"`
 }
 type DeleteDatasource struct {
 	DatasourceId int64
 }
+type TestDatasource struct {
+	DatasourceId int64
+	ClusterId    int64 `help:"Cluster to look for file and HDFS datasources from"`
+	_            int
+	Message      string
+}
 type CreateDataset struct {
-	ClusterId          int64
-	DatasourceId       int64
-	Name               string
-	Description        string
-	ResponseColumnName string
-	_                  int
-	DatasetId          int64
+	ClusterId           int64
+	DatasourceId        int64
+	Name                string
+	Description         string
+	ResponseColumnName  string
+	CompareWithPrevious bool `help:"Compare the new dataset with the previous dataset from the same datasource"`
+	_                   int
+	JobId               int64 `help:"The job importing the dataset; see GetDatasetJob"`
+}
+type GetDatasetJob struct {
+	JobId      int64
+	_          int
+	DatasetJob DatasetJob
+}
+type ReimportDataset struct {
+	DatasetId int64
+	_         int
+	JobId     int64
+}
+type GetDatasetJobs struct {
+	DatasourceId int64
+	Offset       int64
+	Limit        int64
+	_            int
+	DatasetJobs  []DatasetJob
+}
+type CancelDatasetJob struct {
+	JobId int64
 }
 type GetDatasets struct {
 	DatasourceId int64
@@ -537,11 +922,51 @@ type GetDataset struct {
 	_         int
 	Dataset   Dataset
 }
+type GetDatasetColumns struct {
+	DatasetId int64
+	Refresh   bool `help:"Recompute the profile from the dataset's frame on its cluster"`
+	_         int
+	Columns   []DatasetColumn
+}
+type GetDatasetColumnRoles struct {
+	DatasetId int64
+	_         int
+	Roles     DatasetColumnRoles
+}
+type SetDatasetColumnRole struct {
+	DatasetId  int64
+	ColumnName string
+	Role       string `help:"response, ignored, weight, fold or offset; empty to clear"`
+}
+type PreviewDataset struct {
+	DatasetId int64
+	Offset    int64
+	RowCount  int64    `help:"Number of rows to return; 0 returns 10, and at most 1000 can be returned"`
+	Columns   []string `help:"Names of the columns to return, in order; all columns if empty"`
+	_         int
+	Preview   DatasetPreview
+}
+type GetDatasetVersions struct {
+	DatasourceId int64
+	_            int
+	Datasets     []Dataset
+}
 type GetDatasetsFromCluster struct {
 	ClusterId int64
 	_         int
 	Dataset   []Dataset
 }
+type CompareDatasets struct {
+	BaseDatasetId  int64
+	OtherDatasetId int64
+	_              int
+	Comparison     DatasetComparison
+}
+type GetDatasetComparison struct {
+	DatasetId  int64
+	_          int
+	Comparison DatasetComparison
+}
 type UpdateDataset struct {
 	DatasetId          int64
 	Name               string
@@ -550,8 +975,9 @@ type UpdateDataset struct {
 }
 type SplitDataset struct {
 	DatasetId  int64
-	Ratio1     int
-	Ratio2     int
+	Ratio1     int   `help:"Percentage of rows in the training split"`
+	Ratio2     int   `help:"Percentage of rows in the validation split; any remainder becomes a test split"`
+	Seed       int64 `help:"Random seed; 0 picks one, which is recorded on the splits"`
 	_          int
 	DatasetIds []int64
 }
@@ -862,6 +1288,10 @@ type UpdateWorkgroup struct {
 	Name        string `help:"A string name."`
 	Description string `help:"A string description"`
 }
+type SetWorkgroupIdleTimeout struct {
+	WorkgroupId int64 `help:"Integer ID of a workgroup in Steam."`
+	Minutes     int64 `help:"Idle timeout in minutes (0 disables idle shutdown)"`
+}
 type DeleteWorkgroup struct {
 	WorkgroupId int64 `help:"Integer ID of a workgroup in Steam."`
 }
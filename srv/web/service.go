@@ -33,29 +33,31 @@ import (
 // --- Types ---
 
 type BinomialModel struct {
-	Id                  int64   `json:"id"`
-	TrainingDatasetId   int64   `json:"training_dataset_id"`
-	ValidationDatasetId int64   `json:"validation_dataset_id"`
-	Name                string  `json:"name"`
-	ClusterName         string  `json:"cluster_name"`
-	ModelKey            string  `json:"model_key"`
-	Algorithm           string  `json:"algorithm"`
-	ModelCategory       string  `json:"model_category"`
-	DatasetName         string  `json:"dataset_name"`
-	ResponseColumnName  string  `json:"response_column_name"`
-	LogicalName         string  `json:"logical_name"`
-	Location            string  `json:"location"`
-	ModelObjectType     string  `json:"model_object_type"`
-	MaxRuntime          int     `json:"max_runtime"`
-	JSONMetrics         string  `json:"json_metrics"`
-	CreatedAt           int64   `json:"created_at"`
-	LabelId             int64   `json:"label_id"`
-	LabelName           string  `json:"label_name"`
-	Mse                 float64 `json:"mse"`
-	RSquared            float64 `json:"r_squared"`
-	Logloss             float64 `json:"logloss"`
-	Auc                 float64 `json:"auc"`
-	Gini                float64 `json:"gini"`
+	Id                     int64   `json:"id"`
+	TrainingDatasetId      int64   `json:"training_dataset_id"`
+	ValidationDatasetId    int64   `json:"validation_dataset_id"`
+	TrainingDatasetVersion int64   `json:"training_dataset_version"`
+	Stale                  bool    `json:"stale"`
+	Name                   string  `json:"name"`
+	ClusterName            string  `json:"cluster_name"`
+	ModelKey               string  `json:"model_key"`
+	Algorithm              string  `json:"algorithm"`
+	ModelCategory          string  `json:"model_category"`
+	DatasetName            string  `json:"dataset_name"`
+	ResponseColumnName     string  `json:"response_column_name"`
+	LogicalName            string  `json:"logical_name"`
+	Location               string  `json:"location"`
+	ModelObjectType        string  `json:"model_object_type"`
+	MaxRuntime             int     `json:"max_runtime"`
+	JSONMetrics            string  `json:"json_metrics"`
+	CreatedAt              int64   `json:"created_at"`
+	LabelId                int64   `json:"label_id"`
+	LabelName              string  `json:"label_name"`
+	Mse                    float64 `json:"mse"`
+	RSquared               float64 `json:"r_squared"`
+	Logloss                float64 `json:"logloss"`
+	Auc                    float64 `json:"auc"`
+	Gini                   float64 `json:"gini"`
 }
 
 type Cluster struct {
@@ -68,6 +70,37 @@ type Cluster struct {
 	CreatedAt int64  `json:"created_at"`
 }
 
+type ClusterLaunch struct {
+	ClusterId     int64  `json:"cluster_id"`
+	State         string `json:"state"`
+	ApplicationId string `json:"application_id"`
+	Size          int    `json:"size"`
+	NodesUp       int    `json:"nodes_up"`
+	Errors        string `json:"errors"`
+	Log           string `json:"log"`
+}
+
+type ClusterLogs struct {
+	ClusterId     int64  `json:"cluster_id"`
+	ApplicationId string `json:"application_id"`
+	Log           string `json:"log"`
+	TotalLines    int64  `json:"total_lines"`
+	Offset        int64  `json:"offset"`
+}
+
+type ClusterMetric struct {
+	Time         int64   `json:"time"`
+	Node         string  `json:"node"`
+	Healthy      bool    `json:"healthy"`
+	SysLoad      float64 `json:"sys_load"`
+	MyCpuPct     int     `json:"my_cpu_pct"`
+	SysCpuPct    int     `json:"sys_cpu_pct"`
+	FreeMem      int64   `json:"free_mem"`
+	MaxMem       int64   `json:"max_mem"`
+	PojoMem      int64   `json:"pojo_mem"`
+	MemValueSize int64   `json:"mem_value_size"`
+}
+
 type ClusterStatus struct {
 	Version              string `json:"version"`
 	Status               string `json:"status"`
@@ -76,11 +109,47 @@ type ClusterStatus struct {
 	TotalAllowedCpuCount int    `json:"total_allowed_cpu_count"`
 }
 
+type ClusterSync struct {
+	ClusterId     int64           `json:"cluster_id"`
+	FrameCount    int64           `json:"frame_count"`
+	ModelCount    int64           `json:"model_count"`
+	StaleDatasets []*StaleDataset `json:"stale_datasets"`
+	StaleModels   []*StaleModel   `json:"stale_models"`
+	SyncedAt      int64           `json:"synced_at"`
+}
+
+type ClusterTemplate struct {
+	Id          int64  `json:"id"`
+	Name        string `json:"name"`
+	EngineId    int64  `json:"engine_id"`
+	Size        int    `json:"size"`
+	Memory      string `json:"memory"`
+	DriverArgs  string `json:"driver_args"`
+	Queue       string `json:"queue"`
+	IdleTimeout int64  `json:"idle_timeout"`
+	Tags        string `json:"tags"`
+	MaxClusters int    `json:"max_clusters"`
+	CreatedAt   int64  `json:"created_at"`
+}
+
 type ClusterType struct {
 	Id   int64  `json:"id"`
 	Name string `json:"name"`
 }
 
+type ColumnComparison struct {
+	Name          string   `json:"name"`
+	BaseType      string   `json:"base_type"`
+	OtherType     string   `json:"other_type"`
+	NewLevels     []string `json:"new_levels"`
+	MissingLevels []string `json:"missing_levels"`
+	MeanDelta     float64  `json:"mean_delta"`
+	SigmaDelta    float64  `json:"sigma_delta"`
+	PSI           float64  `json:"p_s_i"`
+	HasPSI        bool     `json:"has_p_s_i"`
+	Role          string   `json:"role"`
+}
+
 type Config struct {
 	KerberosEnabled     bool   `json:"kerberos_enabled"`
 	ClusterProxyAddress string `json:"cluster_proxy_address"`
@@ -89,29 +158,127 @@ type Config struct {
 type Dataset struct {
 	Id                 int64  `json:"id"`
 	DatasourceId       int64  `json:"datasource_id"`
+	ClusterId          int64  `json:"cluster_id"`
+	ParentId           int64  `json:"parent_id"`
+	Version            int64  `json:"version"`
+	PreviousId         int64  `json:"previous_id"`
+	RowCount           int64  `json:"row_count"`
+	ColumnHash         string `json:"column_hash"`
+	Checksum           string `json:"checksum"`
 	Name               string `json:"name"`
 	Description        string `json:"description"`
 	FrameName          string `json:"frame_name"`
 	ResponseColumnName string `json:"response_column_name"`
 	JSONProperties     string `json:"json_properties"`
 	CreatedAt          int64  `json:"created_at"`
+	Stale              bool   `json:"stale"`
+}
+
+type DatasetColumn struct {
+	Name              string   `json:"name"`
+	Type              string   `json:"type"`
+	MissingCount      int64    `json:"missing_count"`
+	ZeroCount         int64    `json:"zero_count"`
+	Min               float64  `json:"min"`
+	Max               float64  `json:"max"`
+	Mean              float64  `json:"mean"`
+	Sigma             float64  `json:"sigma"`
+	Domain            []string `json:"domain"`
+	DomainCardinality int64    `json:"domain_cardinality"`
+	HistogramBase     float64  `json:"histogram_base"`
+	HistogramStride   float64  `json:"histogram_stride"`
+	HistogramBins     []int64  `json:"histogram_bins"`
+	Role              string   `json:"role"`
+}
+
+type DatasetColumnRoles struct {
+	DatasetId          int64    `json:"dataset_id"`
+	ResponseColumnName string   `json:"response_column_name"`
+	IgnoredColumns     []string `json:"ignored_columns"`
+	WeightsColumn      string   `json:"weights_column"`
+	FoldColumn         string   `json:"fold_column"`
+	OffsetColumn       string   `json:"offset_column"`
+}
+
+type DatasetComparison struct {
+	BaseDatasetId  int64               `json:"base_dataset_id"`
+	OtherDatasetId int64               `json:"other_dataset_id"`
+	AddedColumns   []string            `json:"added_columns"`
+	RemovedColumns []string            `json:"removed_columns"`
+	Columns        []*ColumnComparison `json:"columns"`
+	SchemaChanged  bool                `json:"schema_changed"`
+	MaxPSI         float64             `json:"max_p_s_i"`
+	CreatedAt      int64               `json:"created_at"`
+}
+
+type DatasetJob struct {
+	Id             int64   `json:"id"`
+	ClusterId      int64   `json:"cluster_id"`
+	DatasourceId   int64   `json:"datasource_id"`
+	DatasetId      int64   `json:"dataset_id"`
+	Name           string  `json:"name"`
+	State          string  `json:"state"`
+	ClusterJobName string  `json:"cluster_job_name"`
+	Progress       float64 `json:"progress"`
+	Error          string  `json:"error"`
+	CreatedAt      int64   `json:"created_at"`
+	FinishedAt     int64   `json:"finished_at"`
+}
+
+type DatasetPreview struct {
+	DatasetId int64                   `json:"dataset_id"`
+	Source    string                  `json:"source"`
+	Offset    int64                   `json:"offset"`
+	TotalRows int64                   `json:"total_rows"`
+	Columns   []*DatasetPreviewColumn `json:"columns"`
+	Rows      []*DatasetPreviewRow    `json:"rows"`
+}
+
+type DatasetPreviewColumn struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+}
+
+type DatasetPreviewRow struct {
+	Values  []string `json:"values"`
+	Missing []bool   `json:"missing"`
 }
 
 type Datasource struct {
-	Id            int64  `json:"id"`
-	ProjectId     int64  `json:"project_id"`
-	Name          string `json:"name"`
-	Description   string `json:"description"`
-	Kind          string `json:"kind"`
-	Configuration string `json:"configuration"`
-	CreatedAt     int64  `json:"created_at"`
+	Id          int64             `json:"id"`
+	ProjectId   int64             `json:"project_id"`
+	Name        string            `json:"name"`
+	Description string            `json:"description"`
+	Kind        string            `json:"kind"`
+	Config      *DatasourceConfig `json:"config"`
+	CreatedAt   int64             `json:"created_at"`
+}
+
+type DatasourceConfig struct {
+	Path      string `json:"path"`
+	Uri       string `json:"uri"`
+	Endpoint  string `json:"endpoint"`
+	Region    string `json:"region"`
+	Bucket    string `json:"bucket"`
+	Prefix    string `json:"prefix"`
+	AccessKey string `json:"access_key"`
+	Url       string `json:"url"`
+	Table     string `json:"table"`
+	Query     string `json:"query"`
+	Username  string `json:"username"`
 }
 
 type Engine struct {
-	Id        int64  `json:"id"`
-	Name      string `json:"name"`
-	Location  string `json:"location"`
-	CreatedAt int64  `json:"created_at"`
+	Id             int64    `json:"id"`
+	Name           string   `json:"name"`
+	Location       string   `json:"location"`
+	CreatedAt      int64    `json:"created_at"`
+	Version        string   `json:"version"`
+	Distribution   string   `json:"distribution"`
+	Checksum       string   `json:"checksum"`
+	ApiLevel       int      `json:"api_level"`
+	MojoAlgorithms []string `json:"mojo_algorithms"`
+	DeepWater      bool     `json:"deep_water"`
 }
 
 type EntityHistory struct {
@@ -150,6 +317,13 @@ type Job struct {
 	CompletedAt int64  `json:"completed_at"`
 }
 
+type Keytab struct {
+	Id        int64  `json:"id"`
+	Name      string `json:"name"`
+	Principal string `json:"principal"`
+	CreatedAt int64  `json:"created_at"`
+}
+
 type Label struct {
 	Id          int64  `json:"id"`
 	ProjectId   int64  `json:"project_id"`
@@ -160,48 +334,52 @@ type Label struct {
 }
 
 type Model struct {
-	Id                  int64  `json:"id"`
-	TrainingDatasetId   int64  `json:"training_dataset_id"`
-	ValidationDatasetId int64  `json:"validation_dataset_id"`
-	Name                string `json:"name"`
-	ClusterName         string `json:"cluster_name"`
-	ModelKey            string `json:"model_key"`
-	Algorithm           string `json:"algorithm"`
-	ModelCategory       string `json:"model_category"`
-	DatasetName         string `json:"dataset_name"`
-	ResponseColumnName  string `json:"response_column_name"`
-	LogicalName         string `json:"logical_name"`
-	Location            string `json:"location"`
-	ModelObjectType     string `json:"model_object_type"`
-	MaxRuntime          int    `json:"max_runtime"`
-	JSONMetrics         string `json:"json_metrics"`
-	CreatedAt           int64  `json:"created_at"`
-	LabelId             int64  `json:"label_id"`
-	LabelName           string `json:"label_name"`
+	Id                     int64  `json:"id"`
+	TrainingDatasetId      int64  `json:"training_dataset_id"`
+	ValidationDatasetId    int64  `json:"validation_dataset_id"`
+	TrainingDatasetVersion int64  `json:"training_dataset_version"`
+	Stale                  bool   `json:"stale"`
+	Name                   string `json:"name"`
+	ClusterName            string `json:"cluster_name"`
+	ModelKey               string `json:"model_key"`
+	Algorithm              string `json:"algorithm"`
+	ModelCategory          string `json:"model_category"`
+	DatasetName            string `json:"dataset_name"`
+	ResponseColumnName     string `json:"response_column_name"`
+	LogicalName            string `json:"logical_name"`
+	Location               string `json:"location"`
+	ModelObjectType        string `json:"model_object_type"`
+	MaxRuntime             int    `json:"max_runtime"`
+	JSONMetrics            string `json:"json_metrics"`
+	CreatedAt              int64  `json:"created_at"`
+	LabelId                int64  `json:"label_id"`
+	LabelName              string `json:"label_name"`
 }
 
 type MultinomialModel struct {
-	Id                  int64   `json:"id"`
-	TrainingDatasetId   int64   `json:"training_dataset_id"`
-	ValidationDatasetId int64   `json:"validation_dataset_id"`
-	Name                string  `json:"name"`
-	ClusterName         string  `json:"cluster_name"`
-	ModelKey            string  `json:"model_key"`
-	Algorithm           string  `json:"algorithm"`
-	ModelCategory       string  `json:"model_category"`
-	DatasetName         string  `json:"dataset_name"`
-	ResponseColumnName  string  `json:"response_column_name"`
-	LogicalName         string  `json:"logical_name"`
-	Location            string  `json:"location"`
-	ModelObjectType     string  `json:"model_object_type"`
-	MaxRuntime          int     `json:"max_runtime"`
-	JSONMetrics         string  `json:"json_metrics"`
-	CreatedAt           int64   `json:"created_at"`
-	LabelId             int64   `json:"label_id"`
-	LabelName           string  `json:"label_name"`
-	Mse                 float64 `json:"mse"`
-	RSquared            float64 `json:"r_squared"`
-	Logloss             float64 `json:"logloss"`
+	Id                     int64   `json:"id"`
+	TrainingDatasetId      int64   `json:"training_dataset_id"`
+	ValidationDatasetId    int64   `json:"validation_dataset_id"`
+	TrainingDatasetVersion int64   `json:"training_dataset_version"`
+	Stale                  bool    `json:"stale"`
+	Name                   string  `json:"name"`
+	ClusterName            string  `json:"cluster_name"`
+	ModelKey               string  `json:"model_key"`
+	Algorithm              string  `json:"algorithm"`
+	ModelCategory          string  `json:"model_category"`
+	DatasetName            string  `json:"dataset_name"`
+	ResponseColumnName     string  `json:"response_column_name"`
+	LogicalName            string  `json:"logical_name"`
+	Location               string  `json:"location"`
+	ModelObjectType        string  `json:"model_object_type"`
+	MaxRuntime             int     `json:"max_runtime"`
+	JSONMetrics            string  `json:"json_metrics"`
+	CreatedAt              int64   `json:"created_at"`
+	LabelId                int64   `json:"label_id"`
+	LabelName              string  `json:"label_name"`
+	Mse                    float64 `json:"mse"`
+	RSquared               float64 `json:"r_squared"`
+	Logloss                float64 `json:"logloss"`
 }
 
 type Permission struct {
@@ -219,27 +397,29 @@ type Project struct {
 }
 
 type RegressionModel struct {
-	Id                   int64   `json:"id"`
-	TrainingDatasetId    int64   `json:"training_dataset_id"`
-	ValidationDatasetId  int64   `json:"validation_dataset_id"`
-	Name                 string  `json:"name"`
-	ClusterName          string  `json:"cluster_name"`
-	ModelKey             string  `json:"model_key"`
-	Algorithm            string  `json:"algorithm"`
-	ModelCategory        string  `json:"model_category"`
-	DatasetName          string  `json:"dataset_name"`
-	ResponseColumnName   string  `json:"response_column_name"`
-	LogicalName          string  `json:"logical_name"`
-	Location             string  `json:"location"`
-	ModelObjectType      string  `json:"model_object_type"`
-	MaxRuntime           int     `json:"max_runtime"`
-	JSONMetrics          string  `json:"json_metrics"`
-	CreatedAt            int64   `json:"created_at"`
-	LabelId              int64   `json:"label_id"`
-	LabelName            string  `json:"label_name"`
-	Mse                  float64 `json:"mse"`
-	RSquared             float64 `json:"r_squared"`
-	MeanResidualDeviance float64 `json:"mean_residual_deviance"`
+	Id                     int64   `json:"id"`
+	TrainingDatasetId      int64   `json:"training_dataset_id"`
+	ValidationDatasetId    int64   `json:"validation_dataset_id"`
+	TrainingDatasetVersion int64   `json:"training_dataset_version"`
+	Stale                  bool    `json:"stale"`
+	Name                   string  `json:"name"`
+	ClusterName            string  `json:"cluster_name"`
+	ModelKey               string  `json:"model_key"`
+	Algorithm              string  `json:"algorithm"`
+	ModelCategory          string  `json:"model_category"`
+	DatasetName            string  `json:"dataset_name"`
+	ResponseColumnName     string  `json:"response_column_name"`
+	LogicalName            string  `json:"logical_name"`
+	Location               string  `json:"location"`
+	ModelObjectType        string  `json:"model_object_type"`
+	MaxRuntime             int     `json:"max_runtime"`
+	JSONMetrics            string  `json:"json_metrics"`
+	CreatedAt              int64   `json:"created_at"`
+	LabelId                int64   `json:"label_id"`
+	LabelName              string  `json:"label_name"`
+	Mse                    float64 `json:"mse"`
+	RSquared               float64 `json:"r_squared"`
+	MeanResidualDeviance   float64 `json:"mean_residual_deviance"`
 }
 
 type Role struct {
@@ -260,6 +440,20 @@ type ScoringService struct {
 	CreatedAt int64  `json:"created_at"`
 }
 
+type StaleDataset struct {
+	DatasetId    int64  `json:"dataset_id"`
+	Name         string `json:"name"`
+	FrameName    string `json:"frame_name"`
+	Reimportable bool   `json:"reimportable"`
+}
+
+type StaleModel struct {
+	ModelId  int64  `json:"model_id"`
+	Name     string `json:"name"`
+	ModelKey string `json:"model_key"`
+	Exported bool   `json:"exported"`
+}
+
 type UserRole struct {
 	Kind         string `json:"kind"`
 	IdentityId   int64  `json:"identity_id"`
@@ -273,6 +467,7 @@ type Workgroup struct {
 	Name        string `json:"name"`
 	Description string `json:"description"`
 	Created     int64  `json:"created"`
+	IdleTimeout int64  `json:"idle_timeout"`
 }
 
 type YarnCluster struct {
@@ -282,6 +477,11 @@ type YarnCluster struct {
 	ApplicationId string `json:"application_id"`
 	Memory        string `json:"memory"`
 	Username      string `json:"username"`
+	IdleTimeout   int64  `json:"idle_timeout"`
+	IdleDeadline  int64  `json:"idle_deadline"`
+	Queue         string `json:"queue"`
+	NodeLabel     string `json:"node_label"`
+	DriverArgs    string `json:"driver_args"`
 }
 
 // --- Interface ---
@@ -292,32 +492,64 @@ type Az interface {
 type Service interface {
 	PingServer(pz az.Principal, input string) (string, error)
 	GetConfig(pz az.Principal) (*Config, error)
-	RegisterCluster(pz az.Principal, address string) (int64, error)
+	RegisterCluster(pz az.Principal, address string, scheme string, caCert string, username string, password string) (int64, error)
 	UnregisterCluster(pz az.Principal, clusterId int64) error
-	StartClusterOnYarn(pz az.Principal, clusterName string, engineId int64, size int, memory string, keytab string) (int64, error)
-	StopClusterOnYarn(pz az.Principal, clusterId int64, keytab string) error
+	StartCluster(pz az.Principal, clusterName string, clusterType string, engineId int64, size int, memory string, keytabId int64) (int64, error)
+	StopCluster(pz az.Principal, clusterId int64) error
+	StartClusterOnYarn(pz az.Principal, clusterName string, engineId int64, size int, memory string, keytabId int64, queue string, nodeLabel string, driverArgs string) (int64, error)
+	StopClusterOnYarn(pz az.Principal, clusterId int64, keytabId int64) error
+	GetClusterLaunch(pz az.Principal, clusterId int64, logLines int) (*ClusterLaunch, error)
+	GetClusterLogs(pz az.Principal, clusterId int64, containerId string, tailLines int, offset int64) (*ClusterLogs, error)
+	CancelClusterLaunch(pz az.Principal, clusterId int64) error
+	SetClusterIdleTimeout(pz az.Principal, clusterId int64, minutes int64) error
+	KeepAlive(pz az.Principal, clusterId int64) error
+	GetClusterMetrics(pz az.Principal, clusterId int64, from int64, to int64, step int64) ([]*ClusterMetric, error)
+	SyncCluster(pz az.Principal, clusterId int64) (*ClusterSync, error)
+	CreateClusterTemplate(pz az.Principal, name string, engineId int64, size int, memory string, driverArgs string, queue string, idleTimeout int64, tags string, maxClusters int) (int64, error)
+	GetClusterTemplate(pz az.Principal, templateId int64) (*ClusterTemplate, error)
+	GetClusterTemplates(pz az.Principal, offset int64, limit int64) ([]*ClusterTemplate, error)
+	UpdateClusterTemplate(pz az.Principal, templateId int64, name string, engineId int64, size int, memory string, driverArgs string, queue string, idleTimeout int64, tags string, maxClusters int) error
+	DeleteClusterTemplate(pz az.Principal, templateId int64) error
+	StartClusterFromTemplate(pz az.Principal, templateId int64, clusterName string, keytabId int64) (int64, error)
+	GetKeytabs(pz az.Principal) ([]*Keytab, error)
+	DeleteKeytab(pz az.Principal, keytabId int64) error
+	GetClusterProxyPolicy(pz az.Principal, clusterId int64) (string, error)
+	SetClusterProxyPolicy(pz az.Principal, clusterId int64, policy string) error
 	GetCluster(pz az.Principal, clusterId int64) (*Cluster, error)
 	GetClusterOnYarn(pz az.Principal, clusterId int64) (*YarnCluster, error)
 	GetClusters(pz az.Principal, offset int64, limit int64) ([]*Cluster, error)
 	GetClusterStatus(pz az.Principal, clusterId int64) (*ClusterStatus, error)
 	DeleteCluster(pz az.Principal, clusterId int64) error
 	GetJob(pz az.Principal, clusterId int64, jobName string) (*Job, error)
+	CancelJob(pz az.Principal, clusterId int64, jobName string) error
 	GetJobs(pz az.Principal, clusterId int64) ([]*Job, error)
 	CreateProject(pz az.Principal, name string, description string, modelCategory string) (int64, error)
 	GetProjects(pz az.Principal, offset int64, limit int64) ([]*Project, error)
 	GetProject(pz az.Principal, projectId int64) (*Project, error)
 	DeleteProject(pz az.Principal, projectId int64) error
-	CreateDatasource(pz az.Principal, projectId int64, name string, description string, path string) (int64, error)
+	CreateDatasource(pz az.Principal, projectId int64, name string, description string, kind string, configuration string) (int64, error)
 	GetDatasources(pz az.Principal, projectId int64, offset int64, limit int64) ([]*Datasource, error)
 	GetDatasource(pz az.Principal, datasourceId int64) (*Datasource, error)
-	UpdateDatasource(pz az.Principal, datasourceId int64, name string, description string, path string) error
+	UpdateDatasource(pz az.Principal, datasourceId int64, name string, description string, kind string, configuration string) error
 	DeleteDatasource(pz az.Principal, datasourceId int64) error
-	CreateDataset(pz az.Principal, clusterId int64, datasourceId int64, name string, description string, responseColumnName string) (int64, error)
+	TestDatasource(pz az.Principal, datasourceId int64, clusterId int64) (string, error)
+	CreateDataset(pz az.Principal, clusterId int64, datasourceId int64, name string, description string, responseColumnName string, compareWithPrevious bool) (int64, error)
+	GetDatasetJob(pz az.Principal, jobId int64) (*DatasetJob, error)
+	GetDatasetJobs(pz az.Principal, datasourceId int64, offset int64, limit int64) ([]*DatasetJob, error)
+	CancelDatasetJob(pz az.Principal, jobId int64) error
+	ReimportDataset(pz az.Principal, datasetId int64) (int64, error)
 	GetDatasets(pz az.Principal, datasourceId int64, offset int64, limit int64) ([]*Dataset, error)
 	GetDataset(pz az.Principal, datasetId int64) (*Dataset, error)
+	GetDatasetColumns(pz az.Principal, datasetId int64, refresh bool) ([]*DatasetColumn, error)
+	GetDatasetColumnRoles(pz az.Principal, datasetId int64) (*DatasetColumnRoles, error)
+	SetDatasetColumnRole(pz az.Principal, datasetId int64, columnName string, role string) error
+	PreviewDataset(pz az.Principal, datasetId int64, offset int64, rowCount int64, columns []string) (*DatasetPreview, error)
+	GetDatasetVersions(pz az.Principal, datasourceId int64) ([]*Dataset, error)
 	GetDatasetsFromCluster(pz az.Principal, clusterId int64) ([]*Dataset, error)
+	CompareDatasets(pz az.Principal, baseDatasetId int64, otherDatasetId int64) (*DatasetComparison, error)
+	GetDatasetComparison(pz az.Principal, datasetId int64) (*DatasetComparison, error)
 	UpdateDataset(pz az.Principal, datasetId int64, name string, description string, responseColumnName string) error
-	SplitDataset(pz az.Principal, datasetId int64, ratio1 int, ratio2 int) ([]int64, error)
+	SplitDataset(pz az.Principal, datasetId int64, ratio1 int, ratio2 int, seed int64) ([]int64, error)
 	DeleteDataset(pz az.Principal, datasetId int64) error
 	BuildModel(pz az.Principal, clusterId int64, datasetId int64, algorithm string) (int64, error)
 	BuildModelAuto(pz az.Principal, clusterId int64, dataset string, targetName string, maxRunTime int) (*Model, error)
@@ -377,6 +609,7 @@ type Service interface {
 	GetWorkgroup(pz az.Principal, workgroupId int64) (*Workgroup, error)
 	GetWorkgroupByName(pz az.Principal, name string) (*Workgroup, error)
 	UpdateWorkgroup(pz az.Principal, workgroupId int64, name string, description string) error
+	SetWorkgroupIdleTimeout(pz az.Principal, workgroupId int64, minutes int64) error
 	DeleteWorkgroup(pz az.Principal, workgroupId int64) error
 	CreateIdentity(pz az.Principal, name string, password string) (int64, error)
 	GetIdentities(pz az.Principal, offset int64, limit int64) ([]*Identity, error)
@@ -424,7 +657,11 @@ type GetConfigOut struct {
 }
 
 type RegisterClusterIn struct {
-	Address string `json:"address"`
+	Address  string `json:"address"`
+	Scheme   string `json:"scheme"`
+	CaCert   string `json:"ca_cert"`
+	Username string `json:"username"`
+	Password string `json:"password"`
 }
 
 type RegisterClusterOut struct {
@@ -438,12 +675,35 @@ type UnregisterClusterIn struct {
 type UnregisterClusterOut struct {
 }
 
+type StartClusterIn struct {
+	ClusterName string `json:"cluster_name"`
+	ClusterType string `json:"cluster_type"`
+	EngineId    int64  `json:"engine_id"`
+	Size        int    `json:"size"`
+	Memory      string `json:"memory"`
+	KeytabId    int64  `json:"keytab_id"`
+}
+
+type StartClusterOut struct {
+	ClusterId int64 `json:"cluster_id"`
+}
+
+type StopClusterIn struct {
+	ClusterId int64 `json:"cluster_id"`
+}
+
+type StopClusterOut struct {
+}
+
 type StartClusterOnYarnIn struct {
 	ClusterName string `json:"cluster_name"`
 	EngineId    int64  `json:"engine_id"`
 	Size        int    `json:"size"`
 	Memory      string `json:"memory"`
-	Keytab      string `json:"keytab"`
+	KeytabId    int64  `json:"keytab_id"`
+	Queue       string `json:"queue"`
+	NodeLabel   string `json:"node_label"`
+	DriverArgs  string `json:"driver_args"`
 }
 
 type StartClusterOnYarnOut struct {
@@ -451,13 +711,170 @@ type StartClusterOnYarnOut struct {
 }
 
 type StopClusterOnYarnIn struct {
-	ClusterId int64  `json:"cluster_id"`
-	Keytab    string `json:"keytab"`
+	ClusterId int64 `json:"cluster_id"`
+	KeytabId  int64 `json:"keytab_id"`
 }
 
 type StopClusterOnYarnOut struct {
 }
 
+type GetClusterLaunchIn struct {
+	ClusterId int64 `json:"cluster_id"`
+	LogLines  int   `json:"log_lines"`
+}
+
+type GetClusterLaunchOut struct {
+	Launch *ClusterLaunch `json:"launch"`
+}
+
+type GetClusterLogsIn struct {
+	ClusterId   int64  `json:"cluster_id"`
+	ContainerId string `json:"container_id"`
+	TailLines   int    `json:"tail_lines"`
+	Offset      int64  `json:"offset"`
+}
+
+type GetClusterLogsOut struct {
+	Logs *ClusterLogs `json:"logs"`
+}
+
+type CancelClusterLaunchIn struct {
+	ClusterId int64 `json:"cluster_id"`
+}
+
+type CancelClusterLaunchOut struct {
+}
+
+type SetClusterIdleTimeoutIn struct {
+	ClusterId int64 `json:"cluster_id"`
+	Minutes   int64 `json:"minutes"`
+}
+
+type SetClusterIdleTimeoutOut struct {
+}
+
+type KeepAliveIn struct {
+	ClusterId int64 `json:"cluster_id"`
+}
+
+type KeepAliveOut struct {
+}
+
+type GetClusterMetricsIn struct {
+	ClusterId int64 `json:"cluster_id"`
+	From      int64 `json:"from"`
+	To        int64 `json:"to"`
+	Step      int64 `json:"step"`
+}
+
+type GetClusterMetricsOut struct {
+	Metrics []*ClusterMetric `json:"metrics"`
+}
+
+type SyncClusterIn struct {
+	ClusterId int64 `json:"cluster_id"`
+}
+
+type SyncClusterOut struct {
+	Sync *ClusterSync `json:"sync"`
+}
+
+type CreateClusterTemplateIn struct {
+	Name        string `json:"name"`
+	EngineId    int64  `json:"engine_id"`
+	Size        int    `json:"size"`
+	Memory      string `json:"memory"`
+	DriverArgs  string `json:"driver_args"`
+	Queue       string `json:"queue"`
+	IdleTimeout int64  `json:"idle_timeout"`
+	Tags        string `json:"tags"`
+	MaxClusters int    `json:"max_clusters"`
+}
+
+type CreateClusterTemplateOut struct {
+	TemplateId int64 `json:"template_id"`
+}
+
+type GetClusterTemplateIn struct {
+	TemplateId int64 `json:"template_id"`
+}
+
+type GetClusterTemplateOut struct {
+	Template *ClusterTemplate `json:"template"`
+}
+
+type GetClusterTemplatesIn struct {
+	Offset int64 `json:"offset"`
+	Limit  int64 `json:"limit"`
+}
+
+type GetClusterTemplatesOut struct {
+	Templates []*ClusterTemplate `json:"templates"`
+}
+
+type UpdateClusterTemplateIn struct {
+	TemplateId  int64  `json:"template_id"`
+	Name        string `json:"name"`
+	EngineId    int64  `json:"engine_id"`
+	Size        int    `json:"size"`
+	Memory      string `json:"memory"`
+	DriverArgs  string `json:"driver_args"`
+	Queue       string `json:"queue"`
+	IdleTimeout int64  `json:"idle_timeout"`
+	Tags        string `json:"tags"`
+	MaxClusters int    `json:"max_clusters"`
+}
+
+type UpdateClusterTemplateOut struct {
+}
+
+type DeleteClusterTemplateIn struct {
+	TemplateId int64 `json:"template_id"`
+}
+
+type DeleteClusterTemplateOut struct {
+}
+
+type StartClusterFromTemplateIn struct {
+	TemplateId  int64  `json:"template_id"`
+	ClusterName string `json:"cluster_name"`
+	KeytabId    int64  `json:"keytab_id"`
+}
+
+type StartClusterFromTemplateOut struct {
+	ClusterId int64 `json:"cluster_id"`
+}
+
+type GetKeytabsIn struct {
+}
+
+type GetKeytabsOut struct {
+	Keytabs []*Keytab `json:"keytabs"`
+}
+
+type DeleteKeytabIn struct {
+	KeytabId int64 `json:"keytab_id"`
+}
+
+type DeleteKeytabOut struct {
+}
+
+type GetClusterProxyPolicyIn struct {
+	ClusterId int64 `json:"cluster_id"`
+}
+
+type GetClusterProxyPolicyOut struct {
+	Policy string `json:"policy"`
+}
+
+type SetClusterProxyPolicyIn struct {
+	ClusterId int64  `json:"cluster_id"`
+	Policy    string `json:"policy"`
+}
+
+type SetClusterProxyPolicyOut struct {
+}
+
 type GetClusterIn struct {
 	ClusterId int64 `json:"cluster_id"`
 }
@@ -507,6 +924,14 @@ type GetJobOut struct {
 	Job *Job `json:"job"`
 }
 
+type CancelJobIn struct {
+	ClusterId int64  `json:"cluster_id"`
+	JobName   string `json:"job_name"`
+}
+
+type CancelJobOut struct {
+}
+
 type GetJobsIn struct {
 	ClusterId int64 `json:"cluster_id"`
 }
@@ -550,10 +975,11 @@ type DeleteProjectOut struct {
 }
 
 type CreateDatasourceIn struct {
-	ProjectId   int64  `json:"project_id"`
-	Name        string `json:"name"`
-	Description string `json:"description"`
-	Path        string `json:"path"`
+	ProjectId     int64  `json:"project_id"`
+	Name          string `json:"name"`
+	Description   string `json:"description"`
+	Kind          string `json:"kind"`
+	Configuration string `json:"configuration"`
 }
 
 type CreateDatasourceOut struct {
@@ -579,10 +1005,11 @@ type GetDatasourceOut struct {
 }
 
 type UpdateDatasourceIn struct {
-	DatasourceId int64  `json:"datasource_id"`
-	Name         string `json:"name"`
-	Description  string `json:"description"`
-	Path         string `json:"path"`
+	DatasourceId  int64  `json:"datasource_id"`
+	Name          string `json:"name"`
+	Description   string `json:"description"`
+	Kind          string `json:"kind"`
+	Configuration string `json:"configuration"`
 }
 
 type UpdateDatasourceOut struct {
@@ -595,18 +1022,61 @@ type DeleteDatasourceIn struct {
 type DeleteDatasourceOut struct {
 }
 
+type TestDatasourceIn struct {
+	DatasourceId int64 `json:"datasource_id"`
+	ClusterId    int64 `json:"cluster_id"`
+}
+
+type TestDatasourceOut struct {
+	Message string `json:"message"`
+}
+
 type CreateDatasetIn struct {
-	ClusterId          int64  `json:"cluster_id"`
-	DatasourceId       int64  `json:"datasource_id"`
-	Name               string `json:"name"`
-	Description        string `json:"description"`
-	ResponseColumnName string `json:"response_column_name"`
+	ClusterId           int64  `json:"cluster_id"`
+	DatasourceId        int64  `json:"datasource_id"`
+	Name                string `json:"name"`
+	Description         string `json:"description"`
+	ResponseColumnName  string `json:"response_column_name"`
+	CompareWithPrevious bool   `json:"compare_with_previous"`
 }
 
 type CreateDatasetOut struct {
+	JobId int64 `json:"job_id"`
+}
+
+type GetDatasetJobIn struct {
+	JobId int64 `json:"job_id"`
+}
+
+type GetDatasetJobOut struct {
+	DatasetJob *DatasetJob `json:"dataset_job"`
+}
+
+type GetDatasetJobsIn struct {
+	DatasourceId int64 `json:"datasource_id"`
+	Offset       int64 `json:"offset"`
+	Limit        int64 `json:"limit"`
+}
+
+type GetDatasetJobsOut struct {
+	DatasetJobs []*DatasetJob `json:"dataset_jobs"`
+}
+
+type CancelDatasetJobIn struct {
+	JobId int64 `json:"job_id"`
+}
+
+type CancelDatasetJobOut struct {
+}
+
+type ReimportDatasetIn struct {
 	DatasetId int64 `json:"dataset_id"`
 }
 
+type ReimportDatasetOut struct {
+	JobId int64 `json:"job_id"`
+}
+
 type GetDatasetsIn struct {
 	DatasourceId int64 `json:"datasource_id"`
 	Offset       int64 `json:"offset"`
@@ -625,6 +1095,51 @@ type GetDatasetOut struct {
 	Dataset *Dataset `json:"dataset"`
 }
 
+type GetDatasetColumnsIn struct {
+	DatasetId int64 `json:"dataset_id"`
+	Refresh   bool  `json:"refresh"`
+}
+
+type GetDatasetColumnsOut struct {
+	Columns []*DatasetColumn `json:"columns"`
+}
+
+type GetDatasetColumnRolesIn struct {
+	DatasetId int64 `json:"dataset_id"`
+}
+
+type GetDatasetColumnRolesOut struct {
+	Roles *DatasetColumnRoles `json:"roles"`
+}
+
+type SetDatasetColumnRoleIn struct {
+	DatasetId  int64  `json:"dataset_id"`
+	ColumnName string `json:"column_name"`
+	Role       string `json:"role"`
+}
+
+type SetDatasetColumnRoleOut struct {
+}
+
+type PreviewDatasetIn struct {
+	DatasetId int64    `json:"dataset_id"`
+	Offset    int64    `json:"offset"`
+	RowCount  int64    `json:"row_count"`
+	Columns   []string `json:"columns"`
+}
+
+type PreviewDatasetOut struct {
+	Preview *DatasetPreview `json:"preview"`
+}
+
+type GetDatasetVersionsIn struct {
+	DatasourceId int64 `json:"datasource_id"`
+}
+
+type GetDatasetVersionsOut struct {
+	Datasets []*Dataset `json:"datasets"`
+}
+
 type GetDatasetsFromClusterIn struct {
 	ClusterId int64 `json:"cluster_id"`
 }
@@ -633,6 +1148,23 @@ type GetDatasetsFromClusterOut struct {
 	Dataset []*Dataset `json:"dataset"`
 }
 
+type CompareDatasetsIn struct {
+	BaseDatasetId  int64 `json:"base_dataset_id"`
+	OtherDatasetId int64 `json:"other_dataset_id"`
+}
+
+type CompareDatasetsOut struct {
+	Comparison *DatasetComparison `json:"comparison"`
+}
+
+type GetDatasetComparisonIn struct {
+	DatasetId int64 `json:"dataset_id"`
+}
+
+type GetDatasetComparisonOut struct {
+	Comparison *DatasetComparison `json:"comparison"`
+}
+
 type UpdateDatasetIn struct {
 	DatasetId          int64  `json:"dataset_id"`
 	Name               string `json:"name"`
@@ -647,6 +1179,7 @@ type SplitDatasetIn struct {
 	DatasetId int64 `json:"dataset_id"`
 	Ratio1    int   `json:"ratio1"`
 	Ratio2    int   `json:"ratio2"`
+	Seed      int64 `json:"seed"`
 }
 
 type SplitDatasetOut struct {
@@ -1152,6 +1685,14 @@ type UpdateWorkgroupIn struct {
 type UpdateWorkgroupOut struct {
 }
 
+type SetWorkgroupIdleTimeoutIn struct {
+	WorkgroupId int64 `json:"workgroup_id"`
+	Minutes     int64 `json:"minutes"`
+}
+
+type SetWorkgroupIdleTimeoutOut struct {
+}
+
 type DeleteWorkgroupIn struct {
 	WorkgroupId int64 `json:"workgroup_id"`
 }
@@ -1422,8 +1963,8 @@ func (this *Remote) GetConfig() (*Config, error) {
 	return out.Config, nil
 }
 
-func (this *Remote) RegisterCluster(address string) (int64, error) {
-	in := RegisterClusterIn{address}
+func (this *Remote) RegisterCluster(address string, scheme string, caCert string, username string, password string) (int64, error) {
+	in := RegisterClusterIn{address, scheme, caCert, username, password}
 	var out RegisterClusterOut
 	err := this.Proc.Call("RegisterCluster", &in, &out)
 	if err != nil {
@@ -1442,37 +1983,227 @@ func (this *Remote) UnregisterCluster(clusterId int64) error {
 	return nil
 }
 
-func (this *Remote) StartClusterOnYarn(clusterName string, engineId int64, size int, memory string, keytab string) (int64, error) {
-	in := StartClusterOnYarnIn{clusterName, engineId, size, memory, keytab}
-	var out StartClusterOnYarnOut
-	err := this.Proc.Call("StartClusterOnYarn", &in, &out)
+func (this *Remote) StartCluster(clusterName string, clusterType string, engineId int64, size int, memory string, keytabId int64) (int64, error) {
+	in := StartClusterIn{clusterName, clusterType, engineId, size, memory, keytabId}
+	var out StartClusterOut
+	err := this.Proc.Call("StartCluster", &in, &out)
 	if err != nil {
 		return 0, err
 	}
 	return out.ClusterId, nil
 }
 
-func (this *Remote) StopClusterOnYarn(clusterId int64, keytab string) error {
-	in := StopClusterOnYarnIn{clusterId, keytab}
-	var out StopClusterOnYarnOut
-	err := this.Proc.Call("StopClusterOnYarn", &in, &out)
+func (this *Remote) StopCluster(clusterId int64) error {
+	in := StopClusterIn{clusterId}
+	var out StopClusterOut
+	err := this.Proc.Call("StopCluster", &in, &out)
 	if err != nil {
 		return err
 	}
 	return nil
 }
 
-func (this *Remote) GetCluster(clusterId int64) (*Cluster, error) {
-	in := GetClusterIn{clusterId}
-	var out GetClusterOut
-	err := this.Proc.Call("GetCluster", &in, &out)
+func (this *Remote) StartClusterOnYarn(clusterName string, engineId int64, size int, memory string, keytabId int64, queue string, nodeLabel string, driverArgs string) (int64, error) {
+	in := StartClusterOnYarnIn{clusterName, engineId, size, memory, keytabId, queue, nodeLabel, driverArgs}
+	var out StartClusterOnYarnOut
+	err := this.Proc.Call("StartClusterOnYarn", &in, &out)
 	if err != nil {
-		return nil, err
+		return 0, err
 	}
-	return out.Cluster, nil
+	return out.ClusterId, nil
 }
 
-func (this *Remote) GetClusterOnYarn(clusterId int64) (*YarnCluster, error) {
+func (this *Remote) StopClusterOnYarn(clusterId int64, keytabId int64) error {
+	in := StopClusterOnYarnIn{clusterId, keytabId}
+	var out StopClusterOnYarnOut
+	err := this.Proc.Call("StopClusterOnYarn", &in, &out)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (this *Remote) GetClusterLaunch(clusterId int64, logLines int) (*ClusterLaunch, error) {
+	in := GetClusterLaunchIn{clusterId, logLines}
+	var out GetClusterLaunchOut
+	err := this.Proc.Call("GetClusterLaunch", &in, &out)
+	if err != nil {
+		return nil, err
+	}
+	return out.Launch, nil
+}
+
+func (this *Remote) GetClusterLogs(clusterId int64, containerId string, tailLines int, offset int64) (*ClusterLogs, error) {
+	in := GetClusterLogsIn{clusterId, containerId, tailLines, offset}
+	var out GetClusterLogsOut
+	err := this.Proc.Call("GetClusterLogs", &in, &out)
+	if err != nil {
+		return nil, err
+	}
+	return out.Logs, nil
+}
+
+func (this *Remote) CancelClusterLaunch(clusterId int64) error {
+	in := CancelClusterLaunchIn{clusterId}
+	var out CancelClusterLaunchOut
+	err := this.Proc.Call("CancelClusterLaunch", &in, &out)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (this *Remote) SetClusterIdleTimeout(clusterId int64, minutes int64) error {
+	in := SetClusterIdleTimeoutIn{clusterId, minutes}
+	var out SetClusterIdleTimeoutOut
+	err := this.Proc.Call("SetClusterIdleTimeout", &in, &out)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (this *Remote) KeepAlive(clusterId int64) error {
+	in := KeepAliveIn{clusterId}
+	var out KeepAliveOut
+	err := this.Proc.Call("KeepAlive", &in, &out)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (this *Remote) GetClusterMetrics(clusterId int64, from int64, to int64, step int64) ([]*ClusterMetric, error) {
+	in := GetClusterMetricsIn{clusterId, from, to, step}
+	var out GetClusterMetricsOut
+	err := this.Proc.Call("GetClusterMetrics", &in, &out)
+	if err != nil {
+		return nil, err
+	}
+	return out.Metrics, nil
+}
+
+func (this *Remote) SyncCluster(clusterId int64) (*ClusterSync, error) {
+	in := SyncClusterIn{clusterId}
+	var out SyncClusterOut
+	err := this.Proc.Call("SyncCluster", &in, &out)
+	if err != nil {
+		return nil, err
+	}
+	return out.Sync, nil
+}
+
+func (this *Remote) CreateClusterTemplate(name string, engineId int64, size int, memory string, driverArgs string, queue string, idleTimeout int64, tags string, maxClusters int) (int64, error) {
+	in := CreateClusterTemplateIn{name, engineId, size, memory, driverArgs, queue, idleTimeout, tags, maxClusters}
+	var out CreateClusterTemplateOut
+	err := this.Proc.Call("CreateClusterTemplate", &in, &out)
+	if err != nil {
+		return 0, err
+	}
+	return out.TemplateId, nil
+}
+
+func (this *Remote) GetClusterTemplate(templateId int64) (*ClusterTemplate, error) {
+	in := GetClusterTemplateIn{templateId}
+	var out GetClusterTemplateOut
+	err := this.Proc.Call("GetClusterTemplate", &in, &out)
+	if err != nil {
+		return nil, err
+	}
+	return out.Template, nil
+}
+
+func (this *Remote) GetClusterTemplates(offset int64, limit int64) ([]*ClusterTemplate, error) {
+	in := GetClusterTemplatesIn{offset, limit}
+	var out GetClusterTemplatesOut
+	err := this.Proc.Call("GetClusterTemplates", &in, &out)
+	if err != nil {
+		return nil, err
+	}
+	return out.Templates, nil
+}
+
+func (this *Remote) UpdateClusterTemplate(templateId int64, name string, engineId int64, size int, memory string, driverArgs string, queue string, idleTimeout int64, tags string, maxClusters int) error {
+	in := UpdateClusterTemplateIn{templateId, name, engineId, size, memory, driverArgs, queue, idleTimeout, tags, maxClusters}
+	var out UpdateClusterTemplateOut
+	err := this.Proc.Call("UpdateClusterTemplate", &in, &out)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (this *Remote) DeleteClusterTemplate(templateId int64) error {
+	in := DeleteClusterTemplateIn{templateId}
+	var out DeleteClusterTemplateOut
+	err := this.Proc.Call("DeleteClusterTemplate", &in, &out)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (this *Remote) StartClusterFromTemplate(templateId int64, clusterName string, keytabId int64) (int64, error) {
+	in := StartClusterFromTemplateIn{templateId, clusterName, keytabId}
+	var out StartClusterFromTemplateOut
+	err := this.Proc.Call("StartClusterFromTemplate", &in, &out)
+	if err != nil {
+		return 0, err
+	}
+	return out.ClusterId, nil
+}
+
+func (this *Remote) GetKeytabs() ([]*Keytab, error) {
+	in := GetKeytabsIn{}
+	var out GetKeytabsOut
+	err := this.Proc.Call("GetKeytabs", &in, &out)
+	if err != nil {
+		return nil, err
+	}
+	return out.Keytabs, nil
+}
+
+func (this *Remote) DeleteKeytab(keytabId int64) error {
+	in := DeleteKeytabIn{keytabId}
+	var out DeleteKeytabOut
+	err := this.Proc.Call("DeleteKeytab", &in, &out)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (this *Remote) GetClusterProxyPolicy(clusterId int64) (string, error) {
+	in := GetClusterProxyPolicyIn{clusterId}
+	var out GetClusterProxyPolicyOut
+	err := this.Proc.Call("GetClusterProxyPolicy", &in, &out)
+	if err != nil {
+		return "", err
+	}
+	return out.Policy, nil
+}
+
+func (this *Remote) SetClusterProxyPolicy(clusterId int64, policy string) error {
+	in := SetClusterProxyPolicyIn{clusterId, policy}
+	var out SetClusterProxyPolicyOut
+	err := this.Proc.Call("SetClusterProxyPolicy", &in, &out)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (this *Remote) GetCluster(clusterId int64) (*Cluster, error) {
+	in := GetClusterIn{clusterId}
+	var out GetClusterOut
+	err := this.Proc.Call("GetCluster", &in, &out)
+	if err != nil {
+		return nil, err
+	}
+	return out.Cluster, nil
+}
+
+func (this *Remote) GetClusterOnYarn(clusterId int64) (*YarnCluster, error) {
 	in := GetClusterOnYarnIn{clusterId}
 	var out GetClusterOnYarnOut
 	err := this.Proc.Call("GetClusterOnYarn", &in, &out)
@@ -1522,6 +2253,16 @@ func (this *Remote) GetJob(clusterId int64, jobName string) (*Job, error) {
 	return out.Job, nil
 }
 
+func (this *Remote) CancelJob(clusterId int64, jobName string) error {
+	in := CancelJobIn{clusterId, jobName}
+	var out CancelJobOut
+	err := this.Proc.Call("CancelJob", &in, &out)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
 func (this *Remote) GetJobs(clusterId int64) ([]*Job, error) {
 	in := GetJobsIn{clusterId}
 	var out GetJobsOut
@@ -1572,8 +2313,8 @@ func (this *Remote) DeleteProject(projectId int64) error {
 	return nil
 }
 
-func (this *Remote) CreateDatasource(projectId int64, name string, description string, path string) (int64, error) {
-	in := CreateDatasourceIn{projectId, name, description, path}
+func (this *Remote) CreateDatasource(projectId int64, name string, description string, kind string, configuration string) (int64, error) {
+	in := CreateDatasourceIn{projectId, name, description, kind, configuration}
 	var out CreateDatasourceOut
 	err := this.Proc.Call("CreateDatasource", &in, &out)
 	if err != nil {
@@ -1602,8 +2343,8 @@ func (this *Remote) GetDatasource(datasourceId int64) (*Datasource, error) {
 	return out.Datasource, nil
 }
 
-func (this *Remote) UpdateDatasource(datasourceId int64, name string, description string, path string) error {
-	in := UpdateDatasourceIn{datasourceId, name, description, path}
+func (this *Remote) UpdateDatasource(datasourceId int64, name string, description string, kind string, configuration string) error {
+	in := UpdateDatasourceIn{datasourceId, name, description, kind, configuration}
 	var out UpdateDatasourceOut
 	err := this.Proc.Call("UpdateDatasource", &in, &out)
 	if err != nil {
@@ -1622,14 +2363,64 @@ func (this *Remote) DeleteDatasource(datasourceId int64) error {
 	return nil
 }
 
-func (this *Remote) CreateDataset(clusterId int64, datasourceId int64, name string, description string, responseColumnName string) (int64, error) {
-	in := CreateDatasetIn{clusterId, datasourceId, name, description, responseColumnName}
+func (this *Remote) TestDatasource(datasourceId int64, clusterId int64) (string, error) {
+	in := TestDatasourceIn{datasourceId, clusterId}
+	var out TestDatasourceOut
+	err := this.Proc.Call("TestDatasource", &in, &out)
+	if err != nil {
+		return "", err
+	}
+	return out.Message, nil
+}
+
+func (this *Remote) CreateDataset(clusterId int64, datasourceId int64, name string, description string, responseColumnName string, compareWithPrevious bool) (int64, error) {
+	in := CreateDatasetIn{clusterId, datasourceId, name, description, responseColumnName, compareWithPrevious}
 	var out CreateDatasetOut
 	err := this.Proc.Call("CreateDataset", &in, &out)
 	if err != nil {
 		return 0, err
 	}
-	return out.DatasetId, nil
+	return out.JobId, nil
+}
+
+func (this *Remote) GetDatasetJob(jobId int64) (*DatasetJob, error) {
+	in := GetDatasetJobIn{jobId}
+	var out GetDatasetJobOut
+	err := this.Proc.Call("GetDatasetJob", &in, &out)
+	if err != nil {
+		return nil, err
+	}
+	return out.DatasetJob, nil
+}
+
+func (this *Remote) GetDatasetJobs(datasourceId int64, offset int64, limit int64) ([]*DatasetJob, error) {
+	in := GetDatasetJobsIn{datasourceId, offset, limit}
+	var out GetDatasetJobsOut
+	err := this.Proc.Call("GetDatasetJobs", &in, &out)
+	if err != nil {
+		return nil, err
+	}
+	return out.DatasetJobs, nil
+}
+
+func (this *Remote) CancelDatasetJob(jobId int64) error {
+	in := CancelDatasetJobIn{jobId}
+	var out CancelDatasetJobOut
+	err := this.Proc.Call("CancelDatasetJob", &in, &out)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (this *Remote) ReimportDataset(datasetId int64) (int64, error) {
+	in := ReimportDatasetIn{datasetId}
+	var out ReimportDatasetOut
+	err := this.Proc.Call("ReimportDataset", &in, &out)
+	if err != nil {
+		return 0, err
+	}
+	return out.JobId, nil
 }
 
 func (this *Remote) GetDatasets(datasourceId int64, offset int64, limit int64) ([]*Dataset, error) {
@@ -1652,6 +2443,56 @@ func (this *Remote) GetDataset(datasetId int64) (*Dataset, error) {
 	return out.Dataset, nil
 }
 
+func (this *Remote) GetDatasetColumns(datasetId int64, refresh bool) ([]*DatasetColumn, error) {
+	in := GetDatasetColumnsIn{datasetId, refresh}
+	var out GetDatasetColumnsOut
+	err := this.Proc.Call("GetDatasetColumns", &in, &out)
+	if err != nil {
+		return nil, err
+	}
+	return out.Columns, nil
+}
+
+func (this *Remote) GetDatasetColumnRoles(datasetId int64) (*DatasetColumnRoles, error) {
+	in := GetDatasetColumnRolesIn{datasetId}
+	var out GetDatasetColumnRolesOut
+	err := this.Proc.Call("GetDatasetColumnRoles", &in, &out)
+	if err != nil {
+		return nil, err
+	}
+	return out.Roles, nil
+}
+
+func (this *Remote) SetDatasetColumnRole(datasetId int64, columnName string, role string) error {
+	in := SetDatasetColumnRoleIn{datasetId, columnName, role}
+	var out SetDatasetColumnRoleOut
+	err := this.Proc.Call("SetDatasetColumnRole", &in, &out)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (this *Remote) PreviewDataset(datasetId int64, offset int64, rowCount int64, columns []string) (*DatasetPreview, error) {
+	in := PreviewDatasetIn{datasetId, offset, rowCount, columns}
+	var out PreviewDatasetOut
+	err := this.Proc.Call("PreviewDataset", &in, &out)
+	if err != nil {
+		return nil, err
+	}
+	return out.Preview, nil
+}
+
+func (this *Remote) GetDatasetVersions(datasourceId int64) ([]*Dataset, error) {
+	in := GetDatasetVersionsIn{datasourceId}
+	var out GetDatasetVersionsOut
+	err := this.Proc.Call("GetDatasetVersions", &in, &out)
+	if err != nil {
+		return nil, err
+	}
+	return out.Datasets, nil
+}
+
 func (this *Remote) GetDatasetsFromCluster(clusterId int64) ([]*Dataset, error) {
 	in := GetDatasetsFromClusterIn{clusterId}
 	var out GetDatasetsFromClusterOut
@@ -1662,6 +2503,26 @@ func (this *Remote) GetDatasetsFromCluster(clusterId int64) ([]*Dataset, error)
 	return out.Dataset, nil
 }
 
+func (this *Remote) CompareDatasets(baseDatasetId int64, otherDatasetId int64) (*DatasetComparison, error) {
+	in := CompareDatasetsIn{baseDatasetId, otherDatasetId}
+	var out CompareDatasetsOut
+	err := this.Proc.Call("CompareDatasets", &in, &out)
+	if err != nil {
+		return nil, err
+	}
+	return out.Comparison, nil
+}
+
+func (this *Remote) GetDatasetComparison(datasetId int64) (*DatasetComparison, error) {
+	in := GetDatasetComparisonIn{datasetId}
+	var out GetDatasetComparisonOut
+	err := this.Proc.Call("GetDatasetComparison", &in, &out)
+	if err != nil {
+		return nil, err
+	}
+	return out.Comparison, nil
+}
+
 func (this *Remote) UpdateDataset(datasetId int64, name string, description string, responseColumnName string) error {
 	in := UpdateDatasetIn{datasetId, name, description, responseColumnName}
 	var out UpdateDatasetOut
@@ -1672,8 +2533,8 @@ func (this *Remote) UpdateDataset(datasetId int64, name string, description stri
 	return nil
 }
 
-func (this *Remote) SplitDataset(datasetId int64, ratio1 int, ratio2 int) ([]int64, error) {
-	in := SplitDatasetIn{datasetId, ratio1, ratio2}
+func (this *Remote) SplitDataset(datasetId int64, ratio1 int, ratio2 int, seed int64) ([]int64, error) {
+	in := SplitDatasetIn{datasetId, ratio1, ratio2, seed}
 	var out SplitDatasetOut
 	err := this.Proc.Call("SplitDataset", &in, &out)
 	if err != nil {
@@ -2272,6 +3133,16 @@ func (this *Remote) UpdateWorkgroup(workgroupId int64, name string, description
 	return nil
 }
 
+func (this *Remote) SetWorkgroupIdleTimeout(workgroupId int64, minutes int64) error {
+	in := SetWorkgroupIdleTimeoutIn{workgroupId, minutes}
+	var out SetWorkgroupIdleTimeoutOut
+	err := this.Proc.Call("SetWorkgroupIdleTimeout", &in, &out)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
 func (this *Remote) DeleteWorkgroup(workgroupId int64) error {
 	in := DeleteWorkgroupIn{workgroupId}
 	var out DeleteWorkgroupOut
@@ -2539,28 +3410,1091 @@ func (this *Remote) SetAttributesForPackage(projectId int64, packageName string,
 	if err != nil {
 		return err
 	}
-	return nil
-}
+	return nil
+}
+
+func (this *Remote) GetAttributesForPackage(projectId int64, packageName string) (string, error) {
+	in := GetAttributesForPackageIn{projectId, packageName}
+	var out GetAttributesForPackageOut
+	err := this.Proc.Call("GetAttributesForPackage", &in, &out)
+	if err != nil {
+		return "", err
+	}
+	return out.Attributes, nil
+}
+
+// --- Server Stub ---
+
+type Impl struct {
+	Service Service
+	Az      az.Az
+}
+
+func (this *Impl) PingServer(r *http.Request, in *PingServerIn, out *PingServerOut) error {
+	const name = "PingServer"
+
+	guid := xid.New().String()
+
+	pz, azerr := this.Az.Identify(r)
+	if azerr != nil {
+		return azerr
+	}
+
+	req, merr := json.Marshal(in)
+	if merr != nil {
+		log.Println(guid, "REQ", pz, name, merr)
+	} else {
+		log.Println(guid, "REQ", pz, name, string(req))
+	}
+
+	val0, err := this.Service.PingServer(pz, in.Input)
+	if err != nil {
+		log.Println(guid, "ERR", pz, name, err)
+		return err
+	}
+
+	out.Output = val0
+
+	res, merr := json.Marshal(out)
+	if merr != nil {
+		log.Println(guid, "RES", pz, name, merr)
+	} else {
+		log.Println(guid, "RES", pz, name, string(res))
+	}
+
+	return nil
+}
+
+func (this *Impl) GetConfig(r *http.Request, in *GetConfigIn, out *GetConfigOut) error {
+	const name = "GetConfig"
+
+	guid := xid.New().String()
+
+	pz, azerr := this.Az.Identify(r)
+	if azerr != nil {
+		return azerr
+	}
+
+	req, merr := json.Marshal(in)
+	if merr != nil {
+		log.Println(guid, "REQ", pz, name, merr)
+	} else {
+		log.Println(guid, "REQ", pz, name, string(req))
+	}
+
+	val0, err := this.Service.GetConfig(pz)
+	if err != nil {
+		log.Println(guid, "ERR", pz, name, err)
+		return err
+	}
+
+	out.Config = val0
+
+	res, merr := json.Marshal(out)
+	if merr != nil {
+		log.Println(guid, "RES", pz, name, merr)
+	} else {
+		log.Println(guid, "RES", pz, name, string(res))
+	}
+
+	return nil
+}
+
+func (this *Impl) RegisterCluster(r *http.Request, in *RegisterClusterIn, out *RegisterClusterOut) error {
+	const name = "RegisterCluster"
+
+	guid := xid.New().String()
+
+	pz, azerr := this.Az.Identify(r)
+	if azerr != nil {
+		return azerr
+	}
+
+	req, merr := json.Marshal(in)
+	if merr != nil {
+		log.Println(guid, "REQ", pz, name, merr)
+	} else {
+		log.Println(guid, "REQ", pz, name, string(req))
+	}
+
+	val0, err := this.Service.RegisterCluster(pz, in.Address, in.Scheme, in.CaCert, in.Username, in.Password)
+	if err != nil {
+		log.Println(guid, "ERR", pz, name, err)
+		return err
+	}
+
+	out.ClusterId = val0
+
+	res, merr := json.Marshal(out)
+	if merr != nil {
+		log.Println(guid, "RES", pz, name, merr)
+	} else {
+		log.Println(guid, "RES", pz, name, string(res))
+	}
+
+	return nil
+}
+
+func (this *Impl) UnregisterCluster(r *http.Request, in *UnregisterClusterIn, out *UnregisterClusterOut) error {
+	const name = "UnregisterCluster"
+
+	guid := xid.New().String()
+
+	pz, azerr := this.Az.Identify(r)
+	if azerr != nil {
+		return azerr
+	}
+
+	req, merr := json.Marshal(in)
+	if merr != nil {
+		log.Println(guid, "REQ", pz, name, merr)
+	} else {
+		log.Println(guid, "REQ", pz, name, string(req))
+	}
+
+	err := this.Service.UnregisterCluster(pz, in.ClusterId)
+	if err != nil {
+		log.Println(guid, "ERR", pz, name, err)
+		return err
+	}
+
+	res, merr := json.Marshal(out)
+	if merr != nil {
+		log.Println(guid, "RES", pz, name, merr)
+	} else {
+		log.Println(guid, "RES", pz, name, string(res))
+	}
+
+	return nil
+}
+
+func (this *Impl) StartCluster(r *http.Request, in *StartClusterIn, out *StartClusterOut) error {
+	const name = "StartCluster"
+
+	guid := xid.New().String()
+
+	pz, azerr := this.Az.Identify(r)
+	if azerr != nil {
+		return azerr
+	}
+
+	req, merr := json.Marshal(in)
+	if merr != nil {
+		log.Println(guid, "REQ", pz, name, merr)
+	} else {
+		log.Println(guid, "REQ", pz, name, string(req))
+	}
+
+	val0, err := this.Service.StartCluster(pz, in.ClusterName, in.ClusterType, in.EngineId, in.Size, in.Memory, in.KeytabId)
+	if err != nil {
+		log.Println(guid, "ERR", pz, name, err)
+		return err
+	}
+
+	out.ClusterId = val0
+
+	res, merr := json.Marshal(out)
+	if merr != nil {
+		log.Println(guid, "RES", pz, name, merr)
+	} else {
+		log.Println(guid, "RES", pz, name, string(res))
+	}
+
+	return nil
+}
+
+func (this *Impl) StopCluster(r *http.Request, in *StopClusterIn, out *StopClusterOut) error {
+	const name = "StopCluster"
+
+	guid := xid.New().String()
+
+	pz, azerr := this.Az.Identify(r)
+	if azerr != nil {
+		return azerr
+	}
+
+	req, merr := json.Marshal(in)
+	if merr != nil {
+		log.Println(guid, "REQ", pz, name, merr)
+	} else {
+		log.Println(guid, "REQ", pz, name, string(req))
+	}
+
+	err := this.Service.StopCluster(pz, in.ClusterId)
+	if err != nil {
+		log.Println(guid, "ERR", pz, name, err)
+		return err
+	}
+
+	res, merr := json.Marshal(out)
+	if merr != nil {
+		log.Println(guid, "RES", pz, name, merr)
+	} else {
+		log.Println(guid, "RES", pz, name, string(res))
+	}
+
+	return nil
+}
+
+func (this *Impl) StartClusterOnYarn(r *http.Request, in *StartClusterOnYarnIn, out *StartClusterOnYarnOut) error {
+	const name = "StartClusterOnYarn"
+
+	guid := xid.New().String()
+
+	pz, azerr := this.Az.Identify(r)
+	if azerr != nil {
+		return azerr
+	}
+
+	req, merr := json.Marshal(in)
+	if merr != nil {
+		log.Println(guid, "REQ", pz, name, merr)
+	} else {
+		log.Println(guid, "REQ", pz, name, string(req))
+	}
+
+	val0, err := this.Service.StartClusterOnYarn(pz, in.ClusterName, in.EngineId, in.Size, in.Memory, in.KeytabId, in.Queue, in.NodeLabel, in.DriverArgs)
+	if err != nil {
+		log.Println(guid, "ERR", pz, name, err)
+		return err
+	}
+
+	out.ClusterId = val0
+
+	res, merr := json.Marshal(out)
+	if merr != nil {
+		log.Println(guid, "RES", pz, name, merr)
+	} else {
+		log.Println(guid, "RES", pz, name, string(res))
+	}
+
+	return nil
+}
+
+func (this *Impl) StopClusterOnYarn(r *http.Request, in *StopClusterOnYarnIn, out *StopClusterOnYarnOut) error {
+	const name = "StopClusterOnYarn"
+
+	guid := xid.New().String()
+
+	pz, azerr := this.Az.Identify(r)
+	if azerr != nil {
+		return azerr
+	}
+
+	req, merr := json.Marshal(in)
+	if merr != nil {
+		log.Println(guid, "REQ", pz, name, merr)
+	} else {
+		log.Println(guid, "REQ", pz, name, string(req))
+	}
+
+	err := this.Service.StopClusterOnYarn(pz, in.ClusterId, in.KeytabId)
+	if err != nil {
+		log.Println(guid, "ERR", pz, name, err)
+		return err
+	}
+
+	res, merr := json.Marshal(out)
+	if merr != nil {
+		log.Println(guid, "RES", pz, name, merr)
+	} else {
+		log.Println(guid, "RES", pz, name, string(res))
+	}
+
+	return nil
+}
+
+func (this *Impl) GetClusterLaunch(r *http.Request, in *GetClusterLaunchIn, out *GetClusterLaunchOut) error {
+	const name = "GetClusterLaunch"
+
+	guid := xid.New().String()
+
+	pz, azerr := this.Az.Identify(r)
+	if azerr != nil {
+		return azerr
+	}
+
+	req, merr := json.Marshal(in)
+	if merr != nil {
+		log.Println(guid, "REQ", pz, name, merr)
+	} else {
+		log.Println(guid, "REQ", pz, name, string(req))
+	}
+
+	val0, err := this.Service.GetClusterLaunch(pz, in.ClusterId, in.LogLines)
+	if err != nil {
+		log.Println(guid, "ERR", pz, name, err)
+		return err
+	}
+
+	out.Launch = val0
+
+	res, merr := json.Marshal(out)
+	if merr != nil {
+		log.Println(guid, "RES", pz, name, merr)
+	} else {
+		log.Println(guid, "RES", pz, name, string(res))
+	}
+
+	return nil
+}
+
+func (this *Impl) GetClusterLogs(r *http.Request, in *GetClusterLogsIn, out *GetClusterLogsOut) error {
+	const name = "GetClusterLogs"
+
+	guid := xid.New().String()
+
+	pz, azerr := this.Az.Identify(r)
+	if azerr != nil {
+		return azerr
+	}
+
+	req, merr := json.Marshal(in)
+	if merr != nil {
+		log.Println(guid, "REQ", pz, name, merr)
+	} else {
+		log.Println(guid, "REQ", pz, name, string(req))
+	}
+
+	val0, err := this.Service.GetClusterLogs(pz, in.ClusterId, in.ContainerId, in.TailLines, in.Offset)
+	if err != nil {
+		log.Println(guid, "ERR", pz, name, err)
+		return err
+	}
+
+	out.Logs = val0
+
+	res, merr := json.Marshal(out)
+	if merr != nil {
+		log.Println(guid, "RES", pz, name, merr)
+	} else {
+		log.Println(guid, "RES", pz, name, string(res))
+	}
+
+	return nil
+}
+
+func (this *Impl) CancelClusterLaunch(r *http.Request, in *CancelClusterLaunchIn, out *CancelClusterLaunchOut) error {
+	const name = "CancelClusterLaunch"
+
+	guid := xid.New().String()
+
+	pz, azerr := this.Az.Identify(r)
+	if azerr != nil {
+		return azerr
+	}
+
+	req, merr := json.Marshal(in)
+	if merr != nil {
+		log.Println(guid, "REQ", pz, name, merr)
+	} else {
+		log.Println(guid, "REQ", pz, name, string(req))
+	}
+
+	err := this.Service.CancelClusterLaunch(pz, in.ClusterId)
+	if err != nil {
+		log.Println(guid, "ERR", pz, name, err)
+		return err
+	}
+
+	res, merr := json.Marshal(out)
+	if merr != nil {
+		log.Println(guid, "RES", pz, name, merr)
+	} else {
+		log.Println(guid, "RES", pz, name, string(res))
+	}
+
+	return nil
+}
+
+func (this *Impl) SetClusterIdleTimeout(r *http.Request, in *SetClusterIdleTimeoutIn, out *SetClusterIdleTimeoutOut) error {
+	const name = "SetClusterIdleTimeout"
+
+	guid := xid.New().String()
+
+	pz, azerr := this.Az.Identify(r)
+	if azerr != nil {
+		return azerr
+	}
+
+	req, merr := json.Marshal(in)
+	if merr != nil {
+		log.Println(guid, "REQ", pz, name, merr)
+	} else {
+		log.Println(guid, "REQ", pz, name, string(req))
+	}
+
+	err := this.Service.SetClusterIdleTimeout(pz, in.ClusterId, in.Minutes)
+	if err != nil {
+		log.Println(guid, "ERR", pz, name, err)
+		return err
+	}
+
+	res, merr := json.Marshal(out)
+	if merr != nil {
+		log.Println(guid, "RES", pz, name, merr)
+	} else {
+		log.Println(guid, "RES", pz, name, string(res))
+	}
+
+	return nil
+}
+
+func (this *Impl) KeepAlive(r *http.Request, in *KeepAliveIn, out *KeepAliveOut) error {
+	const name = "KeepAlive"
+
+	guid := xid.New().String()
+
+	pz, azerr := this.Az.Identify(r)
+	if azerr != nil {
+		return azerr
+	}
+
+	req, merr := json.Marshal(in)
+	if merr != nil {
+		log.Println(guid, "REQ", pz, name, merr)
+	} else {
+		log.Println(guid, "REQ", pz, name, string(req))
+	}
+
+	err := this.Service.KeepAlive(pz, in.ClusterId)
+	if err != nil {
+		log.Println(guid, "ERR", pz, name, err)
+		return err
+	}
+
+	res, merr := json.Marshal(out)
+	if merr != nil {
+		log.Println(guid, "RES", pz, name, merr)
+	} else {
+		log.Println(guid, "RES", pz, name, string(res))
+	}
+
+	return nil
+}
+
+func (this *Impl) GetClusterMetrics(r *http.Request, in *GetClusterMetricsIn, out *GetClusterMetricsOut) error {
+	const name = "GetClusterMetrics"
+
+	guid := xid.New().String()
+
+	pz, azerr := this.Az.Identify(r)
+	if azerr != nil {
+		return azerr
+	}
+
+	req, merr := json.Marshal(in)
+	if merr != nil {
+		log.Println(guid, "REQ", pz, name, merr)
+	} else {
+		log.Println(guid, "REQ", pz, name, string(req))
+	}
+
+	val0, err := this.Service.GetClusterMetrics(pz, in.ClusterId, in.From, in.To, in.Step)
+	if err != nil {
+		log.Println(guid, "ERR", pz, name, err)
+		return err
+	}
+
+	out.Metrics = val0
+
+	res, merr := json.Marshal(out)
+	if merr != nil {
+		log.Println(guid, "RES", pz, name, merr)
+	} else {
+		log.Println(guid, "RES", pz, name, string(res))
+	}
+
+	return nil
+}
+
+func (this *Impl) SyncCluster(r *http.Request, in *SyncClusterIn, out *SyncClusterOut) error {
+	const name = "SyncCluster"
+
+	guid := xid.New().String()
+
+	pz, azerr := this.Az.Identify(r)
+	if azerr != nil {
+		return azerr
+	}
+
+	req, merr := json.Marshal(in)
+	if merr != nil {
+		log.Println(guid, "REQ", pz, name, merr)
+	} else {
+		log.Println(guid, "REQ", pz, name, string(req))
+	}
+
+	val0, err := this.Service.SyncCluster(pz, in.ClusterId)
+	if err != nil {
+		log.Println(guid, "ERR", pz, name, err)
+		return err
+	}
+
+	out.Sync = val0
+
+	res, merr := json.Marshal(out)
+	if merr != nil {
+		log.Println(guid, "RES", pz, name, merr)
+	} else {
+		log.Println(guid, "RES", pz, name, string(res))
+	}
+
+	return nil
+}
+
+func (this *Impl) CreateClusterTemplate(r *http.Request, in *CreateClusterTemplateIn, out *CreateClusterTemplateOut) error {
+	const name = "CreateClusterTemplate"
+
+	guid := xid.New().String()
+
+	pz, azerr := this.Az.Identify(r)
+	if azerr != nil {
+		return azerr
+	}
+
+	req, merr := json.Marshal(in)
+	if merr != nil {
+		log.Println(guid, "REQ", pz, name, merr)
+	} else {
+		log.Println(guid, "REQ", pz, name, string(req))
+	}
+
+	val0, err := this.Service.CreateClusterTemplate(pz, in.Name, in.EngineId, in.Size, in.Memory, in.DriverArgs, in.Queue, in.IdleTimeout, in.Tags, in.MaxClusters)
+	if err != nil {
+		log.Println(guid, "ERR", pz, name, err)
+		return err
+	}
+
+	out.TemplateId = val0
+
+	res, merr := json.Marshal(out)
+	if merr != nil {
+		log.Println(guid, "RES", pz, name, merr)
+	} else {
+		log.Println(guid, "RES", pz, name, string(res))
+	}
+
+	return nil
+}
+
+func (this *Impl) GetClusterTemplate(r *http.Request, in *GetClusterTemplateIn, out *GetClusterTemplateOut) error {
+	const name = "GetClusterTemplate"
+
+	guid := xid.New().String()
+
+	pz, azerr := this.Az.Identify(r)
+	if azerr != nil {
+		return azerr
+	}
+
+	req, merr := json.Marshal(in)
+	if merr != nil {
+		log.Println(guid, "REQ", pz, name, merr)
+	} else {
+		log.Println(guid, "REQ", pz, name, string(req))
+	}
+
+	val0, err := this.Service.GetClusterTemplate(pz, in.TemplateId)
+	if err != nil {
+		log.Println(guid, "ERR", pz, name, err)
+		return err
+	}
+
+	out.Template = val0
+
+	res, merr := json.Marshal(out)
+	if merr != nil {
+		log.Println(guid, "RES", pz, name, merr)
+	} else {
+		log.Println(guid, "RES", pz, name, string(res))
+	}
+
+	return nil
+}
+
+func (this *Impl) GetClusterTemplates(r *http.Request, in *GetClusterTemplatesIn, out *GetClusterTemplatesOut) error {
+	const name = "GetClusterTemplates"
+
+	guid := xid.New().String()
+
+	pz, azerr := this.Az.Identify(r)
+	if azerr != nil {
+		return azerr
+	}
+
+	req, merr := json.Marshal(in)
+	if merr != nil {
+		log.Println(guid, "REQ", pz, name, merr)
+	} else {
+		log.Println(guid, "REQ", pz, name, string(req))
+	}
+
+	val0, err := this.Service.GetClusterTemplates(pz, in.Offset, in.Limit)
+	if err != nil {
+		log.Println(guid, "ERR", pz, name, err)
+		return err
+	}
+
+	out.Templates = val0
+
+	res, merr := json.Marshal(out)
+	if merr != nil {
+		log.Println(guid, "RES", pz, name, merr)
+	} else {
+		log.Println(guid, "RES", pz, name, string(res))
+	}
+
+	return nil
+}
+
+func (this *Impl) UpdateClusterTemplate(r *http.Request, in *UpdateClusterTemplateIn, out *UpdateClusterTemplateOut) error {
+	const name = "UpdateClusterTemplate"
+
+	guid := xid.New().String()
+
+	pz, azerr := this.Az.Identify(r)
+	if azerr != nil {
+		return azerr
+	}
+
+	req, merr := json.Marshal(in)
+	if merr != nil {
+		log.Println(guid, "REQ", pz, name, merr)
+	} else {
+		log.Println(guid, "REQ", pz, name, string(req))
+	}
+
+	err := this.Service.UpdateClusterTemplate(pz, in.TemplateId, in.Name, in.EngineId, in.Size, in.Memory, in.DriverArgs, in.Queue, in.IdleTimeout, in.Tags, in.MaxClusters)
+	if err != nil {
+		log.Println(guid, "ERR", pz, name, err)
+		return err
+	}
+
+	res, merr := json.Marshal(out)
+	if merr != nil {
+		log.Println(guid, "RES", pz, name, merr)
+	} else {
+		log.Println(guid, "RES", pz, name, string(res))
+	}
+
+	return nil
+}
+
+func (this *Impl) DeleteClusterTemplate(r *http.Request, in *DeleteClusterTemplateIn, out *DeleteClusterTemplateOut) error {
+	const name = "DeleteClusterTemplate"
+
+	guid := xid.New().String()
+
+	pz, azerr := this.Az.Identify(r)
+	if azerr != nil {
+		return azerr
+	}
+
+	req, merr := json.Marshal(in)
+	if merr != nil {
+		log.Println(guid, "REQ", pz, name, merr)
+	} else {
+		log.Println(guid, "REQ", pz, name, string(req))
+	}
+
+	err := this.Service.DeleteClusterTemplate(pz, in.TemplateId)
+	if err != nil {
+		log.Println(guid, "ERR", pz, name, err)
+		return err
+	}
+
+	res, merr := json.Marshal(out)
+	if merr != nil {
+		log.Println(guid, "RES", pz, name, merr)
+	} else {
+		log.Println(guid, "RES", pz, name, string(res))
+	}
+
+	return nil
+}
+
+func (this *Impl) StartClusterFromTemplate(r *http.Request, in *StartClusterFromTemplateIn, out *StartClusterFromTemplateOut) error {
+	const name = "StartClusterFromTemplate"
+
+	guid := xid.New().String()
+
+	pz, azerr := this.Az.Identify(r)
+	if azerr != nil {
+		return azerr
+	}
+
+	req, merr := json.Marshal(in)
+	if merr != nil {
+		log.Println(guid, "REQ", pz, name, merr)
+	} else {
+		log.Println(guid, "REQ", pz, name, string(req))
+	}
+
+	val0, err := this.Service.StartClusterFromTemplate(pz, in.TemplateId, in.ClusterName, in.KeytabId)
+	if err != nil {
+		log.Println(guid, "ERR", pz, name, err)
+		return err
+	}
+
+	out.ClusterId = val0
+
+	res, merr := json.Marshal(out)
+	if merr != nil {
+		log.Println(guid, "RES", pz, name, merr)
+	} else {
+		log.Println(guid, "RES", pz, name, string(res))
+	}
+
+	return nil
+}
+
+func (this *Impl) GetKeytabs(r *http.Request, in *GetKeytabsIn, out *GetKeytabsOut) error {
+	const name = "GetKeytabs"
+
+	guid := xid.New().String()
+
+	pz, azerr := this.Az.Identify(r)
+	if azerr != nil {
+		return azerr
+	}
+
+	req, merr := json.Marshal(in)
+	if merr != nil {
+		log.Println(guid, "REQ", pz, name, merr)
+	} else {
+		log.Println(guid, "REQ", pz, name, string(req))
+	}
+
+	val0, err := this.Service.GetKeytabs(pz)
+	if err != nil {
+		log.Println(guid, "ERR", pz, name, err)
+		return err
+	}
+
+	out.Keytabs = val0
+
+	res, merr := json.Marshal(out)
+	if merr != nil {
+		log.Println(guid, "RES", pz, name, merr)
+	} else {
+		log.Println(guid, "RES", pz, name, string(res))
+	}
+
+	return nil
+}
+
+func (this *Impl) DeleteKeytab(r *http.Request, in *DeleteKeytabIn, out *DeleteKeytabOut) error {
+	const name = "DeleteKeytab"
+
+	guid := xid.New().String()
+
+	pz, azerr := this.Az.Identify(r)
+	if azerr != nil {
+		return azerr
+	}
+
+	req, merr := json.Marshal(in)
+	if merr != nil {
+		log.Println(guid, "REQ", pz, name, merr)
+	} else {
+		log.Println(guid, "REQ", pz, name, string(req))
+	}
+
+	err := this.Service.DeleteKeytab(pz, in.KeytabId)
+	if err != nil {
+		log.Println(guid, "ERR", pz, name, err)
+		return err
+	}
+
+	res, merr := json.Marshal(out)
+	if merr != nil {
+		log.Println(guid, "RES", pz, name, merr)
+	} else {
+		log.Println(guid, "RES", pz, name, string(res))
+	}
+
+	return nil
+}
+
+func (this *Impl) GetClusterProxyPolicy(r *http.Request, in *GetClusterProxyPolicyIn, out *GetClusterProxyPolicyOut) error {
+	const name = "GetClusterProxyPolicy"
+
+	guid := xid.New().String()
+
+	pz, azerr := this.Az.Identify(r)
+	if azerr != nil {
+		return azerr
+	}
+
+	req, merr := json.Marshal(in)
+	if merr != nil {
+		log.Println(guid, "REQ", pz, name, merr)
+	} else {
+		log.Println(guid, "REQ", pz, name, string(req))
+	}
+
+	val0, err := this.Service.GetClusterProxyPolicy(pz, in.ClusterId)
+	if err != nil {
+		log.Println(guid, "ERR", pz, name, err)
+		return err
+	}
+
+	out.Policy = val0
+
+	res, merr := json.Marshal(out)
+	if merr != nil {
+		log.Println(guid, "RES", pz, name, merr)
+	} else {
+		log.Println(guid, "RES", pz, name, string(res))
+	}
+
+	return nil
+}
+
+func (this *Impl) SetClusterProxyPolicy(r *http.Request, in *SetClusterProxyPolicyIn, out *SetClusterProxyPolicyOut) error {
+	const name = "SetClusterProxyPolicy"
+
+	guid := xid.New().String()
+
+	pz, azerr := this.Az.Identify(r)
+	if azerr != nil {
+		return azerr
+	}
+
+	req, merr := json.Marshal(in)
+	if merr != nil {
+		log.Println(guid, "REQ", pz, name, merr)
+	} else {
+		log.Println(guid, "REQ", pz, name, string(req))
+	}
+
+	err := this.Service.SetClusterProxyPolicy(pz, in.ClusterId, in.Policy)
+	if err != nil {
+		log.Println(guid, "ERR", pz, name, err)
+		return err
+	}
+
+	res, merr := json.Marshal(out)
+	if merr != nil {
+		log.Println(guid, "RES", pz, name, merr)
+	} else {
+		log.Println(guid, "RES", pz, name, string(res))
+	}
+
+	return nil
+}
+
+func (this *Impl) GetCluster(r *http.Request, in *GetClusterIn, out *GetClusterOut) error {
+	const name = "GetCluster"
+
+	guid := xid.New().String()
+
+	pz, azerr := this.Az.Identify(r)
+	if azerr != nil {
+		return azerr
+	}
+
+	req, merr := json.Marshal(in)
+	if merr != nil {
+		log.Println(guid, "REQ", pz, name, merr)
+	} else {
+		log.Println(guid, "REQ", pz, name, string(req))
+	}
+
+	val0, err := this.Service.GetCluster(pz, in.ClusterId)
+	if err != nil {
+		log.Println(guid, "ERR", pz, name, err)
+		return err
+	}
+
+	out.Cluster = val0
+
+	res, merr := json.Marshal(out)
+	if merr != nil {
+		log.Println(guid, "RES", pz, name, merr)
+	} else {
+		log.Println(guid, "RES", pz, name, string(res))
+	}
+
+	return nil
+}
+
+func (this *Impl) GetClusterOnYarn(r *http.Request, in *GetClusterOnYarnIn, out *GetClusterOnYarnOut) error {
+	const name = "GetClusterOnYarn"
+
+	guid := xid.New().String()
+
+	pz, azerr := this.Az.Identify(r)
+	if azerr != nil {
+		return azerr
+	}
+
+	req, merr := json.Marshal(in)
+	if merr != nil {
+		log.Println(guid, "REQ", pz, name, merr)
+	} else {
+		log.Println(guid, "REQ", pz, name, string(req))
+	}
+
+	val0, err := this.Service.GetClusterOnYarn(pz, in.ClusterId)
+	if err != nil {
+		log.Println(guid, "ERR", pz, name, err)
+		return err
+	}
+
+	out.Cluster = val0
+
+	res, merr := json.Marshal(out)
+	if merr != nil {
+		log.Println(guid, "RES", pz, name, merr)
+	} else {
+		log.Println(guid, "RES", pz, name, string(res))
+	}
+
+	return nil
+}
+
+func (this *Impl) GetClusters(r *http.Request, in *GetClustersIn, out *GetClustersOut) error {
+	const name = "GetClusters"
+
+	guid := xid.New().String()
+
+	pz, azerr := this.Az.Identify(r)
+	if azerr != nil {
+		return azerr
+	}
+
+	req, merr := json.Marshal(in)
+	if merr != nil {
+		log.Println(guid, "REQ", pz, name, merr)
+	} else {
+		log.Println(guid, "REQ", pz, name, string(req))
+	}
+
+	val0, err := this.Service.GetClusters(pz, in.Offset, in.Limit)
+	if err != nil {
+		log.Println(guid, "ERR", pz, name, err)
+		return err
+	}
+
+	out.Clusters = val0
+
+	res, merr := json.Marshal(out)
+	if merr != nil {
+		log.Println(guid, "RES", pz, name, merr)
+	} else {
+		log.Println(guid, "RES", pz, name, string(res))
+	}
+
+	return nil
+}
+
+func (this *Impl) GetClusterStatus(r *http.Request, in *GetClusterStatusIn, out *GetClusterStatusOut) error {
+	const name = "GetClusterStatus"
+
+	guid := xid.New().String()
+
+	pz, azerr := this.Az.Identify(r)
+	if azerr != nil {
+		return azerr
+	}
+
+	req, merr := json.Marshal(in)
+	if merr != nil {
+		log.Println(guid, "REQ", pz, name, merr)
+	} else {
+		log.Println(guid, "REQ", pz, name, string(req))
+	}
+
+	val0, err := this.Service.GetClusterStatus(pz, in.ClusterId)
+	if err != nil {
+		log.Println(guid, "ERR", pz, name, err)
+		return err
+	}
+
+	out.ClusterStatus = val0
+
+	res, merr := json.Marshal(out)
+	if merr != nil {
+		log.Println(guid, "RES", pz, name, merr)
+	} else {
+		log.Println(guid, "RES", pz, name, string(res))
+	}
+
+	return nil
+}
+
+func (this *Impl) DeleteCluster(r *http.Request, in *DeleteClusterIn, out *DeleteClusterOut) error {
+	const name = "DeleteCluster"
+
+	guid := xid.New().String()
+
+	pz, azerr := this.Az.Identify(r)
+	if azerr != nil {
+		return azerr
+	}
+
+	req, merr := json.Marshal(in)
+	if merr != nil {
+		log.Println(guid, "REQ", pz, name, merr)
+	} else {
+		log.Println(guid, "REQ", pz, name, string(req))
+	}
+
+	err := this.Service.DeleteCluster(pz, in.ClusterId)
+	if err != nil {
+		log.Println(guid, "ERR", pz, name, err)
+		return err
+	}
+
+	res, merr := json.Marshal(out)
+	if merr != nil {
+		log.Println(guid, "RES", pz, name, merr)
+	} else {
+		log.Println(guid, "RES", pz, name, string(res))
+	}
+
+	return nil
+}
+
+func (this *Impl) GetJob(r *http.Request, in *GetJobIn, out *GetJobOut) error {
+	const name = "GetJob"
+
+	guid := xid.New().String()
+
+	pz, azerr := this.Az.Identify(r)
+	if azerr != nil {
+		return azerr
+	}
+
+	req, merr := json.Marshal(in)
+	if merr != nil {
+		log.Println(guid, "REQ", pz, name, merr)
+	} else {
+		log.Println(guid, "REQ", pz, name, string(req))
+	}
 
-func (this *Remote) GetAttributesForPackage(projectId int64, packageName string) (string, error) {
-	in := GetAttributesForPackageIn{projectId, packageName}
-	var out GetAttributesForPackageOut
-	err := this.Proc.Call("GetAttributesForPackage", &in, &out)
+	val0, err := this.Service.GetJob(pz, in.ClusterId, in.JobName)
 	if err != nil {
-		return "", err
+		log.Println(guid, "ERR", pz, name, err)
+		return err
 	}
-	return out.Attributes, nil
-}
 
-// --- Server Stub ---
+	out.Job = val0
 
-type Impl struct {
-	Service Service
-	Az      az.Az
+	res, merr := json.Marshal(out)
+	if merr != nil {
+		log.Println(guid, "RES", pz, name, merr)
+	} else {
+		log.Println(guid, "RES", pz, name, string(res))
+	}
+
+	return nil
 }
 
-func (this *Impl) PingServer(r *http.Request, in *PingServerIn, out *PingServerOut) error {
-	const name = "PingServer"
+func (this *Impl) CancelJob(r *http.Request, in *CancelJobIn, out *CancelJobOut) error {
+	const name = "CancelJob"
 
 	guid := xid.New().String()
 
@@ -2576,14 +4510,12 @@ func (this *Impl) PingServer(r *http.Request, in *PingServerIn, out *PingServerO
 		log.Println(guid, "REQ", pz, name, string(req))
 	}
 
-	val0, err := this.Service.PingServer(pz, in.Input)
+	err := this.Service.CancelJob(pz, in.ClusterId, in.JobName)
 	if err != nil {
 		log.Println(guid, "ERR", pz, name, err)
 		return err
 	}
 
-	out.Output = val0
-
 	res, merr := json.Marshal(out)
 	if merr != nil {
 		log.Println(guid, "RES", pz, name, merr)
@@ -2594,8 +4526,8 @@ func (this *Impl) PingServer(r *http.Request, in *PingServerIn, out *PingServerO
 	return nil
 }
 
-func (this *Impl) GetConfig(r *http.Request, in *GetConfigIn, out *GetConfigOut) error {
-	const name = "GetConfig"
+func (this *Impl) GetJobs(r *http.Request, in *GetJobsIn, out *GetJobsOut) error {
+	const name = "GetJobs"
 
 	guid := xid.New().String()
 
@@ -2611,13 +4543,13 @@ func (this *Impl) GetConfig(r *http.Request, in *GetConfigIn, out *GetConfigOut)
 		log.Println(guid, "REQ", pz, name, string(req))
 	}
 
-	val0, err := this.Service.GetConfig(pz)
+	val0, err := this.Service.GetJobs(pz, in.ClusterId)
 	if err != nil {
 		log.Println(guid, "ERR", pz, name, err)
 		return err
 	}
 
-	out.Config = val0
+	out.Jobs = val0
 
 	res, merr := json.Marshal(out)
 	if merr != nil {
@@ -2629,8 +4561,8 @@ func (this *Impl) GetConfig(r *http.Request, in *GetConfigIn, out *GetConfigOut)
 	return nil
 }
 
-func (this *Impl) RegisterCluster(r *http.Request, in *RegisterClusterIn, out *RegisterClusterOut) error {
-	const name = "RegisterCluster"
+func (this *Impl) CreateProject(r *http.Request, in *CreateProjectIn, out *CreateProjectOut) error {
+	const name = "CreateProject"
 
 	guid := xid.New().String()
 
@@ -2646,13 +4578,13 @@ func (this *Impl) RegisterCluster(r *http.Request, in *RegisterClusterIn, out *R
 		log.Println(guid, "REQ", pz, name, string(req))
 	}
 
-	val0, err := this.Service.RegisterCluster(pz, in.Address)
+	val0, err := this.Service.CreateProject(pz, in.Name, in.Description, in.ModelCategory)
 	if err != nil {
 		log.Println(guid, "ERR", pz, name, err)
 		return err
 	}
 
-	out.ClusterId = val0
+	out.ProjectId = val0
 
 	res, merr := json.Marshal(out)
 	if merr != nil {
@@ -2664,8 +4596,8 @@ func (this *Impl) RegisterCluster(r *http.Request, in *RegisterClusterIn, out *R
 	return nil
 }
 
-func (this *Impl) UnregisterCluster(r *http.Request, in *UnregisterClusterIn, out *UnregisterClusterOut) error {
-	const name = "UnregisterCluster"
+func (this *Impl) GetProjects(r *http.Request, in *GetProjectsIn, out *GetProjectsOut) error {
+	const name = "GetProjects"
 
 	guid := xid.New().String()
 
@@ -2681,12 +4613,14 @@ func (this *Impl) UnregisterCluster(r *http.Request, in *UnregisterClusterIn, ou
 		log.Println(guid, "REQ", pz, name, string(req))
 	}
 
-	err := this.Service.UnregisterCluster(pz, in.ClusterId)
+	val0, err := this.Service.GetProjects(pz, in.Offset, in.Limit)
 	if err != nil {
 		log.Println(guid, "ERR", pz, name, err)
 		return err
 	}
 
+	out.Projects = val0
+
 	res, merr := json.Marshal(out)
 	if merr != nil {
 		log.Println(guid, "RES", pz, name, merr)
@@ -2697,8 +4631,8 @@ func (this *Impl) UnregisterCluster(r *http.Request, in *UnregisterClusterIn, ou
 	return nil
 }
 
-func (this *Impl) StartClusterOnYarn(r *http.Request, in *StartClusterOnYarnIn, out *StartClusterOnYarnOut) error {
-	const name = "StartClusterOnYarn"
+func (this *Impl) GetProject(r *http.Request, in *GetProjectIn, out *GetProjectOut) error {
+	const name = "GetProject"
 
 	guid := xid.New().String()
 
@@ -2714,13 +4648,13 @@ func (this *Impl) StartClusterOnYarn(r *http.Request, in *StartClusterOnYarnIn,
 		log.Println(guid, "REQ", pz, name, string(req))
 	}
 
-	val0, err := this.Service.StartClusterOnYarn(pz, in.ClusterName, in.EngineId, in.Size, in.Memory, in.Keytab)
+	val0, err := this.Service.GetProject(pz, in.ProjectId)
 	if err != nil {
 		log.Println(guid, "ERR", pz, name, err)
 		return err
 	}
 
-	out.ClusterId = val0
+	out.Project = val0
 
 	res, merr := json.Marshal(out)
 	if merr != nil {
@@ -2732,8 +4666,8 @@ func (this *Impl) StartClusterOnYarn(r *http.Request, in *StartClusterOnYarnIn,
 	return nil
 }
 
-func (this *Impl) StopClusterOnYarn(r *http.Request, in *StopClusterOnYarnIn, out *StopClusterOnYarnOut) error {
-	const name = "StopClusterOnYarn"
+func (this *Impl) DeleteProject(r *http.Request, in *DeleteProjectIn, out *DeleteProjectOut) error {
+	const name = "DeleteProject"
 
 	guid := xid.New().String()
 
@@ -2749,7 +4683,7 @@ func (this *Impl) StopClusterOnYarn(r *http.Request, in *StopClusterOnYarnIn, ou
 		log.Println(guid, "REQ", pz, name, string(req))
 	}
 
-	err := this.Service.StopClusterOnYarn(pz, in.ClusterId, in.Keytab)
+	err := this.Service.DeleteProject(pz, in.ProjectId)
 	if err != nil {
 		log.Println(guid, "ERR", pz, name, err)
 		return err
@@ -2765,8 +4699,8 @@ func (this *Impl) StopClusterOnYarn(r *http.Request, in *StopClusterOnYarnIn, ou
 	return nil
 }
 
-func (this *Impl) GetCluster(r *http.Request, in *GetClusterIn, out *GetClusterOut) error {
-	const name = "GetCluster"
+func (this *Impl) CreateDatasource(r *http.Request, in *CreateDatasourceIn, out *CreateDatasourceOut) error {
+	const name = "CreateDatasource"
 
 	guid := xid.New().String()
 
@@ -2782,13 +4716,13 @@ func (this *Impl) GetCluster(r *http.Request, in *GetClusterIn, out *GetClusterO
 		log.Println(guid, "REQ", pz, name, string(req))
 	}
 
-	val0, err := this.Service.GetCluster(pz, in.ClusterId)
+	val0, err := this.Service.CreateDatasource(pz, in.ProjectId, in.Name, in.Description, in.Kind, in.Configuration)
 	if err != nil {
 		log.Println(guid, "ERR", pz, name, err)
 		return err
 	}
 
-	out.Cluster = val0
+	out.DatasourceId = val0
 
 	res, merr := json.Marshal(out)
 	if merr != nil {
@@ -2800,8 +4734,8 @@ func (this *Impl) GetCluster(r *http.Request, in *GetClusterIn, out *GetClusterO
 	return nil
 }
 
-func (this *Impl) GetClusterOnYarn(r *http.Request, in *GetClusterOnYarnIn, out *GetClusterOnYarnOut) error {
-	const name = "GetClusterOnYarn"
+func (this *Impl) GetDatasources(r *http.Request, in *GetDatasourcesIn, out *GetDatasourcesOut) error {
+	const name = "GetDatasources"
 
 	guid := xid.New().String()
 
@@ -2817,13 +4751,13 @@ func (this *Impl) GetClusterOnYarn(r *http.Request, in *GetClusterOnYarnIn, out
 		log.Println(guid, "REQ", pz, name, string(req))
 	}
 
-	val0, err := this.Service.GetClusterOnYarn(pz, in.ClusterId)
+	val0, err := this.Service.GetDatasources(pz, in.ProjectId, in.Offset, in.Limit)
 	if err != nil {
 		log.Println(guid, "ERR", pz, name, err)
 		return err
 	}
 
-	out.Cluster = val0
+	out.Datasources = val0
 
 	res, merr := json.Marshal(out)
 	if merr != nil {
@@ -2835,8 +4769,8 @@ func (this *Impl) GetClusterOnYarn(r *http.Request, in *GetClusterOnYarnIn, out
 	return nil
 }
 
-func (this *Impl) GetClusters(r *http.Request, in *GetClustersIn, out *GetClustersOut) error {
-	const name = "GetClusters"
+func (this *Impl) GetDatasource(r *http.Request, in *GetDatasourceIn, out *GetDatasourceOut) error {
+	const name = "GetDatasource"
 
 	guid := xid.New().String()
 
@@ -2852,13 +4786,13 @@ func (this *Impl) GetClusters(r *http.Request, in *GetClustersIn, out *GetCluste
 		log.Println(guid, "REQ", pz, name, string(req))
 	}
 
-	val0, err := this.Service.GetClusters(pz, in.Offset, in.Limit)
+	val0, err := this.Service.GetDatasource(pz, in.DatasourceId)
 	if err != nil {
 		log.Println(guid, "ERR", pz, name, err)
 		return err
 	}
 
-	out.Clusters = val0
+	out.Datasource = val0
 
 	res, merr := json.Marshal(out)
 	if merr != nil {
@@ -2870,8 +4804,8 @@ func (this *Impl) GetClusters(r *http.Request, in *GetClustersIn, out *GetCluste
 	return nil
 }
 
-func (this *Impl) GetClusterStatus(r *http.Request, in *GetClusterStatusIn, out *GetClusterStatusOut) error {
-	const name = "GetClusterStatus"
+func (this *Impl) UpdateDatasource(r *http.Request, in *UpdateDatasourceIn, out *UpdateDatasourceOut) error {
+	const name = "UpdateDatasource"
 
 	guid := xid.New().String()
 
@@ -2887,14 +4821,12 @@ func (this *Impl) GetClusterStatus(r *http.Request, in *GetClusterStatusIn, out
 		log.Println(guid, "REQ", pz, name, string(req))
 	}
 
-	val0, err := this.Service.GetClusterStatus(pz, in.ClusterId)
+	err := this.Service.UpdateDatasource(pz, in.DatasourceId, in.Name, in.Description, in.Kind, in.Configuration)
 	if err != nil {
 		log.Println(guid, "ERR", pz, name, err)
 		return err
 	}
 
-	out.ClusterStatus = val0
-
 	res, merr := json.Marshal(out)
 	if merr != nil {
 		log.Println(guid, "RES", pz, name, merr)
@@ -2905,8 +4837,8 @@ func (this *Impl) GetClusterStatus(r *http.Request, in *GetClusterStatusIn, out
 	return nil
 }
 
-func (this *Impl) DeleteCluster(r *http.Request, in *DeleteClusterIn, out *DeleteClusterOut) error {
-	const name = "DeleteCluster"
+func (this *Impl) DeleteDatasource(r *http.Request, in *DeleteDatasourceIn, out *DeleteDatasourceOut) error {
+	const name = "DeleteDatasource"
 
 	guid := xid.New().String()
 
@@ -2922,7 +4854,7 @@ func (this *Impl) DeleteCluster(r *http.Request, in *DeleteClusterIn, out *Delet
 		log.Println(guid, "REQ", pz, name, string(req))
 	}
 
-	err := this.Service.DeleteCluster(pz, in.ClusterId)
+	err := this.Service.DeleteDatasource(pz, in.DatasourceId)
 	if err != nil {
 		log.Println(guid, "ERR", pz, name, err)
 		return err
@@ -2938,8 +4870,8 @@ func (this *Impl) DeleteCluster(r *http.Request, in *DeleteClusterIn, out *Delet
 	return nil
 }
 
-func (this *Impl) GetJob(r *http.Request, in *GetJobIn, out *GetJobOut) error {
-	const name = "GetJob"
+func (this *Impl) TestDatasource(r *http.Request, in *TestDatasourceIn, out *TestDatasourceOut) error {
+	const name = "TestDatasource"
 
 	guid := xid.New().String()
 
@@ -2955,13 +4887,13 @@ func (this *Impl) GetJob(r *http.Request, in *GetJobIn, out *GetJobOut) error {
 		log.Println(guid, "REQ", pz, name, string(req))
 	}
 
-	val0, err := this.Service.GetJob(pz, in.ClusterId, in.JobName)
+	val0, err := this.Service.TestDatasource(pz, in.DatasourceId, in.ClusterId)
 	if err != nil {
 		log.Println(guid, "ERR", pz, name, err)
 		return err
 	}
 
-	out.Job = val0
+	out.Message = val0
 
 	res, merr := json.Marshal(out)
 	if merr != nil {
@@ -2973,8 +4905,8 @@ func (this *Impl) GetJob(r *http.Request, in *GetJobIn, out *GetJobOut) error {
 	return nil
 }
 
-func (this *Impl) GetJobs(r *http.Request, in *GetJobsIn, out *GetJobsOut) error {
-	const name = "GetJobs"
+func (this *Impl) CreateDataset(r *http.Request, in *CreateDatasetIn, out *CreateDatasetOut) error {
+	const name = "CreateDataset"
 
 	guid := xid.New().String()
 
@@ -2990,13 +4922,13 @@ func (this *Impl) GetJobs(r *http.Request, in *GetJobsIn, out *GetJobsOut) error
 		log.Println(guid, "REQ", pz, name, string(req))
 	}
 
-	val0, err := this.Service.GetJobs(pz, in.ClusterId)
+	val0, err := this.Service.CreateDataset(pz, in.ClusterId, in.DatasourceId, in.Name, in.Description, in.ResponseColumnName, in.CompareWithPrevious)
 	if err != nil {
 		log.Println(guid, "ERR", pz, name, err)
 		return err
 	}
 
-	out.Jobs = val0
+	out.JobId = val0
 
 	res, merr := json.Marshal(out)
 	if merr != nil {
@@ -3008,8 +4940,8 @@ func (this *Impl) GetJobs(r *http.Request, in *GetJobsIn, out *GetJobsOut) error
 	return nil
 }
 
-func (this *Impl) CreateProject(r *http.Request, in *CreateProjectIn, out *CreateProjectOut) error {
-	const name = "CreateProject"
+func (this *Impl) GetDatasetJob(r *http.Request, in *GetDatasetJobIn, out *GetDatasetJobOut) error {
+	const name = "GetDatasetJob"
 
 	guid := xid.New().String()
 
@@ -3025,13 +4957,13 @@ func (this *Impl) CreateProject(r *http.Request, in *CreateProjectIn, out *Creat
 		log.Println(guid, "REQ", pz, name, string(req))
 	}
 
-	val0, err := this.Service.CreateProject(pz, in.Name, in.Description, in.ModelCategory)
+	val0, err := this.Service.GetDatasetJob(pz, in.JobId)
 	if err != nil {
 		log.Println(guid, "ERR", pz, name, err)
 		return err
 	}
 
-	out.ProjectId = val0
+	out.DatasetJob = val0
 
 	res, merr := json.Marshal(out)
 	if merr != nil {
@@ -3043,8 +4975,8 @@ func (this *Impl) CreateProject(r *http.Request, in *CreateProjectIn, out *Creat
 	return nil
 }
 
-func (this *Impl) GetProjects(r *http.Request, in *GetProjectsIn, out *GetProjectsOut) error {
-	const name = "GetProjects"
+func (this *Impl) GetDatasetJobs(r *http.Request, in *GetDatasetJobsIn, out *GetDatasetJobsOut) error {
+	const name = "GetDatasetJobs"
 
 	guid := xid.New().String()
 
@@ -3060,13 +4992,13 @@ func (this *Impl) GetProjects(r *http.Request, in *GetProjectsIn, out *GetProjec
 		log.Println(guid, "REQ", pz, name, string(req))
 	}
 
-	val0, err := this.Service.GetProjects(pz, in.Offset, in.Limit)
+	val0, err := this.Service.GetDatasetJobs(pz, in.DatasourceId, in.Offset, in.Limit)
 	if err != nil {
 		log.Println(guid, "ERR", pz, name, err)
 		return err
 	}
 
-	out.Projects = val0
+	out.DatasetJobs = val0
 
 	res, merr := json.Marshal(out)
 	if merr != nil {
@@ -3078,8 +5010,8 @@ func (this *Impl) GetProjects(r *http.Request, in *GetProjectsIn, out *GetProjec
 	return nil
 }
 
-func (this *Impl) GetProject(r *http.Request, in *GetProjectIn, out *GetProjectOut) error {
-	const name = "GetProject"
+func (this *Impl) CancelDatasetJob(r *http.Request, in *CancelDatasetJobIn, out *CancelDatasetJobOut) error {
+	const name = "CancelDatasetJob"
 
 	guid := xid.New().String()
 
@@ -3095,14 +5027,12 @@ func (this *Impl) GetProject(r *http.Request, in *GetProjectIn, out *GetProjectO
 		log.Println(guid, "REQ", pz, name, string(req))
 	}
 
-	val0, err := this.Service.GetProject(pz, in.ProjectId)
+	err := this.Service.CancelDatasetJob(pz, in.JobId)
 	if err != nil {
 		log.Println(guid, "ERR", pz, name, err)
 		return err
 	}
 
-	out.Project = val0
-
 	res, merr := json.Marshal(out)
 	if merr != nil {
 		log.Println(guid, "RES", pz, name, merr)
@@ -3113,8 +5043,8 @@ func (this *Impl) GetProject(r *http.Request, in *GetProjectIn, out *GetProjectO
 	return nil
 }
 
-func (this *Impl) DeleteProject(r *http.Request, in *DeleteProjectIn, out *DeleteProjectOut) error {
-	const name = "DeleteProject"
+func (this *Impl) ReimportDataset(r *http.Request, in *ReimportDatasetIn, out *ReimportDatasetOut) error {
+	const name = "ReimportDataset"
 
 	guid := xid.New().String()
 
@@ -3130,12 +5060,14 @@ func (this *Impl) DeleteProject(r *http.Request, in *DeleteProjectIn, out *Delet
 		log.Println(guid, "REQ", pz, name, string(req))
 	}
 
-	err := this.Service.DeleteProject(pz, in.ProjectId)
+	val0, err := this.Service.ReimportDataset(pz, in.DatasetId)
 	if err != nil {
 		log.Println(guid, "ERR", pz, name, err)
 		return err
 	}
 
+	out.JobId = val0
+
 	res, merr := json.Marshal(out)
 	if merr != nil {
 		log.Println(guid, "RES", pz, name, merr)
@@ -3146,8 +5078,8 @@ func (this *Impl) DeleteProject(r *http.Request, in *DeleteProjectIn, out *Delet
 	return nil
 }
 
-func (this *Impl) CreateDatasource(r *http.Request, in *CreateDatasourceIn, out *CreateDatasourceOut) error {
-	const name = "CreateDatasource"
+func (this *Impl) GetDatasets(r *http.Request, in *GetDatasetsIn, out *GetDatasetsOut) error {
+	const name = "GetDatasets"
 
 	guid := xid.New().String()
 
@@ -3163,15 +5095,21 @@ func (this *Impl) CreateDatasource(r *http.Request, in *CreateDatasourceIn, out
 		log.Println(guid, "REQ", pz, name, string(req))
 	}
 
-	val0, err := this.Service.CreateDatasource(pz, in.ProjectId, in.Name, in.Description, in.Path)
+	val0, err := this.Service.GetDatasets(pz, in.DatasourceId, in.Offset, in.Limit)
 	if err != nil {
 		log.Println(guid, "ERR", pz, name, err)
 		return err
 	}
 
-	out.DatasourceId = val0
+	out.Datasets = val0
 
-	res, merr := json.Marshal(out)
+	aux := make([]Dataset, len(out.Datasets))
+	for i, val := range out.Datasets {
+		aux[i] = *val
+		aux[i].JSONProperties = "JSON DATA OMITTED..."
+	}
+
+	res, merr := json.Marshal(aux)
 	if merr != nil {
 		log.Println(guid, "RES", pz, name, merr)
 	} else {
@@ -3181,8 +5119,8 @@ func (this *Impl) CreateDatasource(r *http.Request, in *CreateDatasourceIn, out
 	return nil
 }
 
-func (this *Impl) GetDatasources(r *http.Request, in *GetDatasourcesIn, out *GetDatasourcesOut) error {
-	const name = "GetDatasources"
+func (this *Impl) GetDataset(r *http.Request, in *GetDatasetIn, out *GetDatasetOut) error {
+	const name = "GetDataset"
 
 	guid := xid.New().String()
 
@@ -3198,15 +5136,18 @@ func (this *Impl) GetDatasources(r *http.Request, in *GetDatasourcesIn, out *Get
 		log.Println(guid, "REQ", pz, name, string(req))
 	}
 
-	val0, err := this.Service.GetDatasources(pz, in.ProjectId, in.Offset, in.Limit)
+	val0, err := this.Service.GetDataset(pz, in.DatasetId)
 	if err != nil {
 		log.Println(guid, "ERR", pz, name, err)
 		return err
 	}
 
-	out.Datasources = val0
+	out.Dataset = val0
 
-	res, merr := json.Marshal(out)
+	aux := *out.Dataset
+	aux.JSONProperties = "JSON DATA OMITTED..."
+
+	res, merr := json.Marshal(aux)
 	if merr != nil {
 		log.Println(guid, "RES", pz, name, merr)
 	} else {
@@ -3216,8 +5157,8 @@ func (this *Impl) GetDatasources(r *http.Request, in *GetDatasourcesIn, out *Get
 	return nil
 }
 
-func (this *Impl) GetDatasource(r *http.Request, in *GetDatasourceIn, out *GetDatasourceOut) error {
-	const name = "GetDatasource"
+func (this *Impl) GetDatasetColumns(r *http.Request, in *GetDatasetColumnsIn, out *GetDatasetColumnsOut) error {
+	const name = "GetDatasetColumns"
 
 	guid := xid.New().String()
 
@@ -3233,13 +5174,13 @@ func (this *Impl) GetDatasource(r *http.Request, in *GetDatasourceIn, out *GetDa
 		log.Println(guid, "REQ", pz, name, string(req))
 	}
 
-	val0, err := this.Service.GetDatasource(pz, in.DatasourceId)
+	val0, err := this.Service.GetDatasetColumns(pz, in.DatasetId, in.Refresh)
 	if err != nil {
 		log.Println(guid, "ERR", pz, name, err)
 		return err
 	}
 
-	out.Datasource = val0
+	out.Columns = val0
 
 	res, merr := json.Marshal(out)
 	if merr != nil {
@@ -3251,8 +5192,8 @@ func (this *Impl) GetDatasource(r *http.Request, in *GetDatasourceIn, out *GetDa
 	return nil
 }
 
-func (this *Impl) UpdateDatasource(r *http.Request, in *UpdateDatasourceIn, out *UpdateDatasourceOut) error {
-	const name = "UpdateDatasource"
+func (this *Impl) GetDatasetColumnRoles(r *http.Request, in *GetDatasetColumnRolesIn, out *GetDatasetColumnRolesOut) error {
+	const name = "GetDatasetColumnRoles"
 
 	guid := xid.New().String()
 
@@ -3268,12 +5209,14 @@ func (this *Impl) UpdateDatasource(r *http.Request, in *UpdateDatasourceIn, out
 		log.Println(guid, "REQ", pz, name, string(req))
 	}
 
-	err := this.Service.UpdateDatasource(pz, in.DatasourceId, in.Name, in.Description, in.Path)
+	val0, err := this.Service.GetDatasetColumnRoles(pz, in.DatasetId)
 	if err != nil {
 		log.Println(guid, "ERR", pz, name, err)
 		return err
 	}
 
+	out.Roles = val0
+
 	res, merr := json.Marshal(out)
 	if merr != nil {
 		log.Println(guid, "RES", pz, name, merr)
@@ -3284,8 +5227,8 @@ func (this *Impl) UpdateDatasource(r *http.Request, in *UpdateDatasourceIn, out
 	return nil
 }
 
-func (this *Impl) DeleteDatasource(r *http.Request, in *DeleteDatasourceIn, out *DeleteDatasourceOut) error {
-	const name = "DeleteDatasource"
+func (this *Impl) SetDatasetColumnRole(r *http.Request, in *SetDatasetColumnRoleIn, out *SetDatasetColumnRoleOut) error {
+	const name = "SetDatasetColumnRole"
 
 	guid := xid.New().String()
 
@@ -3301,7 +5244,7 @@ func (this *Impl) DeleteDatasource(r *http.Request, in *DeleteDatasourceIn, out
 		log.Println(guid, "REQ", pz, name, string(req))
 	}
 
-	err := this.Service.DeleteDatasource(pz, in.DatasourceId)
+	err := this.Service.SetDatasetColumnRole(pz, in.DatasetId, in.ColumnName, in.Role)
 	if err != nil {
 		log.Println(guid, "ERR", pz, name, err)
 		return err
@@ -3317,8 +5260,8 @@ func (this *Impl) DeleteDatasource(r *http.Request, in *DeleteDatasourceIn, out
 	return nil
 }
 
-func (this *Impl) CreateDataset(r *http.Request, in *CreateDatasetIn, out *CreateDatasetOut) error {
-	const name = "CreateDataset"
+func (this *Impl) PreviewDataset(r *http.Request, in *PreviewDatasetIn, out *PreviewDatasetOut) error {
+	const name = "PreviewDataset"
 
 	guid := xid.New().String()
 
@@ -3334,13 +5277,13 @@ func (this *Impl) CreateDataset(r *http.Request, in *CreateDatasetIn, out *Creat
 		log.Println(guid, "REQ", pz, name, string(req))
 	}
 
-	val0, err := this.Service.CreateDataset(pz, in.ClusterId, in.DatasourceId, in.Name, in.Description, in.ResponseColumnName)
+	val0, err := this.Service.PreviewDataset(pz, in.DatasetId, in.Offset, in.RowCount, in.Columns)
 	if err != nil {
 		log.Println(guid, "ERR", pz, name, err)
 		return err
 	}
 
-	out.DatasetId = val0
+	out.Preview = val0
 
 	res, merr := json.Marshal(out)
 	if merr != nil {
@@ -3352,8 +5295,8 @@ func (this *Impl) CreateDataset(r *http.Request, in *CreateDatasetIn, out *Creat
 	return nil
 }
 
-func (this *Impl) GetDatasets(r *http.Request, in *GetDatasetsIn, out *GetDatasetsOut) error {
-	const name = "GetDatasets"
+func (this *Impl) GetDatasetVersions(r *http.Request, in *GetDatasetVersionsIn, out *GetDatasetVersionsOut) error {
+	const name = "GetDatasetVersions"
 
 	guid := xid.New().String()
 
@@ -3369,7 +5312,7 @@ func (this *Impl) GetDatasets(r *http.Request, in *GetDatasetsIn, out *GetDatase
 		log.Println(guid, "REQ", pz, name, string(req))
 	}
 
-	val0, err := this.Service.GetDatasets(pz, in.DatasourceId, in.Offset, in.Limit)
+	val0, err := this.Service.GetDatasetVersions(pz, in.DatasourceId)
 	if err != nil {
 		log.Println(guid, "ERR", pz, name, err)
 		return err
@@ -3393,8 +5336,8 @@ func (this *Impl) GetDatasets(r *http.Request, in *GetDatasetsIn, out *GetDatase
 	return nil
 }
 
-func (this *Impl) GetDataset(r *http.Request, in *GetDatasetIn, out *GetDatasetOut) error {
-	const name = "GetDataset"
+func (this *Impl) GetDatasetsFromCluster(r *http.Request, in *GetDatasetsFromClusterIn, out *GetDatasetsFromClusterOut) error {
+	const name = "GetDatasetsFromCluster"
 
 	guid := xid.New().String()
 
@@ -3410,7 +5353,7 @@ func (this *Impl) GetDataset(r *http.Request, in *GetDatasetIn, out *GetDatasetO
 		log.Println(guid, "REQ", pz, name, string(req))
 	}
 
-	val0, err := this.Service.GetDataset(pz, in.DatasetId)
+	val0, err := this.Service.GetDatasetsFromCluster(pz, in.ClusterId)
 	if err != nil {
 		log.Println(guid, "ERR", pz, name, err)
 		return err
@@ -3418,8 +5361,11 @@ func (this *Impl) GetDataset(r *http.Request, in *GetDatasetIn, out *GetDatasetO
 
 	out.Dataset = val0
 
-	aux := *out.Dataset
-	aux.JSONProperties = "JSON DATA OMITTED..."
+	aux := make([]Dataset, len(out.Dataset))
+	for i, val := range out.Dataset {
+		aux[i] = *val
+		aux[i].JSONProperties = "JSON DATA OMITTED..."
+	}
 
 	res, merr := json.Marshal(aux)
 	if merr != nil {
@@ -3431,8 +5377,8 @@ func (this *Impl) GetDataset(r *http.Request, in *GetDatasetIn, out *GetDatasetO
 	return nil
 }
 
-func (this *Impl) GetDatasetsFromCluster(r *http.Request, in *GetDatasetsFromClusterIn, out *GetDatasetsFromClusterOut) error {
-	const name = "GetDatasetsFromCluster"
+func (this *Impl) CompareDatasets(r *http.Request, in *CompareDatasetsIn, out *CompareDatasetsOut) error {
+	const name = "CompareDatasets"
 
 	guid := xid.New().String()
 
@@ -3448,21 +5394,50 @@ func (this *Impl) GetDatasetsFromCluster(r *http.Request, in *GetDatasetsFromClu
 		log.Println(guid, "REQ", pz, name, string(req))
 	}
 
-	val0, err := this.Service.GetDatasetsFromCluster(pz, in.ClusterId)
+	val0, err := this.Service.CompareDatasets(pz, in.BaseDatasetId, in.OtherDatasetId)
 	if err != nil {
 		log.Println(guid, "ERR", pz, name, err)
 		return err
 	}
 
-	out.Dataset = val0
+	out.Comparison = val0
 
-	aux := make([]Dataset, len(out.Dataset))
-	for i, val := range out.Dataset {
-		aux[i] = *val
-		aux[i].JSONProperties = "JSON DATA OMITTED..."
+	res, merr := json.Marshal(out)
+	if merr != nil {
+		log.Println(guid, "RES", pz, name, merr)
+	} else {
+		log.Println(guid, "RES", pz, name, string(res))
 	}
 
-	res, merr := json.Marshal(aux)
+	return nil
+}
+
+func (this *Impl) GetDatasetComparison(r *http.Request, in *GetDatasetComparisonIn, out *GetDatasetComparisonOut) error {
+	const name = "GetDatasetComparison"
+
+	guid := xid.New().String()
+
+	pz, azerr := this.Az.Identify(r)
+	if azerr != nil {
+		return azerr
+	}
+
+	req, merr := json.Marshal(in)
+	if merr != nil {
+		log.Println(guid, "REQ", pz, name, merr)
+	} else {
+		log.Println(guid, "REQ", pz, name, string(req))
+	}
+
+	val0, err := this.Service.GetDatasetComparison(pz, in.DatasetId)
+	if err != nil {
+		log.Println(guid, "ERR", pz, name, err)
+		return err
+	}
+
+	out.Comparison = val0
+
+	res, merr := json.Marshal(out)
 	if merr != nil {
 		log.Println(guid, "RES", pz, name, merr)
 	} else {
@@ -3522,7 +5497,7 @@ func (this *Impl) SplitDataset(r *http.Request, in *SplitDatasetIn, out *SplitDa
 		log.Println(guid, "REQ", pz, name, string(req))
 	}
 
-	val0, err := this.Service.SplitDataset(pz, in.DatasetId, in.Ratio1, in.Ratio2)
+	val0, err := this.Service.SplitDataset(pz, in.DatasetId, in.Ratio1, in.Ratio2, in.Seed)
 	if err != nil {
 		log.Println(guid, "ERR", pz, name, err)
 		return err
@@ -5616,6 +7591,39 @@ func (this *Impl) UpdateWorkgroup(r *http.Request, in *UpdateWorkgroupIn, out *U
 	return nil
 }
 
+func (this *Impl) SetWorkgroupIdleTimeout(r *http.Request, in *SetWorkgroupIdleTimeoutIn, out *SetWorkgroupIdleTimeoutOut) error {
+	const name = "SetWorkgroupIdleTimeout"
+
+	guid := xid.New().String()
+
+	pz, azerr := this.Az.Identify(r)
+	if azerr != nil {
+		return azerr
+	}
+
+	req, merr := json.Marshal(in)
+	if merr != nil {
+		log.Println(guid, "REQ", pz, name, merr)
+	} else {
+		log.Println(guid, "REQ", pz, name, string(req))
+	}
+
+	err := this.Service.SetWorkgroupIdleTimeout(pz, in.WorkgroupId, in.Minutes)
+	if err != nil {
+		log.Println(guid, "ERR", pz, name, err)
+		return err
+	}
+
+	res, merr := json.Marshal(out)
+	if merr != nil {
+		log.Println(guid, "RES", pz, name, merr)
+	} else {
+		log.Println(guid, "RES", pz, name, string(res))
+	}
+
+	return nil
+}
+
 func (this *Impl) DeleteWorkgroup(r *http.Request, in *DeleteWorkgroupIn, out *DeleteWorkgroupOut) error {
 	const name = "DeleteWorkgroup"
 
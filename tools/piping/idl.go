@@ -195,6 +195,28 @@ func formatOf(t string, isArray, isStruct bool) string {
 	}
 }
 
+// addStruct adds a parameter type, and the types of its struct fields, to
+// structs.
+func addStruct(structs, dict map[string]*Struct, name string) error {
+	if _, ok := structs[name]; ok {
+		return nil
+	}
+	s, ok := dict[name]
+	if !ok {
+		return fmt.Errorf("Could not find parameter definition %s", name)
+	}
+	structs[name] = s
+
+	for _, f := range s.Fields {
+		if f.IsStruct {
+			if err := addStruct(structs, dict, f.Type); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
 func toInterface(facadeName string, dict map[string]*Struct) (*Interface, error) {
 	facade, ok := dict[facadeName]
 	if !ok {
@@ -225,12 +247,8 @@ func toInterface(facadeName string, dict map[string]*Struct) (*Interface, error)
 				outputs = append(outputs, f)
 			}
 			if f.IsStruct {
-				if _, ok := structs[f.Type]; !ok {
-					s, ok := dict[f.Type]
-					if !ok {
-						return nil, fmt.Errorf("Could not find parameter definition %s", m.Type)
-					}
-					structs[f.Type] = s
+				if err := addStruct(structs, dict, f.Type); err != nil {
+					return nil, err
 				}
 			}
 		}